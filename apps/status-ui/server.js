@@ -11,6 +11,7 @@ const readonly = process.env.READONLY === '1';
 const host = process.env.STATUS_UI_HOST || process.env.HOST || (readonlyProd ? '127.0.0.1' : '0.0.0.0');
 const cacheMs = Math.max(0, Number(process.env.CACHE_MS || 1000));
 const divergenceWarn = Math.max(0, Number(process.env.DIVERGENCE_WARN || 3));
+const qikchaindUrl = String(process.env.QIKCHAIND_URL || '').trim().replace(/\/+$/, '');
 const txRateLimitPerMinBase = Math.max(1, Number(process.env.TX_RATE_LIMIT_PER_MIN || 10));
 const txRateLimitPerMin = readonlyProd ? Math.min(txRateLimitPerMinBase, 5) : txRateLimitPerMinBase;
 const rawTxMaxBytesBase = Math.max(1, Number(process.env.RAW_TX_MAX_BYTES || 8192));
@@ -30,6 +31,9 @@ const authEnabled = authUser.length > 0 && authPass.length > 0;
 const txToken = process.env.TX_TOKEN || '';
 const txEnabled = txToken.length > 0;
 const txFromPrivateKey = process.env.TX_FROM_PRIVATE_KEY || '';
+// Extra txhelper signer flags, e.g. "--keystore /keys --account 0 --passphrase-file /run/pass"
+// or "--dev-key" on a local devnet.
+const txHelperArgs = String(process.env.TXHELPER_ARGS || '').trim().split(/\s+/).filter(Boolean);
 
 const statusCache = {
   value: null,
@@ -90,8 +94,10 @@ function maskRpcUrl(rpc) {
 }
 
 function runTxHelper(args) {
+  // txhelper reads a hex key from CI_FUNDER_PRIVKEY; hand it the UI's funding key.
+  const env = txFromPrivateKey ? { ...process.env, CI_FUNDER_PRIVKEY: txFromPrivateKey } : process.env;
   return new Promise((resolve, reject) => {
-    execFile(txHelperPath, args, { cwd: repoRoot, timeout: TX_TIMEOUT_MS }, (error, stdout, stderr) => {
+    execFile(txHelperPath, [...args, ...txHelperArgs], { cwd: repoRoot, env, timeout: TX_TIMEOUT_MS }, (error, stdout, stderr) => {
       if (error) {
         const errMessage = (stderr || error.message || 'tx helper failed').trim();
         reject(new Error(errMessage));
@@ -221,7 +227,70 @@ function summarize(nodes) {
   };
 }
 
+// fetchCluster reads the aggregated record of `qikchaind run --listen` and
+// maps it onto the shape checkNode/summarize produce.
+async function fetchCluster() {
+  const controller = new AbortController();
+  const timeout = setTimeout(() => controller.abort(), RPC_TIMEOUT_MS);
+  let cluster;
+  try {
+    const response = await fetch(`${qikchaindUrl}/cluster`, { signal: controller.signal });
+    if (!response.ok) {
+      throw new Error(`qikchaind /cluster returned HTTP ${response.status}`);
+    }
+    cluster = await response.json();
+  } finally {
+    clearTimeout(timeout);
+  }
+
+  const nodes = (cluster.nodes || []).map((node) => ({
+    name: node.name,
+    rpc: maskRpcUrl(node.rpc),
+    reachable: Boolean(node.up),
+    chainId: node.chainHex || null,
+    netPeerCount: node.up ? node.peerCount || 0 : null,
+    ethBlockNumber: node.up ? node.blockNumber || 0 : null,
+    stalled: Boolean(node.stalled),
+    error: node.error ? sanitizeError(node.error) : null,
+  }));
+  const reasons = cluster.reasons || [];
+  return {
+    nodes,
+    overall: {
+      healthy: Boolean(cluster.healthy),
+      reason: reasons.length > 0 ? reasons.join('; ') : `${cluster.nodesUp} of ${cluster.nodesTotal} nodes up.`,
+    },
+    summary: {
+      nodesUp: cluster.nodesUp,
+      nodesTotal: cluster.nodesTotal,
+      nodesSealing: cluster.nodesUp,
+      chainId: cluster.chainId ? `0x${Number(cluster.chainId).toString(16)}` : null,
+      minBlockHead: cluster.minHead,
+      maxBlockHead: cluster.maxHead,
+      headDivergence: cluster.headDivergence,
+    },
+  };
+}
+
 async function computeStatus() {
+  if (qikchaindUrl) {
+    const { nodes, overall, summary } = await fetchCluster();
+    return {
+      timestamp: new Date().toISOString(),
+      source: 'qikchaind',
+      readonlyProd,
+      authEnabled,
+      divergenceWarn,
+      txEnabled,
+      writeMode: txEnabled ? 'enabled' : 'disabled',
+      overall,
+      summary,
+      configuredRpcs: nodes.length,
+      rpcs: nodes.map((node) => node.rpc),
+      nodes,
+    };
+  }
+
   const rpcUrls = parseRpcUrls();
   const nodes = await Promise.all(rpcUrls.map((rpc) => checkNode(rpc)));
   const sanitizedNodes = nodes.map((node) => ({
@@ -373,7 +442,7 @@ function txGateMiddleware(req, res, next) {
 }
 
 function ensureFundingKey(res) {
-  if (txFromPrivateKey) {
+  if (txFromPrivateKey || txHelperArgs.length > 0) {
     return true;
   }
   res.status(503).json({ error: 'funding key not configured' });
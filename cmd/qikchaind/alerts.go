@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/alert"
+	"github.com/BioMark3r/qikchain/internal/logging"
+	"github.com/BioMark3r/qikchain/internal/metrics"
+	"github.com/BioMark3r/qikchain/internal/monitor"
+)
+
+// alertSignals are the signals rules can refer to. Node signals other than
+// node.up are unknown while the node is down, so their alerts hold.
+var alertSignals = map[string]bool{
+	"node.up":                       true,
+	"node.peers":                    true,
+	"node.head_age_seconds":         true,
+	"node.head_stalled":             true,
+	"node.chain_id_mismatch":        true,
+	"cluster.healthy":               true,
+	"cluster.nodes_up":              true,
+	"cluster.head_divergence":       true,
+	"cluster.head_stalled":          true,
+	"cluster.head_age_seconds":      true,
+	"validator.participation_ratio": true,
+	"validator.missed_ratio":        true,
+	"validator.missed_blocks":       true,
+}
+
+// nodeValueSignals are the node signals read from a node that is up.
+var nodeValueSignals = []string{"node.peers", "node.head_age_seconds", "node.head_stalled", "node.chain_id_mismatch"}
+
+type alerting struct {
+	log      *slog.Logger
+	engine   *alert.Engine
+	notifier *alert.Notifier
+	severity map[string]string // rule name to severity
+	firing   *metrics.Vec
+	failures *metrics.Vec
+}
+
+func newAlerting(log *slog.Logger, reg *metrics.Registry) *alerting {
+	return &alerting{
+		log:      log,
+		engine:   alert.NewEngine(nil),
+		severity: map[string]string{},
+		firing:   reg.Gauge("qikchain_alerts_firing", "Firing alerts by rule.", "rule", "severity"),
+		failures: reg.Counter("qikchain_alert_webhook_failures_total", "Failed webhook deliveries.", "webhook"),
+	}
+}
+
+// configure switches to the rules and webhooks of cfg, which must have been
+// validated. Alerts of unchanged rules keep their state; firing alerts of
+// removed or changed rules are resolved through the old webhooks.
+func (a *alerting) configure(now time.Time, cfg *alert.Config, timeout time.Duration) {
+	if cfg == nil {
+		cfg = &alert.Config{}
+	}
+	for _, n := range a.engine.SetRules(now, cfg.Rules) {
+		a.dispatch(n)
+	}
+	if a.notifier != nil {
+		// Let the old notifier drain in the background.
+		go a.notifier.Close()
+	}
+	for rule := range a.severity {
+		a.firing.DeletePrefix(rule)
+	}
+	a.severity = map[string]string{}
+	for _, r := range cfg.Rules {
+		a.severity[r.Name] = r.Severity
+	}
+	for rule, count := range a.engine.Firing() {
+		a.firing.Set(float64(count), rule, a.severity[rule])
+	}
+	for _, w := range cfg.Webhooks {
+		a.failures.Add(0, w.Label())
+	}
+	a.notifier = alert.NewNotifier(cfg.Webhooks, timeout, func(w alert.Webhook, n alert.Notification, err error) {
+		a.failures.Inc(w.Label())
+		a.log.Error("alert webhook failed", "webhook", w.Label(), "rule", n.Rule, "subject", n.Subject, "status", n.Status, logging.Err(err))
+	})
+}
+
+func signalNames() []string {
+	out := make([]string, 0, len(alertSignals))
+	for s := range alertSignals {
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// evaluate samples the cycle's cluster record and participation window and
+// sends a notification for every alert that fired or resolved.
+func (a *alerting) evaluate(now time.Time, cluster monitor.Cluster, report *participationReport) {
+	for _, n := range a.engine.Evaluate(now, alertSamples(cluster, report)) {
+		a.dispatch(n)
+	}
+	for rule, count := range a.engine.Firing() {
+		a.firing.Set(float64(count), rule, a.severity[rule])
+	}
+}
+
+func (a *alerting) dispatch(n alert.Notification) {
+	level := slog.LevelWarn
+	if n.Status == alert.StatusResolved {
+		level = slog.LevelInfo
+	}
+	a.log.Log(context.Background(), level, "alert "+n.Status, "rule", n.Rule, "severity", n.Severity, "subject", n.Subject, "summary", n.Summary)
+	if !a.notifier.Notify(n) {
+		a.log.Error("alert queue full, notification dropped", "rule", n.Rule, "subject", n.Subject, "status", n.Status)
+	}
+}
+
+func alertSamples(c monitor.Cluster, report *participationReport) []alert.Sample {
+	var out []alert.Sample
+	add := func(signal, subject string, v float64) {
+		out = append(out, alert.Sample{Signal: signal, Subject: subject, Value: v})
+	}
+	minAge := -1.0
+	for _, st := range c.Nodes {
+		add("node.up", st.Name, boolValue(st.Up))
+		if !st.Up {
+			// Keep firing peers or head-age alerts firing rather than
+			// resolving them just as the node goes down.
+			for _, signal := range nodeValueSignals {
+				add(signal, st.Name, math.NaN())
+			}
+			continue
+		}
+		add("node.peers", st.Name, float64(st.PeerCount))
+		add("node.head_age_seconds", st.Name, st.HeadAgeSeconds)
+		add("node.head_stalled", st.Name, boolValue(st.Stalled))
+		add("node.chain_id_mismatch", st.Name, boolValue(st.ChainIDMismatch))
+		if minAge < 0 || st.HeadAgeSeconds < minAge {
+			minAge = st.HeadAgeSeconds
+		}
+	}
+	add("cluster.healthy", "cluster", boolValue(c.Healthy))
+	add("cluster.nodes_up", "cluster", float64(c.NodesUp))
+	add("cluster.head_divergence", "cluster", float64(c.HeadDivergence))
+	add("cluster.head_stalled", "cluster", boolValue(c.HeadStalled))
+	if minAge >= 0 {
+		add("cluster.head_age_seconds", "cluster", minAge)
+	}
+	for _, s := range report.validators() {
+		add("validator.participation_ratio", s.Validator, s.Ratio)
+		add("validator.missed_ratio", s.Validator, 1-s.Ratio)
+		add("validator.missed_blocks", s.Validator, float64(s.Missed))
+	}
+	return out
+}
+
+func (a *alerting) close() {
+	a.notifier.Close()
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/alert"
+	"github.com/BioMark3r/qikchain/internal/monitor"
+)
+
+// runSettings is the configuration of run. It starts from the flags; the
+// keys present in the --config file override them. Everything in it can be
+// reloaded.
+type runSettings struct {
+	Nodes               []monitor.Node `json:"nodes"`
+	Interval            alert.Duration `json:"interval"`
+	Timeout             alert.Duration `json:"timeout"`
+	Listen              string         `json:"listen"`
+	ChainID             uint64         `json:"chainId"`
+	Quorum              int            `json:"quorum"`
+	MaxDivergence       uint64         `json:"maxDivergence"`
+	StallCycles         int            `json:"stallCycles"`
+	ReadyMaxHeadAge     alert.Duration `json:"readyMaxHeadAge"`
+	ReadyMinPeers       uint64         `json:"readyMinPeers"`
+	AlertsFile          string         `json:"alertsFile"`
+	Alerts              *alert.Config  `json:"alerts"`
+	History             string         `json:"history"`
+	HistoryRetention    alert.Duration `json:"historyRetention"`
+	ParticipationWindow uint64         `json:"participationWindow"`
+}
+
+func (s *runSettings) policy() monitor.Policy {
+	return monitor.Policy{Quorum: s.Quorum, MaxDivergence: s.MaxDivergence, StallCycles: s.StallCycles, ChainID: s.ChainID}
+}
+
+func (s *runSettings) ready() monitor.ReadyPolicy {
+	return monitor.ReadyPolicy{MaxHeadAge: time.Duration(s.ReadyMaxHeadAge), MinPeers: s.ReadyMinPeers}
+}
+
+// loadRunSettings overlays the config file at path, if any, on base, reads
+// the alerts file and validates the result.
+func loadRunSettings(base runSettings, path string) (*runSettings, error) {
+	s := base
+	s.Nodes = append([]monitor.Node(nil), base.Nodes...)
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var keys map[string]json.RawMessage
+		if err := json.Unmarshal(data, &keys); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&s); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		_, inline := keys["alerts"]
+		_, file := keys["alertsFile"]
+		if inline && file {
+			return nil, fmt.Errorf("%s: set alerts or alertsFile, not both", path)
+		}
+		if inline {
+			s.AlertsFile = ""
+		}
+	}
+	if s.AlertsFile != "" {
+		cfg, err := alert.LoadConfig(s.AlertsFile)
+		if err != nil {
+			return nil, err
+		}
+		s.Alerts = cfg
+	}
+	if err := s.validate(); err != nil {
+		if path != "" {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *runSettings) validate() error {
+	nodes, err := monitor.CheckNodes(s.Nodes)
+	if err != nil {
+		return fmt.Errorf("nodes: %w", err)
+	}
+	s.Nodes = nodes
+	switch {
+	case s.Interval <= 0:
+		return fmt.Errorf("interval must be > 0")
+	case s.Timeout <= 0:
+		return fmt.Errorf("timeout must be > 0")
+	case s.HistoryRetention <= 0:
+		return fmt.Errorf("history retention must be > 0")
+	case s.Quorum > len(s.Nodes):
+		return fmt.Errorf("quorum %d exceeds the %d nodes", s.Quorum, len(s.Nodes))
+	}
+	if s.Alerts == nil {
+		return nil
+	}
+	if err := s.Alerts.Validate(); err != nil {
+		return fmt.Errorf("alerts: %w", err)
+	}
+	for _, r := range s.Alerts.Rules {
+		if !alertSignals[r.Signal] {
+			return fmt.Errorf("alerts: rule %q: unknown signal %q (known: %s)", r.Name, r.Signal, strings.Join(signalNames(), ", "))
+		}
+	}
+	return nil
+}
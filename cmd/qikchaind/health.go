@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/monitor"
+)
+
+// snapshot holds the latest cluster record for the HTTP endpoints of run.
+type snapshot struct {
+	mu       sync.Mutex
+	ready    monitor.ReadyPolicy
+	maxStale time.Duration // a record older than this is not ready
+	part     *participation
+	cluster  *monitor.Cluster
+	at       time.Time
+}
+
+type statusOutput struct {
+	Ready         bool                 `json:"ready"`
+	NotReady      []string             `json:"notReady,omitempty"`
+	PolledAt      string               `json:"polledAt"`
+	Cluster       *monitor.Cluster     `json:"cluster"`
+	Participation *participationReport `json:"participation,omitempty"`
+}
+
+func (s *snapshot) configure(ready monitor.ReadyPolicy, maxStale time.Duration, part *participation) {
+	s.mu.Lock()
+	s.ready, s.maxStale, s.part = ready, maxStale, part
+	s.mu.Unlock()
+}
+
+func (s *snapshot) store(c monitor.Cluster, at time.Time) {
+	s.mu.Lock()
+	s.cluster, s.at = &c, at
+	s.mu.Unlock()
+}
+
+func (s *snapshot) status(now time.Time) *statusOutput {
+	s.mu.Lock()
+	c, at, ready, maxStale, part := s.cluster, s.at, s.ready, s.maxStale, s.part
+	s.mu.Unlock()
+	if c == nil {
+		return nil
+	}
+	out := &statusOutput{PolledAt: at.UTC().Format(time.RFC3339), Cluster: c, Participation: part.latest()}
+	out.NotReady = c.NotReady(ready)
+	if age := now.Sub(at); age > maxStale {
+		out.NotReady = append(out.NotReady, fmt.Sprintf("last poll finished %s ago", age.Round(time.Second)))
+	}
+	out.Ready = len(out.NotReady) == 0
+	return out
+}
+
+func (s *snapshot) register(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		st := s.status(time.Now())
+		switch {
+		case st == nil:
+			writeJSON(w, http.StatusServiceUnavailable, map[string]any{"ready": false, "notReady": []string{"no poll has completed yet"}})
+		case !st.Ready:
+			writeJSON(w, http.StatusServiceUnavailable, map[string]any{"ready": false, "notReady": st.NotReady})
+		default:
+			writeJSON(w, http.StatusOK, map[string]any{"ready": true})
+		}
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, _ *http.Request) {
+		if st := s.status(time.Now()); st != nil {
+			writeJSON(w, http.StatusOK, st)
+			return
+		}
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "no poll has completed yet"})
+	})
+	mux.HandleFunc("/participation", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		part := s.part
+		s.mu.Unlock()
+		if part == nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "participation tracking is off"})
+			return
+		}
+		part.ServeHTTP(w, r)
+	})
+	mux.HandleFunc("/cluster", func(w http.ResponseWriter, _ *http.Request) {
+		if st := s.status(time.Now()); st != nil {
+			writeJSON(w, http.StatusOK, st.Cluster)
+			return
+		}
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "no poll has completed yet"})
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
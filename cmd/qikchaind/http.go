@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/logging"
+)
+
+// startHTTP serves h on addr in the background. Serve errors after startup
+// are logged to log.
+func startHTTP(log *slog.Logger, addr string, h http.Handler) (*http.Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	srv := &http.Server{Handler: h, ReadHeaderTimeout: 5 * time.Second, ErrorLog: slog.NewLogLogger(log.Handler(), slog.LevelWarn)}
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("http server stopped", logging.Err(err))
+		}
+	}()
+	log.Info("listening", "addr", "http://"+ln.Addr().String())
+	return srv, nil
+}
+
+// stopHTTP shuts srv down in the background, letting in-flight requests,
+// such as the reload that replaced it, finish first.
+func stopHTTP(srv *http.Server) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/flagutil"
+	"github.com/BioMark3r/qikchain/internal/index"
+	"github.com/BioMark3r/qikchain/internal/logging"
+	"github.com/BioMark3r/qikchain/internal/pos"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func cmdIndex(args []string) int {
+	if len(args) == 0 || args[0] != "staking" {
+		fmt.Fprintln(os.Stderr, "index: expected target: staking")
+		return 2
+	}
+	return cmdIndexStaking(args[1:])
+}
+
+func cmdIndexStaking(args []string) int {
+	fs := flag.NewFlagSet("index staking", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	cf := bindCommonFlags(fs)
+	dbPath := fs.String("db", flagutil.EnvOr("QIKCHAIN_INDEX_DB", ".data/index.sqlite"), "SQLite database file")
+	staking := fs.String("staking", "", "staking contract address (default: staking.address from --deployments)")
+	deployments := fs.String("deployments", flagutil.EnvOr("POS_DEPLOYMENTS_FILE", "build/deployments/pos.local.json"), "PoS deployments file")
+	stakeManager := fs.String("stake-manager", os.Getenv("POS_STAKE_MANAGER"), "StakeManager address (default: stakeManager from --addresses; not indexed when neither is set)")
+	addresses := fs.String("addresses", flagutil.EnvOr("POS_ADDRESSES_FILE", ".data/pos/addresses.json"), "addresses file written by script/pos/DeployPos.s.sol")
+	from := fs.Uint64("from", 0, "first block to index; at or before the contracts' deployment")
+	confirmations := fs.Uint64("confirmations", 0, "blocks to stay behind the head")
+	batch := fs.Uint64("batch", 1000, "max blocks per eth_getLogs request")
+	interval := fs.Duration("interval", 2*time.Second, "head poll interval")
+	listen := fs.String("listen", "", "serve the JSON API on this address, e.g. 127.0.0.1:8790")
+	once := fs.Bool("once", false, "index up to the head and exit")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	log := cf.logger("index")
+	if log == nil {
+		return 2
+	}
+	stopTracing := cf.tracer(log)
+	if stopTracing == nil {
+		return 2
+	}
+	defer stopTracing()
+	if fs.NArg() != 0 {
+		log.Error("unexpected positional arguments")
+		return 2
+	}
+	if *interval <= 0 || *batch == 0 {
+		log.Error("--interval and --batch must be > 0")
+		return 2
+	}
+
+	stakingAddr, err := resolveStaking(*staking, *deployments)
+	if err != nil {
+		log.Error("resolve staking", logging.Err(err))
+		return 2
+	}
+	managerAddr, err := resolveStakeManager(*stakeManager, *addresses, flagSet(fs, "addresses") || os.Getenv("POS_ADDRESSES_FILE") != "")
+	if err != nil {
+		log.Error("resolve stake manager", logging.Err(err))
+		return 2
+	}
+	decoder, err := index.NewDecoder(stakingAddr, managerAddr)
+	if err != nil {
+		log.Error("build decoder", logging.Err(err))
+		return 1
+	}
+
+	c := cf.client(log)
+	chainID, err := c.ChainID()
+	if err != nil {
+		log.Error("eth_chainId failed", logging.KeyRPC, cf.rpcURL, logging.Err(err))
+		return 1
+	}
+	if err := os.MkdirAll(filepath.Dir(*dbPath), 0o755); err != nil {
+		log.Error("create index directory", logging.Err(err))
+		return 1
+	}
+	store, err := index.Open(*dbPath)
+	if err != nil {
+		log.Error("open index", "db", *dbPath, logging.Err(err))
+		return 1
+	}
+	defer store.Close()
+	if err := store.Bind(chainID.Uint64(), decoder.Contracts()); err != nil {
+		log.Error("bind index", "db", *dbPath, logging.Err(err))
+		return 1
+	}
+	ix := &index.Indexer{Store: store, Chain: index.RPCChain{Client: c}, Decoder: decoder, From: *from, Confirmations: *confirmations, Batch: *batch}
+	report := func(p index.Progress) { printJSON(p) }
+
+	if *once {
+		if err := ix.Sync(report); err != nil {
+			log.Error("sync failed", logging.KeyRPC, cf.rpcURL, logging.Err(err))
+			return 1
+		}
+		return 0
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *listen != "" {
+		srv, err := startHTTP(log, *listen, index.Handler(store))
+		if err != nil {
+			log.Error("listen", "addr", *listen, logging.Err(err))
+			return 1
+		}
+		defer srv.Close()
+	}
+	log.Info("indexing", "staking", stakingAddr.Hex(), "stake_manager", managerAddr.Hex(), "db", *dbPath, logging.KeyRPC, cf.rpcURL)
+
+	sync := func() {
+		if err := ix.Sync(report); err != nil {
+			log.Warn("sync failed", logging.KeyRPC, cf.rpcURL, logging.Err(err))
+		}
+	}
+	sync()
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-ticker.C:
+			sync()
+		}
+	}
+}
+
+// resolveStakeManager returns the zero address, meaning StakeManager is not
+// indexed, when no address is given and the default addresses file is absent.
+func resolveStakeManager(flagValue, addressesPath string, explicit bool) (common.Address, error) {
+	if flagValue != "" {
+		if !common.IsHexAddress(flagValue) {
+			return common.Address{}, fmt.Errorf("invalid stake manager address %q", flagValue)
+		}
+		return common.HexToAddress(flagValue), nil
+	}
+	addr, err := pos.LoadStakeManager(addressesPath)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return common.Address{}, nil
+	}
+	return addr, err
+}
+
+func flagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
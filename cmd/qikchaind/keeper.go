@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/flagutil"
+	"github.com/BioMark3r/qikchain/internal/genesis"
+	"github.com/BioMark3r/qikchain/internal/keeper"
+	"github.com/BioMark3r/qikchain/internal/logging"
+	"github.com/BioMark3r/qikchain/internal/pos"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/BioMark3r/qikchain/internal/signer"
+	"github.com/BioMark3r/qikchain/internal/txbuild"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const keeperKeyEnv = "POS_KEEPER_PK"
+
+func cmdKeeper(args []string) int {
+	if len(args) == 0 || args[0] != "epoch" {
+		fmt.Fprintln(os.Stderr, "keeper: expected duty: epoch")
+		return 2
+	}
+	return cmdKeeperEpoch(args[1:])
+}
+
+func cmdKeeperEpoch(args []string) int {
+	fs := flag.NewFlagSet("keeper epoch", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	cf := bindCommonFlags(fs)
+	interval := fs.Duration("interval", 500*time.Millisecond, "head poll interval; keep it well under the block time")
+	window := fs.Uint64("window", 5, "blocks before a boundary in which the active set is read and checked")
+	epochManager := fs.String("epoch-manager", os.Getenv("POS_EPOCH_MANAGER"), "EpochManager address (default: epochManager from --addresses)")
+	addresses := fs.String("addresses", flagutil.EnvOr("POS_ADDRESSES_FILE", ".data/pos/addresses.json"), "addresses file written by script/pos/DeployPos.s.sol")
+	staking := fs.String("staking", "", "staking contract address (default: staking.address from --deployments)")
+	deployments := fs.String("deployments", flagutil.EnvOr("POS_DEPLOYMENTS_FILE", "build/deployments/pos.local.json"), "PoS deployments file")
+	feeMultiplier := fs.Float64("fee-multiplier", 1, "scale the node's gas price or tip suggestion")
+	sc := signer.Config{KeyEnv: keeperKeyEnv}
+	sc.BindFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	log := cf.logger("keeper")
+	if log == nil {
+		return 2
+	}
+	stopTracing := cf.tracer(log)
+	if stopTracing == nil {
+		return 2
+	}
+	defer stopTracing()
+	if fs.NArg() != 0 {
+		log.Error("unexpected positional arguments")
+		return 2
+	}
+	if *interval <= 0 {
+		log.Error("--interval must be > 0")
+		return 2
+	}
+
+	manager, err := resolveEpochManager(*epochManager, *addresses)
+	if err != nil {
+		log.Error("resolve epoch manager", logging.Err(err))
+		return 2
+	}
+	stakingAddr, err := resolveStaking(*staking, *deployments)
+	if err != nil {
+		log.Error("resolve staking", logging.Err(err))
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	sc.Timeout = cf.timeout
+	s, err := signer.New(ctx, sc)
+	if err != nil {
+		log.Error("load signer", logging.Err(err))
+		return 1
+	}
+	c := cf.client(log)
+	fees := txbuild.FeeOptions{Strategy: txbuild.StrategyMultiplier, Multiplier: *feeMultiplier}
+	chain, length, err := keeper.NewRPCEpochChain(c, s, manager, stakingAddr, fees)
+	if err != nil {
+		log.Error("bind epoch chain", logging.KeyRPC, cf.rpcURL, logging.Err(err))
+		return 1
+	}
+	k := &keeper.EpochKeeper{Chain: chain, Length: length, Window: *window}
+	log.Info("keeping epochs", "epoch_manager", manager.Hex(), "staking", stakingAddr.Hex(), "epoch_length", length, "keeper", s.Address().Hex(), logging.KeyRPC, cf.rpcURL)
+
+	tick := func() {
+		blockHex, err := c.CallString("eth_blockNumber")
+		if err != nil {
+			log.Warn("eth_blockNumber failed", logging.KeyRPC, cf.rpcURL, logging.Err(err))
+			return
+		}
+		head, err := rpc.HexToUint64(blockHex)
+		if err != nil {
+			log.Warn("eth_blockNumber decode failed", logging.KeyRPC, cf.rpcURL, logging.Err(err))
+			return
+		}
+		events, err := k.Tick(head)
+		for _, e := range events {
+			printJSON(e)
+			if e.Level == keeper.LevelAlert {
+				log.Error("epoch alert", "epoch", e.Epoch, "boundary", e.Boundary, "event", e.Event, "message", e.Message)
+			}
+		}
+		if err != nil {
+			log.Warn("tick failed", "head", head, logging.Err(err))
+		}
+	}
+
+	tick()
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-ticker.C:
+			tick()
+		}
+	}
+}
+
+func resolveEpochManager(flagValue, addressesPath string) (common.Address, error) {
+	if flagValue == "" {
+		addr, err := pos.LoadEpochManager(addressesPath)
+		if err != nil {
+			return common.Address{}, fmt.Errorf("%w (or pass --epoch-manager)", err)
+		}
+		return addr, nil
+	}
+	if !common.IsHexAddress(flagValue) {
+		return common.Address{}, fmt.Errorf("invalid epoch manager address %q", flagValue)
+	}
+	return common.HexToAddress(flagValue), nil
+}
+
+func resolveStaking(flagValue, deploymentsPath string) (common.Address, error) {
+	if flagValue == "" {
+		addrs, err := genesis.LoadPOSAddresses(deploymentsPath)
+		if err != nil {
+			return common.Address{}, fmt.Errorf("%w (or pass --staking)", err)
+		}
+		flagValue = addrs.Staking
+	}
+	if !common.IsHexAddress(flagValue) {
+		return common.Address{}, fmt.Errorf("invalid staking address %q", flagValue)
+	}
+	return common.HexToAddress(flagValue), nil
+}
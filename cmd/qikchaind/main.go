@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
+	"github.com/BioMark3r/qikchain/internal/logging"
+	"github.com/BioMark3r/qikchain/internal/monitor"
 	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/BioMark3r/qikchain/internal/tracing"
 )
 
 var (
@@ -19,21 +22,11 @@ var (
 	date    = "unknown"
 )
 
-type output struct {
-	RPC         string `json:"rpc"`
-	Timestamp   string `json:"timestamp"`
-	ChainID     uint64 `json:"chainId,omitempty"`
-	ChainHex    string `json:"chainHex,omitempty"`
-	BlockNumber uint64 `json:"blockNumber,omitempty"`
-	BlockHex    string `json:"blockHex,omitempty"`
-	PeerCount   uint64 `json:"peerCount,omitempty"`
-	PeerHex     string `json:"peerHex,omitempty"`
-	Error       string `json:"error,omitempty"`
-}
-
 type commonFlags struct {
 	rpcURL  string
 	timeout time.Duration
+	log     logging.Config
+	trace   tracing.Config
 }
 
 func main() {
@@ -42,7 +35,7 @@ func main() {
 
 func run(argv []string) int {
 	if len(argv) == 0 {
-		fmt.Fprintln(os.Stderr, "qikchaind: expected command: once|run")
+		fmt.Fprintln(os.Stderr, "qikchaind: expected command: once|run|keeper|index")
 		return 2
 	}
 
@@ -51,6 +44,10 @@ func run(argv []string) int {
 		return cmdOnce(argv[1:])
 	case "run":
 		return cmdRun(argv[1:])
+	case "keeper":
+		return cmdKeeper(argv[1:])
+	case "index":
+		return cmdIndex(argv[1:])
 	case "-h", "--help", "help":
 		printHelp()
 		return 0
@@ -63,11 +60,28 @@ func run(argv []string) int {
 func printHelp() {
 	fmt.Print(`Usage:
   qikchaind once --rpc <url> [--timeout 5s]
-  qikchaind run --rpc <url> [--timeout 5s] [--interval 5s]
+  qikchaind run [--rpc <url> | --nodes name=url,...] [--config <file>] [--interval 5s] [--listen :9700] [--alerts <file>] [--history <file>]
+  qikchaind keeper epoch --rpc <url> [--epoch-manager <addr>] [--staking <addr>] [--window 5]
+  qikchaind index staking --rpc <url> [--db .data/index.sqlite] [--listen <addr>] [--once]
 
 Commands:
-  once    Poll one cycle and print one JSON line.
-  run     Poll continuously and print JSON lines.
+  once          Poll one cycle and print one JSON line.
+  run           Poll the nodes concurrently and print one cluster health JSON
+                line per cycle, tracking validator participation from the
+                IBFT seals; with --listen, serve /metrics, /cluster and
+                /participation. SIGHUP or POST /-/reload rereads --config
+                and the alerts file without losing state.
+  keeper epoch  Snapshot the active set into EpochManager at every epoch
+                boundary, signed with POS_KEEPER_PK; prints JSON events.
+  index staking Backfill and follow staking events into a SQLite index,
+                optionally serving it as a JSON API.
+
+Every command takes --log-format text|json and --log-level
+debug|info|warn|error (env QIKCHAIN_LOG_FORMAT, QIKCHAIN_LOG_LEVEL). Logs go
+to stderr; stdout carries only the JSON output. --trace-exporter otlp|file
+with --trace-endpoint or --trace-file (env QIKCHAIN_TRACE_EXPORTER,
+QIKCHAIN_TRACE_ENDPOINT, QIKCHAIN_TRACE_FILE) exports OpenTelemetry spans
+for every poll cycle and RPC call.
 `)
 }
 
@@ -77,68 +91,34 @@ func cmdOnce(args []string) int {
 		fmt.Fprintln(os.Stderr, err)
 		return 2
 	}
-
-	c := rpc.NewClient(flags.rpcURL, flags.timeout)
-	out, err := collect(c, flags.rpcURL)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		return 1
-	}
-	printJSON(out)
-	return 0
-}
-
-func cmdRun(args []string) int {
-	fs := flag.NewFlagSet("run", flag.ContinueOnError)
-	fs.SetOutput(os.Stderr)
-	common := bindCommonFlags(fs)
-	interval := fs.Duration("interval", 5*time.Second, "poll interval")
-	if err := fs.Parse(args); err != nil {
-		return 2
-	}
-	if fs.NArg() != 0 {
-		fmt.Fprintln(os.Stderr, "run: unexpected positional arguments")
+	log := flags.logger("once")
+	if log == nil {
 		return 2
 	}
-	if *interval <= 0 {
-		fmt.Fprintln(os.Stderr, "run: --interval must be > 0")
+	stopTracing := flags.tracer(log)
+	if stopTracing == nil {
 		return 2
 	}
+	defer stopTracing()
 
-	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
-	defer stop()
-
-	c := rpc.NewClient(common.rpcURL, common.timeout)
-	runCycle := func() {
-		out, err := collect(c, common.rpcURL)
-		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			printJSON(output{
-				RPC:       common.rpcURL,
-				Timestamp: time.Now().UTC().Format(time.RFC3339),
-				Error:     err.Error(),
-			})
-			return
-		}
-		printJSON(out)
+	nodes, err := monitor.ParseNodes(flags.rpcURL)
+	if err != nil {
+		log.Error("invalid --rpc", logging.Err(err))
+		return 2
 	}
-
-	runCycle()
-
-	ticker := time.NewTicker(*interval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return 0
-		case <-ticker.C:
-			runCycle()
-		}
+	ctx, span := tracing.Start(context.Background(), "qikchaind.once", tracing.String("qikchaind.node", nodes[0].Name))
+	out := monitor.Collect(flags.client(log).WithContext(ctx), nodes[0])
+	span.SetAttributes(tracing.Bool("qikchaind.up", out.Up))
+	span.End()
+	if !out.Up {
+		log.Error("poll failed", logging.KeyNode, out.Name, logging.KeyRPC, out.RPC, logging.KeyError, out.Error)
+		return 1
 	}
+	printJSON(out)
+	return 0
 }
 
-func parseCommonFlags(name string, args []string) (commonFlags, error) {
+func parseCommonFlags(name string, args []string) (*commonFlags, error) {
 	fs := flag.NewFlagSet(name, flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	common := bindCommonFlags(fs)
@@ -151,7 +131,7 @@ func parseCommonFlags(name string, args []string) (commonFlags, error) {
 	return common, nil
 }
 
-func bindCommonFlags(fs *flag.FlagSet) commonFlags {
+func bindCommonFlags(fs *flag.FlagSet) *commonFlags {
 	defaultRPC := os.Getenv("QIKCHAIN_RPC")
 	if defaultRPC == "" {
 		defaultRPC = "http://127.0.0.1:8545"
@@ -165,56 +145,59 @@ func bindCommonFlags(fs *flag.FlagSet) commonFlags {
 		}
 	}
 
-	common := commonFlags{}
+	common := &commonFlags{}
 	fs.StringVar(&common.rpcURL, "rpc", defaultRPC, "JSON-RPC endpoint")
 	fs.DurationVar(&common.timeout, "timeout", defaultTimeout, "request timeout")
+	common.log.BindFlags(fs)
+	common.trace.BindFlags(fs)
 	return common
 }
 
-func collect(c *rpc.Client, rpcURL string) (output, error) {
-	chainHex, err := c.CallString("eth_chainId")
-	if err != nil {
-		return output{}, fmt.Errorf("eth_chainId: %w", err)
-	}
-	blockHex, err := c.CallString("eth_blockNumber")
+// logger installs the --log-format and --log-level logger for component. On
+// a bad value it reports on stderr and returns nil.
+func (c *commonFlags) logger(component string) *slog.Logger {
+	l, err := c.log.Setup(component)
 	if err != nil {
-		return output{}, fmt.Errorf("eth_blockNumber: %w", err)
-	}
-	peerHex, err := c.CallString("net_peerCount")
-	if err != nil {
-		return output{}, fmt.Errorf("net_peerCount: %w", err)
+		fmt.Fprintf(os.Stderr, "%s: %v\n", component, err)
+		return nil
 	}
+	return l
+}
 
-	chainID, err := rpc.HexToUint64(chainHex)
+// tracer starts the --trace-exporter exporter. The returned function flushes
+// it; it is nil when the flags are invalid, which has been logged.
+func (c *commonFlags) tracer(log *slog.Logger) func() {
+	stop, err := c.trace.Setup("qikchaind")
 	if err != nil {
-		return output{}, fmt.Errorf("eth_chainId decode: %w", err)
-	}
-	blockNumber, err := rpc.HexToUint64(blockHex)
-	if err != nil {
-		return output{}, fmt.Errorf("eth_blockNumber decode: %w", err)
-	}
-	peerCount, err := rpc.HexToUint64(peerHex)
-	if err != nil {
-		return output{}, fmt.Errorf("net_peerCount decode: %w", err)
+		log.Error("invalid tracing flags", logging.Err(err))
+		return nil
 	}
+	return stop
+}
 
-	return output{
-		RPC:         rpcURL,
-		Timestamp:   time.Now().UTC().Format(time.RFC3339),
-		ChainID:     chainID,
-		ChainHex:    chainHex,
-		BlockNumber: blockNumber,
-		BlockHex:    blockHex,
-		PeerCount:   peerCount,
-		PeerHex:     peerHex,
-	}, nil
+// client returns a --rpc client that logs every call to log at debug level.
+func (c *commonFlags) client(log *slog.Logger) *rpc.Client {
+	client := rpc.NewClient(c.rpcURL, c.timeout)
+	client.SetObserver(func(method string, took time.Duration, err error) {
+		if err != nil {
+			log.Debug("rpc call failed", logging.KeyRPC, c.rpcURL, "method", method, logging.Duration(took), logging.Err(err))
+			return
+		}
+		log.Debug("rpc call", logging.KeyRPC, c.rpcURL, "method", method, logging.Duration(took))
+	})
+	return client
 }
 
 func printJSON(v any) {
+	fprintJSON(os.Stdout, v)
+}
+
+// fprintJSON writes v to w as one JSON line.
+func fprintJSON(w io.Writer, v any) {
 	b, err := json.Marshal(v)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		slog.Error("encode output", logging.Err(err))
 		return
 	}
-	fmt.Println(string(b))
+	fmt.Fprintln(w, string(b))
 }
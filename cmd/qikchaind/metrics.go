@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/logging"
+	"github.com/BioMark3r/qikchain/internal/metrics"
+	"github.com/BioMark3r/qikchain/internal/monitor"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+)
+
+// nodeMetrics are the per-node series served at /metrics. Every series has
+// a node label.
+type nodeMetrics struct {
+	up            *metrics.Vec
+	head          *metrics.Vec
+	headTime      *metrics.Vec
+	headAge       *metrics.Vec
+	peers         *metrics.Vec
+	chainID       *metrics.Vec
+	chainMismatch *metrics.Vec
+	polls         *metrics.Vec
+	pollFailures  *metrics.Vec
+	rpcDuration   *metrics.HistogramVec
+	rpcErrors     *metrics.Vec
+	stalled       *metrics.Vec
+
+	clusterHealthy    *metrics.Vec
+	clusterNodesUp    *metrics.Vec
+	clusterNodesTotal *metrics.Vec
+	clusterDivergence *metrics.Vec
+	clusterStalled    *metrics.Vec
+}
+
+func newNodeMetrics(reg *metrics.Registry) *nodeMetrics {
+	reg.Gauge("qikchaind_build_info", "qikchaind build; always 1.", "version", "commit").Set(1, version, commit)
+	return &nodeMetrics{
+		up:            reg.Gauge("qikchain_node_up", "1 when the last poll of the node succeeded.", "node"),
+		head:          reg.Gauge("qikchain_head_block", "Latest block number reported by the node.", "node"),
+		headTime:      reg.Gauge("qikchain_head_timestamp_seconds", "Timestamp of the node's latest block.", "node"),
+		headAge:       reg.Gauge("qikchain_head_age_seconds", "Seconds between the node's latest block timestamp and the last poll.", "node"),
+		peers:         reg.Gauge("qikchain_peers", "Peer count reported by net_peerCount.", "node"),
+		chainID:       reg.Gauge("qikchain_chain_id", "Chain ID reported by eth_chainId.", "node"),
+		chainMismatch: reg.Gauge("qikchain_chain_id_mismatch", "1 when the node's chain ID differs from the expected one.", "node"),
+		polls:         reg.Counter("qikchain_polls_total", "Poll cycles run against the node.", "node"),
+		pollFailures:  reg.Counter("qikchain_poll_failures_total", "Poll cycles that failed.", "node"),
+		rpcDuration:   reg.Histogram("qikchain_rpc_request_duration_seconds", "JSON-RPC request latency by method.", metrics.DefaultBuckets, "node", "method"),
+		rpcErrors:     reg.Counter("qikchain_rpc_errors_total", "Failed JSON-RPC requests by method.", "node", "method"),
+		stalled:       reg.Gauge("qikchain_node_head_stalled", "1 when the node's head has not advanced for --stall-cycles polls.", "node"),
+
+		clusterHealthy:    reg.Gauge("qikchain_cluster_healthy", "1 when the cluster passes every health check."),
+		clusterNodesUp:    reg.Gauge("qikchain_cluster_nodes_up", "Monitored nodes that answered the last poll."),
+		clusterNodesTotal: reg.Gauge("qikchain_cluster_nodes_total", "Monitored nodes."),
+		clusterDivergence: reg.Gauge("qikchain_cluster_head_divergence", "Spread in blocks between the highest and lowest head of up nodes."),
+		clusterStalled:    reg.Gauge("qikchain_cluster_head_stalled", "1 when the highest head has not advanced for --stall-cycles polls."),
+	}
+}
+
+// instrument records the latency and errors of every call c makes and logs
+// each call to log at debug level.
+func (m *nodeMetrics) instrument(log *slog.Logger, c *rpc.Client, node string) {
+	m.polls.Add(0, node)
+	m.pollFailures.Add(0, node)
+	c.SetObserver(func(method string, took time.Duration, err error) {
+		m.rpcDuration.Observe(took.Seconds(), node, method)
+		if err != nil && !errors.Is(err, rpc.ErrNotFound) {
+			m.rpcErrors.Inc(node, method)
+			log.Debug("rpc call failed", "method", method, logging.Duration(took), logging.Err(err))
+			return
+		}
+		log.Debug("rpc call", "method", method, logging.Duration(took))
+	})
+}
+
+// forget drops the series of a node that is no longer monitored.
+func (m *nodeMetrics) forget(node string) {
+	for _, v := range []*metrics.Vec{m.up, m.head, m.headTime, m.headAge, m.peers, m.chainID, m.chainMismatch, m.polls, m.pollFailures, m.rpcErrors, m.stalled} {
+		v.DeletePrefix(node)
+	}
+	m.rpcDuration.DeletePrefix(node)
+}
+
+// record updates the node's gauges from one poll. On failure the last known
+// head, peers and chain ID are kept and only up drops to 0.
+func (m *nodeMetrics) record(st monitor.NodeStatus) {
+	node := st.Name
+	m.polls.Inc(node)
+	if !st.Up {
+		m.pollFailures.Inc(node)
+		m.up.Set(0, node)
+		return
+	}
+	m.up.Set(1, node)
+	m.head.Set(float64(st.BlockNumber), node)
+	m.headTime.Set(float64(st.BlockTime), node)
+	m.headAge.Set(st.HeadAgeSeconds, node)
+	m.peers.Set(float64(st.PeerCount), node)
+	m.chainID.Set(float64(st.ChainID), node)
+	m.chainMismatch.Set(boolValue(st.ChainIDMismatch), node)
+	m.stalled.Set(boolValue(st.Stalled), node)
+}
+
+func (m *nodeMetrics) recordCluster(c monitor.Cluster) {
+	m.clusterHealthy.Set(boolValue(c.Healthy))
+	m.clusterNodesUp.Set(float64(c.NodesUp))
+	m.clusterNodesTotal.Set(float64(c.NodesTotal))
+	m.clusterDivergence.Set(float64(c.HeadDivergence))
+	m.clusterStalled.Set(boolValue(c.HeadStalled))
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
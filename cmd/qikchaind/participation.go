@@ -0,0 +1,162 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/BioMark3r/qikchain/internal/metrics"
+	"github.com/BioMark3r/qikchain/internal/monitor"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/BioMark3r/qikchain/internal/uptime"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// participationWorkers bounds the concurrent block reads per cycle.
+const participationWorkers = 8
+
+type participationReport struct {
+	Window     int            `json:"window"`
+	FromBlock  uint64         `json:"fromBlock"`
+	ToBlock    uint64         `json:"toBlock"`
+	Validators []uptime.Stats `json:"validators"`
+}
+
+// participation follows the chain head and keeps per-validator committed
+// seal and proposer counts over the last size blocks.
+type participation struct {
+	size   uint64
+	window *uptime.Window
+
+	ratio, signed, missed, proposed, lastSigned *metrics.Vec
+	missedTotal, proposedTotal                  *metrics.Vec
+	head                                        *metrics.Vec
+
+	mu     sync.Mutex
+	report *participationReport
+}
+
+func newParticipation(reg *metrics.Registry, size uint64) *participation {
+	return &participation{
+		size:          size,
+		window:        uptime.NewWindow(int(size)),
+		ratio:         reg.Gauge("qikchain_validator_participation_ratio", "Share of the window's blocks carrying the validator's committed seal.", "validator"),
+		signed:        reg.Gauge("qikchain_validator_signed_blocks", "Blocks in the window carrying the validator's committed seal.", "validator"),
+		missed:        reg.Gauge("qikchain_validator_missed_blocks", "Blocks in the window the validator was in the set for but did not seal.", "validator"),
+		proposed:      reg.Gauge("qikchain_validator_proposed_blocks", "Blocks in the window proposed by the validator.", "validator"),
+		lastSigned:    reg.Gauge("qikchain_validator_last_signed_block", "Newest block carrying the validator's committed seal.", "validator"),
+		missedTotal:   reg.Counter("qikchain_validator_missed_blocks_total", "Blocks the validator did not seal since qikchaind started.", "validator"),
+		proposedTotal: reg.Counter("qikchain_validator_proposed_blocks_total", "Blocks proposed by the validator since qikchaind started.", "validator"),
+		head:          reg.Gauge("qikchain_participation_head_block", "Newest block included in the participation window."),
+	}
+}
+
+// resize keeps the newest size blocks of the window and tracks size blocks
+// from now on; the metrics follow on the next update.
+func (p *participation) resize(size uint64) {
+	p.size = size
+	p.window.Resize(int(size))
+}
+
+// clear empties the window and drops the validator series, for when
+// participation tracking is switched off.
+func (p *participation) clear() {
+	for _, vec := range []*metrics.Vec{p.ratio, p.signed, p.missed, p.proposed, p.lastSigned} {
+		for _, s := range p.latest().validators() {
+			vec.DeletePrefix(s.Validator)
+		}
+	}
+	p.head.Set(0)
+	p.window = uptime.NewWindow(int(p.size))
+	p.mu.Lock()
+	p.report = nil
+	p.mu.Unlock()
+}
+
+// update reads the blocks after the last one seen from the up node with the
+// highest head. IBFT blocks are final, so heights are never revisited.
+func (p *participation) update(cluster monitor.Cluster, clients []*rpc.Client) error {
+	src := -1
+	for i, st := range cluster.Nodes {
+		if st.Up && !st.ChainIDMismatch && (src < 0 || st.BlockNumber > cluster.Nodes[src].BlockNumber) {
+			src = i
+		}
+	}
+	if src < 0 {
+		return nil
+	}
+	to := cluster.Nodes[src].BlockNumber
+	from := p.window.Head() + 1
+	if to >= p.size && to-p.size+1 > from {
+		from = to - p.size + 1
+	}
+	records, err := uptime.Fetch(clients[src], from, to, participationWorkers)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	for _, r := range records {
+		p.window.Add(r)
+		signed := make(map[common.Address]bool, len(r.Signers))
+		for _, a := range r.Signers {
+			signed[a] = true
+		}
+		for _, v := range r.Validators {
+			p.missedTotal.Add(0, v.Hex())
+			if !signed[v] {
+				p.missedTotal.Inc(v.Hex())
+			}
+		}
+		if r.Proposer != (common.Address{}) {
+			p.proposedTotal.Inc(r.Proposer.Hex())
+		}
+	}
+
+	stats := p.window.Stats()
+	for _, vec := range []*metrics.Vec{p.ratio, p.signed, p.missed, p.proposed, p.lastSigned} {
+		for _, s := range p.report.validators() {
+			vec.DeletePrefix(s.Validator)
+		}
+	}
+	for _, s := range stats {
+		p.ratio.Set(s.Ratio, s.Validator)
+		p.signed.Set(float64(s.Signed), s.Validator)
+		p.missed.Set(float64(s.Missed), s.Validator)
+		p.proposed.Set(float64(s.Proposed), s.Validator)
+		p.lastSigned.Set(float64(s.LastSignedBlock), s.Validator)
+	}
+	p.head.Set(float64(p.window.Head()))
+
+	report := &participationReport{Window: p.window.Len(), ToBlock: p.window.Head(), Validators: stats}
+	report.FromBlock = report.ToBlock - uint64(report.Window) + 1
+	p.mu.Lock()
+	p.report = report
+	p.mu.Unlock()
+	return nil
+}
+
+func (r *participationReport) validators() []uptime.Stats {
+	if r == nil {
+		return nil
+	}
+	return r.Validators
+}
+
+// latest returns the report of the last update, or nil before the first.
+func (p *participation) latest() *participationReport {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.report
+}
+
+func (p *participation) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	if report := p.latest(); report != nil {
+		writeJSON(w, http.StatusOK, report)
+		return
+	}
+	writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "no blocks read yet"})
+}
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/alert"
+	"github.com/BioMark3r/qikchain/internal/history"
+	"github.com/BioMark3r/qikchain/internal/logging"
+	"github.com/BioMark3r/qikchain/internal/metrics"
+	"github.com/BioMark3r/qikchain/internal/monitor"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/BioMark3r/qikchain/internal/tracing"
+)
+
+func cmdRun(args []string) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	cf := bindCommonFlags(fs)
+	var base runSettings
+	configPath := fs.String("config", os.Getenv("QIKCHAIND_CONFIG"), "JSON config file whose keys override the flags; reloaded on SIGHUP and POST /-/reload")
+	fs.DurationVar((*time.Duration)(&base.Interval), "interval", 5*time.Second, "poll interval")
+	nodesSpec := fs.String("nodes", os.Getenv("QIKCHAIND_NODES"), "comma-separated nodes to monitor as name=url or url (default: --rpc)")
+	fs.StringVar(&base.Listen, "listen", os.Getenv("QIKCHAIND_LISTEN"), "serve /metrics, /healthz, /readyz, /status, /cluster, /participation and /-/reload on this address, e.g. :9700")
+	fs.DurationVar((*time.Duration)(&base.ReadyMaxHeadAge), "ready-max-head-age", 30*time.Second, "/readyz fails when the newest block is older; 0 disables")
+	fs.Uint64Var(&base.ReadyMinPeers, "ready-min-peers", 0, "/readyz fails when an up node has fewer peers")
+	fs.StringVar(&base.AlertsFile, "alerts", os.Getenv("QIKCHAIND_ALERTS"), "alert rules and webhooks file (JSON)")
+	fs.StringVar(&base.History, "history", os.Getenv("QIKCHAIND_HISTORY"), "record every cycle to this SQLite file, e.g. .data/qikchaind/history.sqlite")
+	fs.DurationVar((*time.Duration)(&base.HistoryRetention), "history-retention", 7*24*time.Hour, "drop history older than this")
+	fs.Uint64Var(&base.ParticipationWindow, "participation-window", 1000, "blocks over which validator participation is tracked; 0 disables")
+	fs.Uint64Var(&base.ChainID, "chain-id", 0, "expected chain ID (default: the first one a node reports)")
+	fs.IntVar(&base.Quorum, "quorum", 0, "nodes that must be up (default: a majority)")
+	fs.Uint64Var(&base.MaxDivergence, "max-divergence", 3, "max head spread in blocks between up nodes")
+	fs.IntVar(&base.StallCycles, "stall-cycles", 3, "polls without head progress before a head counts as stalled; 0 disables")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	log := cf.logger("run")
+	if log == nil {
+		return 2
+	}
+	stopTracing := cf.tracer(log)
+	if stopTracing == nil {
+		return 2
+	}
+	defer stopTracing()
+	if fs.NArg() != 0 {
+		log.Error("unexpected positional arguments")
+		return 2
+	}
+	spec := *nodesSpec
+	if spec == "" {
+		spec = cf.rpcURL
+	}
+	nodes, err := monitor.ParseNodes(spec)
+	if err != nil {
+		log.Error("invalid --nodes", logging.Err(err))
+		return 2
+	}
+	base.Nodes = nodes
+	base.Timeout = alert.Duration(cf.timeout)
+	s, err := loadRunSettings(base, *configPath)
+	if err != nil {
+		log.Error("invalid configuration", logging.Err(err))
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	r := newRunner(log, base, *configPath)
+	defer r.close()
+	if err := r.apply(s); err != nil {
+		log.Error("start", logging.Err(err))
+		return 1
+	}
+
+	r.cycle()
+	ticker := time.NewTicker(time.Duration(r.s.Interval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-ticker.C:
+			r.cycle()
+		case <-hup:
+			r.reload("signal")
+			ticker.Reset(time.Duration(r.s.Interval))
+		case reply := <-r.reloads:
+			reply <- r.reload("http")
+			ticker.Reset(time.Duration(r.s.Interval))
+		}
+	}
+}
+
+// runner is the state of run. The metrics, head tracking, participation
+// window, alert state and history outlive a config reload; apply only
+// changes what the new settings change.
+type runner struct {
+	log     *slog.Logger
+	base    runSettings // from the flags
+	path    string      // --config
+	out     io.Writer   // one cluster JSON line per cycle
+	reg     *metrics.Registry
+	nm      *nodeMetrics
+	mux     *http.ServeMux
+	snap    snapshot
+	tracker monitor.Tracker
+	reloads chan chan error
+
+	reloadsTotal *metrics.Vec
+	reloadOK     *metrics.Vec
+
+	s       *runSettings // applied settings
+	clients []*rpc.Client
+	part    *participation // kept while switched off
+	alerts  *alerting
+	srv     *http.Server
+	hist    *history.Store
+	pruned  time.Time
+	healthy bool
+}
+
+func newRunner(log *slog.Logger, base runSettings, path string) *runner {
+	r := &runner{log: log, base: base, path: path, out: os.Stdout, reg: metrics.NewRegistry(), mux: http.NewServeMux(), reloads: make(chan chan error), healthy: true}
+	r.nm = newNodeMetrics(r.reg)
+	r.reloadsTotal = r.reg.Counter("qikchaind_config_reloads_total", "Config reloads by result.", "result")
+	r.reloadOK = r.reg.Gauge("qikchaind_config_last_reload_successful", "1 when the last config reload was applied.")
+	r.reloadsTotal.Add(0, "success")
+	r.reloadsTotal.Add(0, "failure")
+	r.reloadOK.Set(1)
+	r.mux.Handle("/metrics", r.reg.Handler())
+	r.mux.HandleFunc("/-/reload", r.serveReload)
+	r.snap.register(r.mux)
+	return r
+}
+
+// apply switches to s, which must have been validated. The listener and the
+// history file are opened first, so a failure leaves the running
+// configuration untouched.
+func (r *runner) apply(s *runSettings) error {
+	first := r.s == nil
+	timeout := time.Duration(s.Timeout)
+	var srv *http.Server
+	listenChanged := first || s.Listen != r.s.Listen
+	if listenChanged && s.Listen != "" {
+		var err error
+		if srv, err = startHTTP(r.log, s.Listen, r.mux); err != nil {
+			return fmt.Errorf("listen %s: %w", s.Listen, err)
+		}
+	}
+	var hist *history.Store
+	historyChanged := first || s.History != r.s.History
+	if historyChanged && s.History != "" {
+		err := os.MkdirAll(filepath.Dir(s.History), 0o755)
+		if err == nil {
+			hist, err = history.Open(s.History)
+		}
+		if err != nil {
+			if srv != nil {
+				srv.Close()
+			}
+			return fmt.Errorf("history: %w", err)
+		}
+	}
+
+	if listenChanged {
+		if r.srv != nil {
+			stopHTTP(r.srv)
+		}
+		r.srv = srv
+	}
+	if historyChanged {
+		if r.hist != nil {
+			r.hist.Close()
+		}
+		r.hist = hist
+	}
+
+	// Keep the client, and with it the series, of every node whose URL and
+	// timeout are unchanged.
+	old := map[string]int{}
+	if !first {
+		for i, n := range r.s.Nodes {
+			old[n.Name] = i
+		}
+	}
+	clients := make([]*rpc.Client, len(s.Nodes))
+	for i, n := range s.Nodes {
+		if j, ok := old[n.Name]; ok && r.s.Nodes[j].URL == n.URL && r.s.Timeout == s.Timeout {
+			clients[i] = r.clients[j]
+		} else {
+			clients[i] = rpc.NewClient(n.URL, timeout)
+			r.nm.instrument(r.log.With(logging.KeyNode, n.Name, logging.KeyRPC, n.URL), clients[i], n.Name)
+		}
+		delete(old, n.Name)
+	}
+	for name := range old {
+		r.nm.forget(name)
+		r.tracker.Forget(name)
+	}
+
+	policy := s.policy()
+	if policy.ChainID == 0 {
+		policy.ChainID = r.tracker.Policy.ChainID
+	}
+	r.tracker.Policy = policy
+
+	switch {
+	case s.ParticipationWindow > 0 && r.part == nil:
+		r.part = newParticipation(r.reg, s.ParticipationWindow)
+	case s.ParticipationWindow > 0:
+		r.part.resize(s.ParticipationWindow)
+	case r.part != nil && r.s.ParticipationWindow > 0:
+		r.part.clear()
+	}
+	if s.Alerts != nil && r.alerts == nil {
+		r.alerts = newAlerting(r.log, r.reg)
+	}
+	if r.alerts != nil {
+		r.alerts.configure(time.Now(), s.Alerts, timeout)
+	}
+
+	r.s, r.clients = s, clients
+	// A poll cycle can take up to --timeout per request; allow a few.
+	r.snap.configure(s.ready(), 3*(time.Duration(s.Interval)+4*timeout), r.participation())
+	return nil
+}
+
+// participation returns the participation tracker, or nil while it is off.
+func (r *runner) participation() *participation {
+	if r.s.ParticipationWindow == 0 {
+		return nil
+	}
+	return r.part
+}
+
+func (r *runner) reload(source string) error {
+	s, err := loadRunSettings(r.base, r.path)
+	if err == nil {
+		err = r.apply(s)
+	}
+	if err != nil {
+		r.reloadsTotal.Inc("failure")
+		r.reloadOK.Set(0)
+		r.log.Error("config reload failed, keeping the running config", "source", source, logging.Err(err))
+		return err
+	}
+	r.reloadsTotal.Inc("success")
+	r.reloadOK.Set(1)
+	r.log.Info("config reloaded", "source", source, "nodes", len(s.Nodes))
+	return nil
+}
+
+// serveReload asks the run loop to reload and reports the result.
+func (r *runner) serveReload(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "use POST"})
+		return
+	}
+	reply := make(chan error, 1)
+	select {
+	case r.reloads <- reply:
+	case <-req.Context().Done():
+		return
+	}
+	select {
+	case err := <-reply:
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"reloaded": false, "error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"reloaded": true})
+	case <-req.Context().Done():
+	}
+}
+
+// cycle polls once. Its span parents the spans of every RPC call it makes.
+func (r *runner) cycle() {
+	start := time.Now()
+	ctx, span := tracing.Start(context.Background(), "qikchaind.cycle", tracing.Int("qikchaind.nodes", len(r.s.Nodes)))
+	defer span.End()
+	part := r.participation()
+	cluster := r.tracker.Evaluate(start, monitor.Poll(r.s.Nodes, withContext(ctx, r.clients)))
+	span.SetAttributes(tracing.Int("qikchaind.nodes_up", cluster.NodesUp), tracing.Bool("qikchaind.healthy", cluster.Healthy))
+	for _, st := range cluster.Nodes {
+		r.nm.record(st)
+		if !st.Up {
+			r.log.Warn("node down", logging.KeyNode, st.Name, logging.KeyRPC, st.RPC, logging.KeyError, st.Error)
+		}
+	}
+	r.nm.recordCluster(cluster)
+	if part != nil {
+		pctx, pspan := tracing.Start(ctx, "qikchaind.participation")
+		if err := part.update(cluster, withContext(pctx, r.clients)); err != nil {
+			pspan.RecordError(err)
+			r.log.Warn("participation update failed", logging.Err(err))
+		}
+		pspan.End()
+	}
+	if r.alerts != nil {
+		r.alerts.evaluate(time.Now(), cluster, part.latest())
+	}
+	if cluster.Healthy != r.healthy {
+		if cluster.Healthy {
+			r.log.Info("cluster healthy again")
+		} else {
+			r.log.Warn("cluster unhealthy", "reasons", strings.Join(cluster.Reasons, "; "))
+		}
+		r.healthy = cluster.Healthy
+	}
+	now := time.Now()
+	r.snap.store(cluster, now)
+	if r.hist != nil {
+		_, hspan := tracing.Start(ctx, "qikchaind.history")
+		if err := r.hist.Record(now, cluster); err != nil {
+			hspan.RecordError(err)
+			r.log.Error("record history", logging.Err(err))
+		}
+		hspan.End()
+		if now.Sub(r.pruned) >= time.Minute {
+			if err := r.hist.Prune(now.Add(-time.Duration(r.s.HistoryRetention))); err != nil {
+				r.log.Error("prune history", logging.Err(err))
+			}
+			r.pruned = now
+		}
+	}
+	fprintJSON(r.out, cluster)
+	r.log.Debug("cycle done", "healthy", cluster.Healthy, "nodes_up", cluster.NodesUp, logging.Duration(time.Since(start)))
+}
+
+// withContext binds clients to ctx for one cycle.
+func withContext(ctx context.Context, clients []*rpc.Client) []*rpc.Client {
+	out := make([]*rpc.Client, len(clients))
+	for i, c := range clients {
+		out[i] = c.WithContext(ctx)
+	}
+	return out
+}
+
+func (r *runner) close() {
+	if r.srv != nil {
+		r.srv.Close()
+	}
+	if r.alerts != nil {
+		r.alerts.close()
+	}
+	if r.hist != nil {
+		r.hist.Close()
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/alert"
+	"github.com/BioMark3r/qikchain/internal/ibft"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// newNode serves a chain whose head stays at block head.
+func newNode(t *testing.T, head uint64) *httptest.Server {
+	t.Helper()
+	validators := []common.Address{common.HexToAddress("0xa"), common.HexToAddress("0xb")}
+	body, err := rlp.EncodeToBytes([]any{validators, []byte{}, [][]byte{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	extra := append(bytes.Repeat([]byte{0}, ibft.ExtraVanity), body...)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		var result any
+		switch req.Method {
+		case "eth_chainId":
+			result = "0x64"
+		case "net_peerCount":
+			result = "0x3"
+		case "eth_getBlockByNumber":
+			var tag string
+			json.Unmarshal(req.Params[0], &tag)
+			n := head
+			if tag != "latest" {
+				n, _ = hexutil.DecodeUint64(tag)
+			}
+			result = rpc.Block{Number: hexutil.Uint64(n), Hash: common.BigToHash(new(big.Int).SetUint64(n)), Timestamp: hexutil.Uint64(time.Now().Unix()), Miner: validators[n%2], ExtraData: extra}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestRunner(t *testing.T, config string) (*runner, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "qikchaind.json")
+	writeConfig(t, path, config)
+	base := runSettings{
+		Interval:         alert.Duration(time.Second),
+		Timeout:          alert.Duration(2 * time.Second),
+		HistoryRetention: alert.Duration(time.Hour),
+		MaxDivergence:    3,
+	}
+	r := newRunner(slog.New(slog.NewTextHandler(io.Discard, nil)), base, path)
+	r.out = io.Discard
+	t.Cleanup(r.close)
+	s, err := loadRunSettings(base, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.apply(s); err != nil {
+		t.Fatal(err)
+	}
+	return r, path
+}
+
+func writeConfig(t *testing.T, path, config string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func metricsText(t *testing.T, r *runner) string {
+	t.Helper()
+	var b strings.Builder
+	if err := r.reg.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestRunnerReloadKeepsState(t *testing.T) {
+	n1, n2 := newNode(t, 5), newNode(t, 5)
+	one := `{"nodes":[{"name":"n1","rpc":"` + n1.URL + `"}],"stallCycles":1,"participationWindow":10}`
+	both := `{"nodes":[{"name":"n1","rpc":"` + n1.URL + `"},{"name":"n2","rpc":"` + n2.URL + `"}],"stallCycles":1,"participationWindow":10}`
+	r, path := newTestRunner(t, one)
+	r.cycle()
+	client, part := r.clients[0], r.part
+	if part.window.Len() != 5 {
+		t.Fatalf("window holds %d blocks", part.window.Len())
+	}
+
+	writeConfig(t, path, both)
+	if err := r.reload("test"); err != nil {
+		t.Fatal(err)
+	}
+	if r.clients[0] != client || r.part != part || part.window.Len() != 5 {
+		t.Fatal("adding a node replaced the client or participation window of n1")
+	}
+	r.cycle()
+	// n1's head is unchanged since the first cycle, n2 was just added.
+	if c := r.snap.cluster; strings.Join(c.StalledNodes, ",") != "n1" {
+		t.Fatalf("stalled nodes %q: head tracking was reset", c.StalledNodes)
+	}
+	if m := metricsText(t, r); !strings.Contains(m, `qikchain_polls_total{node="n1"} 2`) || !strings.Contains(m, `qikchain_polls_total{node="n2"} 1`) {
+		t.Fatalf("poll series:\n%s", m)
+	}
+
+	// Removing n1 drops its series and its head, so it starts afresh when
+	// it is added back.
+	writeConfig(t, path, `{"nodes":[{"name":"n2","rpc":"`+n2.URL+`"}],"stallCycles":1,"participationWindow":10}`)
+	if err := r.reload("test"); err != nil {
+		t.Fatal(err)
+	}
+	if m := metricsText(t, r); strings.Contains(m, `node="n1"`) {
+		t.Fatalf("n1 series left:\n%s", m)
+	}
+	writeConfig(t, path, both)
+	if err := r.reload("test"); err != nil {
+		t.Fatal(err)
+	}
+	r.cycle()
+	if c := r.snap.cluster; strings.Join(c.StalledNodes, ",") != "n2" {
+		t.Fatalf("stalled nodes %q: n1 kept its old head", c.StalledNodes)
+	}
+}
+
+func TestRunnerFailedReload(t *testing.T) {
+	n1 := newNode(t, 5)
+	good := `{"nodes":[{"name":"n1","rpc":"` + n1.URL + `"}],"listen":"127.0.0.1:0"}`
+	r, path := newTestRunner(t, good)
+	s, srv, clients := r.s, r.srv, r.clients
+
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer busy.Close()
+	for name, config := range map[string]string{
+		"busy listen address": `{"nodes":[{"name":"n1","rpc":"` + n1.URL + `"}],"listen":"` + busy.Addr().String() + `"}`,
+		"invalid rules":       `{"nodes":[{"name":"n1","rpc":"` + n1.URL + `"}],"listen":"127.0.0.1:0","alerts":{"rules":[{"name":"a","signal":"node.typo","op":"<","threshold":1,"severity":"warning"}]}}`,
+	} {
+		writeConfig(t, path, config)
+		if err := r.reload("test"); err == nil {
+			t.Fatalf("%s: reload succeeded", name)
+		}
+		if r.s != s || r.srv != srv || &r.clients[0] != &clients[0] || r.alerts != nil {
+			t.Fatalf("%s: running config changed", name)
+		}
+	}
+	if m := metricsText(t, r); !strings.Contains(m, `qikchaind_config_reloads_total{result="failure"} 2`) || !strings.Contains(m, "qikchaind_config_last_reload_successful 0") {
+		t.Fatalf("reload metrics:\n%s", m)
+	}
+}
@@ -2,8 +2,6 @@ package main
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"flag"
@@ -13,17 +11,22 @@ import (
 	"strings"
 	"time"
 
+	"github.com/BioMark3r/qikchain/internal/logging"
+	"github.com/BioMark3r/qikchain/internal/signer"
+	"github.com/BioMark3r/qikchain/internal/txbuild"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
-const defaultPrivKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+// devKey is the well-known Hardhat account #0 key, used only with --dev-key.
+const devKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
 const testDeployBytecode = "0x6001600c60003960016000f300"
 
+var signerCfg = signer.Config{KeyEnv: "CI_FUNDER_PRIVKEY"}
+
 type output struct {
 	OK              bool    `json:"ok"`
 	RPC             string  `json:"rpc"`
@@ -43,13 +46,28 @@ func main() {
 	waitReceipt := flag.Bool("waitReceipt", false, "wait for receipt")
 	waitTimeoutSec := flag.Int("waitTimeoutSec", 10, "wait timeout in seconds")
 	timeoutSec := flag.Int("timeoutSec", 20, "overall timeout in seconds")
+	useDevKey := flag.Bool("dev-key", false, "devnet only: sign with the public Hardhat account #0 key when no key is configured")
+	signerCfg.BindFlags(flag.CommandLine)
+	var logCfg logging.Config
+	logCfg.BindFlags(flag.CommandLine)
 	flag.Parse()
+	signerCfg.Timeout = time.Duration(*timeoutSec) * time.Second
+	if *useDevKey {
+		signerCfg.DefaultKey = devKey
+	}
+
+	log, err := logCfg.Setup("txhelper")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "txhelper: %v\n", err)
+		os.Exit(2)
+	}
+	log = log.With(logging.KeyRPC, *rpcURL, "action", *action)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*timeoutSec)*time.Second)
 	defer cancel()
 
+	start := time.Now()
 	var out output
-	var err error
 	switch *action {
 	case "burn":
 		out, err = sendNative(ctx, *rpcURL, *toArg, *valueWeiArg, *waitReceipt, *waitTimeoutSec)
@@ -62,12 +80,13 @@ func main() {
 	}
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "txhelper error: %v\n", err)
+		log.Error("txhelper failed", logging.Duration(time.Since(start)), logging.Err(err))
 		os.Exit(1)
 	}
+	log.Debug("txhelper done", "tx", out.TxHash, "mined", out.Mined, logging.Duration(time.Since(start)))
 
 	if err := json.NewEncoder(os.Stdout).Encode(out); err != nil {
-		fmt.Fprintf(os.Stderr, "encode output: %v\n", err)
+		log.Error("encode output", logging.Err(err))
 		os.Exit(1)
 	}
 }
@@ -107,7 +126,7 @@ func submitRaw(ctx context.Context, rpcURL, rawTx string, wait bool, waitTimeout
 }
 
 func deployTest(ctx context.Context, rpcURL string, deployGasCap uint64, wait bool, waitTimeoutSec int) (output, error) {
-	privKey, err := loadPrivateKey()
+	txSigner, err := signer.New(ctx, signerCfg)
 	if err != nil {
 		return output{}, err
 	}
@@ -117,14 +136,14 @@ func deployTest(ctx context.Context, rpcURL string, deployGasCap uint64, wait bo
 	}
 	defer client.Close()
 
-	from := crypto.PubkeyToAddress(privKey.PublicKey)
-	chainID, nonce, tipCap, feeCap, err := txParams(ctx, client, from)
+	from := txSigner.Address()
+	params, err := loadTxParams(ctx, client, from)
 	if err != nil {
 		return output{}, err
 	}
 
 	data := common.FromHex(testDeployBytecode)
-	gasEstimate, err := client.EstimateGas(ctx, ethereum.CallMsg{From: from, Data: data, GasTipCap: tipCap, GasFeeCap: feeCap})
+	gasEstimate, err := client.EstimateGas(ctx, params.callMsg(from, nil, nil, data))
 	if err != nil {
 		return output{}, fmt.Errorf("estimate gas: %w", err)
 	}
@@ -132,8 +151,11 @@ func deployTest(ctx context.Context, rpcURL string, deployGasCap uint64, wait bo
 		gasEstimate = deployGasCap
 	}
 
-	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: nonce, GasTipCap: tipCap, GasFeeCap: feeCap, Gas: gasEstimate, Data: data})
-	signedTx, err := types.SignTx(tx, types.NewLondonSigner(chainID), privKey)
+	tx, err := params.tx(nil, nil, data, gasEstimate)
+	if err != nil {
+		return output{}, err
+	}
+	signedTx, err := txSigner.SignTx(ctx, tx, params.chainID)
 	if err != nil {
 		return output{}, fmt.Errorf("sign tx: %w", err)
 	}
@@ -162,7 +184,7 @@ func deployTest(ctx context.Context, rpcURL string, deployGasCap uint64, wait bo
 }
 
 func sendNative(ctx context.Context, rpcURL, toArg, valueWeiArg string, wait bool, waitTimeoutSec int) (output, error) {
-	privKey, err := loadPrivateKey()
+	txSigner, err := signer.New(ctx, signerCfg)
 	if err != nil {
 		return output{}, err
 	}
@@ -181,18 +203,21 @@ func sendNative(ctx context.Context, rpcURL, toArg, valueWeiArg string, wait boo
 	}
 	defer client.Close()
 
-	from := crypto.PubkeyToAddress(privKey.PublicKey)
-	chainID, nonce, tipCap, feeCap, err := txParams(ctx, client, from)
+	from := txSigner.Address()
+	params, err := loadTxParams(ctx, client, from)
 	if err != nil {
 		return output{}, err
 	}
-	gasLimit, err := client.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &to, Value: valueWei, GasTipCap: tipCap, GasFeeCap: feeCap})
+	gasLimit, err := client.EstimateGas(ctx, params.callMsg(from, &to, valueWei, nil))
 	if err != nil {
 		return output{}, fmt.Errorf("estimate gas: %w", err)
 	}
 
-	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: nonce, GasTipCap: tipCap, GasFeeCap: feeCap, Gas: gasLimit, To: &to, Value: valueWei})
-	signedTx, err := types.SignTx(tx, types.NewLondonSigner(chainID), privKey)
+	tx, err := params.tx(&to, valueWei, nil, gasLimit)
+	if err != nil {
+		return output{}, err
+	}
+	signedTx, err := txSigner.SignTx(ctx, tx, params.chainID)
 	if err != nil {
 		return output{}, fmt.Errorf("sign tx: %w", err)
 	}
@@ -216,48 +241,59 @@ func sendNative(ctx context.Context, rpcURL, toArg, valueWeiArg string, wait boo
 	return out, nil
 }
 
-func txParams(ctx context.Context, client *ethclient.Client, from common.Address) (*big.Int, uint64, *big.Int, *big.Int, error) {
-	chainID, err := client.ChainID(ctx)
-	if err != nil {
-		return nil, 0, nil, nil, fmt.Errorf("fetch chain id: %w", err)
-	}
-	nonce, err := client.PendingNonceAt(ctx, from)
-	if err != nil {
-		return nil, 0, nil, nil, fmt.Errorf("fetch pending nonce: %w", err)
+type txParams struct {
+	chainID *big.Int
+	nonce   uint64
+	txType  uint8
+	fees    txbuild.Fees
+}
+
+// loadTxParams prices an EIP-1559 transaction when the chain has a base fee
+// and falls back to a legacy transaction otherwise (BASE_FEE_ENABLED=false).
+func loadTxParams(ctx context.Context, client *ethclient.Client, from common.Address) (txParams, error) {
+	var p txParams
+	var err error
+	if p.chainID, err = client.ChainID(ctx); err != nil {
+		return p, fmt.Errorf("fetch chain id: %w", err)
 	}
-	tipCap, err := client.SuggestGasTipCap(ctx)
-	if err != nil {
-		return nil, 0, nil, nil, fmt.Errorf("suggest gas tip cap: %w", err)
+	if p.nonce, err = client.PendingNonceAt(ctx, from); err != nil {
+		return p, fmt.Errorf("fetch pending nonce: %w", err)
 	}
 	head, err := client.HeaderByNumber(ctx, nil)
 	if err != nil {
-		return nil, 0, nil, nil, fmt.Errorf("fetch latest header: %w", err)
+		return p, fmt.Errorf("fetch latest header: %w", err)
+	}
+	baseFee := head.BaseFee
+	if baseFee != nil && baseFee.Sign() == 0 {
+		baseFee = nil
+	}
+	if p.txType, err = txbuild.SelectType("auto", baseFee); err != nil {
+		return p, err
 	}
-	if head.BaseFee == nil {
-		return nil, 0, nil, nil, errors.New("latest header has no baseFee")
+	if baseFee == nil {
+		price, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return p, fmt.Errorf("suggest gas price: %w", err)
+		}
+		p.fees = txbuild.Fees{GasPrice: price}
+		return p, nil
+	}
+	tipCap, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return p, fmt.Errorf("suggest gas tip cap: %w", err)
 	}
-	feeCap := new(big.Int).Mul(head.BaseFee, big.NewInt(2))
+	feeCap := new(big.Int).Mul(baseFee, big.NewInt(2))
 	feeCap.Add(feeCap, tipCap)
-	return chainID, nonce, tipCap, feeCap, nil
+	p.fees = txbuild.Fees{GasTipCap: tipCap, GasFeeCap: feeCap}
+	return p, nil
 }
 
-func loadPrivateKey() (*ecdsa.PrivateKey, error) {
-	privHex := strings.TrimSpace(os.Getenv("CI_FUNDER_PRIVKEY"))
-	if privHex == "" {
-		privHex = defaultPrivKey
-	}
-	return parsePrivateKey(privHex)
+func (p txParams) callMsg(from common.Address, to *common.Address, value *big.Int, data []byte) ethereum.CallMsg {
+	return ethereum.CallMsg{From: from, To: to, Value: value, Data: data, GasPrice: p.fees.GasPrice, GasTipCap: p.fees.GasTipCap, GasFeeCap: p.fees.GasFeeCap}
 }
 
-func parsePrivateKey(raw string) (*ecdsa.PrivateKey, error) {
-	trimmed := strings.TrimPrefix(strings.TrimSpace(raw), "0x")
-	if len(trimmed) != 64 {
-		return nil, fmt.Errorf("expected 32-byte hex key, got %d hex chars", len(trimmed))
-	}
-	if _, err := hex.DecodeString(trimmed); err != nil {
-		return nil, fmt.Errorf("decode hex: %w", err)
-	}
-	return crypto.HexToECDSA(trimmed)
+func (p txParams) tx(to *common.Address, value *big.Int, data []byte, gas uint64) (*types.Transaction, error) {
+	return txbuild.Build(txbuild.Request{ChainID: p.chainID, Type: p.txType, Nonce: p.nonce, To: to, Value: value, Data: data, Gas: gas, Fees: p.fees})
 }
 
 func waitForReceipt(ctx context.Context, client *ethclient.Client, hash common.Hash) (*types.Receipt, error) {
@@ -2,51 +2,60 @@ package main
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"encoding/hex"
 	"flag"
 	"fmt"
-	"log"
 	"math/big"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/BioMark3r/qikchain/internal/logging"
+	"github.com/BioMark3r/qikchain/internal/signer"
+	"github.com/BioMark3r/qikchain/internal/txbuild"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-const defaultPrivKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+// devKey is the well-known Hardhat account #0 key, used only with --dev-key.
+const devKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
 
 func main() {
 	rpcURL := flag.String("rpc", "http://127.0.0.1:8545", "JSON-RPC endpoint")
 	toArg := flag.String("to", "0x000000000000000000000000000000000000dEaD", "destination address")
 	valueWeiArg := flag.String("valueWei", "1", "value to transfer in wei")
 	timeout := flag.Duration("timeout", 45*time.Second, "overall timeout")
+	useDevKey := flag.Bool("dev-key", false, "devnet only: sign with the public Hardhat account #0 key when no key is configured")
+	signerCfg := signer.Config{KeyEnv: "CI_FUNDER_PRIVKEY"}
+	signerCfg.BindFlags(flag.CommandLine)
+	var logCfg logging.Config
+	logCfg.BindFlags(flag.CommandLine)
 	flag.Parse()
-
-	privHex := strings.TrimSpace(os.Getenv("CI_FUNDER_PRIVKEY"))
-	if privHex == "" {
-		privHex = defaultPrivKey
-		log.Printf("CI_FUNDER_PRIVKEY not set, using default dev key")
+	signerCfg.Timeout = *timeout
+	if *useDevKey {
+		signerCfg.DefaultKey = devKey
 	}
 
-	privKey, err := parsePrivateKey(privHex)
+	log, err := logCfg.Setup("txsmoke")
 	if err != nil {
-		log.Fatalf("invalid CI_FUNDER_PRIVKEY: %v", err)
+		fmt.Fprintf(os.Stderr, "txsmoke: %v\n", err)
+		os.Exit(2)
+	}
+	log = log.With(logging.KeyRPC, *rpcURL)
+	fatal := func(msg string, args ...any) {
+		log.Error(msg, args...)
+		os.Exit(1)
 	}
 
 	to := common.HexToAddress(*toArg)
 	if to == (common.Address{}) && !strings.EqualFold(*toArg, "0x0000000000000000000000000000000000000000") {
-		log.Fatalf("invalid --to address: %s", *toArg)
+		fatal("invalid --to address", "to", *toArg)
 	}
 
 	valueWei, ok := new(big.Int).SetString(*valueWeiArg, 10)
 	if !ok || valueWei.Sign() < 0 {
-		log.Fatalf("invalid --valueWei value: %s", *valueWeiArg)
+		fatal("invalid --valueWei value", "valueWei", *valueWeiArg)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
@@ -54,93 +63,87 @@ func main() {
 
 	client, err := ethclient.DialContext(ctx, *rpcURL)
 	if err != nil {
-		log.Fatalf("dial rpc: %v", err)
+		fatal("dial rpc", logging.Err(err))
 	}
 	defer client.Close()
 
-	from := crypto.PubkeyToAddress(privKey.PublicKey)
-
-	chainID, err := client.ChainID(ctx)
+	if signerCfg.UsesDefaultKey() {
+		log.Warn("CI_FUNDER_PRIVKEY not set, using the public dev key (--dev-key)")
+	}
+	txSigner, err := signer.New(ctx, signerCfg)
 	if err != nil {
-		log.Fatalf("fetch chain id: %v", err)
+		fatal("load signer", logging.Err(err))
 	}
+	from := txSigner.Address()
 
-	nonce, err := client.PendingNonceAt(ctx, from)
+	chainID, err := client.ChainID(ctx)
 	if err != nil {
-		log.Fatalf("fetch pending nonce: %v", err)
+		fatal("fetch chain id", logging.Err(err))
 	}
 
-	tipCap, err := client.SuggestGasTipCap(ctx)
+	nonce, err := client.PendingNonceAt(ctx, from)
 	if err != nil {
-		log.Fatalf("suggest gas tip cap: %v", err)
+		fatal("fetch pending nonce", logging.Err(err))
 	}
 
 	head, err := client.HeaderByNumber(ctx, nil)
 	if err != nil {
-		log.Fatalf("fetch latest header: %v", err)
+		fatal("fetch latest header", logging.Err(err))
 	}
-	if head.BaseFee == nil {
-		log.Fatalf("latest header has no baseFee; EIP-1559 unavailable")
-	}
-
-	feeCap := new(big.Int).Mul(head.BaseFee, big.NewInt(2))
-	feeCap.Add(feeCap, tipCap)
 
-	callMsg := ethereum.CallMsg{
-		From:      from,
-		To:        &to,
-		Value:     valueWei,
-		GasFeeCap: feeCap,
-		GasTipCap: tipCap,
-		Data:      nil,
+	// Without a base fee (BASE_FEE_ENABLED=false) fall back to a legacy tx.
+	callMsg := ethereum.CallMsg{From: from, To: &to, Value: valueWei}
+	var fees txbuild.Fees
+	txType := uint8(types.LegacyTxType)
+	if head.BaseFee == nil || head.BaseFee.Sign() == 0 {
+		price, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			fatal("suggest gas price", logging.Err(err))
+		}
+		fees.GasPrice = price
+		callMsg.GasPrice = price
+	} else {
+		tipCap, err := client.SuggestGasTipCap(ctx)
+		if err != nil {
+			fatal("suggest gas tip cap", logging.Err(err))
+		}
+		feeCap := new(big.Int).Mul(head.BaseFee, big.NewInt(2))
+		feeCap.Add(feeCap, tipCap)
+		fees.GasTipCap, fees.GasFeeCap = tipCap, feeCap
+		callMsg.GasTipCap, callMsg.GasFeeCap = tipCap, feeCap
+		txType = types.DynamicFeeTxType
 	}
+
 	gasLimit, err := client.EstimateGas(ctx, callMsg)
 	if err != nil {
-		log.Fatalf("estimate gas: %v", err)
+		fatal("estimate gas", logging.Err(err))
 	}
 
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   chainID,
-		Nonce:     nonce,
-		GasTipCap: tipCap,
-		GasFeeCap: feeCap,
-		Gas:       gasLimit,
-		To:        &to,
-		Value:     valueWei,
-		Data:      nil,
-	})
+	tx, err := txbuild.Build(txbuild.Request{ChainID: chainID, Type: txType, Nonce: nonce, To: &to, Value: valueWei, Gas: gasLimit, Fees: fees})
+	if err != nil {
+		fatal("build tx", logging.Err(err))
+	}
 
-	signedTx, err := types.SignTx(tx, types.NewLondonSigner(chainID), privKey)
+	signedTx, err := txSigner.SignTx(ctx, tx, chainID)
 	if err != nil {
-		log.Fatalf("sign tx: %v", err)
+		fatal("sign tx", logging.Err(err))
 	}
 
 	if err := client.SendTransaction(ctx, signedTx); err != nil {
-		log.Fatalf("send tx: %v", err)
+		fatal("send tx", logging.Err(err))
 	}
 
 	fmt.Printf("tx_hash=%s from=%s to=%s\n", signedTx.Hash().Hex(), from.Hex(), to.Hex())
 
 	receipt, err := waitForReceipt(ctx, client, signedTx.Hash())
 	if err != nil {
-		log.Fatalf("wait for receipt: %v", err)
+		fatal("wait for receipt", logging.Err(err))
 	}
 
 	fmt.Printf("receipt_status=%d block=%d gas_used=%d\n", receipt.Status, receipt.BlockNumber.Uint64(), receipt.GasUsed)
 	if receipt.Status != types.ReceiptStatusSuccessful {
-		log.Fatalf("transaction failed: receipt.status=%d", receipt.Status)
-	}
-}
-
-func parsePrivateKey(raw string) (*ecdsa.PrivateKey, error) {
-	trimmed := strings.TrimPrefix(strings.TrimSpace(raw), "0x")
-	if len(trimmed) != 64 {
-		return nil, fmt.Errorf("expected 32-byte hex key, got %d hex chars", len(trimmed))
-	}
-	if _, err := hex.DecodeString(trimmed); err != nil {
-		return nil, fmt.Errorf("decode hex: %w", err)
+		fatal("transaction failed", "tx", signedTx.Hash().Hex(), "receipt_status", receipt.Status)
 	}
-	return crypto.HexToECDSA(trimmed)
 }
 
 func waitForReceipt(ctx context.Context, client *ethclient.Client, hash common.Hash) (*types.Receipt, error) {
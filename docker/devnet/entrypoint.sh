@@ -3,6 +3,7 @@ set -euo pipefail
 
 APP_DIR="${APP_DIR:-/app}"
 EDGE_BIN="${EDGE_BIN:-$APP_DIR/bin/polygon-edge}"
+QIKCHAIND_BIN="${QIKCHAIND_BIN:-$APP_DIR/bin/qikchaind}"
 NODE_NAME="${NODE_NAME:-node1}"
 NODE_DATA_DIR="${NODE_DATA_DIR:-/data/${NODE_NAME}}"
 
@@ -25,6 +26,18 @@ VALIDATOR_COUNT="${VALIDATOR_COUNT:-4}"
 
 log() { echo "[$(date +"%H:%M:%S")] [$NODE_NAME] $*"; }
 
+# qikchaind serves /healthz, /readyz and /status for this node on
+# QIKCHAIND_LISTEN, which it reads from the environment.
+start_qikchaind() {
+  [[ -n "${QIKCHAIND_LISTEN:-}" ]] || return 0
+  if [[ ! -x "$QIKCHAIND_BIN" ]]; then
+    log "QIKCHAIND_LISTEN is set but $QIKCHAIND_BIN is missing"
+    return 0
+  fi
+  log "Starting qikchaind on $QIKCHAIND_LISTEN"
+  "$QIKCHAIND_BIN" run --rpc "http://127.0.0.1:${RPC_PORT}" --participation-window 0 >/dev/null &
+}
+
 detect_metrics_flag() {
   if "$EDGE_BIN" server --help 2>&1 | grep -q -- '--prometheus'; then
     echo "--prometheus"
@@ -152,6 +165,8 @@ main() {
     args+=(--bootnode "$(cat "$NODE1_MULTIADDR_FILE")")
   fi
 
+  start_qikchaind
+
   log "Starting polygon-edge"
   exec "$EDGE_BIN" "${args[@]}"
 }
@@ -1,6 +1,15 @@
 #!/usr/bin/env bash
 set -euo pipefail
 
+# Defer to qikchaind's readiness check when the entrypoint started it. curl
+# exits 7 when nothing listens there, e.g. because the image has no
+# qikchaind or it died; check the node directly then.
+if [[ -n "${QIKCHAIND_LISTEN:-}" ]]; then
+  rc=0
+  curl -fsS -o /dev/null "http://127.0.0.1:${QIKCHAIND_LISTEN##*:}/readyz" 2>/dev/null || rc=$?
+  (( rc == 7 )) || exit "$rc"
+fi
+
 RPC_URL="${HEALTHCHECK_RPC_URL:-http://127.0.0.1:${RPC_PORT:-8545}}"
 STATE_DIR="${HEALTHCHECK_STATE_DIR:-/tmp}"
 STATE_FILE="${STATE_DIR}/health-${NODE_NAME:-node}.block"
@@ -0,0 +1,434 @@
+package abiutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BioMark3r/qikchain/internal/txbuild"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Load reads a plain ABI array or a Foundry/Hardhat artifact with an "abi"
+// field.
+func Load(path string) (*abi.ABI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return parsed, nil
+}
+
+func Parse(data []byte) (*abi.ABI, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return nil, err
+		}
+		if len(artifact.ABI) == 0 {
+			return nil, errors.New("artifact has no abi field")
+		}
+		data = artifact.ABI
+	}
+	parsed, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// LoadArtifact reads a Foundry or Hardhat artifact and returns its ABI and
+// creation bytecode.
+func LoadArtifact(path string) (*abi.ABI, []byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	parsed, code, err := ParseArtifact(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return parsed, code, nil
+}
+
+// ParseArtifact accepts both bytecode layouts: Foundry's {"object": "0x.."}
+// and Hardhat's plain hex string.
+func ParseArtifact(data []byte) (*abi.ABI, []byte, error) {
+	parsed, err := Parse(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var artifact struct {
+		Bytecode json.RawMessage `json:"bytecode"`
+	}
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, nil, err
+	}
+	var object string
+	if err := json.Unmarshal(artifact.Bytecode, &object); err != nil {
+		var foundry struct {
+			Object string `json:"object"`
+		}
+		if err := json.Unmarshal(artifact.Bytecode, &foundry); err != nil {
+			return nil, nil, errors.New("artifact has no bytecode field")
+		}
+		object = foundry.Object
+	}
+	if strings.Contains(object, "__") {
+		return nil, nil, errors.New("artifact bytecode has unlinked library references")
+	}
+	if !strings.HasPrefix(object, "0x") {
+		object = "0x" + object
+	}
+	code, err := hexutil.Decode(object)
+	if err != nil {
+		return nil, nil, fmt.Errorf("artifact bytecode: %w", err)
+	}
+	if len(code) == 0 {
+		return nil, nil, errors.New("artifact has empty bytecode (abstract contract or interface?)")
+	}
+	return parsed, code, nil
+}
+
+// ParseSignature builds a single-method ABI from a human-readable signature
+// such as "getOperator(address)((address,address,bytes,uint256,bool,bool))",
+// the form Foundry's cast accepts. The optional second group lists the
+// outputs. Without an ABI the method is treated as payable.
+func ParseSignature(sig string) (*abi.ABI, error) {
+	sig = strings.ReplaceAll(sig, " ", "")
+	depth, end := 0, -1
+	for i, c := range sig {
+		if c == '(' {
+			depth++
+		} else if c == ')' {
+			depth--
+			if depth == 0 {
+				end = i + 1
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return nil, fmt.Errorf("invalid signature %q", sig)
+	}
+	in, err := abi.ParseSelector(sig[:end])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature %q: %w", sig, err)
+	}
+	var outputs []abi.ArgumentMarshaling
+	if rest := sig[end:]; rest != "" {
+		out, err := abi.ParseSelector("outputs" + rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature %q: %w", sig, err)
+		}
+		outputs = out.Inputs
+	}
+	data, err := json.Marshal([]map[string]any{{
+		"type":            "function",
+		"name":            in.Name,
+		"inputs":          in.Inputs,
+		"outputs":         outputs,
+		"stateMutability": "payable",
+	}})
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	// Drop the placeholder names ParseSelector generates so values print
+	// positionally.
+	for _, m := range parsed.Methods {
+		for _, args := range []abi.Arguments{m.Inputs, m.Outputs} {
+			for i := range args {
+				args[i].Name = ""
+				clearTupleNames(&args[i].Type)
+			}
+		}
+	}
+	return parsed, nil
+}
+
+func clearTupleNames(t *abi.Type) {
+	for i := range t.TupleRawNames {
+		t.TupleRawNames[i] = ""
+	}
+	for _, elem := range t.TupleElems {
+		clearTupleNames(elem)
+	}
+	if t.Elem != nil {
+		clearTupleNames(t.Elem)
+	}
+}
+
+// Method finds a method by name or, for overloads, by its full signature such
+// as "stakeOf(address,address)".
+func Method(a *abi.ABI, spec string) (*abi.Method, error) {
+	if strings.Contains(spec, "(") {
+		spec = strings.ReplaceAll(spec, " ", "")
+		for _, m := range a.Methods {
+			if m.Sig == spec {
+				m := m
+				return &m, nil
+			}
+		}
+		return nil, fmt.Errorf("no method with signature %s", spec)
+	}
+	if m, ok := a.Methods[spec]; ok {
+		var overloads []string
+		for _, other := range a.Methods {
+			if other.RawName == spec && other.Name != m.Name {
+				overloads = append(overloads, other.Sig)
+			}
+		}
+		if len(overloads) > 0 {
+			overloads = append(overloads, m.Sig)
+			sort.Strings(overloads)
+			return nil, fmt.Errorf("method %s is overloaded; use one of %s", spec, strings.Join(overloads, ", "))
+		}
+		return &m, nil
+	}
+	return nil, fmt.Errorf("no method named %s", spec)
+}
+
+// MethodNames lists the distinct method names, sorted.
+func MethodNames(a *abi.ABI) []string {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(a.Methods))
+	for _, m := range a.Methods {
+		if !seen[m.RawName] {
+			seen[m.RawName] = true
+			out = append(out, m.RawName)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ParseArgs converts command-line strings into values accepted by
+// args.Pack. Scalars use their natural notation (decimal or 0x integers,
+// amounts with units like 1.5qik, true/false, 0x bytes); arrays and tuples are
+// JSON, e.g. '["0x..", 5]' or '{"operator":"0x..","amount":"1qik"}'.
+func ParseArgs(args abi.Arguments, raw []string) ([]any, error) {
+	if len(raw) != len(args) {
+		return nil, fmt.Errorf("expected %d arguments (%s), got %d", len(args), describeArgs(args), len(raw))
+	}
+	out := make([]any, len(args))
+	for i, arg := range args {
+		var input any = raw[i]
+		if arg.Type.T == abi.SliceTy || arg.Type.T == abi.ArrayTy || arg.Type.T == abi.TupleTy {
+			dec := json.NewDecoder(strings.NewReader(raw[i]))
+			dec.UseNumber()
+			if err := dec.Decode(&input); err != nil {
+				return nil, fmt.Errorf("argument %s: %s expects JSON: %w", argName(arg, i), arg.Type.String(), err)
+			}
+		}
+		v, err := convert(arg.Type, input)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s (%s): %w", argName(arg, i), arg.Type.String(), err)
+		}
+		out[i] = v.Interface()
+	}
+	return out, nil
+}
+
+func describeArgs(args abi.Arguments) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = strings.TrimSpace(arg.Type.String() + " " + arg.Name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func argName(arg abi.Argument, i int) string {
+	if arg.Name != "" {
+		return arg.Name
+	}
+	return strconv.Itoa(i)
+}
+
+func convert(t abi.Type, input any) (reflect.Value, error) {
+	switch t.T {
+	case abi.SliceTy, abi.ArrayTy:
+		items, ok := input.([]any)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("want a JSON array, got %v", input)
+		}
+		var out reflect.Value
+		if t.T == abi.SliceTy {
+			out = reflect.MakeSlice(t.GetType(), len(items), len(items))
+		} else {
+			if len(items) != t.Size {
+				return reflect.Value{}, fmt.Errorf("want %d elements, got %d", t.Size, len(items))
+			}
+			out = reflect.New(t.GetType()).Elem()
+		}
+		for i, item := range items {
+			v, err := convert(*t.Elem, item)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out.Index(i).Set(v)
+		}
+		return out, nil
+
+	case abi.TupleTy:
+		out := reflect.New(t.GetType()).Elem()
+		switch in := input.(type) {
+		case []any:
+			if len(in) != len(t.TupleElems) {
+				return reflect.Value{}, fmt.Errorf("want %d tuple fields, got %d", len(t.TupleElems), len(in))
+			}
+			for i, elem := range t.TupleElems {
+				v, err := convert(*elem, in[i])
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("field %d: %w", i, err)
+				}
+				out.Field(i).Set(v)
+			}
+		case map[string]any:
+			for i, elem := range t.TupleElems {
+				name := t.TupleRawNames[i]
+				item, ok := in[name]
+				if !ok {
+					return reflect.Value{}, fmt.Errorf("missing tuple field %q", name)
+				}
+				v, err := convert(*elem, item)
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("field %s: %w", name, err)
+				}
+				out.Field(i).Set(v)
+			}
+		default:
+			return reflect.Value{}, fmt.Errorf("want a JSON array or object, got %v", input)
+		}
+		return out, nil
+	}
+
+	var s string
+	switch in := input.(type) {
+	case string:
+		s = in
+	case json.Number:
+		s = in.String()
+	case bool:
+		s = strconv.FormatBool(in)
+	default:
+		return reflect.Value{}, fmt.Errorf("unexpected value %v", input)
+	}
+	if t.T != abi.StringTy {
+		s = strings.TrimSpace(s)
+	}
+
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		n, err := parseInt(s, t.T == abi.IntTy)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if t.T == abi.UintTy && n.BitLen() > t.Size {
+			return reflect.Value{}, fmt.Errorf("%s overflows uint%d", s, t.Size)
+		}
+		if t.T == abi.IntTy {
+			limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+			if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+				return reflect.Value{}, fmt.Errorf("%s overflows int%d", s, t.Size)
+			}
+		}
+		goType := t.GetType()
+		if goType == reflect.TypeOf(&big.Int{}) {
+			return reflect.ValueOf(n), nil
+		}
+		if t.T == abi.IntTy {
+			return reflect.ValueOf(n.Int64()).Convert(goType), nil
+		}
+		return reflect.ValueOf(n.Uint64()).Convert(goType), nil
+
+	case abi.BoolTy:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid bool %q", s)
+		}
+		return reflect.ValueOf(b), nil
+
+	case abi.AddressTy:
+		if !common.IsHexAddress(s) {
+			return reflect.Value{}, fmt.Errorf("invalid address %q", s)
+		}
+		return reflect.ValueOf(common.HexToAddress(s)), nil
+
+	case abi.StringTy:
+		return reflect.ValueOf(s), nil
+
+	case abi.BytesTy:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid bytes %q: %w", s, err)
+		}
+		return reflect.ValueOf(b), nil
+
+	case abi.FixedBytesTy, abi.FunctionTy:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid bytes %q: %w", s, err)
+		}
+		size := t.Size
+		if t.T == abi.FunctionTy {
+			size = 24
+		}
+		if len(b) != size {
+			return reflect.Value{}, fmt.Errorf("want %d bytes, got %d", size, len(b))
+		}
+		out := reflect.New(t.GetType()).Elem()
+		reflect.Copy(out, reflect.ValueOf(b))
+		return out, nil
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported type %s", t.String())
+}
+
+// parseInt accepts decimal, 0x hex, and unsigned amounts with a unit suffix
+// (wei, gwei, qik, ether).
+func parseInt(s string, signed bool) (*big.Int, error) {
+	if s == "" {
+		return nil, errors.New("empty integer")
+	}
+	neg := false
+	if signed && strings.HasPrefix(s, "-") {
+		neg, s = true, s[1:]
+	}
+	var n *big.Int
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		v, ok := new(big.Int).SetString(s[2:], 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", s)
+		}
+		n = v
+	} else {
+		v, err := txbuild.ParseAmount(s)
+		if err != nil {
+			return nil, err
+		}
+		n = v
+	}
+	if neg {
+		n.Neg(n)
+	}
+	return n, nil
+}
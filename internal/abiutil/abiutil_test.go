@@ -0,0 +1,185 @@
+package abiutil
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testABI = `[
+  {"type":"function","name":"getOperator","stateMutability":"view","inputs":[{"name":"operator","type":"address"}],
+   "outputs":[{"name":"","type":"tuple","components":[{"name":"operator","type":"address"},{"name":"payout","type":"address"},{"name":"consensusKey","type":"bytes"},{"name":"totalStake","type":"uint256"},{"name":"active","type":"bool"},{"name":"jailed","type":"bool"}]}]},
+  {"type":"function","name":"set","stateMutability":"nonpayable","inputs":[{"name":"ids","type":"uint8[]"},{"name":"p","type":"tuple","components":[{"name":"who","type":"address"},{"name":"amount","type":"uint256"}]},{"name":"delta","type":"int16"},{"name":"tag","type":"bytes4"}],"outputs":[]},
+  {"type":"function","name":"f","stateMutability":"view","inputs":[{"name":"a","type":"uint256"}],"outputs":[]},
+  {"type":"function","name":"f","stateMutability":"view","inputs":[{"name":"a","type":"address"}],"outputs":[]},
+  {"type":"event","name":"Staked","anonymous":false,"inputs":[{"indexed":true,"name":"staker","type":"address"},{"indexed":true,"name":"operator","type":"address"},{"indexed":false,"name":"amount","type":"uint256"}]},
+  {"type":"error","name":"TooLow","inputs":[{"name":"have","type":"uint256"},{"name":"want","type":"uint256"}]}
+]`
+
+func mustParse(t *testing.T) *abi.ABI {
+	t.Helper()
+	a, err := Parse([]byte(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestParseArtifactAndRepoABI(t *testing.T) {
+	if _, err := Parse([]byte(`{"abi":` + testABI + `}`)); err != nil {
+		t.Fatalf("artifact: %v", err)
+	}
+	a, err := Load("../../abi/IQikStaking.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Method(a, "getOperator"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseArtifactBytecode(t *testing.T) {
+	for _, bytecode := range []string{`{"object":"0x6080"}`, `"0x6080"`, `{"object":"6080"}`} {
+		_, code, err := ParseArtifact([]byte(`{"abi":[],"bytecode":` + bytecode + `}`))
+		if err != nil {
+			t.Fatalf("%s: %v", bytecode, err)
+		}
+		if len(code) != 2 || code[0] != 0x60 || code[1] != 0x80 {
+			t.Fatalf("%s: code %x", bytecode, code)
+		}
+	}
+	for _, bytecode := range []string{`{"object":"0x"}`, `"0x6080__$abc$__"`} {
+		if _, _, err := ParseArtifact([]byte(`{"abi":[],"bytecode":` + bytecode + `}`)); err == nil {
+			t.Fatalf("%s: expected error", bytecode)
+		}
+	}
+}
+
+func TestMethodOverloads(t *testing.T) {
+	a := mustParse(t)
+	if _, err := Method(a, "f"); err == nil || !strings.Contains(err.Error(), "overloaded") {
+		t.Fatalf("expected overload error, got %v", err)
+	}
+	m, err := Method(a, "f(address)")
+	if err != nil || m.Inputs[0].Type.T != abi.AddressTy {
+		t.Fatalf("f(address) = %v, %v", m, err)
+	}
+}
+
+func TestParseArgsRoundTrip(t *testing.T) {
+	a := mustParse(t)
+	m, _ := Method(a, "set")
+	who := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	values, err := ParseArgs(m.Inputs, []string{`[1, "0x02"]`, `{"who":"` + who.Hex() + `","amount":"1.5qik"}`, "-7", "0xdeadbeef"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed, err := m.Inputs.Pack(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Decode(m.Inputs, packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Text(decoded)
+	want := "(ids: [1, 2], p: (who: " + who.Hex() + ", amount: 1500000000000000000), delta: -7, tag: 0xdeadbeef)"
+	if got != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+
+	if _, err := ParseArgs(m.Inputs, []string{`[256]`, `["` + who.Hex() + `", 1]`, "0", "0x00000000"}); err == nil {
+		t.Fatal("expected uint8 overflow")
+	}
+	if _, err := ParseArgs(m.Inputs, []string{`[]`}); err == nil {
+		t.Fatal("expected argument count error")
+	}
+}
+
+func TestRevert(t *testing.T) {
+	a := mustParse(t)
+	reason, _ := abi.Arguments{{Type: mustType(t, "string")}}.Pack("stake too low")
+	data := append(crypto.Keccak256([]byte("Error(string)"))[:4], reason...)
+	if got := Revert(a, data); got != "execution reverted: stake too low" {
+		t.Fatalf("Error(string) = %q", got)
+	}
+	custom, _ := a.Errors["TooLow"].Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	data = append(a.Errors["TooLow"].ID.Bytes()[:4], custom...)
+	if got := Revert(a, data); got != "execution reverted: TooLow(have: 1, want: 2)" {
+		t.Fatalf("custom error = %q", got)
+	}
+	if got := Revert(nil, nil); got != "execution reverted" {
+		t.Fatalf("empty = %q", got)
+	}
+}
+
+func TestDecodeLog(t *testing.T) {
+	a := mustParse(t)
+	ev := a.Events["Staked"]
+	staker := common.HexToAddress("0x1")
+	operator := common.HexToAddress("0x2")
+	data, _ := ev.Inputs.NonIndexed().Pack(big.NewInt(42))
+	got, err := DecodeLog(a, []common.Hash{ev.ID, common.BytesToHash(staker.Bytes()), common.BytesToHash(operator.Bytes())}, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Staked" || Text(got.Fields) != "(staker: "+staker.Hex()+", operator: "+operator.Hex()+", amount: 42)" {
+		t.Fatalf("decoded %s%s", got.Name, Text(got.Fields))
+	}
+	if _, err := DecodeLog(a, []common.Hash{{1}}, nil); err == nil {
+		t.Fatal("expected unknown event error")
+	}
+}
+
+func mustType(t *testing.T, s string) abi.Type {
+	t.Helper()
+	typ, err := abi.NewType(s, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return typ
+}
+
+func TestParseSignature(t *testing.T) {
+	a, err := ParseSignature("getOperator(address)((address,address,bytes,uint256,bool,bool))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Method(a, "getOperator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := crypto.Keccak256([]byte("getOperator(address)"))[:4]
+	if string(m.ID) != string(want) {
+		t.Fatalf("selector %x, want %x", m.ID, want)
+	}
+	full, _ := Parse([]byte(testABI))
+	ref := full.Methods["getOperator"]
+	who := common.HexToAddress("0x2")
+	packed, _ := ref.Outputs.Pack(struct {
+		Operator     common.Address
+		Payout       common.Address
+		ConsensusKey []byte
+		TotalStake   *big.Int
+		Active       bool
+		Jailed       bool
+	}{who, who, []byte{0xab}, big.NewInt(5), true, false})
+	values, err := Decode(m.Outputs, packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Text(values[0].Value); got != "("+who.Hex()+", "+who.Hex()+", 0xab, 5, true, false)" {
+		t.Fatalf("got %s", got)
+	}
+
+	if a, err = ParseSignature("stakeOf(address,address)(uint256)"); err != nil || len(a.Methods["stakeOf"].Outputs) != 1 {
+		t.Fatalf("stakeOf: %v", err)
+	}
+	if _, err := ParseSignature("broken(address"); err == nil {
+		t.Fatal("expected error")
+	}
+}
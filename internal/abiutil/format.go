@@ -0,0 +1,166 @@
+package abiutil
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Value is a decoded ABI value. Integers become decimal strings, addresses
+// checksummed hex, byte strings 0x hex, arrays []any and tuples []Value.
+type Value struct {
+	Name  string `json:"name,omitempty"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// Decode unpacks data against args.
+func Decode(args abi.Arguments, data []byte) ([]Value, error) {
+	values, err := args.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	return named(args, values), nil
+}
+
+func named(args abi.Arguments, values []any) []Value {
+	out := make([]Value, len(values))
+	for i, v := range values {
+		out[i] = Value{Name: args[i].Name, Type: args[i].Type.String(), Value: format(args[i].Type, reflect.ValueOf(v))}
+	}
+	return out
+}
+
+func format(t abi.Type, v reflect.Value) any {
+	switch t.T {
+	case abi.SliceTy, abi.ArrayTy:
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = format(*t.Elem, v.Index(i))
+		}
+		return items
+	case abi.TupleTy:
+		fields := make([]Value, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			fields[i] = Value{Name: t.TupleRawNames[i], Type: elem.String(), Value: format(*elem, v.Field(i))}
+		}
+		return fields
+	case abi.IntTy, abi.UintTy:
+		if n, ok := v.Interface().(*big.Int); ok {
+			return n.String()
+		}
+		return fmt.Sprint(v.Interface())
+	case abi.AddressTy:
+		return v.Interface().(common.Address).Hex()
+	case abi.BytesTy:
+		return hexutil.Encode(v.Bytes())
+	case abi.FixedBytesTy, abi.FunctionTy, abi.HashTy:
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return hexutil.Encode(b)
+	default:
+		return v.Interface()
+	}
+}
+
+// Text renders decoded values on one line, e.g. "(0xAb.., true, [1, 2])".
+func Text(v any) string {
+	switch x := v.(type) {
+	case []Value:
+		parts := make([]string, len(x))
+		for i, f := range x {
+			parts[i] = Text(f.Value)
+			if f.Name != "" {
+				parts[i] = f.Name + ": " + parts[i]
+			}
+		}
+		return "(" + strings.Join(parts, ", ") + ")"
+	case []any:
+		parts := make([]string, len(x))
+		for i, item := range x {
+			parts[i] = Text(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case string:
+		return x
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// Revert describes revert data: a require/revert string, a panic code, or a
+// custom error declared in a (which may be nil).
+func Revert(a *abi.ABI, data []byte) string {
+	if len(data) == 0 {
+		return "execution reverted"
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return "execution reverted: " + reason
+	}
+	if a != nil && len(data) >= 4 {
+		var id [4]byte
+		copy(id[:], data[:4])
+		if e, err := a.ErrorByID(id); err == nil {
+			if values, err := e.Inputs.Unpack(data[4:]); err == nil {
+				return "execution reverted: " + e.Name + Text(named(e.Inputs, values))
+			}
+		}
+	}
+	return "execution reverted: " + hexutil.Encode(data)
+}
+
+// Event is a log decoded against an ABI.
+type Event struct {
+	Name   string  `json:"name"`
+	Sig    string  `json:"signature"`
+	Fields []Value `json:"fields"`
+}
+
+// DecodeLog decodes a log whose first topic matches an event in a. Indexed
+// dynamic values (strings, bytes, arrays, tuples) are only available as their
+// keccak256 hash.
+func DecodeLog(a *abi.ABI, topics []common.Hash, data []byte) (*Event, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("anonymous log")
+	}
+	ev, err := a.EventByID(topics[0])
+	if err != nil {
+		return nil, err
+	}
+	nonIndexed, err := ev.Inputs.NonIndexed().Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ev.Name, err)
+	}
+
+	out := &Event{Name: ev.Name, Sig: ev.Sig}
+	topic := 1
+	for _, arg := range ev.Inputs {
+		if !arg.Indexed {
+			v := nonIndexed[0]
+			nonIndexed = nonIndexed[1:]
+			out.Fields = append(out.Fields, Value{Name: arg.Name, Type: arg.Type.String(), Value: format(arg.Type, reflect.ValueOf(v))})
+			continue
+		}
+		if topic >= len(topics) {
+			return nil, fmt.Errorf("%s: missing topic for %s", ev.Name, arg.Name)
+		}
+		t := topics[topic]
+		topic++
+		switch arg.Type.T {
+		case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+			out.Fields = append(out.Fields, Value{Name: arg.Name, Type: "bytes32", Value: t.Hex()})
+		default:
+			values, err := abi.Arguments{{Type: arg.Type}}.Unpack(t.Bytes())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", ev.Name, arg.Name, err)
+			}
+			out.Fields = append(out.Fields, Value{Name: arg.Name, Type: arg.Type.String(), Value: format(arg.Type, reflect.ValueOf(values[0]))})
+		}
+	}
+	return out, nil
+}
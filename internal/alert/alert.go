@@ -0,0 +1,315 @@
+// Package alert evaluates threshold rules against sampled signals and
+// notifies webhooks when an alert starts firing and when it resolves.
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is a time.Duration written as a string such as "30s" in JSON.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"30s\"")
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Rule fires for a subject when its signal compares true against Threshold
+// for at least For.
+type Rule struct {
+	Name      string   `json:"name"`
+	Signal    string   `json:"signal"`
+	Op        string   `json:"op"`
+	Threshold float64  `json:"threshold"`
+	For       Duration `json:"for,omitempty"`
+	Severity  string   `json:"severity"`
+	Summary   string   `json:"summary,omitempty"`
+}
+
+func (r Rule) match(v float64) bool {
+	switch r.Op {
+	case ">":
+		return v > r.Threshold
+	case ">=":
+		return v >= r.Threshold
+	case "<":
+		return v < r.Threshold
+	case "<=":
+		return v <= r.Threshold
+	case "==":
+		return v == r.Threshold
+	case "!=":
+		return v != r.Threshold
+	}
+	return false
+}
+
+// Config is the rules file.
+type Config struct {
+	Rules    []Rule    `json:"rules"`
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+var severities = map[string]bool{"info": true, "warning": true, "critical": true}
+
+// LoadConfig reads and validates a rules file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+func (c *Config) Validate() error {
+	names := map[string]bool{}
+	for i, r := range c.Rules {
+		switch {
+		case r.Name == "":
+			return fmt.Errorf("rule %d: name is required", i)
+		case names[r.Name]:
+			return fmt.Errorf("rule %q is defined twice", r.Name)
+		case r.Signal == "":
+			return fmt.Errorf("rule %q: signal is required", r.Name)
+		case !r.validOp():
+			return fmt.Errorf("rule %q: op %q is not one of > >= < <= == !=", r.Name, r.Op)
+		case !severities[r.Severity]:
+			return fmt.Errorf("rule %q: severity %q is not info, warning or critical", r.Name, r.Severity)
+		case r.For < 0:
+			return fmt.Errorf("rule %q: for must not be negative", r.Name)
+		}
+		names[r.Name] = true
+	}
+	for i, w := range c.Webhooks {
+		if w.URL == "" {
+			return fmt.Errorf("webhook %d: url is required", i)
+		}
+		if w.Format != "" && w.Format != FormatJSON && w.Format != FormatSlack {
+			return fmt.Errorf("webhook %d: format %q is not %s or %s", i, w.Format, FormatJSON, FormatSlack)
+		}
+	}
+	return nil
+}
+
+func (r Rule) validOp() bool {
+	switch r.Op {
+	case ">", ">=", "<", "<=", "==", "!=":
+		return true
+	}
+	return false
+}
+
+// Sample is the value of a signal for one subject, such as a node name or a
+// validator address, in one evaluation. A NaN value means the subject is
+// still there but its value is unknown, as for the peers of a node that is
+// down: its alerts keep their state, neither firing nor resolving.
+type Sample struct {
+	Signal  string
+	Subject string
+	Value   float64
+}
+
+// Status values of a Notification.
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+// Notification reports a state change of one alert.
+type Notification struct {
+	Status    string  `json:"status"`
+	Rule      string  `json:"rule"`
+	Severity  string  `json:"severity"`
+	Subject   string  `json:"subject"`
+	Signal    string  `json:"signal"`
+	Value     float64 `json:"value"`
+	Op        string  `json:"op"`
+	Threshold float64 `json:"threshold"`
+	Summary   string  `json:"summary"`
+	StartsAt  string  `json:"startsAt"`
+	EndsAt    string  `json:"endsAt,omitempty"`
+}
+
+type state struct {
+	pendingSince time.Time
+	firing       bool
+	firedAt      time.Time
+	value        float64
+}
+
+// Engine keeps the state of every rule and subject between evaluations.
+type Engine struct {
+	rules  []Rule
+	states map[string]*state // rule + "\x00" + subject
+}
+
+func NewEngine(rules []Rule) *Engine {
+	return &Engine{rules: rules, states: map[string]*state{}}
+}
+
+// Evaluate applies the rules to samples and returns the alerts that started
+// firing or resolved. An alert fires once when its condition has held for
+// the rule's For and resolves once when the condition clears or its signal
+// is no longer sampled; it is not repeated in between. Unknown (NaN) samples
+// leave the alert as it is.
+func (e *Engine) Evaluate(now time.Time, samples []Sample) []Notification {
+	var out []Notification
+	for _, r := range e.rules {
+		seen := map[string]bool{}
+		for _, s := range samples {
+			if s.Signal != r.Signal {
+				continue
+			}
+			seen[s.Subject] = true
+			if math.IsNaN(s.Value) {
+				continue
+			}
+			key := r.Name + "\x00" + s.Subject
+			st := e.states[key]
+			if !r.match(s.Value) {
+				if st != nil {
+					if st.firing {
+						st.value = s.Value
+						out = append(out, e.notification(r, s.Subject, st, now, StatusResolved))
+					}
+					delete(e.states, key)
+				}
+				continue
+			}
+			if st == nil {
+				st = &state{pendingSince: now}
+				e.states[key] = st
+			}
+			st.value = s.Value
+			if !st.firing && now.Sub(st.pendingSince) >= time.Duration(r.For) {
+				st.firing, st.firedAt = true, now
+				out = append(out, e.notification(r, s.Subject, st, now, StatusFiring))
+			}
+		}
+		var gone []string
+		for key := range e.states {
+			if subject, ok := strings.CutPrefix(key, r.Name+"\x00"); ok && !seen[subject] {
+				gone = append(gone, subject)
+			}
+		}
+		sort.Strings(gone)
+		for _, subject := range gone {
+			key := r.Name + "\x00" + subject
+			if st := e.states[key]; st.firing {
+				out = append(out, e.notification(r, subject, st, now, StatusResolved))
+			}
+			delete(e.states, key)
+		}
+	}
+	return out
+}
+
+// SetRules replaces the rules. Alerts of a rule whose name, signal, op,
+// threshold and for are unchanged carry over. Alerts of any other old rule
+// are dropped, and a resolved notification is returned for each one that
+// was firing.
+func (e *Engine) SetRules(now time.Time, rules []Rule) []Notification {
+	next := map[string]Rule{}
+	for _, r := range rules {
+		next[r.Name] = r
+	}
+	var out []Notification
+	for _, r := range e.rules {
+		if n, ok := next[r.Name]; ok && n.Signal == r.Signal && n.Op == r.Op && n.Threshold == r.Threshold && n.For == r.For {
+			continue
+		}
+		var subjects []string
+		for key := range e.states {
+			if subject, ok := strings.CutPrefix(key, r.Name+"\x00"); ok {
+				subjects = append(subjects, subject)
+			}
+		}
+		sort.Strings(subjects)
+		for _, subject := range subjects {
+			key := r.Name + "\x00" + subject
+			if st := e.states[key]; st.firing {
+				out = append(out, e.notification(r, subject, st, now, StatusResolved))
+			}
+			delete(e.states, key)
+		}
+	}
+	e.rules = rules
+	return out
+}
+
+// Firing returns the number of firing alerts per rule.
+func (e *Engine) Firing() map[string]int {
+	out := map[string]int{}
+	for _, r := range e.rules {
+		out[r.Name] = 0
+	}
+	for key, st := range e.states {
+		if st.firing {
+			name, _, _ := strings.Cut(key, "\x00")
+			out[name]++
+		}
+	}
+	return out
+}
+
+func (e *Engine) notification(r Rule, subject string, st *state, now time.Time, status string) Notification {
+	n := Notification{
+		Status:    status,
+		Rule:      r.Name,
+		Severity:  r.Severity,
+		Subject:   subject,
+		Signal:    r.Signal,
+		Value:     st.value,
+		Op:        r.Op,
+		Threshold: r.Threshold,
+		StartsAt:  st.firedAt.UTC().Format(time.RFC3339),
+	}
+	if status == StatusResolved {
+		n.EndsAt = now.UTC().Format(time.RFC3339)
+	}
+	summary := r.Summary
+	if summary == "" {
+		summary = "{{signal}} {{op}} {{threshold}} on {{subject}} (value {{value}})"
+	}
+	n.Summary = strings.NewReplacer(
+		"{{subject}}", subject,
+		"{{signal}}", r.Signal,
+		"{{op}}", r.Op,
+		"{{value}}", formatValue(st.value),
+		"{{threshold}}", formatValue(r.Threshold),
+	).Replace(summary)
+	return n
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
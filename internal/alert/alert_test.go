@@ -0,0 +1,173 @@
+package alert
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEngine(t *testing.T) {
+	e := NewEngine([]Rule{
+		{Name: "LowPeers", Signal: "node.peers", Op: "<", Threshold: 2, For: Duration(10 * time.Second), Severity: "warning", Summary: "{{subject}} has {{value}} peers"},
+		{Name: "Divergence", Signal: "cluster.head_divergence", Op: ">", Threshold: 5, Severity: "critical"},
+	})
+	t0 := time.Unix(1700000000, 0)
+	at := func(s int) time.Time { return t0.Add(time.Duration(s) * time.Second) }
+	peers := func(n1, n2 float64) []Sample {
+		return []Sample{{"node.peers", "n1", n1}, {"node.peers", "n2", n2}, {"cluster.head_divergence", "cluster", 0}}
+	}
+
+	if got := e.Evaluate(at(0), peers(1, 3)); len(got) != 0 {
+		t.Fatalf("pending rule fired: %+v", got)
+	}
+	if got := e.Evaluate(at(5), peers(0, 3)); len(got) != 0 {
+		t.Fatalf("fired before for: %+v", got)
+	}
+	got := e.Evaluate(at(10), peers(1, 3))
+	if len(got) != 1 || got[0].Status != StatusFiring || got[0].Subject != "n1" || got[0].Summary != "n1 has 1 peers" || got[0].StartsAt != "2023-11-14T22:13:30Z" {
+		t.Fatalf("firing: %+v", got)
+	}
+	if got := e.Evaluate(at(15), peers(1, 3)); len(got) != 0 {
+		t.Fatalf("not deduplicated: %+v", got)
+	}
+	if e.Firing()["LowPeers"] != 1 || e.Firing()["Divergence"] != 0 {
+		t.Fatalf("firing counts %v", e.Firing())
+	}
+
+	got = e.Evaluate(at(20), []Sample{{"node.peers", "n1", 4}, {"node.peers", "n2", 1}, {"cluster.head_divergence", "cluster", 9}})
+	if len(got) != 2 || got[0].Status != StatusResolved || got[0].Subject != "n1" || got[0].EndsAt == "" || got[1].Rule != "Divergence" || got[1].Status != StatusFiring {
+		t.Fatalf("resolve and fire: %+v", got)
+	}
+	if got[1].Summary != "cluster.head_divergence > 5 on cluster (value 9)" {
+		t.Fatalf("default summary %q", got[1].Summary)
+	}
+
+	// n2 has been low for 10s; a signal that is no longer sampled resolves.
+	got = e.Evaluate(at(30), []Sample{{"node.peers", "n2", 1}})
+	if len(got) != 2 || got[0].Subject != "n2" || got[0].Status != StatusFiring || got[1].Rule != "Divergence" || got[1].Status != StatusResolved {
+		t.Fatalf("absent: %+v", got)
+	}
+}
+
+func TestEngineSetRules(t *testing.T) {
+	low := Rule{Name: "LowPeers", Signal: "node.peers", Op: "<", Threshold: 2, Severity: "warning"}
+	down := Rule{Name: "NodeDown", Signal: "node.up", Op: "==", Threshold: 0, Severity: "critical"}
+	e := NewEngine([]Rule{low, down})
+	now := time.Unix(1700000000, 0)
+	if got := e.Evaluate(now, []Sample{{"node.peers", "n1", 0}, {"node.up", "n2", 0}}); len(got) != 2 {
+		t.Fatalf("firing: %+v", got)
+	}
+
+	// LowPeers only changes severity and keeps firing; NodeDown is removed.
+	low.Severity = "critical"
+	got := e.SetRules(now, []Rule{low})
+	if len(got) != 1 || got[0].Rule != "NodeDown" || got[0].Status != StatusResolved || got[0].Subject != "n2" {
+		t.Fatalf("removed rule: %+v", got)
+	}
+	if got := e.Evaluate(now.Add(time.Second), []Sample{{"node.peers", "n1", 0}}); len(got) != 0 || e.Firing()["LowPeers"] != 1 {
+		t.Fatalf("kept rule refired: %+v %v", got, e.Firing())
+	}
+
+	low.Threshold = 1
+	if got := e.SetRules(now, []Rule{low}); len(got) != 1 || got[0].Rule != "LowPeers" || got[0].Severity != "critical" {
+		t.Fatalf("changed rule: %+v", got)
+	}
+	if len(e.states) != 0 {
+		t.Fatalf("states left: %v", e.states)
+	}
+}
+
+func TestEngineUnknownSample(t *testing.T) {
+	e := NewEngine([]Rule{{Name: "LowPeers", Signal: "node.peers", Op: "<", Threshold: 2, Severity: "warning"}})
+	now := time.Unix(1700000000, 0)
+	if got := e.Evaluate(now, []Sample{{"node.peers", "n1", 1}}); len(got) != 1 {
+		t.Fatalf("firing: %+v", got)
+	}
+
+	// n1 goes down: its peers are unknown, which must not resolve the alert.
+	if got := e.Evaluate(now.Add(time.Second), []Sample{{"node.peers", "n1", math.NaN()}}); len(got) != 0 || e.Firing()["LowPeers"] != 1 {
+		t.Fatalf("unknown sample: %+v %v", got, e.Firing())
+	}
+	if got := e.Evaluate(now.Add(2*time.Second), []Sample{{"node.peers", "n1", 0}}); len(got) != 0 {
+		t.Fatalf("refired after the node came back: %+v", got)
+	}
+	got := e.Evaluate(now.Add(3*time.Second), []Sample{{"node.peers", "n1", 5}})
+	if len(got) != 1 || got[0].Status != StatusResolved || got[0].Subject != "n1" {
+		t.Fatalf("resolve: %+v", got)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(body string) string {
+		p := filepath.Join(dir, "alerts.json")
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	cfg, err := LoadConfig(write(`{"rules":[{"name":"a","signal":"node.peers","op":"<","threshold":2,"for":"30s","severity":"warning"}],"webhooks":[{"url":"http://x","format":"slack"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Duration(cfg.Rules[0].For) != 30*time.Second || cfg.Webhooks[0].Format != FormatSlack {
+		t.Fatalf("config %+v", cfg)
+	}
+	for _, bad := range []string{
+		`{"rules":[{"name":"a","signal":"s","op":"=>","severity":"warning"}]}`,
+		`{"rules":[{"name":"a","signal":"s","op":">","severity":"page"}]}`,
+		`{"rules":[{"name":"a","signal":"s","op":">","severity":"info","for":30}]}`,
+		`{"rules":[{"name":"a","signal":"s","op":">","severity":"info"},{"name":"a","signal":"s","op":">","severity":"info"}]}`,
+		`{"webhooks":[{"url":"http://x","format":"teams"}]}`,
+		`{"rule":[]}`,
+	} {
+		if _, err := LoadConfig(write(bad)); err == nil {
+			t.Errorf("LoadConfig(%s) succeeded", bad)
+		}
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies = map[string][]map[string]any{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		bodies[r.URL.Path] = append(bodies[r.URL.Path], body)
+		mu.Unlock()
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	var failures []string
+	n := NewNotifier([]Webhook{
+		{URL: srv.URL + "/json", Headers: map[string]string{"Authorization": "Bearer x"}},
+		{Name: "slack", URL: srv.URL + "/slack", Format: FormatSlack},
+		{Name: "broken", URL: srv.URL + "/fail"},
+	}, time.Second, func(w Webhook, _ Notification, err error) { failures = append(failures, w.Label()+": "+err.Error()) })
+	n.Notify(Notification{Status: StatusFiring, Rule: "LowPeers", Severity: "warning", Subject: "n1", Summary: "n1 has 1 peers"})
+	n.Notify(Notification{Status: StatusResolved, Rule: "LowPeers", Severity: "warning", Subject: "n1", Summary: "n1 has 3 peers"})
+	n.Close()
+
+	if len(bodies["/json"]) != 2 || bodies["/json"][0]["rule"] != "LowPeers" || bodies["/json"][1]["status"] != StatusResolved {
+		t.Fatalf("json bodies %v", bodies["/json"])
+	}
+	if text, _ := bodies["/slack"][0]["text"].(string); !strings.Contains(text, "[FIRING] warning LowPeers: n1 has 1 peers") {
+		t.Fatalf("slack text %q", text)
+	}
+	if len(failures) != 2 || failures[0] != "broken: webhook broken: HTTP 502" {
+		t.Fatalf("failures %q", failures)
+	}
+}
@@ -0,0 +1,125 @@
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Webhook payload formats.
+const (
+	FormatJSON  = "json"
+	FormatSlack = "slack"
+)
+
+// Webhook is a notification target. Format "json" (the default) posts the
+// Notification as is; "slack" posts a Slack incoming-webhook message.
+type Webhook struct {
+	Name    string            `json:"name,omitempty"`
+	URL     string            `json:"url"`
+	Format  string            `json:"format,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Label names the webhook in logs and metrics.
+func (w Webhook) Label() string {
+	if w.Name != "" {
+		return w.Name
+	}
+	return w.URL
+}
+
+func (w Webhook) payload(n Notification) any {
+	if w.Format != FormatSlack {
+		return n
+	}
+	icon := ":rotating_light:"
+	if n.Status == StatusResolved {
+		icon = ":white_check_mark:"
+	}
+	return map[string]string{
+		"text": fmt.Sprintf("%s [%s] %s %s: %s", icon, strings.ToUpper(n.Status), n.Severity, n.Rule, n.Summary),
+	}
+}
+
+// Send posts n to the webhook and fails on any non-2xx response.
+func (w Webhook) Send(client *http.Client, n Notification) error {
+	body, err := json.Marshal(w.payload(n))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s: HTTP %d", w.Label(), resp.StatusCode)
+	}
+	return nil
+}
+
+// Notifier delivers notifications to every webhook in order from a single
+// goroutine, so a slow target does not hold up evaluation.
+type Notifier struct {
+	webhooks []Webhook
+	client   *http.Client
+	queue    chan Notification
+	done     chan struct{}
+	onError  func(Webhook, Notification, error)
+}
+
+// NewNotifier starts the delivery goroutine. onError, if set, is called for
+// every failed delivery.
+func NewNotifier(webhooks []Webhook, timeout time.Duration, onError func(Webhook, Notification, error)) *Notifier {
+	n := &Notifier{
+		webhooks: webhooks,
+		client:   &http.Client{Timeout: timeout},
+		queue:    make(chan Notification, 256),
+		done:     make(chan struct{}),
+		onError:  onError,
+	}
+	go n.loop()
+	return n
+}
+
+func (n *Notifier) loop() {
+	defer close(n.done)
+	for note := range n.queue {
+		for _, w := range n.webhooks {
+			if err := w.Send(n.client, note); err != nil && n.onError != nil {
+				n.onError(w, note, err)
+			}
+		}
+	}
+}
+
+// Notify queues a notification. It drops it when the queue is full rather
+// than block.
+func (n *Notifier) Notify(note Notification) bool {
+	select {
+	case n.queue <- note:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close delivers the queued notifications and stops the goroutine.
+func (n *Notifier) Close() {
+	close(n.queue)
+	<-n.done
+}
@@ -1,6 +1,7 @@
 package allocations
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/BioMark3r/qikchain/internal/config"
@@ -113,3 +114,24 @@ func TestReportTotalsAndFormatting(t *testing.T) {
 		t.Fatalf("unexpected total qik: %s", report.TotalPremineQIK)
 	}
 }
+
+func TestFormatUnits(t *testing.T) {
+	cases := []struct {
+		value       string
+		decimals    int
+		maxDecimals int
+		want        string
+	}{
+		{"1500000000000000000", 18, 6, "1.5"},
+		{"-250000", 6, 6, "-0.25"},
+		{"123", 0, 6, "123"},
+		{"1234567", 6, 2, "1.23"},
+		{"1000000", 6, 0, "1"},
+	}
+	for _, tc := range cases {
+		v, _ := new(big.Int).SetString(tc.value, 10)
+		if got := FormatUnits(v, tc.decimals, tc.maxDecimals); got != tc.want {
+			t.Fatalf("FormatUnits(%s, %d, %d) = %q, want %q", tc.value, tc.decimals, tc.maxDecimals, got, tc.want)
+		}
+	}
+}
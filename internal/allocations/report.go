@@ -64,22 +64,37 @@ func BuildReport(cfg config.AllocationConfig, token config.TokenConfig, maxDecim
 }
 
 func FormatQIK(wei *big.Int, maxDecimals int) string {
+	return FormatUnits(wei, 18, maxDecimals)
+}
+
+// FormatUnits renders value, expressed in the smallest unit of a token with
+// the given decimals, as a decimal string truncated to maxDecimals.
+func FormatUnits(value *big.Int, decimals, maxDecimals int) string {
 	if maxDecimals < 0 {
 		maxDecimals = 0
 	}
-	divisor := big.NewInt(1_000_000_000_000_000_000)
-	whole := new(big.Int).Div(new(big.Int).Set(wei), divisor)
-	rem := new(big.Int).Mod(new(big.Int).Set(wei), divisor)
+	if decimals <= 0 {
+		return value.String()
+	}
+	neg := value.Sign() < 0
+	abs := new(big.Int).Abs(value)
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole := new(big.Int).Div(abs, divisor)
+	rem := new(big.Int).Mod(abs, divisor)
+	sign := ""
+	if neg {
+		sign = "-"
+	}
 	if maxDecimals == 0 {
-		return whole.String()
+		return sign + whole.String()
 	}
-	frac := fmt.Sprintf("%018s", rem.String())
+	frac := fmt.Sprintf("%0*s", decimals, rem.String())
 	if maxDecimals < len(frac) {
 		frac = frac[:maxDecimals]
 	}
 	frac = strings.TrimRight(frac, "0")
 	if frac == "" {
-		return whole.String()
+		return sign + whole.String()
 	}
-	return whole.String() + "." + frac
+	return sign + whole.String() + "." + frac
 }
@@ -0,0 +1,206 @@
+// Package analyze computes block production statistics over a range of
+// blocks: intervals, transactions, gas and IBFT rounds.
+package analyze
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/ibft"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FetchBlocks reads blocks from..to inclusive without transaction bodies,
+// batch blocks per JSON-RPC batch request. A batch of 1 sends single
+// requests.
+func FetchBlocks(c *rpc.Client, from, to uint64, batch int) ([]*rpc.Block, error) {
+	if to < from {
+		return nil, nil
+	}
+	if batch < 1 {
+		batch = 1
+	}
+	out := make([]*rpc.Block, 0, to-from+1)
+	for start := from; start <= to; start += uint64(batch) {
+		end := start + uint64(batch) - 1
+		if end > to || end < start {
+			end = to
+		}
+		if batch == 1 {
+			b, err := c.BlockByNumber(hexutil.EncodeUint64(start), false)
+			if err != nil {
+				return nil, fmt.Errorf("block %d: %w", start, err)
+			}
+			out = append(out, b)
+			continue
+		}
+		blocks := make([]rpc.Block, end-start+1)
+		elems := make([]rpc.BatchElem, len(blocks))
+		for i := range elems {
+			elems[i] = rpc.BatchElem{Method: "eth_getBlockByNumber", Params: []any{hexutil.EncodeUint64(start + uint64(i)), false}, Result: &blocks[i]}
+		}
+		if err := c.BatchCall(elems); err != nil {
+			return nil, fmt.Errorf("blocks %d-%d: %w", start, end, err)
+		}
+		for i := range elems {
+			if elems[i].Error != nil {
+				return nil, fmt.Errorf("block %d: %w", start+uint64(i), elems[i].Error)
+			}
+			out = append(out, &blocks[i])
+		}
+		if end == to {
+			break
+		}
+	}
+	return out, nil
+}
+
+// Distribution summarizes a series with nearest-rank percentiles.
+type Distribution struct {
+	Min  float64 `json:"min"`
+	P50  float64 `json:"p50"`
+	P95  float64 `json:"p95"`
+	Max  float64 `json:"max"`
+	Mean float64 `json:"mean"`
+}
+
+func distribution(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	return Distribution{Min: sorted[0], P50: rank(0.5), P95: rank(0.95), Max: sorted[len(sorted)-1], Mean: sum / float64(len(sorted))}
+}
+
+// Options tune the report.
+type Options struct {
+	// TargetBlockTime is the configured block time; 0 skips the comparison.
+	TargetBlockTime time.Duration
+	// GasLimit is the configured block gas limit; 0 measures utilisation
+	// against each header's own gas limit.
+	GasLimit uint64
+}
+
+// Report is the analysis of a contiguous range of blocks.
+type Report struct {
+	FromBlock       uint64  `json:"fromBlock"`
+	ToBlock         uint64  `json:"toBlock"`
+	Blocks          int     `json:"blocks"`
+	DurationSeconds float64 `json:"durationSeconds"`
+
+	IntervalSeconds        Distribution `json:"intervalSeconds"`
+	TargetBlockTimeSeconds float64      `json:"targetBlockTimeSeconds,omitempty"`
+	IntervalsOverTarget    int          `json:"intervalsOverTarget,omitempty"`
+
+	EmptyBlocks     int          `json:"emptyBlocks"`
+	EmptyRatio      float64      `json:"emptyRatio"`
+	Transactions    int          `json:"transactions"`
+	TxPerSecond     float64      `json:"txPerSecond"`
+	TxPerBlock      Distribution `json:"txPerBlock"`
+	GasUsed         uint64       `json:"gasUsed"`
+	GasUsedPerBlock Distribution `json:"gasUsedPerBlock"`
+
+	GasLimit          uint64       `json:"gasLimit,omitempty"`
+	HeaderGasLimitMin uint64       `json:"headerGasLimitMin"`
+	HeaderGasLimitMax uint64       `json:"headerGasLimitMax"`
+	GasUtilisation    Distribution `json:"gasUtilisation"`
+
+	// RoundChanges counts blocks sealed after round 0.
+	RoundChanges  int            `json:"roundChanges"`
+	MaxRound      uint64         `json:"maxRound"`
+	Rounds        map[string]int `json:"rounds"`
+	RoundsUnknown int            `json:"roundsUnknown,omitempty"`
+}
+
+// Blocks analyzes blocks, which must be contiguous and in order.
+func Blocks(blocks []*rpc.Block, opts Options) (Report, error) {
+	r := Report{Rounds: map[string]int{}, GasLimit: opts.GasLimit}
+	if len(blocks) == 0 {
+		return r, fmt.Errorf("no blocks to analyze")
+	}
+	first, last := blocks[0], blocks[len(blocks)-1]
+	r.FromBlock, r.ToBlock, r.Blocks = uint64(first.Number), uint64(last.Number), len(blocks)
+	if last.Timestamp > first.Timestamp {
+		r.DurationSeconds = float64(last.Timestamp - first.Timestamp)
+	}
+	r.TargetBlockTimeSeconds = opts.TargetBlockTime.Seconds()
+	r.HeaderGasLimitMin = uint64(first.GasLimit)
+
+	var intervals, txs, gas, util []float64
+	for i, b := range blocks {
+		if i > 0 {
+			prev := blocks[i-1]
+			if b.Number != prev.Number+1 {
+				return r, fmt.Errorf("block %d follows %d", uint64(b.Number), uint64(prev.Number))
+			}
+			d := float64(0)
+			if b.Timestamp > prev.Timestamp {
+				d = float64(b.Timestamp - prev.Timestamp)
+			}
+			intervals = append(intervals, d)
+			if opts.TargetBlockTime > 0 && d > opts.TargetBlockTime.Seconds() {
+				r.IntervalsOverTarget++
+			}
+		}
+		n := len(b.Transactions)
+		r.Transactions += n
+		if n == 0 {
+			r.EmptyBlocks++
+		}
+		txs = append(txs, float64(n))
+		r.GasUsed += uint64(b.GasUsed)
+		gas = append(gas, float64(b.GasUsed))
+
+		limit := uint64(b.GasLimit)
+		if limit < r.HeaderGasLimitMin {
+			r.HeaderGasLimitMin = limit
+		}
+		if limit > r.HeaderGasLimitMax {
+			r.HeaderGasLimitMax = limit
+		}
+		if opts.GasLimit > 0 {
+			limit = opts.GasLimit
+		}
+		if limit > 0 {
+			util = append(util, float64(b.GasUsed)/float64(limit))
+		}
+
+		extra, err := ibft.DecodeExtra(b.ExtraData)
+		if err != nil || extra.Round == nil {
+			r.RoundsUnknown++
+			continue
+		}
+		round := *extra.Round
+		r.Rounds[fmt.Sprint(round)]++
+		if round > 0 {
+			r.RoundChanges++
+		}
+		if round > r.MaxRound {
+			r.MaxRound = round
+		}
+	}
+	r.IntervalSeconds = distribution(intervals)
+	r.EmptyRatio = float64(r.EmptyBlocks) / float64(r.Blocks)
+	r.TxPerBlock = distribution(txs)
+	r.GasUsedPerBlock = distribution(gas)
+	r.GasUtilisation = distribution(util)
+	if r.DurationSeconds > 0 {
+		r.TxPerSecond = float64(r.Transactions) / r.DurationSeconds
+	}
+	return r, nil
+}
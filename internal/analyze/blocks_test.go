@@ -0,0 +1,106 @@
+package analyze
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/ibft"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func extraWithRound(t *testing.T, round uint64) []byte {
+	t.Helper()
+	body, err := rlp.EncodeToBytes([]any{[]common.Address{{1}}, []byte{}, [][]byte{}, []byte{}, round})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(bytes.Repeat([]byte{0}, ibft.ExtraVanity), body...)
+}
+
+func testBlocks(t *testing.T) []*rpc.Block {
+	// 21 blocks from 100: 2s apart except a 6s gap before block 110, which
+	// took round 1. Every fifth block carries two transactions.
+	var out []*rpc.Block
+	ts := uint64(1700000000)
+	for i := 0; i < 21; i++ {
+		n := uint64(100 + i)
+		if i > 0 {
+			ts += 2
+			if n == 110 {
+				ts += 4
+			}
+		}
+		b := &rpc.Block{Number: hexutil.Uint64(n), Timestamp: hexutil.Uint64(ts), GasLimit: 30_000_000, ExtraData: extraWithRound(t, 0)}
+		if n == 110 {
+			b.ExtraData = extraWithRound(t, 1)
+		}
+		if i%5 == 0 {
+			b.Transactions = []json.RawMessage{json.RawMessage(`"0x01"`), json.RawMessage(`"0x02"`)}
+			b.GasUsed = 42000
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+func TestBlocks(t *testing.T) {
+	r, err := Blocks(testBlocks(t), Options{TargetBlockTime: 2 * time.Second, GasLimit: 15_000_000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Blocks != 21 || r.FromBlock != 100 || r.ToBlock != 120 || r.DurationSeconds != 44 {
+		t.Fatalf("range %+v", r)
+	}
+	if r.IntervalSeconds.P50 != 2 || r.IntervalSeconds.P95 != 2 || r.IntervalSeconds.Max != 6 || r.IntervalSeconds.Mean != 2.2 || r.IntervalsOverTarget != 1 {
+		t.Fatalf("intervals %+v over %d", r.IntervalSeconds, r.IntervalsOverTarget)
+	}
+	if r.EmptyBlocks != 16 || r.Transactions != 10 || r.TxPerBlock.Max != 2 || r.GasUsed != 210000 {
+		t.Fatalf("txs %+v", r)
+	}
+	if r.GasUtilisation.Max != 42000.0/15_000_000 || r.HeaderGasLimitMax != 30_000_000 {
+		t.Fatalf("gas %+v", r.GasUtilisation)
+	}
+	if r.RoundChanges != 1 || r.MaxRound != 1 || r.Rounds["0"] != 20 || r.Rounds["1"] != 1 {
+		t.Fatalf("rounds %+v", r.Rounds)
+	}
+
+	blocks := testBlocks(t)
+	if _, err := Blocks(append(blocks[:3:3], blocks[4:]...), Options{}); err == nil {
+		t.Fatal("gap in blocks accepted")
+	}
+}
+
+func TestFetchBlocks(t *testing.T) {
+	batches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		batches++
+		var reqs []struct {
+			ID     int   `json:"id"`
+			Params []any `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		var out []map[string]any
+		for _, req := range reqs {
+			out = append(out, map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": map[string]any{"number": req.Params[0]}})
+		}
+		_ = json.NewEncoder(w).Encode(out)
+	}))
+	defer srv.Close()
+
+	blocks, err := FetchBlocks(rpc.NewClient(srv.URL, time.Second), 5, 14, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 10 || blocks[0].Number != 5 || blocks[9].Number != 14 || batches != 3 {
+		t.Fatalf("%d blocks in %d batches", len(blocks), batches)
+	}
+}
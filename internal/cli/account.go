@@ -0,0 +1,322 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BioMark3r/qikchain/internal/allocations"
+	"github.com/BioMark3r/qikchain/internal/config"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+const defaultTokenPath = "config/token.json"
+
+type accountBalanceOutput struct {
+	Address    string `json:"address"`
+	BalanceWei string `json:"balanceWei"`
+	Balance    string `json:"balance"`
+	Symbol     string `json:"symbol"`
+	PremineWei string `json:"premineWei,omitempty"`
+	DeltaWei   string `json:"deltaWei,omitempty"`
+	Genesis    string `json:"genesis,omitempty"`
+}
+
+func newAccountCmd(cfg *Config) *cobra.Command {
+	var block string
+	cmd := &cobra.Command{
+		Use:   "account",
+		Short: "Inspect account balance, nonce, code and storage",
+	}
+	cmd.PersistentFlags().StringVar(&block, "block", "latest", "block number, hash or tag to query state at")
+
+	cmd.AddCommand(newAccountBalanceCmd(cfg, &block))
+	cmd.AddCommand(newAccountNonceCmd(cfg, &block))
+	cmd.AddCommand(newAccountCodeCmd(cfg, &block))
+	cmd.AddCommand(newAccountStorageCmd(cfg, &block))
+	return cmd
+}
+
+func parseAddress(arg string) (common.Address, error) {
+	if !common.IsHexAddress(arg) {
+		return common.Address{}, usageErrorf("invalid address %q", arg)
+	}
+	return common.HexToAddress(arg), nil
+}
+
+func blockParam(ref string) (any, error) {
+	tag, hash, err := parseBlockRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	if hash != nil {
+		return rpc.BlockHashParam(*hash), nil
+	}
+	return tag, nil
+}
+
+func loadTokenOrDefault(path string) (config.TokenConfig, error) {
+	token, err := config.LoadTokenConfig(path)
+	if err == nil {
+		return token, nil
+	}
+	if path == defaultTokenPath && errors.Is(err, os.ErrNotExist) {
+		return config.TokenConfig{Name: "QIK", Symbol: "QIK", Decimals: 18}, nil
+	}
+	return token, err
+}
+
+// renderedPremine returns the genesis balance of every address in the rendered
+// alloc map, keyed by checksummed address.
+func renderedPremine(path string) (map[string]*big.Int, error) {
+	allocCfg, _, err := loadAndVerifyAllocationFile(path, false)
+	if err != nil {
+		return nil, err
+	}
+	data, err := allocations.RenderAllocMap(allocCfg)
+	if err != nil {
+		return nil, err
+	}
+	var alloc map[string]struct {
+		Balance string `json:"balance"`
+	}
+	if err := json.Unmarshal(data, &alloc); err != nil {
+		return nil, err
+	}
+	out := make(map[string]*big.Int, len(alloc))
+	for addr, entry := range alloc {
+		v, err := config.ParseAmountDecimal(entry.Balance)
+		if err != nil {
+			return nil, fmt.Errorf("alloc %s: %w", addr, err)
+		}
+		out[common.HexToAddress(addr).Hex()] = v
+	}
+	return out, nil
+}
+
+func newAccountBalanceCmd(cfg *Config, block *string) *cobra.Command {
+	var (
+		tokenPath    string
+		maxDecimals  int
+		genesisCheck bool
+		allocPath    string
+		env          string
+	)
+	cmd := &cobra.Command{
+		Use:   "balance [addr...]",
+		Short: "Show account balances in wei and QIK",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && !genesisCheck {
+				return usageErrorf("account balance: at least one address is required unless --genesis-check is set")
+			}
+			blk, err := blockParam(*block)
+			if err != nil {
+				return err
+			}
+			token, err := loadTokenOrDefault(tokenPath)
+			if err != nil {
+				return fmt.Errorf("account balance: %w", err)
+			}
+
+			addrs := make([]common.Address, 0, len(args))
+			for _, arg := range args {
+				addr, err := parseAddress(arg)
+				if err != nil {
+					return err
+				}
+				addrs = append(addrs, addr)
+			}
+
+			var premine map[string]*big.Int
+			if genesisCheck {
+				if allocPath == "" {
+					allocPath = filepath.Join("config", "allocations", env+".json")
+				}
+				if premine, err = renderedPremine(allocPath); err != nil {
+					return fmt.Errorf("account balance: %s: %w", allocPath, err)
+				}
+				if len(addrs) == 0 {
+					keys := make([]string, 0, len(premine))
+					for k := range premine {
+						keys = append(keys, k)
+					}
+					sort.Strings(keys)
+					for _, k := range keys {
+						addrs = append(addrs, common.HexToAddress(k))
+					}
+				}
+			}
+
+			client := cfg.client()
+			results := make([]accountBalanceOutput, 0, len(addrs))
+			matched := 0
+			for _, addr := range addrs {
+				bal, err := client.BalanceAt(addr, blk)
+				if err != nil {
+					return fmt.Errorf("account balance %s: %w", addr.Hex(), err)
+				}
+				out := accountBalanceOutput{
+					Address:    addr.Hex(),
+					BalanceWei: bal.String(),
+					Balance:    allocations.FormatUnits(bal, token.Decimals, maxDecimals),
+					Symbol:     token.Symbol,
+				}
+				if premine != nil {
+					want, ok := premine[addr.Hex()]
+					switch {
+					case !ok:
+						out.Genesis = "not-in-alloc"
+					case want.Cmp(bal) == 0:
+						out.Genesis = "match"
+						matched++
+					default:
+						out.Genesis = "changed"
+					}
+					if ok {
+						out.PremineWei = want.String()
+						out.DeltaWei = new(big.Int).Sub(bal, want).String()
+					}
+				}
+				results = append(results, out)
+			}
+
+			if cfg.JSON {
+				return printJSON(results)
+			}
+			for _, r := range results {
+				fmt.Printf("%s balance=%s %s wei=%s", r.Address, r.Balance, r.Symbol, r.BalanceWei)
+				if r.Genesis != "" {
+					fmt.Printf(" genesis=%s", r.Genesis)
+				}
+				if r.PremineWei != "" {
+					premineWei, _ := new(big.Int).SetString(r.PremineWei, 10)
+					deltaWei, _ := new(big.Int).SetString(r.DeltaWei, 10)
+					fmt.Printf(" premine=%s delta=%s", allocations.FormatUnits(premineWei, token.Decimals, maxDecimals), allocations.FormatUnits(deltaWei, token.Decimals, maxDecimals))
+				}
+				fmt.Println()
+			}
+			if premine != nil {
+				fmt.Printf("genesis check: %d/%d balances match premine in %s\n", matched, len(results), allocPath)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&tokenPath, "token", defaultTokenPath, "token metadata file path")
+	cmd.Flags().IntVar(&maxDecimals, "max-decimals", 6, "max fractional decimals in human output")
+	cmd.Flags().BoolVar(&genesisCheck, "genesis-check", false, "compare balances to the premine in the rendered alloc")
+	cmd.Flags().StringVar(&allocPath, "allocations", "", "allocation file path (default config/allocations/<env>.json)")
+	cmd.Flags().StringVar(&env, "env", "devnet", "environment used to locate the allocation file")
+	_ = cmd.RegisterFlagCompletionFunc("token", jsonFileCompletion)
+	_ = cmd.RegisterFlagCompletionFunc("allocations", jsonFileCompletion)
+	_ = cmd.RegisterFlagCompletionFunc("env", cobra.FixedCompletions([]string{"devnet", "staging", "mainnet"}, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+func newAccountNonceCmd(cfg *Config, block *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "nonce <addr>",
+		Short: "Show the account nonce",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := parseAddress(args[0])
+			if err != nil {
+				return err
+			}
+			blk, err := blockParam(*block)
+			if err != nil {
+				return err
+			}
+			nonce, err := cfg.client().NonceAt(addr, blk)
+			if err != nil {
+				return fmt.Errorf("account nonce: %w", err)
+			}
+			if cfg.JSON {
+				return printJSON(map[string]any{"address": addr.Hex(), "nonce": nonce})
+			}
+			fmt.Println(nonce)
+			return nil
+		},
+	}
+}
+
+func newAccountCodeCmd(cfg *Config, block *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "code <addr>",
+		Short: "Show the contract code deployed at an address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := parseAddress(args[0])
+			if err != nil {
+				return err
+			}
+			blk, err := blockParam(*block)
+			if err != nil {
+				return err
+			}
+			code, err := cfg.client().CodeAt(addr, blk)
+			if err != nil {
+				return fmt.Errorf("account code: %w", err)
+			}
+			if cfg.JSON {
+				return printJSON(map[string]any{"address": addr.Hex(), "size": len(code), "code": hexutil.Encode(code)})
+			}
+			if len(code) == 0 {
+				slog.Warn("account has no code", "address", addr.Hex())
+			}
+			fmt.Println(hexutil.Encode(code))
+			return nil
+		},
+	}
+}
+
+func newAccountStorageCmd(cfg *Config, block *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "storage <addr> <slot>",
+		Short: "Read a contract storage slot",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := parseAddress(args[0])
+			if err != nil {
+				return err
+			}
+			slot, err := parseSlot(args[1])
+			if err != nil {
+				return err
+			}
+			blk, err := blockParam(*block)
+			if err != nil {
+				return err
+			}
+			value, err := cfg.client().StorageAt(addr, slot, blk)
+			if err != nil {
+				return fmt.Errorf("account storage: %w", err)
+			}
+			if cfg.JSON {
+				return printJSON(map[string]any{"address": addr.Hex(), "slot": slot.Hex(), "value": value.Hex()})
+			}
+			fmt.Println(value.Hex())
+			return nil
+		},
+	}
+}
+
+// parseSlot accepts a decimal index or a hex value of up to 32 bytes.
+func parseSlot(arg string) (common.Hash, error) {
+	base := 10
+	if strings.HasPrefix(arg, "0x") || strings.HasPrefix(arg, "0X") {
+		arg, base = arg[2:], 16
+	}
+	if v, ok := new(big.Int).SetString(arg, base); ok && v.Sign() >= 0 && v.BitLen() <= 256 {
+		return common.BigToHash(v), nil
+	}
+	return common.Hash{}, usageErrorf("invalid storage slot %q", arg)
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BioMark3r/qikchain/internal/allocations"
+	"github.com/BioMark3r/qikchain/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newAllocationsCmd(cfg *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "allocations",
+		Short: "Verify, report and render genesis allocations",
+	}
+
+	cmd.AddCommand(newAllocationsVerifyCmd())
+	cmd.AddCommand(newAllocationsReportCmd(cfg))
+	cmd.AddCommand(newAllocationsRenderCmd())
+	return cmd
+}
+
+func loadAndVerifyAllocationFile(path string, allowZero bool) (config.AllocationConfig, allocations.Summary, error) {
+	cfg, err := config.LoadAllocationConfig(path)
+	if err != nil {
+		return cfg, allocations.Summary{}, err
+	}
+	summary, errs := allocations.Verify(cfg, allocations.VerifyOptions{AllowZeroAddress: allowZero})
+	if len(errs) > 0 {
+		var sb strings.Builder
+		for _, verifyErr := range errs {
+			sb.WriteString("- ")
+			sb.WriteString(verifyErr.Error())
+			sb.WriteString("\n")
+		}
+		return cfg, summary, errors.New(strings.TrimSpace(sb.String()))
+	}
+	return cfg, summary, nil
+}
+
+func newAllocationsVerifyCmd() *cobra.Command {
+	var (
+		file      string
+		allowZero bool
+		allowDup  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify an allocation file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, summary, err := loadAndVerifyAllocationFile(file, allowZero)
+			if err != nil {
+				return fmt.Errorf("FAIL\n%s", err)
+			}
+			fmt.Printf("PASS buckets=%d operators=%d addresses=%d\n", summary.BucketCount, summary.OperatorCount, summary.AddressCount)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "allocation file path")
+	cmd.Flags().BoolVar(&allowZero, "allow-zero-addr", false, "allow 0x000... address")
+	cmd.Flags().BoolVar(&allowDup, "allow-dup-devnet", false, "unused legacy compatibility flag")
+	_ = cmd.Flags().MarkHidden("allow-dup-devnet")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.RegisterFlagCompletionFunc("file", jsonFileCompletion)
+	return cmd
+}
+
+func newAllocationsRenderCmd() *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render the genesis alloc map for an allocation file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			allocCfg, _, err := loadAndVerifyAllocationFile(file, false)
+			if err != nil {
+				return fmt.Errorf("allocations render: verification failed\n%s", err)
+			}
+			out, err := allocations.RenderAllocMap(allocCfg)
+			if err != nil {
+				return fmt.Errorf("allocations render: %w", err)
+			}
+			fmt.Print(string(out))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "allocation file path")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.RegisterFlagCompletionFunc("file", jsonFileCompletion)
+	return cmd
+}
+
+func newAllocationsReportCmd(cfg *Config) *cobra.Command {
+	var (
+		file        string
+		tokenPath   string
+		maxDecimals int
+	)
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Summarise premine buckets, operators and totals in wei and QIK",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			allocCfg, _, err := loadAndVerifyAllocationFile(file, false)
+			if err != nil {
+				return fmt.Errorf("allocations report: verification failed\n%s", err)
+			}
+			if tokenPath == "" {
+				if _, err := os.Stat("config/token.json"); err != nil {
+					return usageErrorf("allocations report: --token is required when config/token.json is absent")
+				}
+				tokenPath = "config/token.json"
+			}
+			token, err := config.LoadTokenConfig(tokenPath)
+			if err != nil {
+				return fmt.Errorf("allocations report: %w", err)
+			}
+			report, err := allocations.BuildReport(allocCfg, token, maxDecimals)
+			if err != nil {
+				return fmt.Errorf("allocations report: %w", err)
+			}
+			if cfg.JSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(report)
+			}
+			fmt.Printf("Token: %s (%s), decimals=%d, supplyPolicy=%s, phase1PosRewards=%s\n", report.Token.Name, report.Token.Symbol, report.Token.Decimals, report.Token.SupplyPolicy, report.Token.Phase1PosRewards)
+			for _, b := range report.Buckets {
+				fmt.Printf("Bucket %-10s %s wei=%s qik=%s\n", b.Name, b.Address, b.Wei, b.QIK)
+			}
+			fmt.Println("Operators:")
+			for _, op := range report.Operators {
+				fmt.Printf("  %s wei=%s qik=%s\n", op.Address, op.Wei, op.QIK)
+			}
+			fmt.Printf("Deployer %s wei=%s qik=%s\n", report.Deployer.Address, report.Deployer.Wei, report.Deployer.QIK)
+			fmt.Printf("Total premine wei=%s qik=%s\n", report.TotalPremineWei, report.TotalPremineQIK)
+			fmt.Println(report.SupplyPolicyNotes)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "allocation file path")
+	cmd.Flags().StringVar(&tokenPath, "token", "", "token metadata file path")
+	cmd.Flags().IntVar(&maxDecimals, "max-decimals", 6, "max fractional decimals in human QIK output")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.RegisterFlagCompletionFunc("file", jsonFileCompletion)
+	_ = cmd.RegisterFlagCompletionFunc("token", jsonFileCompletion)
+	return cmd
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/analyze"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+func newAnalyzeCmd(cfg *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Analyze chain data",
+	}
+	cmd.AddCommand(newAnalyzeBlocksCmd(cfg))
+	return cmd
+}
+
+func newAnalyzeBlocksCmd(cfg *Config) *cobra.Command {
+	var (
+		from, to, last uint64
+		batch          int
+		blockTime      time.Duration
+		gasLimit       string
+	)
+	cmd := &cobra.Command{
+		Use:   "blocks",
+		Short: "Report block intervals, transactions, gas and IBFT rounds over a range",
+		Long: "Fetch the headers of --from..--to, or of the --last blocks up to the head, and report the block interval " +
+			"distribution against --block-time, the empty-block ratio, transactions and gas used per block, gas " +
+			"utilisation against --gas-limit (default: BLOCK_GAS_LIMIT, else each header's gas limit) and the IBFT " +
+			"rounds recorded in the extra data.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fromSet, toSet := cmd.Flags().Changed("from"), cmd.Flags().Changed("to")
+			if cmd.Flags().Changed("last") && (fromSet || toSet) {
+				return usageErrorf("--last cannot be combined with --from or --to")
+			}
+			if batch < 1 {
+				return usageErrorf("--batch must be >= 1")
+			}
+			opts := analyze.Options{TargetBlockTime: blockTime}
+			if gasLimit != "" {
+				v, ok := parseQuantity(gasLimit)
+				if !ok {
+					return usageErrorf("--gas-limit: invalid quantity %q", gasLimit)
+				}
+				opts.GasLimit = v
+			}
+
+			client := cfg.client()
+			if !toSet {
+				head, err := client.CallString("eth_blockNumber")
+				if err != nil {
+					return fmt.Errorf("analyze blocks: %w", err)
+				}
+				if to, err = rpc.HexToUint64(head); err != nil {
+					return fmt.Errorf("analyze blocks: %w", err)
+				}
+			}
+			if !fromSet {
+				if last == 0 {
+					return usageErrorf("--last must be > 0")
+				}
+				// Skip genesis, whose timestamp skews the intervals.
+				from = 1
+				if to >= last {
+					from = to + 1 - last
+				}
+			}
+			if from > to {
+				return usageErrorf("--from %d is after --to %d", from, to)
+			}
+
+			blocks, err := analyze.FetchBlocks(client, from, to, batch)
+			if err != nil {
+				return fmt.Errorf("analyze blocks: %w", err)
+			}
+			report, err := analyze.Blocks(blocks, opts)
+			if err != nil {
+				return fmt.Errorf("analyze blocks: %w", err)
+			}
+			if cfg.JSON {
+				return printJSON(report)
+			}
+			printBlockReport(report)
+			return nil
+		},
+	}
+	cmd.Flags().Uint64Var(&from, "from", 0, "first block")
+	cmd.Flags().Uint64Var(&to, "to", 0, "last block (default: latest)")
+	cmd.Flags().Uint64Var(&last, "last", 1000, "analyze this many blocks up to the head when --from is not given")
+	cmd.Flags().IntVar(&batch, "batch", 20, "blocks per JSON-RPC batch request (polygon-edge allows 20 by default); 1 disables batching")
+	cmd.Flags().DurationVar(&blockTime, "block-time", 2*time.Second, "configured block time to compare intervals against; 0 disables")
+	cmd.Flags().StringVar(&gasLimit, "gas-limit", os.Getenv("BLOCK_GAS_LIMIT"), "configured block gas limit, decimal or 0x hex")
+	return cmd
+}
+
+func parseQuantity(s string) (uint64, bool) {
+	v, ok := new(big.Int).SetString(strings.TrimSpace(s), 0)
+	if !ok || v.Sign() < 0 || !v.IsUint64() {
+		return 0, false
+	}
+	return v.Uint64(), true
+}
+
+func printBlockReport(r analyze.Report) {
+	pct := func(v float64) string { return strconv.FormatFloat(v*100, 'f', 1, 64) + "%" }
+	fmt.Printf("blocks        %d-%d (%d blocks over %s)\n", r.FromBlock, r.ToBlock, r.Blocks, time.Duration(r.DurationSeconds)*time.Second)
+	iv := r.IntervalSeconds
+	fmt.Printf("interval      p50 %gs  p95 %gs  max %gs  mean %.2fs", iv.P50, iv.P95, iv.Max, iv.Mean)
+	if r.TargetBlockTimeSeconds > 0 && r.Blocks > 1 {
+		fmt.Printf("  (target %gs; %s of intervals slower)", r.TargetBlockTimeSeconds, pct(float64(r.IntervalsOverTarget)/float64(r.Blocks-1)))
+	}
+	fmt.Println()
+	fmt.Printf("empty blocks  %d (%s)\n", r.EmptyBlocks, pct(r.EmptyRatio))
+	fmt.Printf("transactions  %d total, %.2f tx/s; per block p50 %g  p95 %g  max %g  mean %.2f\n",
+		r.Transactions, r.TxPerSecond, r.TxPerBlock.P50, r.TxPerBlock.P95, r.TxPerBlock.Max, r.TxPerBlock.Mean)
+	g := r.GasUsedPerBlock
+	fmt.Printf("gas used      %d total; per block p50 %.0f  p95 %.0f  max %.0f  mean %.0f\n", r.GasUsed, g.P50, g.P95, g.Max, g.Mean)
+	limit := fmt.Sprintf("header %d", r.HeaderGasLimitMax)
+	if r.HeaderGasLimitMin != r.HeaderGasLimitMax {
+		limit = fmt.Sprintf("header %d-%d", r.HeaderGasLimitMin, r.HeaderGasLimitMax)
+	}
+	if r.GasLimit > 0 {
+		limit = fmt.Sprintf("configured %d, %s", r.GasLimit, limit)
+	}
+	u := r.GasUtilisation
+	fmt.Printf("gas limit     %s; utilisation p50 %s  p95 %s  max %s  mean %s\n", limit, pct(u.P50), pct(u.P95), pct(u.Max), pct(u.Mean))
+
+	rounds := make([]string, 0, len(r.Rounds))
+	for k := range r.Rounds {
+		rounds = append(rounds, k)
+	}
+	sort.Slice(rounds, func(i, j int) bool {
+		a, _ := strconv.Atoi(rounds[i])
+		b, _ := strconv.Atoi(rounds[j])
+		return a < b
+	})
+	parts := make([]string, 0, len(rounds))
+	for _, k := range rounds {
+		parts = append(parts, fmt.Sprintf("round %s: %d", k, r.Rounds[k]))
+	}
+	if r.RoundsUnknown > 0 {
+		parts = append(parts, fmt.Sprintf("unknown: %d", r.RoundsUnknown))
+	}
+	fmt.Printf("ibft rounds   %d round changes (%s), max round %d; %s\n", r.RoundChanges, pct(float64(r.RoundChanges)/float64(r.Blocks)), r.MaxRound, strings.Join(parts, ", "))
+}
@@ -9,5 +9,6 @@ func newBlockCmd(cfg *Config) *cobra.Command {
 	}
 
 	cmd.AddCommand(newBlockHeadCmd(cfg))
+	cmd.AddCommand(newBlockGetCmd(cfg))
 	return cmd
 }
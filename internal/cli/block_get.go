@@ -0,0 +1,268 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/ibft"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+type blockGetOutput struct {
+	RPC              string      `json:"rpc"`
+	Number           uint64      `json:"number"`
+	Hash             string      `json:"hash"`
+	ParentHash       string      `json:"parentHash"`
+	Miner            string      `json:"miner"`
+	Timestamp        uint64      `json:"timestamp"`
+	Time             string      `json:"time"`
+	GasLimit         uint64      `json:"gasLimit"`
+	GasUsed          uint64      `json:"gasUsed"`
+	BaseFeePerGas    string      `json:"baseFeePerGas,omitempty"`
+	TransactionCount int         `json:"transactionCount"`
+	Transactions     any         `json:"transactions"`
+	IBFT             *ibftOutput `json:"ibft,omitempty"`
+	IBFTError        string      `json:"ibftError,omitempty"`
+}
+
+type ibftOutput struct {
+	ValidatorType        string   `json:"validatorType"`
+	Round                *uint64  `json:"round,omitempty"`
+	Proposer             string   `json:"proposer,omitempty"`
+	ProposerError        string   `json:"proposerError,omitempty"`
+	Validators           []string `json:"validators"`
+	CommittedSeals       int      `json:"committedSeals"`
+	CommittedSigners     []string `json:"committedSigners"`
+	CommittedError       string   `json:"committedError,omitempty"`
+	ParentCommittedSeals *int     `json:"parentCommittedSeals,omitempty"`
+}
+
+func newBlockGetCmd(cfg *Config) *cobra.Command {
+	var fullTx bool
+	cmd := &cobra.Command{
+		Use:       "get <number|hash|latest>",
+		Short:     "Show a block with its decoded IBFT extra data",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"latest", "earliest", "pending"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := cfg.client()
+			block, err := fetchBlock(client, args[0], fullTx)
+			if err != nil {
+				return fmt.Errorf("block get: %w", err)
+			}
+
+			out := blockGetOutput{
+				RPC:              cfg.RPCURL,
+				Number:           uint64(block.Number),
+				Hash:             block.Hash.Hex(),
+				ParentHash:       block.ParentHash.Hex(),
+				Miner:            block.Miner.Hex(),
+				Timestamp:        uint64(block.Timestamp),
+				Time:             time.Unix(int64(block.Timestamp), 0).UTC().Format(time.RFC3339),
+				GasLimit:         uint64(block.GasLimit),
+				GasUsed:          uint64(block.GasUsed),
+				TransactionCount: len(block.Transactions),
+			}
+			if block.BaseFee != nil {
+				out.BaseFeePerGas = block.BaseFee.ToInt().String()
+			}
+
+			var txs []rpc.Transaction
+			if fullTx {
+				if txs, err = block.FullTransactions(); err != nil {
+					return fmt.Errorf("block get: %w", err)
+				}
+				out.Transactions = txs
+			} else {
+				hashes, err := block.TxHashes()
+				if err != nil {
+					return fmt.Errorf("block get: %w", err)
+				}
+				out.Transactions = hashes
+			}
+
+			extra, err := ibft.DecodeExtra(block.ExtraData)
+			if err != nil {
+				out.IBFTError = err.Error()
+			} else {
+				out.IBFT = describeExtra(extra, block.Hash)
+			}
+
+			if cfg.JSON {
+				return printJSON(out)
+			}
+			printBlock(out, txs)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&fullTx, "full-tx", false, "include full transaction objects")
+	return cmd
+}
+
+// parseBlockRef resolves a block argument to either an RPC block tag (a named
+// tag or hex quantity) or a block hash.
+func parseBlockRef(ref string) (string, *common.Hash, error) {
+	ref = strings.TrimSpace(ref)
+	switch strings.ToLower(ref) {
+	case "latest", "earliest", "pending", "safe", "finalized":
+		return strings.ToLower(ref), nil, nil
+	}
+	if strings.HasPrefix(ref, "0x") || strings.HasPrefix(ref, "0X") {
+		if len(ref) == 2+2*common.HashLength {
+			b, err := hexutil.Decode(ref)
+			if err != nil {
+				return "", nil, usageErrorf("invalid block hash %q", ref)
+			}
+			hash := common.BytesToHash(b)
+			return "", &hash, nil
+		}
+		n, err := hexutil.DecodeUint64(ref)
+		if err != nil {
+			return "", nil, usageErrorf("invalid block number %q", ref)
+		}
+		return hexutil.EncodeUint64(n), nil, nil
+	}
+	n, err := strconv.ParseUint(ref, 10, 64)
+	if err != nil {
+		return "", nil, usageErrorf("invalid block reference %q: want a number, hash or latest", ref)
+	}
+	return hexutil.EncodeUint64(n), nil, nil
+}
+
+func fetchBlock(client *rpc.Client, ref string, fullTx bool) (*rpc.Block, error) {
+	tag, hash, err := parseBlockRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	if hash != nil {
+		return client.BlockByHash(*hash, fullTx)
+	}
+	return client.BlockByNumber(tag, fullTx)
+}
+
+func describeExtra(extra *ibft.Extra, hash common.Hash) *ibftOutput {
+	out := &ibftOutput{
+		ValidatorType:    string(extra.ValidatorType),
+		Round:            extra.Round,
+		Validators:       addressStrings(extra.Validators),
+		CommittedSeals:   extra.CommittedSeals.Count(),
+		CommittedSigners: []string{},
+	}
+	if proposer, err := extra.Proposer(hash); err != nil {
+		out.ProposerError = err.Error()
+	} else {
+		out.Proposer = proposer.Hex()
+	}
+	if signers, err := extra.CommittedSigners(hash); err != nil {
+		out.CommittedError = err.Error()
+	} else {
+		out.CommittedSigners = addressStrings(signers)
+	}
+	if extra.ParentCommittedSeals != nil {
+		n := extra.ParentCommittedSeals.Count()
+		out.ParentCommittedSeals = &n
+	}
+	return out
+}
+
+func addressStrings(addrs []common.Address) []string {
+	out := make([]string, len(addrs))
+	for i, a := range addrs {
+		out[i] = a.Hex()
+	}
+	return out
+}
+
+func printBlock(out blockGetOutput, txs []rpc.Transaction) {
+	fmt.Printf("number:       %d\n", out.Number)
+	fmt.Printf("hash:         %s\n", out.Hash)
+	fmt.Printf("parentHash:   %s\n", out.ParentHash)
+	fmt.Printf("time:         %s (%d)\n", out.Time, out.Timestamp)
+	fmt.Printf("miner:        %s\n", out.Miner)
+	gasPct := 0.0
+	if out.GasLimit > 0 {
+		gasPct = float64(out.GasUsed) * 100 / float64(out.GasLimit)
+	}
+	fmt.Printf("gas:          %d / %d (%.2f%%)\n", out.GasUsed, out.GasLimit, gasPct)
+	if out.BaseFeePerGas != "" {
+		fmt.Printf("baseFee:      %s wei\n", out.BaseFeePerGas)
+	}
+	fmt.Printf("transactions: %d\n", out.TransactionCount)
+	if txs != nil {
+		for _, tx := range txs {
+			to := "(create)"
+			if tx.To != nil {
+				to = tx.To.Hex()
+			}
+			fmt.Printf("  %s %s -> %s value=%s\n", tx.Hash.Hex(), tx.From.Hex(), to, bigString(tx.Value))
+		}
+	} else if hashes, ok := out.Transactions.([]common.Hash); ok {
+		for _, h := range hashes {
+			fmt.Printf("  %s\n", h.Hex())
+		}
+	}
+
+	if out.IBFT == nil {
+		fmt.Printf("ibft:         unable to decode extraData: %s\n", out.IBFTError)
+		return
+	}
+	ext := out.IBFT
+	fmt.Printf("ibft:         %s validators\n", ext.ValidatorType)
+	if ext.Round != nil {
+		fmt.Printf("  round:      %d\n", *ext.Round)
+	}
+	if ext.ProposerError != "" {
+		fmt.Printf("  proposer:   unknown (%s)\n", ext.ProposerError)
+	} else {
+		fmt.Printf("  proposer:   %s\n", ext.Proposer)
+	}
+	fmt.Printf("  validators (%d):\n", len(ext.Validators))
+	signed := make(map[string]bool, len(ext.CommittedSigners))
+	for _, s := range ext.CommittedSigners {
+		signed[s] = true
+	}
+	for _, v := range ext.Validators {
+		mark := " "
+		if signed[v] {
+			mark = "*"
+		}
+		suffix := ""
+		if v == ext.Proposer {
+			suffix = " (proposer)"
+		}
+		fmt.Printf("    %s %s%s\n", mark, v, suffix)
+	}
+	fmt.Printf("  committed seals: %d/%d (* = signed)\n", ext.CommittedSeals, len(ext.Validators))
+	if ext.CommittedError != "" {
+		fmt.Printf("  committed signers: unknown (%s)\n", ext.CommittedError)
+	}
+	for _, s := range ext.CommittedSigners {
+		if !containsString(ext.Validators, s) {
+			fmt.Printf("    %s (not in validator set)\n", s)
+		}
+	}
+	if ext.ParentCommittedSeals != nil {
+		fmt.Printf("  parent committed seals: %d\n", *ext.ParentCommittedSeals)
+	}
+}
+
+func bigString(v *hexutil.Big) string {
+	if v == nil {
+		return "0"
+	}
+	return v.ToInt().String()
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
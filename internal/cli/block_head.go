@@ -21,7 +21,7 @@ func newBlockHeadCmd(cfg *Config) *cobra.Command {
 		Use:   "head",
 		Short: "Show latest block number",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := rpc.NewClient(cfg.RPCURL, cfg.Timeout)
+			client := cfg.client()
 			blockHex, err := client.CallString("eth_blockNumber")
 			if err != nil {
 				return err
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BioMark3r/qikchain/internal/chainmeta"
+	"github.com/BioMark3r/qikchain/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newChainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chain",
+		Short: "Chain metadata commands",
+	}
+
+	cmd.AddCommand(newChainMetadataCmd())
+	return cmd
+}
+
+func newChainMetadataCmd() *cobra.Command {
+	var (
+		tokenPath string
+		outPath   string
+	)
+	cmd := &cobra.Command{
+		Use:   "metadata",
+		Short: "Render chain metadata from the token config",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, err := config.LoadTokenConfig(tokenPath)
+			if err != nil {
+				return fmt.Errorf("chain metadata: %w", err)
+			}
+			data, err := chainmeta.RenderMetadata(token)
+			if err != nil {
+				return fmt.Errorf("chain metadata: %w", err)
+			}
+			if outPath == "" {
+				fmt.Print(string(data))
+				return nil
+			}
+			if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
+				return fmt.Errorf("chain metadata: %w", err)
+			}
+			if err := os.WriteFile(outPath, data, 0o644); err != nil {
+				return fmt.Errorf("chain metadata: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&tokenPath, "token", "", "token metadata file path")
+	cmd.Flags().StringVar(&outPath, "out", "", "output file path")
+	_ = cmd.MarkFlagRequired("token")
+	_ = cmd.RegisterFlagCompletionFunc("token", jsonFileCompletion)
+	_ = cmd.RegisterFlagCompletionFunc("out", jsonFileCompletion)
+	return cmd
+}
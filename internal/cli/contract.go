@@ -0,0 +1,362 @@
+package cli
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/BioMark3r/qikchain/internal/abiutil"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/BioMark3r/qikchain/internal/signer"
+	"github.com/BioMark3r/qikchain/internal/txbuild"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+type contractFlags struct {
+	abiPath string
+	sig     string
+	address string
+}
+
+type contractCallOutput struct {
+	Method  string          `json:"method"`
+	Address string          `json:"address"`
+	Outputs []abiutil.Value `json:"outputs"`
+}
+
+type decodedLog struct {
+	Index   uint64         `json:"logIndex"`
+	Address string         `json:"address"`
+	Event   *abiutil.Event `json:"event"`
+}
+
+type contractSendOutput struct {
+	*txSendOutput
+	Method string       `json:"method"`
+	Events []decodedLog `json:"events,omitempty"`
+	Revert string       `json:"revert,omitempty"`
+}
+
+func newContractCmd(cfg *Config) *cobra.Command {
+	var cf contractFlags
+	cmd := &cobra.Command{
+		Use:   "contract",
+		Short: "Call and transact with contracts using their ABI",
+		Long: "Encode calls from an ABI file (plain ABI array or Foundry artifact), decode return values,\n" +
+			"revert reasons and receipt events. Array and tuple arguments are JSON, e.g. '[\"0x..\",\"0x..\"]'.\n" +
+			"Instead of --abi, --sig takes a cast-style signature such as 'stakeOf(address,address)(uint256)';\n" +
+			"the positional arguments are then only the method arguments.",
+	}
+	cmd.PersistentFlags().StringVar(&cf.abiPath, "abi", "", "ABI JSON file")
+	cmd.PersistentFlags().StringVar(&cf.sig, "sig", "", "method signature with optional outputs, instead of --abi")
+	cmd.PersistentFlags().StringVar(&cf.address, "address", "", "contract address")
+	_ = cmd.RegisterFlagCompletionFunc("abi", jsonFileCompletion)
+
+	cmd.AddCommand(newContractMethodsCmd(&cf))
+	cmd.AddCommand(newContractCallCmd(cfg, &cf))
+	cmd.AddCommand(newContractSendCmd(cfg, &cf))
+	return cmd
+}
+
+func (cf *contractFlags) load() (*abi.ABI, error) {
+	switch {
+	case cf.abiPath != "" && cf.sig != "":
+		return nil, usageErrorf("pass either --abi or --sig, not both")
+	case cf.sig != "":
+		return abiutil.ParseSignature(cf.sig)
+	case cf.abiPath != "":
+		return abiutil.Load(cf.abiPath)
+	default:
+		return nil, usageErrorf("--abi or --sig is required")
+	}
+}
+
+// method loads the ABI and splits args into the method and its arguments.
+func (cf *contractFlags) method(args []string) (*abi.ABI, string, []string, error) {
+	parsed, err := cf.load()
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if cf.sig != "" {
+		for name := range parsed.Methods {
+			return parsed, name, args, nil
+		}
+	}
+	if len(args) == 0 {
+		return nil, "", nil, usageErrorf("a method name is required")
+	}
+	return parsed, args[0], args[1:], nil
+}
+
+func (cf *contractFlags) target() (common.Address, error) {
+	if cf.address == "" {
+		return common.Address{}, usageErrorf("--address is required")
+	}
+	return parseAddress(cf.address)
+}
+
+// methodCompletion completes method names from the --abi file.
+func (cf *contractFlags) methodCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || cf.abiPath == "" || cf.sig != "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	parsed, err := cf.load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return abiutil.MethodNames(parsed), cobra.ShellCompDirectiveNoFileComp
+}
+
+func newContractMethodsCmd(cf *contractFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "methods",
+		Short: "List the methods and events in the ABI",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parsed, err := cf.load()
+			if err != nil {
+				return fmt.Errorf("contract methods: %w", err)
+			}
+			var lines []string
+			for _, m := range parsed.Methods {
+				line := fmt.Sprintf("%-10s %s", m.StateMutability, m.Sig)
+				if len(m.Outputs) > 0 {
+					line += " returns (" + argTypes(m.Outputs) + ")"
+				}
+				lines = append(lines, line)
+			}
+			for _, e := range parsed.Events {
+				lines = append(lines, fmt.Sprintf("%-10s %s", "event", e.Sig))
+			}
+			for _, e := range parsed.Errors {
+				lines = append(lines, fmt.Sprintf("%-10s %s", "error", e.Sig))
+			}
+			sort.Strings(lines)
+			for _, line := range lines {
+				fmt.Println(line)
+			}
+			return nil
+		},
+	}
+}
+
+func argTypes(args abi.Arguments) string {
+	parts := make([]string, len(args))
+	for i, a := range args {
+		parts[i] = a.Type.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+func packCall(parsed *abi.ABI, spec string, raw []string) (*abi.Method, []byte, error) {
+	method, err := abiutil.Method(parsed, spec)
+	if err != nil {
+		return nil, nil, usageErrorf("%v", err)
+	}
+	values, err := abiutil.ParseArgs(method.Inputs, raw)
+	if err != nil {
+		return nil, nil, usageErrorf("%s: %v", method.Sig, err)
+	}
+	packed, err := method.Inputs.Pack(values...)
+	if err != nil {
+		return nil, nil, usageErrorf("%s: %v", method.Sig, err)
+	}
+	return method, append(append([]byte{}, method.ID...), packed...), nil
+}
+
+// revertError decodes revert data carried by an RPC error, if any.
+func revertError(parsed *abi.ABI, err error) error {
+	if data, ok := rpc.RevertData(err); ok {
+		return fmt.Errorf("%s", abiutil.Revert(parsed, data))
+	}
+	return err
+}
+
+func newContractCallCmd(cfg *Config, cf *contractFlags) *cobra.Command {
+	var (
+		from  string
+		block string
+	)
+	cmd := &cobra.Command{
+		Use:               "call <method> [args...]",
+		Short:             "Call a read-only method and decode the result",
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: cf.methodCompletion,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parsed, spec, rest, err := cf.method(args)
+			if err != nil {
+				return fmt.Errorf("contract call: %w", err)
+			}
+			to, err := cf.target()
+			if err != nil {
+				return err
+			}
+			method, data, err := packCall(parsed, spec, rest)
+			if err != nil {
+				return err
+			}
+			msg := rpc.CallMsg{To: &to, Data: data}
+			if from != "" {
+				if msg.From, err = parseAddress(from); err != nil {
+					return err
+				}
+			}
+			blk, err := blockParam(block)
+			if err != nil {
+				return err
+			}
+
+			client := cfg.client()
+			result, err := client.CallContract(msg, blk)
+			if err != nil {
+				return fmt.Errorf("contract call %s: %w", method.Sig, revertError(parsed, err))
+			}
+			if len(result) == 0 && len(method.Outputs) > 0 {
+				return fmt.Errorf("contract call %s: empty result (no contract at %s?)", method.Sig, to.Hex())
+			}
+			outputs, err := abiutil.Decode(method.Outputs, result)
+			if err != nil {
+				return fmt.Errorf("contract call %s: decode: %w", method.Sig, err)
+			}
+
+			if cfg.JSON {
+				return printJSON(contractCallOutput{Method: method.Sig, Address: to.Hex(), Outputs: outputs})
+			}
+			for _, out := range outputs {
+				if out.Name == "" {
+					fmt.Println(abiutil.Text(out.Value))
+					continue
+				}
+				fmt.Printf("%s: %s\n", out.Name, abiutil.Text(out.Value))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "msg.sender for the call")
+	cmd.Flags().StringVar(&block, "block", "latest", "block number, hash or tag")
+	return cmd
+}
+
+func newContractSendCmd(cfg *Config, cf *contractFlags) *cobra.Command {
+	var (
+		opts  txOptions
+		value string
+	)
+	cmd := &cobra.Command{
+		Use:               "send <method> [args...]",
+		Short:             "Sign and send a transaction calling a method",
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: cf.methodCompletion,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parsed, spec, rest, err := cf.method(args)
+			if err != nil {
+				return fmt.Errorf("contract send: %w", err)
+			}
+			to, err := cf.target()
+			if err != nil {
+				return err
+			}
+			method, data, err := packCall(parsed, spec, rest)
+			if err != nil {
+				return err
+			}
+			amount, err := txbuild.ParseAmount(value)
+			if err != nil {
+				return usageErrorf("--value: %v", err)
+			}
+			if amount.Sign() > 0 && !method.IsPayable() {
+				return usageErrorf("%s is not payable", method.Sig)
+			}
+
+			s, err := opts.newSigner(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("contract send: %w", err)
+			}
+			out, err := sendContractTx(cmd, cfg, &opts, s, parsed, method, to, amount, data)
+			if out != nil {
+				if perr := printContractSend(cfg, out); perr != nil {
+					return perr
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("contract send %s: %w", method.Sig, err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&value, "value", "0", "value to send with a payable method (wei or e.g. 1qik)")
+	opts.bind(cmd)
+	return cmd
+}
+
+// sendContractTx wraps sendTx with revert decoding: estimation failures carry
+// the revert data directly, mined failures are replayed with eth_call at the
+// failing block.
+func sendContractTx(cmd *cobra.Command, cfg *Config, opts *txOptions, s signer.Signer, parsed *abi.ABI, method *abi.Method, to common.Address, value *big.Int, data []byte) (*contractSendOutput, error) {
+	tx, err := sendTx(cmd, cfg, opts, s, &to, value, data)
+	if tx == nil {
+		return nil, revertError(parsed, err)
+	}
+	out := &contractSendOutput{txSendOutput: tx, Method: method.Sig}
+	if tx.receipt == nil {
+		return out, err
+	}
+	out.Events = decodeLogs([]*abi.ABI{parsed}, tx.receipt.Logs)
+	if err != nil && tx.Status != nil && *tx.Status != types.ReceiptStatusSuccessful {
+		client := cfg.client()
+		msg := rpc.CallMsg{From: s.Address(), To: &to, Value: (*hexutil.Big)(value), Data: data}
+		if _, cerr := client.CallContract(msg, hexutil.EncodeUint64(*tx.BlockNumber)); cerr != nil {
+			if revert, ok := rpc.RevertData(cerr); ok {
+				out.Revert = abiutil.Revert(parsed, revert)
+				err = fmt.Errorf("%w: %s", err, out.Revert)
+			}
+		}
+	}
+	return out, err
+}
+
+// decodeLogs decodes every log matching an event in one of abis; other logs
+// are skipped.
+func decodeLogs(abis []*abi.ABI, logs []rpc.Log) []decodedLog {
+	var out []decodedLog
+	for _, l := range logs {
+		for _, parsed := range abis {
+			ev, err := abiutil.DecodeLog(parsed, l.Topics, l.Data)
+			if err != nil {
+				continue
+			}
+			out = append(out, decodedLog{Index: uint64(l.LogIndex), Address: l.Address.Hex(), Event: ev})
+			break
+		}
+	}
+	return out
+}
+
+func printEvents(events []decodedLog) {
+	for _, e := range events {
+		fmt.Printf("  [%d] %s %s%s\n", e.Index, e.Address, e.Event.Name, abiutil.Text(e.Event.Fields))
+	}
+}
+
+func printContractSend(cfg *Config, out *contractSendOutput) error {
+	if cfg.JSON {
+		return printJSON(out)
+	}
+	fmt.Printf("method:    %s\n", out.Method)
+	if err := printTxSend(cfg, out.txSendOutput); err != nil {
+		return err
+	}
+	if out.Revert != "" {
+		fmt.Printf("revert:    %s\n", out.Revert)
+	}
+	if len(out.Events) > 0 {
+		fmt.Printf("events:    %d\n", len(out.Events))
+		printEvents(out.Events)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/edge"
+	"github.com/BioMark3r/qikchain/internal/edgecaps"
+	"github.com/spf13/cobra"
+)
+
+func newEdgeCmd(cfg *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edge",
+		Short: "Inspect the Polygon Edge build",
+	}
+
+	cmd.AddCommand(newEdgeForksCmd())
+	cmd.AddCommand(newEdgeCapsCmd(cfg))
+	return cmd
+}
+
+func newEdgeForksCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "forks",
+		Short: "List forks supported by the Polygon Edge build",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("edge forks: %w", err)
+			}
+			forks, _ := edge.DetectSupportedForks(root)
+			for _, key := range forks {
+				fmt.Println(key)
+			}
+			return nil
+		},
+	}
+}
+
+func newEdgeCapsCmd(cfg *Config) *cobra.Command {
+	var (
+		edgeBin string
+		pretty  bool
+		timeout time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "caps",
+		Short: "Detect Polygon Edge CLI flags and forks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := edgecaps.Collect(cmd.Context(), edgeBin, timeout)
+			if err != nil {
+				return fmt.Errorf("edge caps: unable to inspect edge binary %q: %w", edgeBin, err)
+			}
+
+			if cfg.JSON {
+				if pretty {
+					enc := json.NewEncoder(os.Stdout)
+					enc.SetIndent("", "  ")
+					return enc.Encode(report)
+				}
+				b, err := json.Marshal(report)
+				if err != nil {
+					return fmt.Errorf("edge caps: %w", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			}
+
+			fmt.Println("Edge identity")
+			fmt.Printf("  path: %s\n", report.EdgeBin)
+			if report.EdgeVersion != "" {
+				fmt.Printf("  version: %s\n", report.EdgeVersion)
+			}
+			if report.EdgeSHA256 != "" {
+				fmt.Printf("  sha256: %s\n", report.EdgeSHA256)
+			}
+			printBoolMap("Server flags", report.ServerFlags)
+			printBoolMap("Genesis flags", report.GenesisFlags)
+			fmt.Println("Forks")
+			for _, fork := range report.SupportedForks {
+				fmt.Printf("  - %s\n", fork)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&edgeBin, "edge-bin", "./bin/polygon-edge", "path to polygon-edge binary")
+	cmd.Flags().BoolVar(&pretty, "pretty", false, "pretty-print JSON output")
+	cmd.Flags().DurationVar(&timeout, "timeout", 3*time.Second, "timeout for external command execution")
+	return cmd
+}
+
+func printBoolMap(title string, values map[string]bool) {
+	fmt.Println(title)
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf("  %s: %t\n", k, values[k])
+	}
+}
@@ -0,0 +1,338 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BioMark3r/qikchain/internal/edge"
+	"github.com/BioMark3r/qikchain/internal/genesis"
+	"github.com/BioMark3r/qikchain/internal/logging"
+	"github.com/spf13/cobra"
+)
+
+func newGenesisCmd(cfg *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "genesis",
+		Short: "Build, validate and print genesis artifacts",
+	}
+
+	cmd.AddCommand(newGenesisBuildCmd())
+	cmd.AddCommand(newGenesisValidateCmd())
+	cmd.AddCommand(newGenesisPrintCmd(cfg))
+	return cmd
+}
+
+type genesisBuildFlags struct {
+	consensus             string
+	env                   string
+	templatePath          string
+	overlayDir            string
+	tokenPath             string
+	allocationsPath       string
+	chainID               int
+	gasLimit              string
+	blockGasLimit         string
+	difficulty            string
+	extraData             string
+	minGasPrice           string
+	baseFeeEnabled        bool
+	posDeployments        string
+	out                   string
+	outCombined           string
+	outChain              string
+	outGenesis            string
+	metadataOut           string
+	strict                bool
+	acceptLegacyConsensus bool
+	allowMissingPOS       bool
+	pretty                bool
+}
+
+func newGenesisBuildCmd() *cobra.Command {
+	f := &genesisBuildFlags{}
+	cmd := &cobra.Command{
+		Use:   "build",
+		Short: "Render chain.json, genesis-eth.json and metadata from templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenesisBuild(cmd.Context(), f)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&f.consensus, "consensus", "poa", "consensus mode (poa|pos)")
+	flags.StringVar(&f.env, "env", "devnet", "environment (devnet|staging|mainnet)")
+	flags.StringVar(&f.templatePath, "template", "config/genesis.template.json", "genesis template path")
+	flags.StringVar(&f.overlayDir, "overlay-dir", "config/consensus", "consensus overlay directory")
+	flags.StringVar(&f.tokenPath, "token", "config/token.json", "token metadata file path")
+	flags.StringVar(&f.allocationsPath, "allocations", "", "allocation file path")
+	flags.IntVar(&f.chainID, "chain-id", 0, "chain id")
+	flags.StringVar(&f.gasLimit, "gas-limit", "0x1c9c380", "ethereum genesis gas limit (decimal or 0x-hex)")
+	flags.StringVar(&f.blockGasLimit, "block-gas-limit", "", "deprecated alias for --gas-limit")
+	flags.StringVar(&f.difficulty, "difficulty", "0x1", "ethereum genesis difficulty (decimal or 0x-hex)")
+	flags.StringVar(&f.extraData, "extra-data", "0x", "ethereum genesis extraData")
+	flags.StringVar(&f.minGasPrice, "min-gas-price", "0", "minimum gas price in wei")
+	flags.BoolVar(&f.baseFeeEnabled, "base-fee-enabled", false, "enable base fee in ethereum genesis")
+	flags.StringVar(&f.posDeployments, "pos-deployments", "build/deployments/pos.local.json", "PoS deployment file path")
+	flags.StringVar(&f.out, "out", "", "combined genesis output path (deprecated alias: --out-combined)")
+	flags.StringVar(&f.outCombined, "out-combined", "build/genesis.json", "output combined chain+genesis file path")
+	flags.StringVar(&f.outChain, "out-chain", "build/chain.json", "output chain config path")
+	flags.StringVar(&f.outGenesis, "out-genesis", "build/genesis-eth.json", "output Ethereum genesis path")
+	flags.StringVar(&f.metadataOut, "metadata-out", "build/chain-metadata.json", "output chain metadata path")
+	flags.BoolVar(&f.strict, "strict", true, "strict genesis validation (fail on legacy top-level consensus keys)")
+	flags.BoolVar(&f.acceptLegacyConsensus, "accept-legacy-consensus", false, "temporarily accept top-level legacy consensus schema when params.engine.ibft is missing")
+	flags.BoolVar(&f.allowMissingPOS, "allow-missing-pos-addresses", false, "allow unresolved PoS addresses")
+	flags.BoolVar(&f.pretty, "pretty", true, "pretty print output")
+
+	_ = cmd.RegisterFlagCompletionFunc("consensus", cobra.FixedCompletions([]string{"poa", "pos"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("env", cobra.FixedCompletions([]string{"devnet", "staging", "mainnet"}, cobra.ShellCompDirectiveNoFileComp))
+	for _, name := range []string{"template", "token", "allocations", "pos-deployments", "out", "out-combined", "out-chain", "out-genesis", "metadata-out"} {
+		_ = cmd.RegisterFlagCompletionFunc(name, jsonFileCompletion)
+	}
+	_ = cmd.RegisterFlagCompletionFunc("overlay-dir", dirCompletion)
+	return cmd
+}
+
+func runGenesisBuild(ctx context.Context, f *genesisBuildFlags) error {
+	if f.consensus != "poa" && f.consensus != "pos" {
+		return usageErrorf("genesis build: --consensus must be poa or pos")
+	}
+	if f.env != "devnet" && f.env != "staging" && f.env != "mainnet" {
+		return usageErrorf("genesis build: --env must be devnet|staging|mainnet")
+	}
+	if f.allocationsPath == "" {
+		f.allocationsPath = filepath.Join("config", "allocations", f.env+".json")
+	}
+	if f.chainID == 0 {
+		switch f.env {
+		case "devnet":
+			f.chainID = 100
+		case "staging":
+			f.chainID = 101
+		case "mainnet":
+			return usageErrorf("genesis build: --chain-id is required for mainnet")
+		}
+	}
+
+	if strings.TrimSpace(f.blockGasLimit) != "" {
+		f.gasLimit = f.blockGasLimit
+	}
+	gasLimit, err := toHexQuantity(f.gasLimit)
+	if err != nil {
+		return usageErrorf("genesis build: invalid --gas-limit: %v", err)
+	}
+	difficultyHex, err := toHexQuantity(f.difficulty)
+	if err != nil {
+		return usageErrorf("genesis build: invalid --difficulty: %v", err)
+	}
+
+	root, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("genesis build: %w", err)
+	}
+	supportedForks, _ := edge.DetectSupportedForks(root)
+
+	opts := genesis.BuildOptions{
+		Consensus:                f.consensus,
+		Env:                      f.env,
+		TemplatePath:             f.templatePath,
+		OverlayDir:               f.overlayDir,
+		TokenPath:                f.tokenPath,
+		AllocationsPath:          f.allocationsPath,
+		ChainID:                  f.chainID,
+		GasLimit:                 gasLimit,
+		Difficulty:               difficultyHex,
+		ExtraData:                f.extraData,
+		MinGasPrice:              f.minGasPrice,
+		BaseFeeEnabled:           f.baseFeeEnabled,
+		POSDeploymentsPath:       f.posDeployments,
+		OutPath:                  f.out,
+		OutCombinedPath:          f.outCombined,
+		OutChainPath:             f.outChain,
+		OutGenesisPath:           f.outGenesis,
+		MetadataOutPath:          f.metadataOut,
+		Strict:                   f.strict,
+		AllowMissingPOSAddresses: f.allowMissingPOS,
+		AcceptLegacyConsensus:    f.acceptLegacyConsensus,
+		Pretty:                   f.pretty,
+		SupportedForks:           supportedForks,
+	}
+
+	res, err := genesis.BuildContext(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("genesis build: %w", err)
+	}
+	if err := genesis.WriteOutputsContext(ctx, opts, res); err != nil {
+		return fmt.Errorf("genesis build: %w", err)
+	}
+
+	fmt.Printf("consensus=%s env=%s chainId=%d\n", f.consensus, f.env, f.chainID)
+	fmt.Printf("allocTotalWei=%s\n", res.TotalPremineWei)
+	combinedOut := f.outCombined
+	if f.out != "" {
+		combinedOut = f.out
+	}
+	if combinedOut == "" {
+		combinedOut = "build/genesis.json"
+	}
+	chainOut := f.outChain
+	if chainOut == "" {
+		chainOut = filepath.Join(filepath.Dir(combinedOut), "chain.json")
+	}
+	genOut := f.outGenesis
+	if genOut == "" {
+		genOut = "build/genesis-eth.json"
+	}
+	fmt.Printf("combined=%s\nchain=%s\ngenesis=%s\nmetadata=%s\n", combinedOut, chainOut, genOut, f.metadataOut)
+	if res.POSAddressesUsed {
+		fmt.Printf("pos.staking=%s\npos.validatorSet=%s\n", res.POSAddresses.Staking, res.POSAddresses.ValidatorSet)
+	}
+	return nil
+}
+
+func newGenesisValidateCmd() *cobra.Command {
+	var (
+		file                  string
+		chain                 string
+		ethGenesis            string
+		strict                bool
+		acceptLegacyConsensus bool
+		allowMissingPOS       bool
+	)
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate chain and Ethereum genesis files",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := genesis.ValidateOptions{AllowMissingPOSAddresses: allowMissingPOS, Strict: strict, AcceptLegacyConsensus: acceptLegacyConsensus}
+			results := make([]genesis.ValidateResult, 0, 2)
+
+			if chain != "" {
+				res, err := validateGenesisDoc("chain", chain, opts)
+				if err != nil {
+					return err
+				}
+				results = append(results, res)
+			}
+			if ethGenesis != "" {
+				res, err := validateGenesisDoc("genesis", ethGenesis, opts)
+				if err != nil {
+					return err
+				}
+				results = append(results, res)
+			}
+			if chain == "" && ethGenesis == "" {
+				if file == "" {
+					return usageErrorf("genesis validate: provide --chain (and optionally --genesis), or --file")
+				}
+				res, err := validateGenesisDoc("file", file, opts)
+				if err != nil {
+					return err
+				}
+				results = append(results, res)
+			}
+
+			hasErr := false
+			for _, res := range results {
+				for _, w := range res.Warnings {
+					slog.Warn("genesis warning", "warning", w)
+				}
+				for _, e := range res.Errors {
+					slog.Error("genesis error", logging.KeyError, e)
+					hasErr = true
+				}
+			}
+			if hasErr {
+				return errors.New("genesis validation: FAIL")
+			}
+			fmt.Println("genesis validation: PASS")
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&file, "file", "", "genesis or chain file path (legacy)")
+	flags.StringVar(&chain, "chain", "", "chain config file path")
+	flags.StringVar(&ethGenesis, "genesis", "", "ethereum genesis file path")
+	flags.BoolVar(&strict, "strict", true, "strict genesis validation (fail on legacy top-level consensus keys)")
+	flags.BoolVar(&acceptLegacyConsensus, "accept-legacy-consensus", false, "temporarily accept top-level legacy consensus schema when params.engine.ibft is missing")
+	flags.BoolVar(&allowMissingPOS, "allow-missing-pos-addresses", false, "allow unresolved PoS addresses")
+	for _, name := range []string{"file", "chain", "genesis"} {
+		_ = cmd.RegisterFlagCompletionFunc(name, jsonFileCompletion)
+	}
+	return cmd
+}
+
+func validateGenesisDoc(label, path string, opts genesis.ValidateOptions) (genesis.ValidateResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return genesis.ValidateResult{}, fmt.Errorf("genesis validate: failed to read %s: %w", label, err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return genesis.ValidateResult{}, fmt.Errorf("genesis validate: invalid JSON in %s: %w", label, err)
+	}
+	return genesis.Validate(doc, opts), nil
+}
+
+func newGenesisPrintCmd(cfg *Config) *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "print",
+		Short: "Print a genesis file in canonical form (--json prints it as-is)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("genesis print: %w", err)
+			}
+			if cfg.JSON {
+				fmt.Print(string(data))
+				if len(data) == 0 || data[len(data)-1] != '\n' {
+					fmt.Println()
+				}
+				return nil
+			}
+			var doc map[string]any
+			if err := json.Unmarshal(data, &doc); err != nil {
+				return fmt.Errorf("genesis print: invalid JSON: %w", err)
+			}
+			out, err := genesis.MarshalCanonicalIndented(doc)
+			if err != nil {
+				return fmt.Errorf("genesis print: %w", err)
+			}
+			fmt.Print(string(out))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "genesis file path")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.RegisterFlagCompletionFunc("file", jsonFileCompletion)
+	return cmd
+}
+
+func toHexQuantity(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", fmt.Errorf("value is empty")
+	}
+	if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+		n, err := strconv.ParseUint(v[2:], 16, 64)
+		if err != nil {
+			return "", fmt.Errorf("must be decimal or 0x hex")
+		}
+		return fmt.Sprintf("0x%x", n), nil
+	}
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("must be decimal or 0x hex")
+	}
+	return fmt.Sprintf("0x%x", n), nil
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/history"
+	"github.com/spf13/cobra"
+)
+
+const defaultHistoryPath = ".data/qikchaind/history.sqlite"
+
+type historyOutput struct {
+	Since string            `json:"since"`
+	Until string            `json:"until"`
+	Nodes []history.Summary `json:"nodes"`
+}
+
+func newHistoryCmd(cfg *Config) *cobra.Command {
+	var (
+		dbPath, since, until string
+		nodes                []string
+		minGap               time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Summarize node status recorded by qikchaind run --history",
+		Long: "Read the status history written by `qikchaind run --history` and summarize each node over the range: " +
+			"uptime, block production rate, average block time, peer counts and their trend, and gaps in which the node " +
+			"was down, its head did not advance for --gap, or nothing was recorded.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			from, err := parseHistoryTime(since, now)
+			if err != nil {
+				return usageErrorf("--since: %v", err)
+			}
+			to := now
+			if until != "" {
+				if to, err = parseHistoryTime(until, now); err != nil {
+					return usageErrorf("--until: %v", err)
+				}
+			}
+			if !from.Before(to) {
+				return usageErrorf("--since must be before --until")
+			}
+			store, err := history.OpenReadOnly(dbPath)
+			if err != nil {
+				return fmt.Errorf("history: %w (run qikchaind run --history %s)", err, dbPath)
+			}
+			defer store.Close()
+
+			if len(nodes) == 0 {
+				if nodes, err = store.Nodes(from, to); err != nil {
+					return fmt.Errorf("history: %w", err)
+				}
+			}
+			out := historyOutput{Since: from.UTC().Format(time.RFC3339), Until: to.UTC().Format(time.RFC3339), Nodes: []history.Summary{}}
+			for _, node := range nodes {
+				samples, err := store.Samples(node, from, to)
+				if err != nil {
+					return fmt.Errorf("history: %w", err)
+				}
+				out.Nodes = append(out.Nodes, history.Summarize(node, samples, minGap))
+			}
+
+			if cfg.JSON {
+				return printJSON(out)
+			}
+			printHistory(out)
+			return nil
+		},
+	}
+	path := os.Getenv("QIKCHAIND_HISTORY")
+	if path == "" {
+		path = defaultHistoryPath
+	}
+	cmd.Flags().StringVar(&dbPath, "db", path, "SQLite history written by qikchaind run --history")
+	cmd.Flags().StringVar(&since, "since", "1h", "start of the range: a duration ago (1h, 30m) or an RFC3339 time")
+	cmd.Flags().StringVar(&until, "until", "", "end of the range, like --since (default: now)")
+	cmd.Flags().StringSliceVar(&nodes, "node", nil, "node name to summarize; repeatable (default: every node in the range)")
+	cmd.Flags().DurationVar(&minGap, "gap", 30*time.Second, "report a head that does not advance for this long")
+	return cmd
+}
+
+func parseHistoryTime(v string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(v); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("duration %q is negative", v)
+		}
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a duration nor an RFC3339 time", v)
+	}
+	return t, nil
+}
+
+func printHistory(out historyOutput) {
+	clock := func(s string) string {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return s
+		}
+		return t.Format("2006-01-02 15:04:05")
+	}
+	fmt.Printf("%s - %s UTC\n", clock(out.Since), clock(out.Until))
+	if len(out.Nodes) == 0 {
+		fmt.Println("no samples in range")
+		return
+	}
+	for _, s := range out.Nodes {
+		fmt.Printf("\n%s: %d polls, uptime %.1f%%\n", s.Node, s.Samples, s.Uptime*100)
+		if s.UpSamples == 0 {
+			fmt.Println("  never up in range")
+		} else {
+			fmt.Printf("  blocks  %d -> %d (+%d, %.1f/min", s.FirstBlock, s.LastBlock, s.Blocks, s.BlocksPerMinute)
+			if s.AvgBlockTimeSeconds > 0 {
+				fmt.Printf(", %.2fs avg block time", s.AvgBlockTimeSeconds)
+			}
+			fmt.Println(")")
+			trend := make([]string, 0, len(s.Peers.Trend))
+			for _, v := range s.Peers.Trend {
+				if v < 0 {
+					trend = append(trend, "-")
+				} else {
+					trend = append(trend, fmt.Sprintf("%.1f", v))
+				}
+			}
+			fmt.Printf("  peers   min %d, avg %.1f, max %d; %d -> %d; trend %s\n", s.Peers.Min, s.Peers.Avg, s.Peers.Max, s.Peers.First, s.Peers.Last, strings.Join(trend, " "))
+		}
+		if len(s.Gaps) == 0 {
+			fmt.Println("  gaps    none")
+			continue
+		}
+		fmt.Println("  gaps")
+		for _, g := range s.Gaps {
+			end := "ongoing"
+			if g.End != "" {
+				end = clock(g.End)
+			}
+			line := fmt.Sprintf("    %-8s %s -> %s (%s)", g.Reason, clock(g.Start), end, time.Duration(g.DurationSeconds*float64(time.Second)).Round(time.Second))
+			if g.Block > 0 {
+				line += fmt.Sprintf(" at block %d", g.Block)
+			}
+			fmt.Println(line)
+		}
+	}
+}
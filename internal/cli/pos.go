@@ -0,0 +1,357 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/allocations"
+	"github.com/BioMark3r/qikchain/internal/contracts"
+	"github.com/BioMark3r/qikchain/internal/pos"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/BioMark3r/qikchain/internal/signer"
+	"github.com/BioMark3r/qikchain/internal/wallet"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+const defaultBootstrapPath = "config/pos.bootstrap.json"
+
+type posAppliedStep struct {
+	pos.Step
+	TxHash      string `json:"txHash"`
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+type posBootstrapOutput struct {
+	Staking string             `json:"staking"`
+	Journal string             `json:"journal"`
+	Plan    []pos.Step         `json:"plan"`
+	Applied []posAppliedStep   `json:"applied,omitempty"`
+	Active  []string           `json:"activeOperators,omitempty"`
+	Pending []pos.JournalEntry `json:"pending,omitempty"`
+}
+
+func newPosCmd(cfg *Config) *cobra.Command {
+	var sf stakingFlags
+	cmd := &cobra.Command{
+		Use:   "pos",
+		Short: "PoS network operations",
+	}
+	sf.bind(cmd.PersistentFlags())
+	_ = cmd.RegisterFlagCompletionFunc("deployments", jsonFileCompletion)
+
+	cmd.AddCommand(newPosDeployCmd(cfg, &sf))
+	cmd.AddCommand(newPosBootstrapCmd(cfg, &sf))
+	cmd.AddCommand(newPosReconcileCmd(cfg, &sf))
+	return cmd
+}
+
+func newPosBootstrapCmd(cfg *Config, sf *stakingFlags) *cobra.Command {
+	var (
+		opts        txOptions
+		configPath  string
+		journalPath string
+		planOnly    bool
+	)
+	cmd := &cobra.Command{
+		Use:   "bootstrap",
+		Short: "Register and stake the operators of a bootstrap config, idempotently",
+		Long: "Diff the operators in --config against the staking contract, print the plan and apply it. " +
+			"Every transaction is written to --journal before it is broadcast; a rerun first settles journaled " +
+			"transactions that are still in flight, so a timed-out stake is never sent twice. " +
+			"Operators sign through the signer flags, overridden per operator by operators[i].signer; a --keystore " +
+			"without --account uses the operator's account. The local backend otherwise reads the hex key from " +
+			"operators[i].privateKeyEnv, OPERATOR<i>_PK or the deployer key.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			boot, err := pos.LoadBootstrap(configPath, ".", os.LookupEnv)
+			if err != nil {
+				return fmt.Errorf("pos bootstrap: %w", err)
+			}
+			staking, caller, err := sf.caller(cfg)
+			if err != nil {
+				return fmt.Errorf("pos bootstrap: %w", err)
+			}
+			client := cfg.client()
+			chainID, err := client.ChainID()
+			if err != nil {
+				return fmt.Errorf("pos bootstrap: %w", err)
+			}
+			if journalPath == "" {
+				journalPath = filepath.Join(filepath.Dir(sf.deployments), "pos.bootstrap.journal.json")
+			}
+			journal, err := pos.OpenJournal(journalPath, chainID, staking)
+			if err != nil {
+				return fmt.Errorf("pos bootstrap: %w", err)
+			}
+			out := posBootstrapOutput{Staking: staking.Hex(), Journal: journalPath, Plan: []pos.Step{}}
+
+			if out.Pending, err = settleJournal(client, journal, opts.waitTimeout, !planOnly); err != nil {
+				return fmt.Errorf("pos bootstrap: %w", err)
+			}
+			if len(out.Pending) > 0 {
+				if perr := printPosBootstrap(cfg, out); perr != nil {
+					return perr
+				}
+				return fmt.Errorf("pos bootstrap: %d journaled transaction(s) still pending; rerun once they are mined or dropped", len(out.Pending))
+			}
+
+			observed, err := observeOperators(caller, boot.Operators)
+			if err != nil {
+				return fmt.Errorf("pos bootstrap: %w", err)
+			}
+			out.Plan = append(out.Plan, pos.Plan(boot.Operators, observed)...)
+			if planOnly || len(out.Plan) == 0 {
+				return printPosBootstrap(cfg, out)
+			}
+			if !cfg.JSON {
+				printPosPlan(out.Plan)
+			}
+
+			signers, err := bootstrapSigners(cmd, cfg, opts.signer, boot, out.Plan)
+			if err != nil {
+				return fmt.Errorf("pos bootstrap: %w", err)
+			}
+			parsed, err := stakingABI()
+			if err != nil {
+				return err
+			}
+			opts.wait = true
+			for _, step := range out.Plan {
+				s := signers[step.Operator]
+				method, data, value, err := packStep(parsed, step)
+				if err != nil {
+					return err
+				}
+				opts.beforeSend = func(tx *txSendOutput) error {
+					return journal.Record(step.ID(), s.Address(), tx.Nonce, common.HexToHash(tx.Hash))
+				}
+				m := parsed.Methods[method]
+				res, err := sendContractTx(cmd, cfg, &opts, s, parsed, &m, staking, value, data)
+				if res != nil {
+					if jerr := journal.Resolve(common.HexToHash(res.Hash), res.Status); jerr != nil {
+						return fmt.Errorf("pos bootstrap: %w", jerr)
+					}
+				}
+				if err != nil {
+					if cfg.JSON {
+						if perr := printJSON(out); perr != nil {
+							return perr
+						}
+					}
+					return fmt.Errorf("pos bootstrap: %s %s: %w (rerun to resume)", step.Action, step.Operator.Hex(), err)
+				}
+				applied := posAppliedStep{Step: step, TxHash: res.Hash, BlockNumber: *res.BlockNumber}
+				out.Applied = append(out.Applied, applied)
+				if !cfg.JSON {
+					fmt.Printf("applied %-13s %s tx %s block %d\n", step.Action, step.Operator.Hex(), applied.TxHash, applied.BlockNumber)
+				}
+			}
+
+			active, err := caller.GetActiveOperators(nil)
+			if err != nil {
+				return fmt.Errorf("pos bootstrap: getActiveOperators: %w", err)
+			}
+			for _, a := range active {
+				out.Active = append(out.Active, a.Hex())
+			}
+			if cfg.JSON {
+				return printJSON(out)
+			}
+			fmt.Printf("active operators: %s\n", strings.Join(out.Active, ", "))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&configPath, "config", defaultBootstrapPath, "bootstrap config")
+	cmd.Flags().StringVar(&journalPath, "journal", "", "transaction journal (default pos.bootstrap.journal.json next to --deployments)")
+	cmd.Flags().BoolVar(&planOnly, "plan", false, "print the plan without sending anything")
+	cmd.Flags().DurationVar(&opts.waitTimeout, "wait-timeout", 60*time.Second, "how long to wait for each receipt, including journaled ones")
+	_ = cmd.RegisterFlagCompletionFunc("config", jsonFileCompletion)
+	_ = cmd.RegisterFlagCompletionFunc("journal", jsonFileCompletion)
+	opts.bindSigner(cmd)
+	opts.bindFees(cmd)
+	return cmd
+}
+
+// settleJournal resolves journaled transactions. With wait, pending ones are
+// given waitTimeout to be mined before they are reported as still pending.
+func settleJournal(client *rpc.Client, journal *pos.Journal, waitTimeout time.Duration, wait bool) ([]pos.JournalEntry, error) {
+	pending, err := journal.Settle(client)
+	if err != nil || len(pending) == 0 || !wait {
+		return pending, err
+	}
+	for _, e := range pending {
+		slog.Info("waiting for journaled tx", "step", e.Step, "tx", e.TxHash.Hex())
+		if _, err := client.WaitForReceipt(e.TxHash, waitTimeout, time.Second); err != nil {
+			break
+		}
+	}
+	return journal.Settle(client)
+}
+
+func observeOperators(caller *contracts.IQikStakingCaller, ops []pos.Operator) (map[common.Address]pos.Observed, error) {
+	observed := make(map[common.Address]pos.Observed, len(ops))
+	for _, op := range ops {
+		info, err := caller.GetOperator(nil, op.Address)
+		if err != nil {
+			return nil, fmt.Errorf("getOperator %s: %w", op.Address.Hex(), err)
+		}
+		self, err := caller.StakeOf(nil, op.Address, op.Address)
+		if err != nil {
+			return nil, fmt.Errorf("stakeOf %s: %w", op.Address.Hex(), err)
+		}
+		observed[op.Address] = pos.Observed{Registered: info.Registered, ConsensusKey: info.ConsensusKey, Payout: info.Payout, SelfStake: self}
+	}
+	return observed, nil
+}
+
+// bootstrapSigners builds a signer for every operator with work to do,
+// before anything is sent. Each starts from the signer flags, overridden by
+// operators[i].signer; the local backend picks the operator's account from
+// --keystore, or else reads the hex key from the first of
+// operators[i].privateKeyEnv, OPERATOR<i>_PK and the deployer key (when it
+// is the operator's) that is set.
+func bootstrapSigners(cmd *cobra.Command, cfg *Config, base signer.Config, boot *pos.Bootstrap, plan []pos.Step) (map[common.Address]signer.Signer, error) {
+	byAddr := map[common.Address]pos.Operator{}
+	for _, op := range boot.Operators {
+		byAddr[op.Address] = op
+	}
+	signers := map[common.Address]signer.Signer{}
+	for _, step := range plan {
+		if signers[step.Operator] != nil {
+			continue
+		}
+		op := byAddr[step.Operator]
+		c := operatorSignerConfig(base, op)
+		c.KeyEnv = operatorKeyEnv(boot, op)
+		c.Timeout = cfg.Timeout
+		s, err := signer.New(cmd.Context(), c)
+		if err != nil {
+			return nil, fmt.Errorf("operator[%d] %s: %w", op.Index, op.Address.Hex(), err)
+		}
+		if s.Address() != op.Address {
+			return nil, fmt.Errorf("operator[%d] %s: the signer holds %s (check operators[%d].signer, the signer flags or %s)", op.Index, op.Address.Hex(), s.Address().Hex(), op.Index, c.KeyEnv)
+		}
+		signers[op.Address] = s
+	}
+	return signers, nil
+}
+
+// operatorSignerConfig overlays the set fields of op.Signer on base. A
+// keystore without an account, or a remote signer without --from, selects
+// the operator's own address.
+func operatorSignerConfig(base signer.Config, op pos.Operator) signer.Config {
+	c := base
+	o := op.Signer
+	for _, f := range []struct {
+		dst *string
+		v   string
+	}{
+		{&c.Backend, o.Backend},
+		{&c.Keystore, o.Keystore},
+		{&c.Account, o.Account},
+		{&c.PassphraseFile, o.PassphraseFile},
+		{&c.RemoteURL, o.RemoteURL},
+		{&c.RemoteMethod, o.RemoteMethod},
+		{&c.HSMModule, o.HSMModule},
+		{&c.HSMTokenLabel, o.HSMTokenLabel},
+		{&c.HSMKeyLabel, o.HSMKeyLabel},
+		{&c.HSMPinFile, o.HSMPinFile},
+	} {
+		if f.v != "" {
+			*f.dst = f.v
+		}
+	}
+	if c.Keystore != "" && c.Account == "" {
+		c.Account = op.Address.Hex()
+	}
+	if c.From == "" {
+		c.From = op.Address.Hex()
+	}
+	return c
+}
+
+// operatorKeyEnv names the variable holding op's hex key for the local
+// backend. The deployer key only counts when it is the operator's.
+func operatorKeyEnv(boot *pos.Bootstrap, op pos.Operator) string {
+	fallback := fmt.Sprintf("OPERATOR%d_PK", op.Index)
+	for _, env := range []string{op.PrivateKeyEnv, fallback} {
+		if env != "" && strings.TrimSpace(os.Getenv(env)) != "" {
+			return env
+		}
+	}
+	if env := boot.DeployerKeyEnv; env != "" {
+		key, err := wallet.ParsePrivateKey(strings.TrimSpace(os.Getenv(env)))
+		if err == nil && crypto.PubkeyToAddress(key.PublicKey) == op.Address {
+			return env
+		}
+	}
+	if op.PrivateKeyEnv != "" {
+		return op.PrivateKeyEnv
+	}
+	return fallback
+}
+
+func packStep(parsed *abi.ABI, step pos.Step) (string, []byte, *big.Int, error) {
+	value := new(big.Int)
+	var method string
+	var args []any
+	switch step.Action {
+	case pos.ActionRegister:
+		method, args = "registerOperator", []any{[]byte(step.ConsensusKey), common.HexToAddress(step.Payout)}
+	case pos.ActionUpdateKey:
+		method, args = "updateConsensusKey", []any{[]byte(step.ConsensusKey)}
+	case pos.ActionUpdatePayout:
+		method, args = "updatePayout", []any{common.HexToAddress(step.Payout)}
+	case pos.ActionStake:
+		value.SetString(step.AmountWei, 10)
+		method, args = "stake", []any{step.Operator}
+	default:
+		return "", nil, nil, fmt.Errorf("unknown bootstrap action %q", step.Action)
+	}
+	data, err := parsed.Pack(method, args...)
+	return method, data, value, err
+}
+
+func printPosPlan(plan []pos.Step) {
+	if len(plan) == 0 {
+		fmt.Println("plan: nothing to do, on-chain state matches the config")
+		return
+	}
+	fmt.Printf("plan: %d step(s)\n", len(plan))
+	for i, s := range plan {
+		detail := ""
+		switch s.Action {
+		case pos.ActionRegister:
+			detail = fmt.Sprintf("consensusKey=%s payout=%s", s.ConsensusKey, s.Payout)
+		case pos.ActionUpdateKey:
+			detail = fmt.Sprintf("consensusKey=%s", s.ConsensusKey)
+		case pos.ActionUpdatePayout:
+			detail = fmt.Sprintf("payout=%s", s.Payout)
+		case pos.ActionStake:
+			amount, _ := new(big.Int).SetString(s.AmountWei, 10)
+			detail = fmt.Sprintf("amount=%s QIK", allocations.FormatUnits(amount, 18, 18))
+		}
+		fmt.Printf("  %d. %-13s %s %s\n", i+1, s.Action, s.Operator.Hex(), detail)
+	}
+}
+
+func printPosBootstrap(cfg *Config, out posBootstrapOutput) error {
+	if cfg.JSON {
+		return printJSON(out)
+	}
+	for _, e := range out.Pending {
+		fmt.Printf("pending: %s tx %s (nonce %d, sent %s)\n", e.Step, e.TxHash.Hex(), e.Nonce, e.SentAt.Format(time.RFC3339))
+	}
+	if len(out.Pending) == 0 {
+		printPosPlan(out.Plan)
+	}
+	return nil
+}
@@ -0,0 +1,420 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/abiutil"
+	"github.com/BioMark3r/qikchain/internal/pos"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/BioMark3r/qikchain/internal/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultContractsPlanPath = "config/pos.contracts.json"
+	deployerKeyEnv           = "POS_DEPLOYER_PK"
+)
+
+type posDeployedContract struct {
+	Name        string `json:"name"`
+	Contract    string `json:"contract"`
+	Action      string `json:"action"` // deployed, kept, or with --dry-run deploy
+	Address     string `json:"address"`
+	TxHash      string `json:"txHash,omitempty"`
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+}
+
+type posInitChange struct {
+	Name   string `json:"name"`
+	Method string `json:"method"`
+	Value  string `json:"value"`
+	TxHash string `json:"txHash,omitempty"`
+}
+
+type posDeployOutput struct {
+	Record    string                `json:"record"`
+	ChainID   uint64                `json:"chainId"`
+	Deployer  string                `json:"deployer"`
+	DryRun    bool                  `json:"dryRun,omitempty"`
+	Contracts []posDeployedContract `json:"contracts"`
+	Init      []posInitChange       `json:"init,omitempty"`
+}
+
+// posDeployer carries the state of one pos deploy run.
+type posDeployer struct {
+	cmd      *cobra.Command
+	cfg      *Config
+	opts     *txOptions
+	client   *rpc.Client
+	signer   signer.Signer
+	record   *pos.Record
+	out      *posDeployOutput
+	dryRun   bool
+	nonce    uint64 // next nonce, for --dry-run address predictions
+	force    bool
+	create2  bool
+	factory  common.Address
+	saltBase string
+}
+
+func newPosDeployCmd(cfg *Config, sf *stakingFlags) *cobra.Command {
+	opts := txOptions{signer: signer.Config{KeyEnv: deployerKeyEnv}}
+	var (
+		planPath     string
+		artifactsDir string
+		network      string
+		d            posDeployer
+		factory      string
+	)
+	network = os.Getenv("POS_NETWORK")
+	if network == "" {
+		network = "local"
+	}
+	cmd := &cobra.Command{
+		Use:   "deploy",
+		Short: "Deploy the PoS contracts from compiled artifacts and write the deployment record",
+		Long: "Deploy every contract of --plan from its Foundry or Hardhat artifact, then apply its init values " +
+			"through the matching setters. {{POS_DEPLOYER_ADDRESS}} and {{<NAME>_ADDRESS}} placeholders in " +
+			"constructorArgs resolve to the deployer and to earlier deployments; others come from the environment. " +
+			"Contracts already in --deployments with code on chain are kept, so reruns are safe. " +
+			"With --create2 the addresses depend only on the factory, the salt and the init code.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := pos.LoadDeployPlan(planPath)
+			if err != nil {
+				return fmt.Errorf("pos deploy: %w", err)
+			}
+			if d.factory, err = parseAddress(factory); err != nil {
+				return err
+			}
+			d.cmd, d.cfg, d.opts = cmd, cfg, &opts
+			d.client = cfg.client()
+			chainID, err := d.client.ChainID()
+			if err != nil {
+				return fmt.Errorf("pos deploy: %w", err)
+			}
+			if d.record, err = pos.OpenRecord(sf.deployments, chainID.Uint64()); err != nil {
+				return fmt.Errorf("pos deploy: %w", err)
+			}
+			if d.signer, err = opts.newSigner(cmd, cfg); err != nil {
+				return fmt.Errorf("pos deploy: %w", err)
+			}
+			deployer := d.signer.Address()
+			d.record.Network, d.record.RPC, d.record.Deployer = network, cfg.RPCURL, deployer
+			d.out = &posDeployOutput{Record: sf.deployments, ChainID: chainID.Uint64(), Deployer: deployer.Hex(), DryRun: d.dryRun, Contracts: []posDeployedContract{}}
+
+			if d.create2 {
+				code, err := d.client.CodeAt(d.factory, "latest")
+				if err != nil {
+					return fmt.Errorf("pos deploy: %w", err)
+				}
+				if len(code) == 0 {
+					return fmt.Errorf("pos deploy: no CREATE2 factory at %s; predeploy it in the genesis alloc or pass --create2-factory", d.factory.Hex())
+				}
+			}
+			if d.dryRun {
+				if d.nonce, err = d.client.NonceAt(deployer, "pending"); err != nil {
+					return fmt.Errorf("pos deploy: fetch nonce: %w", err)
+				}
+			}
+
+			addresses := map[string]string{"POS_DEPLOYER_ADDRESS": deployer.Hex()}
+			lookup := func(name string) (string, bool) {
+				if v, ok := addresses[name]; ok {
+					return v, true
+				}
+				return os.LookupEnv(name)
+			}
+			opts.wait = true
+			abis := make([]*abi.ABI, len(plan))
+			deployed := make([]bool, len(plan))
+			for i, dep := range plan {
+				artifact := dep.Artifact
+				if artifact == "" {
+					artifact = filepath.Join(artifactsDir, dep.Contract+".sol", dep.Contract+".json")
+				}
+				parsed, code, err := abiutil.LoadArtifact(artifact)
+				if err != nil {
+					return fmt.Errorf("pos deploy: %s: %w (run forge build?)", dep.Name, err)
+				}
+				abis[i] = parsed
+				entry, err := d.deploy(dep, parsed, code, lookup)
+				if err != nil {
+					if d.cfg.JSON {
+						if perr := printJSON(d.out); perr != nil {
+							return perr
+						}
+					}
+					return fmt.Errorf("pos deploy: %s: %w (rerun to resume)", dep.Name, err)
+				}
+				deployed[i] = d.out.Contracts[len(d.out.Contracts)-1].Action != "kept"
+				addresses[pos.AddressPlaceholder(dep.Name)] = entry.Address.Hex()
+			}
+			for i, dep := range plan {
+				if err := d.applyInit(dep, abis[i], lookup, d.dryRun && deployed[i]); err != nil {
+					return fmt.Errorf("pos deploy: %s: %w", dep.Name, err)
+				}
+			}
+			if cfg.JSON {
+				return printJSON(d.out)
+			}
+			if d.dryRun {
+				fmt.Println("dry run: nothing was sent")
+			} else {
+				fmt.Printf("record: %s\n", sf.deployments)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&planPath, "plan", defaultContractsPlanPath, "contracts plan")
+	cmd.Flags().StringVar(&artifactsDir, "artifacts", "out", "compiled artifacts directory, read as <dir>/<Contract>.sol/<Contract>.json")
+	cmd.Flags().StringVar(&network, "network", network, "network name written to the record (env POS_NETWORK)")
+	cmd.Flags().BoolVar(&d.create2, "create2", false, "deploy through the CREATE2 factory for deterministic addresses")
+	cmd.Flags().StringVar(&factory, "create2-factory", pos.Create2Factory.Hex(), "CREATE2 factory address")
+	cmd.Flags().StringVar(&d.saltBase, "salt", "qikchain", "CREATE2 salt, combined with each contract name unless the plan sets salt")
+	cmd.Flags().BoolVar(&d.force, "force", false, "redeploy contracts already in the record (CREATE only)")
+	cmd.Flags().BoolVar(&d.dryRun, "dry-run", false, "print what would be deployed and set without sending anything")
+	cmd.Flags().DurationVar(&opts.waitTimeout, "wait-timeout", 60*time.Second, "how long to wait for each receipt")
+	_ = cmd.RegisterFlagCompletionFunc("plan", jsonFileCompletion)
+	_ = cmd.RegisterFlagCompletionFunc("artifacts", dirCompletion)
+	opts.bindSigner(cmd)
+	opts.bindFees(cmd)
+	return cmd
+}
+
+// deploy deploys dep unless the record already holds a live deployment of it,
+// and records the result.
+func (d *posDeployer) deploy(dep pos.Deployment, parsed *abi.ABI, code []byte, lookup func(string) (string, bool)) (pos.ContractRecord, error) {
+	rawArgs, err := dep.Args(lookup)
+	if err != nil {
+		return pos.ContractRecord{}, err
+	}
+	args, err := abiutil.ParseArgs(parsed.Constructor.Inputs, rawArgs)
+	if err != nil {
+		return pos.ContractRecord{}, fmt.Errorf("constructor: %w", err)
+	}
+	packed, err := parsed.Pack("", args...)
+	if err != nil {
+		return pos.ContractRecord{}, fmt.Errorf("constructor: %w", err)
+	}
+	initCode := append(append([]byte{}, code...), packed...)
+
+	bytecodeHash := crypto.Keccak256Hash(code)
+	want := pos.ContractRecord{Contract: dep.Contract, BytecodeHash: &bytecodeHash, ConstructorArgs: rawArgs}
+	if want.Contract == "" {
+		want.Contract = dep.Artifact
+	}
+	var salt common.Hash
+	if d.create2 {
+		salt = dep.Salt(d.saltBase)
+		want.Create2Factory, want.Create2Salt = &d.factory, &salt
+		want.Address = pos.Create2Address(d.factory, salt, initCode)
+	}
+
+	prev, ok := d.record.Contracts[dep.Name]
+	if ok && prev.Pending() {
+		if ok, err = d.settle(dep.Name, &prev); err != nil {
+			return pos.ContractRecord{}, err
+		}
+	}
+	if ok && d.create2 && prev.Address != want.Address {
+		ok = false
+	}
+	if ok || d.create2 {
+		addr := want.Address
+		if ok {
+			addr = prev.Address
+		}
+		live, err := d.client.CodeAt(addr, "latest")
+		if err != nil {
+			return pos.ContractRecord{}, err
+		}
+		switch {
+		case len(live) > 0 && (d.create2 || !d.force):
+			if !ok {
+				// Deployed by someone else through the factory: same init code,
+				// so the same contract.
+				prev = want
+			}
+			if !prev.SameSource(want) {
+				slog.Warn("deployed from other bytecode or constructor args; pass --force to redeploy", "contract", dep.Name, "address", addr.Hex())
+			}
+			codeHash := crypto.Keccak256Hash(live)
+			prev.CodeHash = &codeHash
+			d.record.Contracts[dep.Name] = prev
+			if !d.dryRun {
+				if err := d.record.Save(); err != nil {
+					return pos.ContractRecord{}, err
+				}
+			}
+			d.report(posDeployedContract{Name: dep.Name, Contract: prev.Contract, Action: "kept", Address: addr.Hex(), TxHash: prev.Tx, BlockNumber: prev.BlockNumber})
+			return prev, nil
+		case len(live) == 0 && ok:
+			slog.Warn("no code at recorded address; redeploying", "contract", dep.Name, "address", addr.Hex())
+		}
+	}
+
+	var to *common.Address
+	data := initCode
+	if d.create2 {
+		to, data = &d.factory, append(salt.Bytes(), initCode...)
+	}
+	if d.dryRun {
+		entry := want
+		if !d.create2 {
+			entry.Address = crypto.CreateAddress(d.signer.Address(), d.nonce)
+		}
+		d.nonce++
+		d.report(posDeployedContract{Name: dep.Name, Contract: entry.Contract, Action: "deploy", Address: entry.Address.Hex()})
+		return entry, nil
+	}
+
+	entry := want
+	recorded := false
+	d.opts.beforeSend = func(tx *txSendOutput) error {
+		if !d.create2 {
+			entry.Address = crypto.CreateAddress(d.signer.Address(), tx.Nonce)
+		}
+		entry.Tx = tx.Hash
+		d.record.Contracts[dep.Name] = entry
+		recorded = true
+		return d.record.Save()
+	}
+	defer func() { d.opts.beforeSend = nil }()
+	res, err := sendTx(d.cmd, d.cfg, d.opts, d.signer, to, new(big.Int), data)
+	if err != nil {
+		// sendTx returns no output when the broadcast itself failed. The node
+		// may still have taken the tx, so the pending record is only dropped
+		// when the node does not know it; otherwise a rerun settles it.
+		if recorded && res == nil {
+			if _, terr := d.client.TransactionByHash(common.HexToHash(entry.Tx)); errors.Is(terr, rpc.ErrNotFound) {
+				delete(d.record.Contracts, dep.Name)
+				if serr := d.record.Save(); serr != nil {
+					return pos.ContractRecord{}, fmt.Errorf("%w (and saving the record: %v)", err, serr)
+				}
+			}
+		}
+		return pos.ContractRecord{}, err
+	}
+	live, err := d.client.CodeAt(entry.Address, "latest")
+	if err != nil {
+		return pos.ContractRecord{}, err
+	}
+	if len(live) == 0 {
+		return pos.ContractRecord{}, fmt.Errorf("no code at %s after tx %s", entry.Address.Hex(), res.Hash)
+	}
+	codeHash := crypto.Keccak256Hash(live)
+	entry.BlockNumber, entry.CodeHash = *res.BlockNumber, &codeHash
+	d.record.Contracts[dep.Name] = entry
+	if err := d.record.Save(); err != nil {
+		return pos.ContractRecord{}, err
+	}
+	d.report(posDeployedContract{Name: dep.Name, Contract: entry.Contract, Action: "deployed", Address: entry.Address.Hex(), TxHash: entry.Tx, BlockNumber: entry.BlockNumber})
+	return entry, nil
+}
+
+// settle resolves a recorded deployment whose tx was not seen mined. It
+// reports whether the deployment is still worth keeping.
+func (d *posDeployer) settle(name string, rec *pos.ContractRecord) (bool, error) {
+	hash := common.HexToHash(rec.Tx)
+	receipt, err := d.client.TransactionReceipt(hash)
+	if err == nil {
+		if receipt.Status != nil && uint64(*receipt.Status) != 1 {
+			slog.Warn("recorded deployment tx reverted; redeploying", "contract", name, "tx", rec.Tx)
+			return false, nil
+		}
+		rec.BlockNumber = uint64(receipt.BlockNumber)
+		return true, nil
+	}
+	if !errors.Is(err, rpc.ErrNotFound) {
+		return false, err
+	}
+	if _, err := d.client.TransactionByHash(hash); err == nil {
+		return false, fmt.Errorf("deployment tx %s is still pending", rec.Tx)
+	} else if !errors.Is(err, rpc.ErrNotFound) {
+		return false, err
+	}
+	slog.Warn("recorded deployment tx is unknown to the node; redeploying", "contract", name, "tx", rec.Tx)
+	return false, nil
+}
+
+// applyInit reads each init value through its getter and sends the setter
+// when it differs. With unread, the contract does not exist yet and every
+// value is reported as to be set.
+func (d *posDeployer) applyInit(dep pos.Deployment, parsed *abi.ABI, lookup func(string) (string, bool), unread bool) error {
+	params, err := dep.InitParams(lookup)
+	if err != nil || len(params) == 0 {
+		return err
+	}
+	addr := d.record.Contracts[dep.Name].Address
+	for _, p := range params {
+		getter, ok := parsed.Methods[p.Name]
+		if !ok || len(getter.Inputs) != 0 || len(getter.Outputs) != 1 {
+			return fmt.Errorf("init.%s: no getter %s() returning one value", p.Name, p.Name)
+		}
+		setter, ok := parsed.Methods[p.Setter()]
+		if !ok || len(setter.Inputs) != 1 {
+			return fmt.Errorf("init.%s: no setter %s with one argument", p.Name, p.Setter())
+		}
+		args, err := abiutil.ParseArgs(setter.Inputs, []string{p.Value})
+		if err != nil {
+			return fmt.Errorf("init.%s: %w", p.Name, err)
+		}
+		want, err := setter.Inputs.Pack(args...)
+		if err != nil {
+			return fmt.Errorf("init.%s: %w", p.Name, err)
+		}
+		if !unread {
+			have, err := d.client.CallContract(rpc.CallMsg{To: &addr, Data: getter.ID}, "latest")
+			if err != nil {
+				return fmt.Errorf("%s: %w", p.Name, err)
+			}
+			if bytes.Equal(have, want) {
+				continue
+			}
+		}
+		change := posInitChange{Name: dep.Name + "." + p.Name, Method: setter.Name, Value: p.Value}
+		if !d.dryRun {
+			data := append(append([]byte{}, setter.ID...), want...)
+			res, err := sendContractTx(d.cmd, d.cfg, d.opts, d.signer, parsed, &setter, addr, new(big.Int), data)
+			if err != nil {
+				return fmt.Errorf("%s: %w", setter.Name, err)
+			}
+			change.TxHash = res.Hash
+		}
+		d.out.Init = append(d.out.Init, change)
+		if !d.cfg.JSON {
+			if change.TxHash == "" {
+				fmt.Printf("set      %s = %s\n", change.Name, change.Value)
+			} else {
+				fmt.Printf("set      %s = %s tx %s\n", change.Name, change.Value, change.TxHash)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *posDeployer) report(c posDeployedContract) {
+	d.out.Contracts = append(d.out.Contracts, c)
+	if d.cfg.JSON {
+		return
+	}
+	line := fmt.Sprintf("%-8s %-13s %-16s %s", c.Action, c.Name, c.Contract, c.Address)
+	if c.TxHash != "" {
+		line += " tx " + c.TxHash
+	}
+	if c.BlockNumber != 0 {
+		line += fmt.Sprintf(" block %d", c.BlockNumber)
+	}
+	fmt.Println(line)
+}
@@ -0,0 +1,258 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/BioMark3r/qikchain/internal/contracts"
+	"github.com/BioMark3r/qikchain/internal/genesis"
+	"github.com/BioMark3r/qikchain/internal/ibft"
+	"github.com/BioMark3r/qikchain/internal/pos"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+const defaultPosAddressesPath = ".data/pos/addresses.json"
+
+type posReconcileOutput struct {
+	Block         uint64               `json:"block"`
+	BlockHash     string               `json:"blockHash"`
+	Consensus     []common.Address     `json:"consensus"`
+	Snapshot      []common.Address     `json:"ibftSnapshot,omitempty"`
+	SnapshotError string               `json:"ibftSnapshotError,omitempty"`
+	Active        []pos.ActiveOperator `json:"active"`
+	ValidatorSet  []common.Address     `json:"validatorSet"`
+	Epoch         *uint64              `json:"epoch,omitempty"`
+	EpochSnapshot []common.Address     `json:"epochSnapshot,omitempty"`
+	EpochSkipped  string               `json:"epochSkipped,omitempty"`
+	Mismatches    []pos.Mismatch       `json:"mismatches"`
+}
+
+func newPosReconcileCmd(cfg *Config, sf *stakingFlags) *cobra.Command {
+	var (
+		blockRef     string
+		validatorSet string
+		epochManager string
+		addresses    string
+	)
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Check that the sealing validators match the staking contract and the epoch snapshot",
+		Long: "Compare, at one block: the validators in the IBFT extraData (and ibft_getSnapshot when the node serves it), " +
+			"the staking active set with the validator addresses of its consensus keys, QikValidatorSet.getValidators " +
+			"and the EpochManager snapshot for the current epoch. Every operator or validator missing from a view is " +
+			"reported; the command fails when there is any mismatch.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, skipped, err := resolveEpochManagerFlag(cmd, epochManager, addresses)
+			if err != nil {
+				return fmt.Errorf("pos reconcile: %w", err)
+			}
+			client := cfg.client()
+			block, err := fetchBlock(client, blockRef, false)
+			if err != nil {
+				return fmt.Errorf("pos reconcile: %w", err)
+			}
+			number := uint64(block.Number)
+			opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(number)}
+			out := posReconcileOutput{Block: number, BlockHash: block.Hash.Hex(), Mismatches: []pos.Mismatch{}}
+			sets := pos.ValidatorSets{}
+
+			extra, err := ibft.DecodeExtra(block.ExtraData)
+			if err != nil {
+				return fmt.Errorf("pos reconcile: block %d: %w", number, err)
+			}
+			sets.Consensus, sets.ConsensusBLS = extra.Validators, extra.BLSPublicKeys
+			out.Consensus = extra.Validators
+			if sets.Snapshot, err = ibftSnapshot(client, number); err != nil {
+				out.SnapshotError = err.Error()
+			}
+			out.Snapshot = sets.Snapshot
+
+			_, staking, err := sf.caller(cfg)
+			if err != nil {
+				return fmt.Errorf("pos reconcile: %w", err)
+			}
+			operators, err := staking.GetActiveOperators(opts)
+			if err != nil {
+				return fmt.Errorf("pos reconcile: getActiveOperators: %w", err)
+			}
+			keys, err := staking.GetActiveConsensusKeys(opts)
+			if err != nil {
+				return fmt.Errorf("pos reconcile: getActiveConsensusKeys: %w", err)
+			}
+			if len(keys) != len(operators) {
+				return fmt.Errorf("pos reconcile: staking returned %d active operators but %d consensus keys", len(operators), len(keys))
+			}
+			for i, op := range operators {
+				sets.Active = append(sets.Active, pos.ActiveOperator{Operator: op, ConsensusKey: keys[i], Validator: validatorAddress(keys[i])})
+			}
+			out.Active = sets.Active
+
+			setAddr, err := resolveValidatorSet(validatorSet, sf.deployments)
+			if err != nil {
+				return fmt.Errorf("pos reconcile: %w", err)
+			}
+			vs, err := contracts.NewIQikValidatorSetCaller(setAddr, contracts.RPCCaller{Client: client})
+			if err != nil {
+				return err
+			}
+			if sets.ValidatorSet, err = vs.GetValidators(opts); err != nil {
+				return fmt.Errorf("pos reconcile: getValidators: %w", err)
+			}
+			out.ValidatorSet = sets.ValidatorSet
+
+			if skipped != "" {
+				out.EpochSkipped = skipped
+			} else {
+				em, err := contracts.NewEpochManagerCaller(manager, contracts.RPCCaller{Client: client})
+				if err != nil {
+					return err
+				}
+				epoch, err := em.CurrentEpoch(opts)
+				if err != nil {
+					return fmt.Errorf("pos reconcile: currentEpoch: %w", err)
+				}
+				e := epoch.Uint64()
+				out.Epoch = &e
+				if sets.EpochSnapshot, err = em.GetActiveSet(opts, epoch); err != nil {
+					return fmt.Errorf("pos reconcile: getActiveSet(%d): %w", e, err)
+				}
+				if sets.EpochSnapshot == nil {
+					sets.EpochSnapshot = []common.Address{}
+				}
+				out.EpochSnapshot = sets.EpochSnapshot
+			}
+
+			out.Mismatches = append(out.Mismatches, pos.Reconcile(sets)...)
+			if cfg.JSON {
+				if err := printJSON(out); err != nil {
+					return err
+				}
+			} else {
+				printPosReconcile(out)
+			}
+			if len(out.Mismatches) > 0 {
+				return fmt.Errorf("pos reconcile: %d mismatch(es) at block %d", len(out.Mismatches), number)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&blockRef, "block", "latest", "block number, tag or hash to reconcile at")
+	cmd.Flags().StringVar(&validatorSet, "validator-set", "", "QikValidatorSet address (default: validatorSet.address from --deployments)")
+	cmd.Flags().StringVar(&epochManager, "epoch-manager", os.Getenv("POS_EPOCH_MANAGER"), "EpochManager address (default: epochManager from --addresses; skipped when neither is set)")
+	addressesPath := os.Getenv("POS_ADDRESSES_FILE")
+	if addressesPath == "" {
+		addressesPath = defaultPosAddressesPath
+	}
+	cmd.Flags().StringVar(&addresses, "addresses", addressesPath, "addresses file written by script/pos/DeployPos.s.sol")
+	_ = cmd.RegisterFlagCompletionFunc("addresses", jsonFileCompletion)
+	return cmd
+}
+
+func resolveValidatorSet(flagValue, deployments string) (common.Address, error) {
+	if flagValue != "" {
+		return parseAddress(flagValue)
+	}
+	addrs, err := genesis.LoadPOSAddresses(deployments)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w (or pass --validator-set)", err)
+	}
+	return common.HexToAddress(addrs.ValidatorSet), nil
+}
+
+// resolveEpochManagerFlag returns the EpochManager to check, or why the epoch
+// check is skipped. Only a missing default addresses file skips it; a bad
+// --epoch-manager or an addresses file that was asked for but cannot be used
+// is an error.
+func resolveEpochManagerFlag(cmd *cobra.Command, flagValue, addresses string) (common.Address, string, error) {
+	if flagValue != "" {
+		if !common.IsHexAddress(flagValue) {
+			return common.Address{}, "", usageErrorf("invalid --epoch-manager %q", flagValue)
+		}
+		return common.HexToAddress(flagValue), "", nil
+	}
+	addr, err := pos.LoadEpochManager(addresses)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !cmd.Flags().Changed("addresses") && os.Getenv("POS_ADDRESSES_FILE") == "" {
+			return common.Address{}, "no EpochManager (pass --epoch-manager or --addresses)", nil
+		}
+		return common.Address{}, "", fmt.Errorf("%w (or pass --epoch-manager)", err)
+	}
+	return addr, "", nil
+}
+
+// ibftSnapshot reads the validators of ibft_getSnapshot at number. Nodes
+// differ in the shape: validators may be plain addresses or objects with an
+// address field, under "validators" or "set".
+func ibftSnapshot(client *rpc.Client, number uint64) ([]common.Address, error) {
+	var raw map[string]json.RawMessage
+	if err := client.Call(&raw, "ibft_getSnapshot", hexutil.EncodeUint64(number)); err != nil {
+		return nil, err
+	}
+	var list json.RawMessage
+	for k, v := range raw {
+		if strings.EqualFold(k, "validators") || strings.EqualFold(k, "set") {
+			list = v
+		}
+	}
+	if list == nil {
+		return nil, errors.New("ibft_getSnapshot: no validators in the response")
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(list, &items); err != nil {
+		return nil, fmt.Errorf("ibft_getSnapshot: %w", err)
+	}
+	out := make([]common.Address, 0, len(items))
+	for _, item := range items {
+		var s string
+		if err := json.Unmarshal(item, &s); err != nil {
+			var obj map[string]json.RawMessage
+			if err := json.Unmarshal(item, &obj); err != nil {
+				return nil, fmt.Errorf("ibft_getSnapshot: validator %s", item)
+			}
+			for k, v := range obj {
+				if strings.EqualFold(k, "address") {
+					_ = json.Unmarshal(v, &s)
+				}
+			}
+		}
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("ibft_getSnapshot: validator %s has no address", item)
+		}
+		out = append(out, common.HexToAddress(s))
+	}
+	return out, nil
+}
+
+func printPosReconcile(out posReconcileOutput) {
+	fmt.Printf("block %d (%s)\n", out.Block, out.BlockHash)
+	fmt.Printf("  consensus (extraData):  %d validator(s)\n", len(out.Consensus))
+	if out.SnapshotError != "" {
+		fmt.Printf("  ibft_getSnapshot:       unavailable (%s)\n", out.SnapshotError)
+	} else {
+		fmt.Printf("  ibft_getSnapshot:       %d validator(s)\n", len(out.Snapshot))
+	}
+	fmt.Printf("  staking active set:     %d operator(s)\n", len(out.Active))
+	fmt.Printf("  validatorSet:           %d operator(s)\n", len(out.ValidatorSet))
+	if out.Epoch != nil {
+		fmt.Printf("  epoch snapshot:         %d operator(s) (epoch %d)\n", len(out.EpochSnapshot), *out.Epoch)
+	} else {
+		fmt.Printf("  epoch snapshot:         skipped (%s)\n", out.EpochSkipped)
+	}
+	if len(out.Mismatches) == 0 {
+		fmt.Println("OK: all views agree")
+		return
+	}
+	fmt.Printf("%d mismatch(es):\n", len(out.Mismatches))
+	for _, m := range out.Mismatches {
+		fmt.Printf("  %-15s %s\n", m.Check, m.Detail)
+	}
+}
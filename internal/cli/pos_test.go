@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BioMark3r/qikchain/internal/pos"
+	"github.com/BioMark3r/qikchain/internal/signer"
+	"github.com/BioMark3r/qikchain/internal/wallet"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+// Hardhat accounts #1 and #2.
+const (
+	operator0Key = "0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
+	operator1Key = "0x5de4111afa1a4b94908f83536e1e4e7ac8d9b4e01ef4d8ed66c1ff0d0cfd2c6c"
+)
+
+func TestBootstrapSigners(t *testing.T) {
+	key0, _ := wallet.ParsePrivateKey(operator0Key)
+	key1, _ := wallet.ParsePrivateKey(operator1Key)
+	op0 := pos.Operator{Index: 0, Address: crypto.PubkeyToAddress(key0.PublicKey)}
+	op1 := pos.Operator{Index: 1, Address: crypto.PubkeyToAddress(key1.PublicKey)}
+	plan := []pos.Step{{Operator: op0.Address}, {Operator: op1.Address}}
+
+	dir := t.TempDir()
+	if _, err := wallet.Open(dir, true).Import(key1, "pw"); err != nil {
+		t.Fatal(err)
+	}
+	pass := filepath.Join(dir, "pass")
+	if err := os.WriteFile(pass, []byte("pw\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("OPERATOR0_PK", operator0Key)
+	t.Setenv("OPERATOR1_PK", "")
+	boot := &pos.Bootstrap{Operators: []pos.Operator{op0, op1}}
+
+	// --keystore applies to every operator and picks each one's account;
+	// op0 has none there, and its hex key does not override the flag.
+	base := signer.Config{Backend: signer.BackendLocal, Keystore: dir, PassphraseFile: pass}
+	if _, err := bootstrapSigners(&cobra.Command{}, &Config{}, base, boot, plan); err == nil || !strings.Contains(err.Error(), "operator[0]") {
+		t.Fatalf("operator[0] without a keystore account: %v", err)
+	}
+
+	// Only op1 uses the keystore; op0 falls back to OPERATOR0_PK.
+	base = signer.Config{Backend: signer.BackendLocal}
+	boot.Operators[1].Signer = pos.OperatorSigner{Keystore: dir, PassphraseFile: pass}
+	signers, err := bootstrapSigners(&cobra.Command{}, &Config{}, base, boot, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signers[op0.Address].Address() != op0.Address || signers[op1.Address].Address() != op1.Address {
+		t.Fatalf("signers %v", signers)
+	}
+
+	// A key of another account is rejected.
+	t.Setenv("OPERATOR0_PK", operator1Key)
+	if _, err := bootstrapSigners(&cobra.Command{}, &Config{}, base, boot, plan); err == nil || !strings.Contains(err.Error(), "OPERATOR0_PK") {
+		t.Fatalf("mismatched key: %v", err)
+	}
+}
@@ -1,14 +1,20 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/BioMark3r/qikchain/internal/logging"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/BioMark3r/qikchain/internal/tracing"
 	"github.com/spf13/cobra"
 )
 
@@ -21,15 +27,28 @@ type Config struct {
 	RPCURL  string
 	Timeout time.Duration
 	JSON    bool
+	Log     logging.Config
+	Trace   tracing.Config
+
+	ctx         context.Context // carries the command span
+	span        *tracing.Span
+	stopTracing func()
 }
 
 func NewRootCmd() *cobra.Command {
-	cfg := &Config{}
+	return newRootCmd(&Config{})
+}
 
+func newRootCmd(cfg *Config) *cobra.Command {
 	root := &cobra.Command{
 		Use:   "qikchain",
-		Short: "Qikchain CLI for chain status and block head queries",
-		Long:  "qikchain provides simple commands for querying JSON-RPC status and block head data.",
+		Short: "Qikchain CLI for genesis tooling and chain queries",
+		Long:  "qikchain builds and validates genesis artifacts and queries JSON-RPC status, blocks, transactions and receipts.",
+		// Without a command qikchain fails with a usage error, not help, so
+		// scripts that forget the command still exit 2.
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return usageErrorf("no command (try --help)")
+		},
 	}
 
 	root.SilenceUsage = true
@@ -38,26 +57,93 @@ func NewRootCmd() *cobra.Command {
 	root.PersistentFlags().StringVar(&cfg.RPCURL, "rpc", defaultRPCFromEnv(), "JSON-RPC endpoint URL")
 	root.PersistentFlags().DurationVar(&cfg.Timeout, "timeout", defaultTimeoutFromEnv(), "RPC request timeout")
 	root.PersistentFlags().BoolVar(&cfg.JSON, "json", false, "Output JSON")
+	cfg.Log.BindFlags(root.PersistentFlags())
+	cfg.Trace.BindFlags(root.PersistentFlags())
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if _, err := cfg.Log.Setup("qikchain"); err != nil {
+			return usageError{msg: err.Error()}
+		}
+		stop, err := cfg.Trace.Setup("qikchain")
+		if err != nil {
+			return usageError{msg: err.Error()}
+		}
+		cfg.stopTracing = stop
+		cfg.ctx, cfg.span = tracing.Start(cmd.Context(), cmd.CommandPath())
+		cmd.SetContext(cfg.ctx)
+		return nil
+	}
 
 	root.AddCommand(newStatusCmd(cfg))
+	root.AddCommand(newHistoryCmd(cfg))
 	root.AddCommand(newBlockCmd(cfg))
+	root.AddCommand(newAnalyzeCmd(cfg))
+	root.AddCommand(newTxCmd(cfg))
+	root.AddCommand(newAccountCmd(cfg))
+	root.AddCommand(newWalletCmd(cfg))
+	root.AddCommand(newContractCmd(cfg))
+	root.AddCommand(newValidatorCmd(cfg))
+	root.AddCommand(newStakeCmd(cfg))
+	root.AddCommand(newPosCmd(cfg))
+	root.AddCommand(newAllocationsCmd(cfg))
+	root.AddCommand(newChainCmd())
+	root.AddCommand(newGenesisCmd(cfg))
+	root.AddCommand(newEdgeCmd(cfg))
 	root.InitDefaultCompletionCmd()
 
 	return root
 }
 
 func Execute() {
-	if err := NewRootCmd().Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+	cfg := &Config{}
+	err := newRootCmd(cfg).Execute()
+	cfg.span.RecordError(err)
+	cfg.span.End()
+	if cfg.stopTracing != nil {
+		cfg.stopTracing()
+	}
+	if err != nil {
+		slog.Error(err.Error())
 		os.Exit(classifyError(err))
 	}
 }
 
+// client returns a --rpc client that logs every call at debug level and
+// traces it under the command span.
+func (c *Config) client() *rpc.Client {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	client := rpc.NewClient(c.RPCURL, c.Timeout).WithContext(ctx)
+	client.SetObserver(func(method string, took time.Duration, err error) {
+		if err != nil {
+			slog.Debug("rpc call failed", logging.KeyRPC, c.RPCURL, "method", method, logging.Duration(took), logging.Err(err))
+			return
+		}
+		slog.Debug("rpc call", logging.KeyRPC, c.RPCURL, "method", method, logging.Duration(took))
+	})
+	return client
+}
+
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string { return e.msg }
+
+func usageErrorf(format string, args ...any) error {
+	return usageError{msg: fmt.Sprintf(format, args...)}
+}
+
 func classifyError(err error) int {
 	if err == nil {
 		return 0
 	}
 
+	var uerr usageError
+	if errors.As(err, &uerr) {
+		return 2
+	}
 	if errors.Is(err, flag.ErrHelp) || strings.Contains(err.Error(), cobra.ShellCompRequestCmd) {
 		return 2
 	}
@@ -68,7 +154,9 @@ func classifyError(err error) int {
 		"unknown shorthand flag",
 		"unknown flag",
 		"required flag",
+		"flag needs an argument",
 		"accepts ",
+		"requires at least",
 		"invalid argument",
 		"argument",
 	}
@@ -106,3 +194,20 @@ func defaultTimeoutFromEnv() time.Duration {
 
 	return defaultTimeout
 }
+
+func jsonFileCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"json"}, cobra.ShellCompDirectiveFilterFileExt
+}
+
+func dirCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveFilterDirs
+}
+
+func printJSON(v any) error {
+	body, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(body))
+	return nil
+}
@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRootExitCodes(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		code int
+	}{
+		{nil, 2},
+		{[]string{"--timeout", "1s"}, 2},
+		{[]string{"frobnicate"}, 2},
+		{[]string{"--help"}, 0},
+		{[]string{"help", "status"}, 0},
+	} {
+		var out bytes.Buffer
+		root := NewRootCmd()
+		root.SetOut(&out)
+		root.SetErr(&out)
+		root.SetArgs(tc.args)
+		if code := classifyError(root.Execute()); code != tc.code {
+			t.Errorf("qikchain %q: exit %d, want %d", tc.args, code, tc.code)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package cli
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/allocations"
+	"github.com/BioMark3r/qikchain/internal/txbuild"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+type unbondingOutput struct {
+	AmountWei  string `json:"amountWei"`
+	Amount     string `json:"amount"`
+	UnlockTime uint64 `json:"unlockTime"`
+	Unlocked   bool   `json:"unlocked"`
+	Remaining  string `json:"remaining,omitempty"`
+}
+
+type unbondingsOutput struct {
+	Operator        string            `json:"operator"`
+	Staker          string            `json:"staker"`
+	BlockNumber     uint64            `json:"blockNumber"`
+	BlockTime       uint64            `json:"blockTime"`
+	Entries         []unbondingOutput `json:"entries"`
+	UnlockedWei     string            `json:"unlockedWei"`
+	PendingWei      string            `json:"pendingWei"`
+	UnbondingPeriod uint64            `json:"unbondingPeriod"`
+}
+
+func newStakeCmd(cfg *Config) *cobra.Command {
+	var sf stakingFlags
+	cmd := &cobra.Command{
+		Use:   "stake",
+		Short: "Unbond stake, withdraw it after the unbonding period and show indexed history",
+	}
+	sf.bind(cmd.PersistentFlags())
+	_ = cmd.RegisterFlagCompletionFunc("deployments", jsonFileCompletion)
+
+	cmd.AddCommand(newStakeUnbondCmd(cfg, &sf))
+	cmd.AddCommand(newStakeUnbondingsCmd(cfg, &sf))
+	cmd.AddCommand(newStakeWithdrawCmd(cfg, &sf))
+	cmd.AddCommand(newStakeHistoryCmd(cfg))
+	return cmd
+}
+
+// loadUnbondings reads the unbonding queue of staker on operator and splits
+// it against the latest block timestamp, the time withdrawUnstaked would see
+// at the earliest.
+func loadUnbondings(cfg *Config, sf *stakingFlags, operator, staker common.Address, maxDecimals int) (*unbondingsOutput, error) {
+	_, caller, err := sf.caller(cfg)
+	if err != nil {
+		return nil, err
+	}
+	head, err := cfg.client().BlockByNumber("latest", false)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(uint64(head.Number))}
+	entries, err := caller.GetUnbondings(opts, operator, staker)
+	if err != nil {
+		return nil, fmt.Errorf("getUnbondings: %w", err)
+	}
+	period, err := caller.UnbondingPeriod(opts)
+	if err != nil {
+		return nil, fmt.Errorf("unbondingPeriod: %w", err)
+	}
+
+	now := uint64(head.Timestamp)
+	out := &unbondingsOutput{
+		Operator:        operator.Hex(),
+		Staker:          staker.Hex(),
+		BlockNumber:     uint64(head.Number),
+		BlockTime:       now,
+		Entries:         []unbondingOutput{},
+		UnbondingPeriod: period.Uint64(),
+	}
+	unlocked, pending := new(big.Int), new(big.Int)
+	for _, e := range entries {
+		entry := unbondingOutput{
+			AmountWei:  e.Amount.String(),
+			Amount:     allocations.FormatUnits(e.Amount, 18, maxDecimals),
+			UnlockTime: e.UnlockTime.Uint64(),
+		}
+		if entry.UnlockTime <= now {
+			entry.Unlocked = true
+			unlocked.Add(unlocked, e.Amount)
+		} else {
+			entry.Remaining = (time.Duration(entry.UnlockTime-now) * time.Second).String()
+			pending.Add(pending, e.Amount)
+		}
+		out.Entries = append(out.Entries, entry)
+	}
+	out.UnlockedWei = unlocked.String()
+	out.PendingWei = pending.String()
+	return out, nil
+}
+
+func printUnbondings(out *unbondingsOutput, maxDecimals int) {
+	fmt.Printf("operator %s staker %s at block %d (%s)\n", out.Operator, out.Staker, out.BlockNumber, time.Unix(int64(out.BlockTime), 0).UTC().Format(time.RFC3339))
+	if len(out.Entries) == 0 {
+		fmt.Println("no unbondings")
+		return
+	}
+	fmt.Printf("%-4s %24s  %-20s  %s\n", "#", "AMOUNT (QIK)", "UNLOCKS (UTC)", "REMAINING")
+	for i, e := range out.Entries {
+		remaining := "unlocked"
+		if !e.Unlocked {
+			remaining = e.Remaining
+		}
+		fmt.Printf("%-4d %24s  %-20s  %s\n", i, e.Amount, time.Unix(int64(e.UnlockTime), 0).UTC().Format(time.RFC3339), remaining)
+	}
+	unlocked, _ := new(big.Int).SetString(out.UnlockedWei, 10)
+	pending, _ := new(big.Int).SetString(out.PendingWei, 10)
+	fmt.Printf("withdrawable: %s QIK, pending: %s QIK (unbonding period %s)\n", allocations.FormatUnits(unlocked, 18, maxDecimals), allocations.FormatUnits(pending, 18, maxDecimals), time.Duration(out.UnbondingPeriod)*time.Second)
+}
+
+func newStakeUnbondCmd(cfg *Config, sf *stakingFlags) *cobra.Command {
+	var (
+		opts             txOptions
+		operator, amount string
+	)
+	cmd := &cobra.Command{
+		Use:   "unbond",
+		Short: "Start unbonding stake from an operator with requestUnstake",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operatorAddr, err := parseAddress(operator)
+			if err != nil {
+				return err
+			}
+			value, err := txbuild.ParseAmount(amount)
+			if err != nil {
+				return usageErrorf("--amount: %v", err)
+			}
+			if value.Sign() <= 0 {
+				return usageErrorf("--amount must be positive")
+			}
+			staking, caller, err := sf.caller(cfg)
+			if err != nil {
+				return fmt.Errorf("stake unbond: %w", err)
+			}
+			s, err := opts.newSigner(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("stake unbond: %w", err)
+			}
+			staked, err := caller.StakeOf(nil, operatorAddr, s.Address())
+			if err != nil {
+				return fmt.Errorf("stake unbond: stakeOf: %w", err)
+			}
+			if staked.Cmp(value) < 0 {
+				return fmt.Errorf("stake unbond: %s has %s QIK staked on %s, cannot unbond %s QIK", s.Address().Hex(), allocations.FormatUnits(staked, 18, 18), operatorAddr.Hex(), allocations.FormatUnits(value, 18, 18))
+			}
+
+			parsed, err := stakingABI()
+			if err != nil {
+				return err
+			}
+			data, err := parsed.Pack("requestUnstake", operatorAddr, value)
+			if err != nil {
+				return err
+			}
+			method := parsed.Methods["requestUnstake"]
+			out, err := sendContractTx(cmd, cfg, &opts, s, parsed, &method, staking, new(big.Int), data)
+			if out != nil {
+				if perr := printContractSend(cfg, out); perr != nil {
+					return perr
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("stake unbond: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&operator, "operator", "", "operator the stake is on")
+	cmd.Flags().StringVar(&amount, "amount", "", "amount to unbond (wei or e.g. 100qik)")
+	_ = cmd.MarkFlagRequired("operator")
+	_ = cmd.MarkFlagRequired("amount")
+	opts.bind(cmd)
+	return cmd
+}
+
+func newStakeUnbondingsCmd(cfg *Config, sf *stakingFlags) *cobra.Command {
+	var (
+		operator, staker string
+		maxDecimals      int
+	)
+	cmd := &cobra.Command{
+		Use:   "unbondings",
+		Short: "List pending unbondings with unlock times and time remaining",
+		Long:  "List the unbonding queue of --staker on --operator. Time remaining is measured against the latest block timestamp, not the local clock.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operatorAddr, err := parseAddress(operator)
+			if err != nil {
+				return err
+			}
+			stakerAddr, err := parseAddress(staker)
+			if err != nil {
+				return err
+			}
+			out, err := loadUnbondings(cfg, sf, operatorAddr, stakerAddr, maxDecimals)
+			if err != nil {
+				return fmt.Errorf("stake unbondings: %w", err)
+			}
+			if cfg.JSON {
+				return printJSON(out)
+			}
+			printUnbondings(out, maxDecimals)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&operator, "operator", "", "operator the stake was on")
+	cmd.Flags().StringVar(&staker, "staker", "", "staker address")
+	cmd.Flags().IntVar(&maxDecimals, "max-decimals", 6, "max fractional decimals in human output")
+	_ = cmd.MarkFlagRequired("operator")
+	_ = cmd.MarkFlagRequired("staker")
+	return cmd
+}
+
+func newStakeWithdrawCmd(cfg *Config, sf *stakingFlags) *cobra.Command {
+	var (
+		opts     txOptions
+		operator string
+	)
+	cmd := &cobra.Command{
+		Use:   "withdraw",
+		Short: "Withdraw unlocked unbondings with withdrawUnstaked",
+		Long:  "Withdraw every unlocked unbonding of the signer on --operator. Nothing is sent while no unbonding has unlocked, since the call would revert.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operatorAddr, err := parseAddress(operator)
+			if err != nil {
+				return err
+			}
+			staking, err := sf.resolve()
+			if err != nil {
+				return fmt.Errorf("stake withdraw: %w", err)
+			}
+			s, err := opts.newSigner(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("stake withdraw: %w", err)
+			}
+			queue, err := loadUnbondings(cfg, sf, operatorAddr, s.Address(), 6)
+			if err != nil {
+				return fmt.Errorf("stake withdraw: %w", err)
+			}
+			if queue.UnlockedWei == "0" {
+				if len(queue.Entries) == 0 {
+					return fmt.Errorf("stake withdraw: %s has no unbondings on %s", s.Address().Hex(), operatorAddr.Hex())
+				}
+				next := queue.Entries[0]
+				for _, e := range queue.Entries[1:] {
+					if e.UnlockTime < next.UnlockTime {
+						next = e
+					}
+				}
+				return fmt.Errorf("stake withdraw: nothing unlocked yet; next unbonding of %s QIK unlocks in %s", next.Amount, next.Remaining)
+			}
+
+			parsed, err := stakingABI()
+			if err != nil {
+				return err
+			}
+			data, err := parsed.Pack("withdrawUnstaked", operatorAddr)
+			if err != nil {
+				return err
+			}
+			method := parsed.Methods["withdrawUnstaked"]
+			out, err := sendContractTx(cmd, cfg, &opts, s, parsed, &method, staking, new(big.Int), data)
+			if out != nil {
+				if perr := printContractSend(cfg, out); perr != nil {
+					return perr
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("stake withdraw: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&operator, "operator", "", "operator the stake was on")
+	_ = cmd.MarkFlagRequired("operator")
+	opts.bind(cmd)
+	return cmd
+}
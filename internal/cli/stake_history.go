@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/allocations"
+	"github.com/BioMark3r/qikchain/internal/index"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+const defaultIndexPath = ".data/index.sqlite"
+
+type stakeHistoryOutput struct {
+	Address   string           `json:"address"`
+	Index     index.Status     `json:"index"`
+	Operator  []index.Operator `json:"operator,omitempty"`
+	Positions []index.Position `json:"positions"`
+	Events    []index.Event    `json:"events"`
+}
+
+func newStakeHistoryCmd(cfg *Config) *cobra.Command {
+	var (
+		dbPath      string
+		limit       int
+		before      uint64
+		maxDecimals int
+	)
+	cmd := &cobra.Command{
+		Use:   "history <address>",
+		Short: "Show indexed staking events and positions of an operator or staker",
+		Long:  "Read the index built by `qikchaind index staking`: the positions of the address as a staker, its operator record if it is one, and the staking events naming it, newest first. No archive node is needed.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := parseAddress(args[0])
+			if err != nil {
+				return err
+			}
+			store, err := index.OpenReadOnly(dbPath)
+			if err != nil {
+				return fmt.Errorf("stake history: %w (run qikchaind index staking --db %s)", err, dbPath)
+			}
+			defer store.Close()
+
+			out := stakeHistoryOutput{Address: addr.Hex()}
+			if out.Index, err = store.Status(); err != nil {
+				return fmt.Errorf("stake history: %w", err)
+			}
+			if out.Operator, err = store.Operators(&addr); err != nil {
+				return fmt.Errorf("stake history: %w", err)
+			}
+			if out.Positions, err = store.Positions(addr, false); err != nil {
+				return fmt.Errorf("stake history: %w", err)
+			}
+			if out.Events, err = store.History(addr, before, limit); err != nil {
+				return fmt.Errorf("stake history: %w", err)
+			}
+			if cfg.JSON {
+				return printJSON(out)
+			}
+			printStakeHistory(out, addr, maxDecimals)
+			return nil
+		},
+	}
+	path := os.Getenv("QIKCHAIN_INDEX_DB")
+	if path == "" {
+		path = defaultIndexPath
+	}
+	cmd.Flags().StringVar(&dbPath, "db", path, "SQLite index written by qikchaind index staking")
+	cmd.Flags().IntVar(&limit, "limit", 50, "max events to show; 0 for all")
+	cmd.Flags().Uint64Var(&before, "before", 0, "only show events before this block")
+	cmd.Flags().IntVar(&maxDecimals, "max-decimals", 6, "max fractional decimals in human output")
+	return cmd
+}
+
+func printStakeHistory(out stakeHistoryOutput, addr common.Address, maxDecimals int) {
+	qik := func(wei string) string {
+		v, ok := new(big.Int).SetString(wei, 10)
+		if !ok {
+			return wei
+		}
+		return allocations.FormatUnits(v, 18, maxDecimals)
+	}
+	if out.Index.Block == nil {
+		fmt.Println("index is empty")
+	} else {
+		fmt.Printf("index at block %d (%d events)\n", *out.Index.Block, out.Index.Events)
+	}
+	for _, o := range out.Operator {
+		fmt.Printf("operator (%s): staked %s QIK, unbonding %s QIK, withdrawn %s QIK, jailed %t\n", o.Source, qik(o.Staked), qik(o.Unbonding), qik(o.Withdrawn), o.Jailed)
+	}
+	if len(out.Positions) > 0 {
+		fmt.Printf("%-13s %-42s %20s %20s %20s\n", "SOURCE", "OPERATOR", "STAKED (QIK)", "UNBONDING (QIK)", "WITHDRAWN (QIK)")
+		for _, p := range out.Positions {
+			fmt.Printf("%-13s %-42s %20s %20s %20s\n", p.Source, p.Operator.Hex(), qik(p.Staked), qik(p.Unbonding), qik(p.Withdrawn))
+		}
+	}
+	if len(out.Events) == 0 {
+		fmt.Printf("no staking events for %s\n", addr.Hex())
+		return
+	}
+	fmt.Printf("%-8s %-20s %-20s %-42s %20s  %s\n", "BLOCK", "TIME (UTC)", "EVENT", "COUNTERPARTY", "AMOUNT (QIK)", "TX")
+	for _, e := range out.Events {
+		counterparty := ""
+		switch {
+		case e.Operator != nil && *e.Operator != addr:
+			counterparty = e.Operator.Hex()
+		case e.Staker != nil && *e.Staker != addr:
+			counterparty = e.Staker.Hex()
+		}
+		amount := ""
+		if e.Amount != "" {
+			amount = qik(e.Amount)
+		}
+		fmt.Printf("%-8d %-20s %-20s %-42s %20s  %s\n", e.Block, time.Unix(int64(e.Time), 0).UTC().Format("2006-01-02 15:04:05"), e.Name, counterparty, amount, e.Tx.Hex())
+	}
+}
@@ -25,7 +25,7 @@ func newStatusCmd(cfg *Config) *cobra.Command {
 		Use:   "status",
 		Short: "Show basic chain status",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := rpc.NewClient(cfg.RPCURL, cfg.Timeout)
+			client := cfg.client()
 
 			chainHex, err := client.CallString("eth_chainId")
 			if err != nil {
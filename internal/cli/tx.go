@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/BioMark3r/qikchain/internal/abiutil"
+	"github.com/BioMark3r/qikchain/internal/allocations"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+func newTxCmd(cfg *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Transaction commands",
+	}
+
+	cmd.AddCommand(newTxGetCmd(cfg))
+	cmd.AddCommand(newTxReceiptCmd(cfg))
+	cmd.AddCommand(newTxSendCmd(cfg))
+	return cmd
+}
+
+func parseTxHash(arg string) (common.Hash, error) {
+	b, err := hexutil.Decode(arg)
+	if err != nil || len(b) != common.HashLength {
+		return common.Hash{}, usageErrorf("invalid transaction hash %q", arg)
+	}
+	return common.BytesToHash(b), nil
+}
+
+func newTxGetCmd(cfg *Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <hash>",
+		Short: "Show a transaction",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hash, err := parseTxHash(args[0])
+			if err != nil {
+				return err
+			}
+			client := cfg.client()
+			tx, err := client.TransactionByHash(hash)
+			if err != nil {
+				return fmt.Errorf("tx get: %w", err)
+			}
+			if cfg.JSON {
+				return printJSON(tx)
+			}
+
+			fmt.Printf("hash:      %s\n", tx.Hash.Hex())
+			if tx.Type != nil {
+				fmt.Printf("type:      %d\n", uint64(*tx.Type))
+			}
+			if tx.BlockNumber == nil {
+				fmt.Println("block:     pending")
+			} else {
+				fmt.Printf("block:     %d (%s) index=%d\n", uint64(*tx.BlockNumber), tx.BlockHash.Hex(), uint64Value(tx.TransactionIndex))
+			}
+			fmt.Printf("from:      %s\n", tx.From.Hex())
+			if tx.To == nil {
+				fmt.Println("to:        (contract creation)")
+			} else {
+				fmt.Printf("to:        %s\n", tx.To.Hex())
+			}
+			fmt.Printf("nonce:     %d\n", uint64(tx.Nonce))
+			fmt.Printf("value:     %s wei (%s QIK)\n", bigString(tx.Value), formatWeiQIK(tx.Value))
+			fmt.Printf("gas:       %d\n", uint64(tx.Gas))
+			if tx.MaxFeePerGas != nil {
+				fmt.Printf("maxFee:    %s wei\n", bigString(tx.MaxFeePerGas))
+				fmt.Printf("maxTip:    %s wei\n", bigString(tx.MaxPriorityFeePerGas))
+			} else if tx.GasPrice != nil {
+				fmt.Printf("gasPrice:  %s wei\n", bigString(tx.GasPrice))
+			}
+			fmt.Printf("input:     %d bytes", len(tx.Input))
+			if len(tx.Input) >= 4 {
+				fmt.Printf(" selector=%s", hexutil.Encode(tx.Input[:4]))
+			}
+			fmt.Println()
+			return nil
+		},
+	}
+}
+
+type txReceiptOutput struct {
+	*rpc.Receipt
+	Events []decodedLog `json:"events,omitempty"`
+}
+
+func newTxReceiptCmd(cfg *Config) *cobra.Command {
+	var abiPaths []string
+	cmd := &cobra.Command{
+		Use:   "receipt <hash>",
+		Short: "Show a transaction receipt",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hash, err := parseTxHash(args[0])
+			if err != nil {
+				return err
+			}
+			var abis []*abi.ABI
+			for _, path := range abiPaths {
+				parsed, err := abiutil.Load(path)
+				if err != nil {
+					return fmt.Errorf("tx receipt: %w", err)
+				}
+				abis = append(abis, parsed)
+			}
+			client := cfg.client()
+			receipt, err := client.TransactionReceipt(hash)
+			if err != nil {
+				return fmt.Errorf("tx receipt: %w", err)
+			}
+			events := decodeLogs(abis, receipt.Logs)
+			if cfg.JSON {
+				return printJSON(txReceiptOutput{Receipt: receipt, Events: events})
+			}
+
+			status := "unknown"
+			if receipt.Status != nil {
+				status = "failed"
+				if *receipt.Status == 1 {
+					status = "success"
+				}
+			}
+			fmt.Printf("hash:      %s\n", receipt.TransactionHash.Hex())
+			fmt.Printf("status:    %s\n", status)
+			fmt.Printf("block:     %d (%s) index=%d\n", uint64(receipt.BlockNumber), receipt.BlockHash.Hex(), uint64(receipt.TransactionIndex))
+			fmt.Printf("from:      %s\n", receipt.From.Hex())
+			if receipt.To != nil {
+				fmt.Printf("to:        %s\n", receipt.To.Hex())
+			}
+			if receipt.ContractAddress != nil && *receipt.ContractAddress != (common.Address{}) {
+				fmt.Printf("contract:  %s\n", receipt.ContractAddress.Hex())
+			}
+			fmt.Printf("gasUsed:   %d (cumulative %d)\n", uint64(receipt.GasUsed), uint64(receipt.CumulativeGasUsed))
+			if receipt.EffectiveGasPrice != nil {
+				fmt.Printf("gasPrice:  %s wei\n", bigString(receipt.EffectiveGasPrice))
+			}
+			fmt.Printf("logs:      %d\n", len(receipt.Logs))
+			for _, l := range receipt.Logs {
+				fmt.Printf("  [%d] %s\n", uint64(l.LogIndex), l.Address.Hex())
+				for i, topic := range l.Topics {
+					fmt.Printf("      topic%d: %s\n", i, topic.Hex())
+				}
+				if len(l.Data) > 0 {
+					fmt.Printf("      data:   %s\n", hexutil.Encode(l.Data))
+				}
+			}
+			if len(events) > 0 {
+				fmt.Printf("events:    %d\n", len(events))
+				printEvents(events)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVar(&abiPaths, "abi", nil, "ABI files used to decode log events (repeatable)")
+	_ = cmd.RegisterFlagCompletionFunc("abi", jsonFileCompletion)
+	return cmd
+}
+
+func formatWeiQIK(v *hexutil.Big) string {
+	if v == nil {
+		return "0"
+	}
+	return allocations.FormatQIK(v.ToInt(), 6)
+}
+
+func uint64Value(v *hexutil.Uint64) uint64 {
+	if v == nil {
+		return 0
+	}
+	return uint64(*v)
+}
@@ -0,0 +1,362 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/BioMark3r/qikchain/internal/signer"
+	"github.com/BioMark3r/qikchain/internal/txbuild"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+const privateKeyEnv = "QIKCHAIN_PRIVATE_KEY"
+
+// txOptions holds the signer, fee and submission flags shared by every
+// transaction-producing command.
+type txOptions struct {
+	signer signer.Config
+
+	txType         string
+	feeStrategy    string
+	feeMultiplier  float64
+	feePercentile  float64
+	feeBlocks      int
+	gasPrice       string
+	maxFee         string
+	priorityFee    string
+	accessListPath string
+	gas            uint64
+	nonce          uint64
+	dryRun         bool
+	wait           bool
+	waitTimeout    time.Duration
+
+	// beforeSend, when set, runs after signing and before broadcast.
+	beforeSend func(out *txSendOutput) error
+}
+
+type txSendOutput struct {
+	From                 string  `json:"from"`
+	To                   string  `json:"to,omitempty"`
+	Type                 uint8   `json:"type"`
+	Nonce                uint64  `json:"nonce"`
+	Gas                  uint64  `json:"gas"`
+	GasPrice             string  `json:"gasPrice,omitempty"`
+	MaxFeePerGas         string  `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string  `json:"maxPriorityFeePerGas,omitempty"`
+	Value                string  `json:"value"`
+	Hash                 string  `json:"hash"`
+	Raw                  string  `json:"raw,omitempty"`
+	Sent                 bool    `json:"sent"`
+	Status               *uint64 `json:"status,omitempty"`
+	BlockNumber          *uint64 `json:"blockNumber,omitempty"`
+	GasUsed              *uint64 `json:"gasUsed,omitempty"`
+	ContractAddress      string  `json:"contractAddress,omitempty"`
+
+	receipt *rpc.Receipt
+}
+
+func (o *txOptions) bind(cmd *cobra.Command) {
+	o.bindSigner(cmd)
+	o.bindFees(cmd)
+	flags := cmd.Flags()
+	flags.Uint64Var(&o.gas, "gas", 0, "gas limit (estimated when 0)")
+	flags.Uint64Var(&o.nonce, "nonce", 0, "nonce (pending nonce of the sender when unset)")
+	flags.BoolVar(&o.dryRun, "dry-run", false, "sign and print the raw transaction without broadcasting it")
+	flags.BoolVar(&o.wait, "wait", true, "wait for the receipt")
+	flags.DurationVar(&o.waitTimeout, "wait-timeout", 60*time.Second, "how long to wait for the receipt")
+}
+
+// bindSigner registers the signer flags. The local key is read from
+// privateKeyEnv unless the command set another variable first.
+func (o *txOptions) bindSigner(cmd *cobra.Command) {
+	if o.signer.KeyEnv == "" {
+		o.signer.KeyEnv = privateKeyEnv
+	}
+	o.signer.BindFlags(cmd.Flags())
+	_ = cmd.RegisterFlagCompletionFunc("key-backend", cobra.FixedCompletions(signer.Backends, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("keystore", dirCompletion)
+}
+
+// bindFees registers the transaction type and fee flags only, for commands
+// that sign with keys of their own.
+func (o *txOptions) bindFees(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.txType, "type", "auto", "transaction type: auto|legacy|2930|1559 (auto picks legacy without a base fee)")
+	flags.StringVar(&o.feeStrategy, "fee-strategy", txbuild.StrategyMultiplier, "fee strategy: multiplier|percentile|fixed")
+	flags.Float64Var(&o.feeMultiplier, "fee-multiplier", 1, "multiplier: scale the node's gas price or tip suggestion")
+	flags.Float64Var(&o.feePercentile, "fee-percentile", 60, "percentile: percentile of recent prices or tips")
+	flags.IntVar(&o.feeBlocks, "fee-blocks", 20, "percentile: number of recent blocks to sample")
+	flags.StringVar(&o.gasPrice, "gas-price", "", "fixed: gas price for legacy and 2930 transactions (wei or e.g. 2gwei)")
+	flags.StringVar(&o.maxFee, "max-fee", "", "fixed: EIP-1559 max fee per gas (default 2*baseFee+priority fee)")
+	flags.StringVar(&o.priorityFee, "priority-fee", "", "fixed: EIP-1559 max priority fee per gas")
+	flags.StringVar(&o.accessListPath, "access-list", "", "JSON access list file for 2930 and 1559 transactions")
+	_ = cmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions([]string{"auto", "legacy", "2930", "1559"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("fee-strategy", cobra.FixedCompletions(txbuild.Strategies, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("access-list", jsonFileCompletion)
+}
+
+func (o *txOptions) newSigner(cmd *cobra.Command, cfg *Config) (signer.Signer, error) {
+	o.signer.Timeout = cfg.Timeout
+	return signer.New(cmd.Context(), o.signer)
+}
+
+func parseOptionalAmount(name, v string) (*big.Int, error) {
+	if v == "" {
+		return nil, nil
+	}
+	amount, err := txbuild.ParseAmount(v)
+	if err != nil {
+		return nil, usageErrorf("--%s: %v", name, err)
+	}
+	return amount, nil
+}
+
+// sendTx builds, signs and (unless --dry-run) broadcasts a transaction from s.
+func sendTx(cmd *cobra.Command, cfg *Config, o *txOptions, s signer.Signer, to *common.Address, value *big.Int, data []byte) (*txSendOutput, error) {
+	client := cfg.client()
+	from := s.Address()
+
+	chainID, err := client.ChainID()
+	if err != nil {
+		return nil, err
+	}
+	head, err := client.BlockByNumber("latest", false)
+	if err != nil {
+		return nil, err
+	}
+	var baseFee *big.Int
+	if head.BaseFee != nil && head.BaseFee.ToInt().Sign() > 0 {
+		baseFee = head.BaseFee.ToInt()
+	}
+	txType, err := txbuild.SelectType(o.txType, baseFee)
+	if err != nil {
+		return nil, usageErrorf("%v", err)
+	}
+
+	var accessList types.AccessList
+	if o.accessListPath != "" {
+		b, err := os.ReadFile(o.accessListPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &accessList); err != nil {
+			return nil, fmt.Errorf("parse access list: %w", err)
+		}
+	}
+
+	feeOpts := txbuild.FeeOptions{
+		Strategy:   o.feeStrategy,
+		Multiplier: o.feeMultiplier,
+		Percentile: o.feePercentile,
+		Blocks:     o.feeBlocks,
+	}
+	if feeOpts.GasPrice, err = parseOptionalAmount("gas-price", o.gasPrice); err != nil {
+		return nil, err
+	}
+	if feeOpts.MaxFee, err = parseOptionalAmount("max-fee", o.maxFee); err != nil {
+		return nil, err
+	}
+	if feeOpts.PriorityFee, err = parseOptionalAmount("priority-fee", o.priorityFee); err != nil {
+		return nil, err
+	}
+	fees, err := txbuild.SuggestFees(client, txType, baseFee, feeOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := o.nonce
+	if !cmd.Flags().Changed("nonce") {
+		if nonce, err = client.NonceAt(from, "pending"); err != nil {
+			return nil, fmt.Errorf("fetch nonce: %w", err)
+		}
+	}
+
+	gas := o.gas
+	if gas == 0 {
+		msg := rpc.CallMsg{From: from, To: to, Value: (*hexutil.Big)(value), Data: data}
+		if len(accessList) > 0 {
+			msg.AccessList = &accessList
+		}
+		if gas, err = client.EstimateGas(msg); err != nil {
+			return nil, fmt.Errorf("estimate gas: %w", err)
+		}
+	}
+
+	tx, err := txbuild.Build(txbuild.Request{
+		ChainID:    chainID,
+		Type:       txType,
+		Nonce:      nonce,
+		To:         to,
+		Value:      value,
+		Data:       data,
+		Gas:        gas,
+		Fees:       fees,
+		AccessList: accessList,
+	})
+	if err != nil {
+		return nil, usageErrorf("%v", err)
+	}
+	signed, err := s.SignTx(cmd.Context(), tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	out := &txSendOutput{
+		From:  from.Hex(),
+		Type:  signed.Type(),
+		Nonce: signed.Nonce(),
+		Gas:   signed.Gas(),
+		Value: signed.Value().String(),
+		Hash:  signed.Hash().Hex(),
+	}
+	if to != nil {
+		out.To = to.Hex()
+	}
+	if txType == types.DynamicFeeTxType {
+		out.MaxFeePerGas = signed.GasFeeCap().String()
+		out.MaxPriorityFeePerGas = signed.GasTipCap().String()
+	} else {
+		out.GasPrice = signed.GasPrice().String()
+	}
+
+	if o.dryRun {
+		raw, err := signed.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out.Raw = hexutil.Encode(raw)
+		return out, nil
+	}
+
+	if o.beforeSend != nil {
+		if err := o.beforeSend(out); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := client.SendRawTransaction(signed); err != nil {
+		return nil, fmt.Errorf("send: %w", err)
+	}
+	out.Sent = true
+	if !o.wait {
+		return out, nil
+	}
+	receipt, err := client.WaitForReceipt(signed.Hash(), o.waitTimeout, time.Second)
+	if err != nil {
+		return out, err
+	}
+	out.receipt = receipt
+	status, block, used := uint64Value(receipt.Status), uint64(receipt.BlockNumber), uint64(receipt.GasUsed)
+	out.Status, out.BlockNumber, out.GasUsed = &status, &block, &used
+	if receipt.ContractAddress != nil && *receipt.ContractAddress != (common.Address{}) {
+		out.ContractAddress = receipt.ContractAddress.Hex()
+	}
+	if status != types.ReceiptStatusSuccessful {
+		return out, fmt.Errorf("transaction %s reverted in block %d", out.Hash, block)
+	}
+	return out, nil
+}
+
+func printTxSend(cfg *Config, out *txSendOutput) error {
+	if cfg.JSON {
+		return printJSON(out)
+	}
+	fmt.Printf("from:      %s\n", out.From)
+	if out.To != "" {
+		fmt.Printf("to:        %s\n", out.To)
+	}
+	fmt.Printf("type:      %d\n", out.Type)
+	fmt.Printf("nonce:     %d\n", out.Nonce)
+	fmt.Printf("gas:       %d\n", out.Gas)
+	if out.GasPrice != "" {
+		fmt.Printf("gasPrice:  %s wei\n", out.GasPrice)
+	} else {
+		fmt.Printf("maxFee:    %s wei\n", out.MaxFeePerGas)
+		fmt.Printf("maxTip:    %s wei\n", out.MaxPriorityFeePerGas)
+	}
+	fmt.Printf("value:     %s wei\n", out.Value)
+	fmt.Printf("hash:      %s\n", out.Hash)
+	if out.Raw != "" {
+		fmt.Printf("raw:       %s\n", out.Raw)
+		fmt.Println("dry run: not broadcast")
+		return nil
+	}
+	if out.Status != nil {
+		status := "success"
+		if *out.Status != types.ReceiptStatusSuccessful {
+			status = "failed"
+		}
+		fmt.Printf("status:    %s (block %d, gasUsed %d)\n", status, *out.BlockNumber, *out.GasUsed)
+	}
+	if out.ContractAddress != "" {
+		fmt.Printf("contract:  %s\n", out.ContractAddress)
+	}
+	return nil
+}
+
+func newTxSendCmd(cfg *Config) *cobra.Command {
+	var (
+		opts  txOptions
+		toArg string
+		value string
+		data  string
+	)
+	cmd := &cobra.Command{
+		Use:   "send",
+		Short: "Sign and send a transaction",
+		Long:  "Sign and send a legacy, EIP-2930 or EIP-1559 transaction. Without --to the transaction creates a contract from --data.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var to *common.Address
+			if toArg != "" {
+				addr, err := parseAddress(toArg)
+				if err != nil {
+					return err
+				}
+				to = &addr
+			}
+			amount, err := txbuild.ParseAmount(value)
+			if err != nil {
+				return usageErrorf("--value: %v", err)
+			}
+			payload, err := hexutil.Decode(orHexEmpty(data))
+			if err != nil {
+				return usageErrorf("--data: %v", err)
+			}
+
+			s, err := opts.newSigner(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("tx send: %w", err)
+			}
+			out, err := sendTx(cmd, cfg, &opts, s, to, amount, payload)
+			if out != nil {
+				if perr := printTxSend(cfg, out); perr != nil {
+					return perr
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("tx send: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&toArg, "to", "", "recipient address (omit to create a contract)")
+	cmd.Flags().StringVar(&value, "value", "0", "value in wei, or with a unit such as 1.5qik or 20gwei")
+	cmd.Flags().StringVar(&data, "data", "", "hex call data or contract init code")
+	opts.bind(cmd)
+	return cmd
+}
+
+func orHexEmpty(s string) string {
+	if s == "" {
+		return "0x"
+	}
+	return s
+}
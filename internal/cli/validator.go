@@ -0,0 +1,500 @@
+package cli
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BioMark3r/qikchain/internal/allocations"
+	"github.com/BioMark3r/qikchain/internal/contracts"
+	"github.com/BioMark3r/qikchain/internal/genesis"
+	"github.com/BioMark3r/qikchain/internal/pos"
+	"github.com/BioMark3r/qikchain/internal/txbuild"
+	"github.com/BioMark3r/qikchain/internal/wallet"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+const defaultDeploymentsPath = "build/deployments/pos.local.json"
+
+// Files written by validator init, relative to --data-dir. The key lives where
+// polygon-edge secrets init puts it so the node can run from the same dir.
+const (
+	validatorKeyFile    = "consensus/validator.key"
+	consensusKeyFile    = "consensus.key"
+	validatorConfigFile = "validator.json"
+)
+
+// stakingFlags locate the staking contract: --staking wins over the
+// deployments file written by the PoS deploy script.
+type stakingFlags struct {
+	address     string
+	deployments string
+}
+
+func (sf *stakingFlags) bind(flags *cobra.FlagSet) {
+	deployments := os.Getenv("POS_DEPLOYMENTS_FILE")
+	if deployments == "" {
+		deployments = defaultDeploymentsPath
+	}
+	flags.StringVar(&sf.address, "staking", "", "staking contract address (default: staking.address from --deployments)")
+	flags.StringVar(&sf.deployments, "deployments", deployments, "PoS deployments file")
+}
+
+func (sf *stakingFlags) resolve() (common.Address, error) {
+	if sf.address != "" {
+		return parseAddress(sf.address)
+	}
+	addrs, err := genesis.LoadPOSAddresses(sf.deployments)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w (or pass --staking)", err)
+	}
+	return common.HexToAddress(addrs.Staking), nil
+}
+
+func (sf *stakingFlags) caller(cfg *Config) (common.Address, *contracts.IQikStakingCaller, error) {
+	addr, err := sf.resolve()
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	caller, err := contracts.NewIQikStakingCaller(addr, contracts.RPCCaller{Client: cfg.client()})
+	return addr, caller, err
+}
+
+// validatorConfig is the validator.json written by validator init.
+type validatorConfig struct {
+	ValidatorAddress string `json:"validatorAddress"`
+	ConsensusKey     string `json:"consensusKey"`
+	Payout           string `json:"payout"`
+	Operator         string `json:"operator,omitempty"`
+}
+
+type validatorInitOutput struct {
+	DataDir string `json:"dataDir"`
+	KeyFile string `json:"keyFile"`
+	Created bool   `json:"created"`
+	validatorConfig
+}
+
+type validatorStatusOutput struct {
+	Operator         string `json:"operator"`
+	Registered       bool   `json:"registered"`
+	Jailed           bool   `json:"jailed"`
+	Payout           string `json:"payout,omitempty"`
+	ConsensusKey     string `json:"consensusKey,omitempty"`
+	ValidatorAddress string `json:"validatorAddress,omitempty"`
+	TotalStakeWei    string `json:"totalStakeWei"`
+	MinStakeWei      string `json:"minStakeWei"`
+	Active           bool   `json:"active"`
+	ActiveRank       int    `json:"activeRank,omitempty"`
+	ActiveSetSize    int    `json:"activeSetSize"`
+	MaxValidators    uint64 `json:"maxValidators"`
+	BlockNumber      uint64 `json:"blockNumber"`
+}
+
+func newValidatorCmd(cfg *Config) *cobra.Command {
+	var sf stakingFlags
+	cmd := &cobra.Command{
+		Use:   "validator",
+		Short: "Set up, register, stake and inspect PoS validators",
+	}
+	sf.bind(cmd.PersistentFlags())
+	_ = cmd.RegisterFlagCompletionFunc("deployments", jsonFileCompletion)
+
+	cmd.AddCommand(newValidatorInitCmd(cfg))
+	cmd.AddCommand(newValidatorRegisterCmd(cfg, &sf))
+	cmd.AddCommand(newValidatorStakeCmd(cfg, &sf))
+	cmd.AddCommand(newValidatorStatusCmd(cfg, &sf))
+	cmd.AddCommand(newValidatorUptimeCmd(cfg, &sf))
+	return cmd
+}
+
+// consensusKey is the value registered on chain for a validator key: its
+// compressed secp256k1 public key.
+func consensusKey(key *ecdsa.PrivateKey) []byte {
+	return crypto.CompressPubkey(&key.PublicKey)
+}
+
+// validatorAddress derives the IBFT validator address from a registered
+// consensus key, or returns "" when the key is not a secp256k1 public key.
+func validatorAddress(key []byte) string {
+	addr, ok := pos.ValidatorAddress(key)
+	if !ok {
+		return ""
+	}
+	return addr.Hex()
+}
+
+func loadValidatorConfig(dataDir string) (validatorConfig, error) {
+	var vc validatorConfig
+	data, err := os.ReadFile(filepath.Join(dataDir, validatorConfigFile))
+	if err != nil {
+		return vc, fmt.Errorf("%w (run qikchain validator init)", err)
+	}
+	if err := json.Unmarshal(data, &vc); err != nil {
+		return vc, fmt.Errorf("parse %s: %w", validatorConfigFile, err)
+	}
+	return vc, nil
+}
+
+func newValidatorInitCmd(cfg *Config) *cobra.Command {
+	var dataDir, payout, operator string
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Generate the consensus key and write the node secrets and payout config",
+		Long: "Generate the validator consensus key in <data-dir>/" + validatorKeyFile + " (the polygon-edge secrets layout, unencrypted like secrets init --insecure), " +
+			"write the public consensus key to register to <data-dir>/" + consensusKeyFile + " and the payout config to <data-dir>/" + validatorConfigFile + ". " +
+			"An existing key is kept, so init can be rerun to change the payout.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			payoutAddr, err := parseAddress(payout)
+			if err != nil {
+				return fmt.Errorf("--payout: %w", err)
+			}
+			if payoutAddr == (common.Address{}) {
+				return usageErrorf("--payout must not be the zero address")
+			}
+			vc := validatorConfig{Payout: payoutAddr.Hex()}
+			if operator != "" {
+				operatorAddr, err := parseAddress(operator)
+				if err != nil {
+					return fmt.Errorf("--operator: %w", err)
+				}
+				vc.Operator = operatorAddr.Hex()
+			}
+
+			out := validatorInitOutput{DataDir: dataDir, KeyFile: filepath.Join(dataDir, validatorKeyFile)}
+			key, err := loadValidatorKey(out.KeyFile)
+			if errors.Is(err, os.ErrNotExist) {
+				if key, err = crypto.GenerateKey(); err != nil {
+					return fmt.Errorf("validator init: %w", err)
+				}
+				if err := writeFile(out.KeyFile, []byte(hex.EncodeToString(crypto.FromECDSA(key))), 0o600); err != nil {
+					return fmt.Errorf("validator init: %w", err)
+				}
+				out.Created = true
+			} else if err != nil {
+				return fmt.Errorf("validator init: %w", err)
+			}
+
+			vc.ConsensusKey = hexutil.Encode(consensusKey(key))
+			vc.ValidatorAddress = crypto.PubkeyToAddress(key.PublicKey).Hex()
+			if err := writeFile(filepath.Join(dataDir, consensusKeyFile), []byte(vc.ConsensusKey+"\n"), 0o644); err != nil {
+				return fmt.Errorf("validator init: %w", err)
+			}
+			body, err := json.MarshalIndent(vc, "", "  ")
+			if err != nil {
+				return err
+			}
+			if err := writeFile(filepath.Join(dataDir, validatorConfigFile), append(body, '\n'), 0o644); err != nil {
+				return fmt.Errorf("validator init: %w", err)
+			}
+			out.validatorConfig = vc
+
+			if cfg.JSON {
+				return printJSON(out)
+			}
+			if out.Created {
+				fmt.Printf("generated:     %s\n", out.KeyFile)
+			} else {
+				fmt.Printf("kept:          %s\n", out.KeyFile)
+			}
+			fmt.Printf("validator:     %s\n", vc.ValidatorAddress)
+			fmt.Printf("consensus key: %s\n", vc.ConsensusKey)
+			fmt.Printf("payout:        %s\n", vc.Payout)
+			if vc.Operator != "" {
+				fmt.Printf("operator:      %s\n", vc.Operator)
+			}
+			fmt.Printf("next:          qikchain validator register --data-dir %s\n", dataDir)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dataDir, "data-dir", "", "validator data directory")
+	cmd.Flags().StringVar(&payout, "payout", "", "address receiving the validator's payouts")
+	cmd.Flags().StringVar(&operator, "operator", "", "operator account that will register the validator (recorded and checked by register)")
+	_ = cmd.MarkFlagRequired("data-dir")
+	_ = cmd.MarkFlagRequired("payout")
+	_ = cmd.RegisterFlagCompletionFunc("data-dir", dirCompletion)
+	return cmd
+}
+
+func loadValidatorKey(path string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := wallet.ParsePrivateKey(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, perm)
+}
+
+func stakingABI() (*abi.ABI, error) {
+	return contracts.IQikStakingMetaData.GetAbi()
+}
+
+func newValidatorRegisterCmd(cfg *Config, sf *stakingFlags) *cobra.Command {
+	var (
+		opts                 txOptions
+		dataDir, key, payout string
+	)
+	cmd := &cobra.Command{
+		Use:   "register",
+		Short: "Register the signer as an operator with registerOperator(consensusKey, payout)",
+		Long:  "Register the signer as an operator. The consensus key and payout come from <data-dir>/" + validatorConfigFile + " unless --consensus-key or --payout is given.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var vc validatorConfig
+			if dataDir != "" {
+				var err error
+				if vc, err = loadValidatorConfig(dataDir); err != nil {
+					return fmt.Errorf("validator register: %w", err)
+				}
+			}
+			if key != "" {
+				vc.ConsensusKey = key
+			}
+			if payout != "" {
+				vc.Payout = payout
+			}
+			if vc.ConsensusKey == "" || vc.Payout == "" {
+				return usageErrorf("validator register: pass --data-dir or both --consensus-key and --payout")
+			}
+			keyBytes, err := hexutil.Decode(strings.TrimSpace(vc.ConsensusKey))
+			if err != nil || len(keyBytes) == 0 {
+				return usageErrorf("validator register: invalid consensus key %q", vc.ConsensusKey)
+			}
+			payoutAddr, err := parseAddress(vc.Payout)
+			if err != nil {
+				return err
+			}
+
+			staking, caller, err := sf.caller(cfg)
+			if err != nil {
+				return fmt.Errorf("validator register: %w", err)
+			}
+			s, err := opts.newSigner(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("validator register: %w", err)
+			}
+			if vc.Operator != "" && !strings.EqualFold(vc.Operator, s.Address().Hex()) {
+				return fmt.Errorf("validator register: signer %s is not the operator %s recorded in %s", s.Address().Hex(), vc.Operator, validatorConfigFile)
+			}
+			info, err := caller.GetOperator(nil, s.Address())
+			if err != nil {
+				return fmt.Errorf("validator register: getOperator: %w", err)
+			}
+			if info.Registered {
+				return fmt.Errorf("validator register: operator %s is already registered", s.Address().Hex())
+			}
+
+			parsed, err := stakingABI()
+			if err != nil {
+				return err
+			}
+			data, err := parsed.Pack("registerOperator", keyBytes, payoutAddr)
+			if err != nil {
+				return err
+			}
+			method := parsed.Methods["registerOperator"]
+			out, err := sendContractTx(cmd, cfg, &opts, s, parsed, &method, staking, new(big.Int), data)
+			if out != nil {
+				if perr := printContractSend(cfg, out); perr != nil {
+					return perr
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("validator register: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dataDir, "data-dir", "", "validator data directory written by validator init")
+	cmd.Flags().StringVar(&key, "consensus-key", "", "hex consensus key (overrides --data-dir)")
+	cmd.Flags().StringVar(&payout, "payout", "", "payout address (overrides --data-dir)")
+	_ = cmd.RegisterFlagCompletionFunc("data-dir", dirCompletion)
+	opts.bind(cmd)
+	return cmd
+}
+
+func newValidatorStakeCmd(cfg *Config, sf *stakingFlags) *cobra.Command {
+	var (
+		opts                     txOptions
+		amount, operator, staker string
+	)
+	cmd := &cobra.Command{
+		Use:   "stake",
+		Short: "Stake on an operator with stake, or stakeFor with --for",
+		Long:  "Stake --amount on --operator (default: the signer). With --for the stake is credited to another staker via stakeFor.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := txbuild.ParseAmount(amount)
+			if err != nil {
+				return usageErrorf("--amount: %v", err)
+			}
+			if value.Sign() <= 0 {
+				return usageErrorf("--amount must be positive")
+			}
+			staking, err := sf.resolve()
+			if err != nil {
+				return fmt.Errorf("validator stake: %w", err)
+			}
+			s, err := opts.newSigner(cmd, cfg)
+			if err != nil {
+				return fmt.Errorf("validator stake: %w", err)
+			}
+			operatorAddr := s.Address()
+			if operator != "" {
+				if operatorAddr, err = parseAddress(operator); err != nil {
+					return err
+				}
+			}
+
+			parsed, err := stakingABI()
+			if err != nil {
+				return err
+			}
+			name := "stake"
+			callArgs := []any{operatorAddr}
+			if staker != "" {
+				stakerAddr, err := parseAddress(staker)
+				if err != nil {
+					return err
+				}
+				name = "stakeFor"
+				callArgs = append(callArgs, stakerAddr)
+			}
+			data, err := parsed.Pack(name, callArgs...)
+			if err != nil {
+				return err
+			}
+			method := parsed.Methods[name]
+			out, err := sendContractTx(cmd, cfg, &opts, s, parsed, &method, staking, value, data)
+			if out != nil {
+				if perr := printContractSend(cfg, out); perr != nil {
+					return perr
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("validator stake: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&amount, "amount", "", "amount to stake (wei or e.g. 1000qik)")
+	cmd.Flags().StringVar(&operator, "operator", "", "operator to stake on (default: the signer)")
+	cmd.Flags().StringVar(&staker, "for", "", "credit the stake to this staker with stakeFor")
+	_ = cmd.MarkFlagRequired("amount")
+	opts.bind(cmd)
+	return cmd
+}
+
+func newValidatorStatusCmd(cfg *Config, sf *stakingFlags) *cobra.Command {
+	var maxDecimals int
+	cmd := &cobra.Command{
+		Use:   "status <operator>",
+		Short: "Show registration, jailed state, stake and active-set membership",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operator, err := parseAddress(args[0])
+			if err != nil {
+				return err
+			}
+			_, caller, err := sf.caller(cfg)
+			if err != nil {
+				return fmt.Errorf("validator status: %w", err)
+			}
+			head, err := cfg.client().BlockByNumber("latest", false)
+			if err != nil {
+				return fmt.Errorf("validator status: %w", err)
+			}
+			// Pin every read to one block so the fields agree with each other.
+			opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(uint64(head.Number))}
+
+			info, err := caller.GetOperator(opts, operator)
+			if err != nil {
+				return fmt.Errorf("validator status: getOperator: %w", err)
+			}
+			active, err := caller.IsActiveOperator(opts, operator)
+			if err != nil {
+				return fmt.Errorf("validator status: isActiveOperator: %w", err)
+			}
+			set, err := caller.GetActiveOperators(opts)
+			if err != nil {
+				return fmt.Errorf("validator status: getActiveOperators: %w", err)
+			}
+			minStake, err := caller.MinStake(opts)
+			if err != nil {
+				return fmt.Errorf("validator status: minStake: %w", err)
+			}
+			maxValidators, err := caller.MaxValidators(opts)
+			if err != nil {
+				return fmt.Errorf("validator status: maxValidators: %w", err)
+			}
+
+			out := validatorStatusOutput{
+				Operator:      operator.Hex(),
+				Registered:    info.Registered,
+				Jailed:        info.Jailed,
+				TotalStakeWei: info.TotalStake.String(),
+				MinStakeWei:   minStake.String(),
+				Active:        active,
+				ActiveSetSize: len(set),
+				MaxValidators: maxValidators.Uint64(),
+				BlockNumber:   uint64(head.Number),
+			}
+			if info.Registered {
+				out.Payout = info.Payout.Hex()
+				out.ConsensusKey = hexutil.Encode(info.ConsensusKey)
+				out.ValidatorAddress = validatorAddress(info.ConsensusKey)
+			}
+			for i, addr := range set {
+				if addr == operator {
+					out.ActiveRank = i + 1
+				}
+			}
+
+			if cfg.JSON {
+				return printJSON(out)
+			}
+			fmt.Printf("operator:      %s\n", out.Operator)
+			fmt.Printf("registered:    %t\n", out.Registered)
+			if out.Registered {
+				fmt.Printf("payout:        %s\n", out.Payout)
+				fmt.Printf("consensus key: %s\n", out.ConsensusKey)
+				if out.ValidatorAddress != "" {
+					fmt.Printf("validator:     %s\n", out.ValidatorAddress)
+				}
+				fmt.Printf("jailed:        %t\n", out.Jailed)
+			}
+			fmt.Printf("total stake:   %s QIK (min %s QIK)\n", allocations.FormatUnits(info.TotalStake, 18, maxDecimals), allocations.FormatUnits(minStake, 18, maxDecimals))
+			if out.ActiveRank > 0 {
+				fmt.Printf("active:        true (rank %d of %d, max %d)\n", out.ActiveRank, out.ActiveSetSize, out.MaxValidators)
+			} else {
+				fmt.Printf("active:        %t (set %d of max %d)\n", out.Active, out.ActiveSetSize, out.MaxValidators)
+			}
+			fmt.Printf("block:         %d\n", out.BlockNumber)
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&maxDecimals, "max-decimals", 6, "max fractional decimals in human output")
+	return cmd
+}
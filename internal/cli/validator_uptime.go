@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/BioMark3r/qikchain/internal/pos"
+	"github.com/BioMark3r/qikchain/internal/uptime"
+	"github.com/spf13/cobra"
+)
+
+// uptimeWorkers bounds the concurrent block reads of validator uptime.
+const uptimeWorkers = 8
+
+type validatorUptimeOutput struct {
+	Operator  string `json:"operator,omitempty"`
+	FromBlock uint64 `json:"fromBlock"`
+	ToBlock   uint64 `json:"toBlock"`
+	uptime.Stats
+}
+
+func newValidatorUptimeCmd(cfg *Config, sf *stakingFlags) *cobra.Command {
+	var (
+		window   uint64
+		blockRef string
+	)
+	cmd := &cobra.Command{
+		Use:   "uptime <validator|operator>",
+		Short: "Show committed-seal participation and proposals over the last --window blocks",
+		Long: "Decode the IBFT extra of the last --window blocks up to --block and count, for the blocks whose validator set " +
+			"includes the address, how many carry its committed seal and how many it proposed. An operator address is " +
+			"resolved to its validator through the staking contract.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := parseAddress(args[0])
+			if err != nil {
+				return err
+			}
+			if window == 0 {
+				return usageErrorf("--window must be > 0")
+			}
+			client := cfg.client()
+			head, err := fetchBlock(client, blockRef, false)
+			if err != nil {
+				return fmt.Errorf("validator uptime: %w", err)
+			}
+			to := uint64(head.Number)
+			from := uint64(1)
+			if to >= window {
+				from = to - window + 1
+			}
+			records, err := uptime.Fetch(client, from, to, uptimeWorkers)
+			if err != nil {
+				return fmt.Errorf("validator uptime: %w", err)
+			}
+			w := uptime.NewWindow(int(window))
+			for _, r := range records {
+				w.Add(r)
+			}
+
+			out := validatorUptimeOutput{FromBlock: from, ToBlock: to, Stats: w.StatsFor(addr)}
+			if out.Blocks == 0 {
+				// Not a consensus address; try it as an operator.
+				if _, caller, err := sf.caller(cfg); err == nil {
+					if info, err := caller.GetOperator(nil, addr); err == nil && info.Registered {
+						if v, ok := pos.ValidatorAddress(info.ConsensusKey); ok {
+							out.Operator = addr.Hex()
+							out.Stats = w.StatsFor(v)
+						}
+					}
+				}
+			}
+			if out.Blocks == 0 {
+				return fmt.Errorf("validator uptime: %s was not in the validator set of blocks %d-%d", addr.Hex(), from, to)
+			}
+
+			if cfg.JSON {
+				return printJSON(out)
+			}
+			if out.Operator != "" {
+				fmt.Printf("operator:   %s\n", out.Operator)
+			}
+			fmt.Printf("validator:  %s\n", out.Validator)
+			fmt.Printf("blocks:     %d-%d (%d in set)\n", out.FromBlock, out.ToBlock, out.Blocks)
+			fmt.Printf("signed:     %d (%.2f%%)\n", out.Signed, out.Ratio*100)
+			fmt.Printf("missed:     %d\n", out.Missed)
+			fmt.Printf("proposed:   %d\n", out.Proposed)
+			if out.LastSignedBlock > 0 {
+				fmt.Printf("last seal:  %d\n", out.LastSignedBlock)
+			}
+			if out.LastMissedBlock > 0 {
+				fmt.Printf("last miss:  %d\n", out.LastMissedBlock)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Uint64Var(&window, "window", 1000, "number of blocks to inspect")
+	cmd.Flags().StringVar(&blockRef, "block", "latest", "newest block of the window (number, hash or tag)")
+	return cmd
+}
@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/BioMark3r/qikchain/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+type walletFlags struct {
+	keystore       string
+	passphraseFile string
+	lightKDF       bool
+}
+
+type walletAccountOutput struct {
+	Index   int    `json:"index"`
+	Address string `json:"address"`
+	Path    string `json:"path"`
+}
+
+func newWalletCmd(cfg *Config) *cobra.Command {
+	wf := &walletFlags{}
+	cmd := &cobra.Command{
+		Use:   "wallet",
+		Short: "Manage encrypted Web3 keystore accounts",
+	}
+	cmd.PersistentFlags().StringVar(&wf.keystore, "keystore", wallet.DefaultDir(), "keystore directory (env QIKCHAIN_KEYSTORE)")
+	cmd.PersistentFlags().StringVar(&wf.passphraseFile, "passphrase-file", "", "read the passphrase from the first line of this file instead of prompting")
+	cmd.PersistentFlags().BoolVar(&wf.lightKDF, "lightkdf", false, "use weak scrypt parameters (devnet keys only)")
+	_ = cmd.RegisterFlagCompletionFunc("keystore", dirCompletion)
+
+	cmd.AddCommand(newWalletNewCmd(cfg, wf))
+	cmd.AddCommand(newWalletImportCmd(cfg, wf))
+	cmd.AddCommand(newWalletListCmd(cfg, wf))
+	cmd.AddCommand(newWalletExportAddressCmd(cfg, wf))
+	return cmd
+}
+
+func printWalletAccount(cfg *Config, out walletAccountOutput) error {
+	if cfg.JSON {
+		return printJSON(out)
+	}
+	fmt.Printf("address=%s\n", out.Address)
+	fmt.Printf("keyfile=%s\n", out.Path)
+	return nil
+}
+
+func newWalletNewCmd(cfg *Config, wf *walletFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "new",
+		Short: "Create a new account",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pass, err := wallet.ReadPassphrase(wf.passphraseFile, "New passphrase: ", true)
+			if err != nil {
+				return fmt.Errorf("wallet new: %w", err)
+			}
+			store := wallet.Open(wf.keystore, wf.lightKDF)
+			acc, err := store.New(pass)
+			if err != nil {
+				return fmt.Errorf("wallet new: %w", err)
+			}
+			return printWalletAccount(cfg, walletAccountOutput{Index: len(store.Accounts()) - 1, Address: acc.Address.Hex(), Path: acc.URL.Path})
+		},
+	}
+}
+
+func newWalletImportCmd(cfg *Config, wf *walletFlags) *cobra.Command {
+	var fromEnv string
+	cmd := &cobra.Command{
+		Use:   "import [keyfile|-]",
+		Short: "Import a hex private key into the keystore",
+		Long:  "Import a raw hex private key read from a file, stdin (-) or an environment variable such as CI_FUNDER_PRIVKEY or POS_DEPLOYER_PK.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var raw string
+			switch {
+			case fromEnv != "" && len(args) > 0:
+				return usageErrorf("wallet import: pass either a key file or --from-env, not both")
+			case fromEnv != "":
+				raw = os.Getenv(fromEnv)
+				if raw == "" {
+					return fmt.Errorf("wallet import: %s is not set", fromEnv)
+				}
+			case len(args) == 1 && args[0] == "-":
+				line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+				if err != nil && err != io.EOF {
+					return fmt.Errorf("wallet import: %w", err)
+				}
+				raw = line
+			case len(args) == 1:
+				data, err := os.ReadFile(args[0])
+				if err != nil {
+					return fmt.Errorf("wallet import: %w", err)
+				}
+				raw = string(data)
+			default:
+				return usageErrorf("wallet import: a key file, - or --from-env is required")
+			}
+
+			key, err := wallet.ParsePrivateKey(strings.TrimSpace(raw))
+			if err != nil {
+				return fmt.Errorf("wallet import: %w", err)
+			}
+			pass, err := wallet.ReadPassphrase(wf.passphraseFile, "New passphrase: ", true)
+			if err != nil {
+				return fmt.Errorf("wallet import: %w", err)
+			}
+			store := wallet.Open(wf.keystore, wf.lightKDF)
+			acc, err := store.Import(key, pass)
+			if err != nil {
+				return fmt.Errorf("wallet import: %w", err)
+			}
+			index := 0
+			for i, a := range store.Accounts() {
+				if a.Address == acc.Address {
+					index = i
+				}
+			}
+			return printWalletAccount(cfg, walletAccountOutput{Index: index, Address: acc.Address.Hex(), Path: acc.URL.Path})
+		},
+	}
+	cmd.Flags().StringVar(&fromEnv, "from-env", "", "read the hex key from this environment variable")
+	return cmd
+}
+
+func newWalletListCmd(cfg *Config, wf *walletFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List keystore accounts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			accs := wallet.Open(wf.keystore, wf.lightKDF).Accounts()
+			out := make([]walletAccountOutput, len(accs))
+			for i, a := range accs {
+				out[i] = walletAccountOutput{Index: i, Address: a.Address.Hex(), Path: a.URL.Path}
+			}
+			if cfg.JSON {
+				return printJSON(out)
+			}
+			if len(out) == 0 {
+				slog.Warn("no accounts", "keystore", wf.keystore)
+			}
+			for _, a := range out {
+				fmt.Printf("#%d %s %s\n", a.Index, a.Address, a.Path)
+			}
+			return nil
+		},
+	}
+}
+
+func newWalletExportAddressCmd(cfg *Config, wf *walletFlags) *cobra.Command {
+	var fromEnv string
+	cmd := &cobra.Command{
+		Use:   "export-address [address|index]",
+		Short: "Print the address of a keystore account",
+		Long:  "Print the address of a keystore account, or with --from-env the address of a raw hex key held in an environment variable.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var addr common.Address
+			if fromEnv != "" {
+				if len(args) > 0 {
+					return usageErrorf("wallet export-address: pass either an account or --from-env, not both")
+				}
+				raw := os.Getenv(fromEnv)
+				if raw == "" {
+					return fmt.Errorf("wallet export-address: %s is not set", fromEnv)
+				}
+				key, err := wallet.ParsePrivateKey(strings.TrimSpace(raw))
+				if err != nil {
+					return fmt.Errorf("wallet export-address: %w", err)
+				}
+				addr = crypto.PubkeyToAddress(key.PublicKey)
+			} else {
+				spec := ""
+				if len(args) == 1 {
+					spec = args[0]
+				}
+				acc, err := wallet.Open(wf.keystore, wf.lightKDF).Find(spec)
+				if err != nil {
+					return fmt.Errorf("wallet export-address: %w", err)
+				}
+				addr = acc.Address
+			}
+			if cfg.JSON {
+				return printJSON(map[string]string{"address": addr.Hex()})
+			}
+			fmt.Println(addr.Hex())
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&fromEnv, "from-env", "", "print the address of the hex key in this environment variable")
+	return cmd
+}
@@ -1,51 +1,120 @@
 package cobra
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
+	"sort"
 	"strings"
-	"time"
 )
 
 const ShellCompRequestCmd = "__complete"
 
 type Command struct {
-	Use           string
-	Short         string
-	Long          string
-	RunE          func(cmd *Command, args []string) error
+	Use     string
+	Aliases []string
+	Short   string
+	Long    string
+	Example string
+	Hidden  bool
+
+	Args              PositionalArgs
+	ValidArgs         []string
+	ValidArgsFunction func(cmd *Command, args []string, toComplete string) ([]string, ShellCompDirective)
+
+	PersistentPreRunE func(cmd *Command, args []string) error
+	RunE              func(cmd *Command, args []string) error
+
 	SilenceUsage  bool
 	SilenceErrors bool
 
 	parent          *Command
 	subcommands     []*Command
+	flags           *FlagSet
 	persistentFlags *FlagSet
+	flagCompletions map[*Flag]completionFunc
+
+	args   []string
+	ctx    context.Context
+	out    io.Writer
+	errOut io.Writer
+}
+
+func (c *Command) Name() string {
+	fields := strings.Fields(c.Use)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+func (c *Command) CommandPath() string {
+	if c.parent == nil {
+		return c.Name()
+	}
+	return c.parent.CommandPath() + " " + c.Name()
+}
+
+func (c *Command) UseLine() string {
+	line := c.Use
+	if c.parent != nil {
+		line = c.parent.CommandPath() + " " + c.Use
+	}
+	if c.HasAvailableFlags() && !strings.Contains(line, "[flags]") {
+		line += " [flags]"
+	}
+	return line
+}
+
+func (c *Command) Root() *Command {
+	root := c
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
 }
 
-type FlagSet struct {
-	stringVars   map[string]*string
-	durationVars map[string]*time.Duration
-	boolVars     map[string]*bool
+func (c *Command) Parent() *Command               { return c.parent }
+func (c *Command) HasParent() bool                { return c.parent != nil }
+func (c *Command) Commands() []*Command           { return c.subcommands }
+func (c *Command) HasSubCommands() bool           { return len(c.subcommands) > 0 }
+func (c *Command) Runnable() bool                 { return c.RunE != nil }
+func (c *Command) SetArgs(args []string)          { c.args = args }
+func (c *Command) SetOut(w io.Writer)             { c.out = w }
+func (c *Command) SetErr(w io.Writer)             { c.errOut = w }
+func (c *Command) SetContext(ctx context.Context) { c.ctx = ctx }
+
+func (c *Command) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
 }
 
-func newFlagSet() *FlagSet {
-	return &FlagSet{
-		stringVars:   map[string]*string{},
-		durationVars: map[string]*time.Duration{},
-		boolVars:     map[string]*bool{},
+func (c *Command) OutOrStdout() io.Writer {
+	for p := c; p != nil; p = p.parent {
+		if p.out != nil {
+			return p.out
+		}
 	}
+	return os.Stdout
 }
 
-func (f *FlagSet) StringVar(p *string, name, value, _ string) { *p = value; f.stringVars[name] = p }
-func (f *FlagSet) DurationVar(p *time.Duration, name string, value time.Duration, _ string) {
-	*p = value
-	f.durationVars[name] = p
+func (c *Command) ErrOrStderr() io.Writer {
+	for p := c; p != nil; p = p.parent {
+		if p.errOut != nil {
+			return p.errOut
+		}
+	}
+	return os.Stderr
 }
-func (f *FlagSet) BoolVar(p *bool, name string, value bool, _ string) {
-	*p = value
-	f.boolVars[name] = p
+
+func (c *Command) Flags() *FlagSet {
+	if c.flags == nil {
+		c.flags = newFlagSet()
+	}
+	return c.flags
 }
 
 func (c *Command) PersistentFlags() *FlagSet {
@@ -55,138 +124,276 @@ func (c *Command) PersistentFlags() *FlagSet {
 	return c.persistentFlags
 }
 
-func (c *Command) AddCommand(cmds ...*Command) {
-	for _, sub := range cmds {
-		sub.parent = c
-		c.subcommands = append(c.subcommands, sub)
+func (c *Command) LocalFlags() *FlagSet {
+	local := newFlagSet()
+	local.AddFlagSet(c.flags)
+	local.AddFlagSet(c.persistentFlags)
+	return local
+}
+
+func (c *Command) InheritedFlags() *FlagSet {
+	inherited := newFlagSet()
+	for p := c.parent; p != nil; p = p.parent {
+		inherited.AddFlagSet(p.persistentFlags)
 	}
+	return inherited
 }
 
-func (c *Command) InitDefaultCompletionCmd() {
-	for _, sub := range c.subcommands {
-		if strings.Fields(sub.Use)[0] == "completion" {
-			return
+// allFlags merges local, persistent and inherited persistent flags. The
+// merged set shares *Flag pointers with the originals so Changed is visible
+// through every view.
+func (c *Command) allFlags() *FlagSet {
+	all := c.LocalFlags()
+	all.AddFlagSet(c.InheritedFlags())
+	if all.Lookup("help") == nil {
+		help := false
+		flag := &Flag{Name: "help", Usage: "help for " + c.Name(), Value: (*boolValue)(&help), DefValue: "false", NoOptDefVal: "true"}
+		if all.ShorthandLookup("h") == nil {
+			flag.Shorthand = "h"
 		}
+		all.AddFlag(flag)
 	}
-	c.AddCommand(&Command{Use: "completion", Short: "Generate completion script", RunE: func(cmd *Command, args []string) error { return nil }})
+	return all
 }
 
-func (c *Command) Execute() error {
-	args := os.Args[1:]
-	return c.execute(args)
+func (c *Command) HasAvailableFlags() bool {
+	return c.LocalFlags().HasAvailableFlags() || c.InheritedFlags().HasAvailableFlags()
 }
 
-func (c *Command) execute(args []string) error {
-	if err := c.applyPersistentFlags(args); err != nil {
-		return err
-	}
+func (c *Command) MarkFlagRequired(name string) error {
+	return c.Flags().SetAnnotation(name, requiredAnnotation, []string{"true"})
+}
 
-	remaining, err := stripFlags(args)
-	if err != nil {
-		return err
-	}
-	if len(remaining) == 0 {
-		if c.RunE != nil {
-			return c.RunE(c, nil)
+func (c *Command) MarkPersistentFlagRequired(name string) error {
+	return c.PersistentFlags().SetAnnotation(name, requiredAnnotation, []string{"true"})
+}
+
+func (c *Command) AddCommand(cmds ...*Command) {
+	for _, sub := range cmds {
+		if sub == c {
+			panic("command can't be a child of itself")
 		}
-		return errors.New("unknown command")
+		sub.parent = c
+		c.subcommands = append(c.subcommands, sub)
 	}
+}
 
-	next := remaining[0]
+func (c *Command) findSub(name string) *Command {
 	for _, sub := range c.subcommands {
-		if strings.Fields(sub.Use)[0] == next {
-			return sub.execute(remaining[1:])
+		if sub.Name() == name || stringInSlice(name, sub.Aliases) {
+			return sub
 		}
 	}
+	return nil
+}
 
-	if c.RunE != nil {
-		return c.RunE(c, remaining)
+// Find descends through subcommand names in args, skipping flags (and the
+// values of flags known to take one), and returns the deepest match along
+// with the remaining arguments.
+func (c *Command) Find(args []string) (*Command, []string) {
+	cmd := c
+	rest := args
+	for {
+		idx := nextPositional(cmd, rest)
+		if idx < 0 {
+			return cmd, rest
+		}
+		sub := cmd.findSub(rest[idx])
+		if sub == nil {
+			return cmd, rest
+		}
+		next := make([]string, 0, len(rest)-1)
+		next = append(next, rest[:idx]...)
+		rest = append(next, rest[idx+1:]...)
+		cmd = sub
 	}
-	return fmt.Errorf("unknown command %q", next)
 }
 
-func stripFlags(args []string) ([]string, error) {
-	remaining := make([]string, 0, len(args))
+func nextPositional(cmd *Command, args []string) int {
+	flags := cmd.allFlags()
 	for i := 0; i < len(args); i++ {
 		a := args[i]
-		if !strings.HasPrefix(a, "--") {
-			remaining = append(remaining, a)
-			continue
+		switch {
+		case a == "--":
+			return -1
+		case strings.HasPrefix(a, "-") && len(a) > 1:
+			if flags.takesValue(a) {
+				i++
+			}
+		default:
+			return i
 		}
-		if a == "--json" {
-			continue
+	}
+	return -1
+}
+
+func (c *Command) Execute() error {
+	_, err := c.ExecuteC()
+	return err
+}
+
+func (c *Command) ExecuteContext(ctx context.Context) error {
+	c.ctx = ctx
+	return c.Execute()
+}
+
+func (c *Command) ExecuteC() (*Command, error) {
+	if c.parent != nil {
+		return c.Root().ExecuteC()
+	}
+	args := c.args
+	if args == nil {
+		args = os.Args[1:]
+	}
+
+	if len(args) > 0 && args[0] == ShellCompRequestCmd {
+		return c, c.writeCompletions(args[1:])
+	}
+	if len(args) > 0 && args[0] == "help" && c.findSub("help") == nil {
+		target, rest := c.Find(args[1:])
+		if len(rest) > 0 {
+			return target, fmt.Errorf("unknown help topic %q", strings.Join(rest, " "))
 		}
-		if strings.Contains(a, "=") {
-			continue
+		return target, target.Help()
+	}
+
+	cmd, rest := c.Find(args)
+	if cmd.ctx == nil {
+		cmd.ctx = c.ctx
+	}
+	err := cmd.execute(rest)
+	if err != nil {
+		if !cmd.SilenceErrors && !c.SilenceErrors {
+			fmt.Fprintln(cmd.ErrOrStderr(), "Error:", err)
 		}
-		if i+1 >= len(args) {
-			return nil, fmt.Errorf("invalid argument %s", a)
+		if !cmd.SilenceUsage && !c.SilenceUsage {
+			fmt.Fprint(cmd.ErrOrStderr(), cmd.UsageString())
 		}
-		i++
 	}
-	return remaining, nil
+	return cmd, err
 }
 
-func (c *Command) applyPersistentFlags(args []string) error {
-	root := c
-	for root.parent != nil {
-		root = root.parent
+func (c *Command) execute(args []string) error {
+	flags := c.allFlags()
+	positional, err := flags.parse(args)
+	if err != nil {
+		return err
 	}
-	if root.persistentFlags == nil {
-		return nil
+	if flags.Changed("help") {
+		if help := flags.Lookup("help"); help.Value.String() == "true" {
+			return c.Help()
+		}
 	}
 
-	for i := 0; i < len(args); i++ {
-		a := args[i]
-		if !strings.HasPrefix(a, "--") {
-			continue
+	if !c.Runnable() {
+		if len(positional) > 0 {
+			return fmt.Errorf("unknown command %q for %q", positional[0], c.CommandPath())
 		}
-		nameVal := strings.TrimPrefix(a, "--")
-		name := nameVal
-		value := ""
-		hasValue := false
-		if strings.Contains(nameVal, "=") {
-			parts := strings.SplitN(nameVal, "=", 2)
-			name = parts[0]
-			value = parts[1]
-			hasValue = true
-		}
-
-		if ptr, ok := root.persistentFlags.boolVars[name]; ok {
-			if hasValue {
-				b, err := strconv.ParseBool(value)
-				if err != nil {
-					return fmt.Errorf("invalid argument %s", a)
-				}
-				*ptr = b
-			} else {
-				*ptr = true
+		return c.Help()
+	}
+
+	validate := c.Args
+	if validate == nil {
+		validate = legacyArgs
+	}
+	if err := validate(c, positional); err != nil {
+		return err
+	}
+	if err := validateRequiredFlags(flags); err != nil {
+		return err
+	}
+
+	for p := c; p != nil; p = p.parent {
+		if p.PersistentPreRunE != nil {
+			if err := p.PersistentPreRunE(c, positional); err != nil {
+				return err
 			}
-			continue
+			break
 		}
+	}
+	return c.RunE(c, positional)
+}
 
-		if !hasValue {
-			if i+1 >= len(args) {
-				return fmt.Errorf("invalid argument --%s", name)
-			}
-			i++
-			value = args[i]
+func validateRequiredFlags(flags *FlagSet) error {
+	missing := []string{}
+	flags.VisitAll(func(flag *Flag) {
+		if values, ok := flag.Annotations[requiredAnnotation]; ok && len(values) > 0 && values[0] == "true" && !flag.Changed {
+			missing = append(missing, flag.Name)
 		}
+	})
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return fmt.Errorf(`required flag(s) "%s" not set`, strings.Join(missing, `", "`))
+}
+
+func (c *Command) Help() error {
+	_, err := io.WriteString(c.OutOrStdout(), c.HelpString())
+	return err
+}
+
+func (c *Command) HelpString() string {
+	var sb strings.Builder
+	desc := strings.TrimSpace(c.Long)
+	if desc == "" {
+		desc = strings.TrimSpace(c.Short)
+	}
+	if desc != "" {
+		sb.WriteString(desc)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString(c.UsageString())
+	return sb.String()
+}
 
-		if ptr, ok := root.persistentFlags.stringVars[name]; ok {
-			*ptr = value
+func (c *Command) UsageString() string {
+	var sb strings.Builder
+	sb.WriteString("Usage:\n")
+	if c.Runnable() {
+		fmt.Fprintf(&sb, "  %s\n", c.UseLine())
+	}
+	if c.HasSubCommands() {
+		fmt.Fprintf(&sb, "  %s [command]\n", c.CommandPath())
+	}
+	if len(c.Aliases) > 0 {
+		fmt.Fprintf(&sb, "\nAliases:\n  %s\n", strings.Join(append([]string{c.Name()}, c.Aliases...), ", "))
+	}
+	if c.Example != "" {
+		fmt.Fprintf(&sb, "\nExamples:\n%s\n", strings.TrimRight(c.Example, "\n"))
+	}
+
+	visible := make([]*Command, 0, len(c.subcommands))
+	width := 0
+	for _, sub := range c.subcommands {
+		if sub.Hidden {
 			continue
 		}
-		if ptr, ok := root.persistentFlags.durationVars[name]; ok {
-			d, err := time.ParseDuration(value)
-			if err != nil {
-				return fmt.Errorf("invalid argument --%s", name)
-			}
-			*ptr = d
-			continue
+		visible = append(visible, sub)
+		if n := len(sub.Name()); n > width {
+			width = n
+		}
+	}
+	if len(visible) > 0 {
+		sort.SliceStable(visible, func(i, j int) bool { return visible[i].Name() < visible[j].Name() })
+		sb.WriteString("\nAvailable Commands:\n")
+		for _, sub := range visible {
+			fmt.Fprintf(&sb, "  %-*s  %s\n", width, sub.Name(), sub.Short)
 		}
+	}
 
-		return fmt.Errorf("unknown flag: --%s", name)
+	local := c.LocalFlags()
+	if local.Lookup("help") == nil {
+		local.AddFlag(c.allFlags().Lookup("help"))
 	}
-	return nil
+	sb.WriteString("\nFlags:\n")
+	sb.WriteString(local.FlagUsages())
+	if inherited := c.InheritedFlags(); inherited.HasAvailableFlags() {
+		sb.WriteString("\nGlobal Flags:\n")
+		sb.WriteString(inherited.FlagUsages())
+	}
+	if len(visible) > 0 {
+		fmt.Fprintf(&sb, "\nUse \"%s [command] --help\" for more information about a command.\n", c.CommandPath())
+	}
+	return sb.String()
 }
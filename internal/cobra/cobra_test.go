@@ -0,0 +1,187 @@
+package cobra
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testTree struct {
+	root    *Command
+	rpc     string
+	timeout time.Duration
+	jsonOut bool
+
+	consensus string
+	chainID   int
+	block     uint64
+	tags      []string
+	amount    big.Int
+	verbose   bool
+	ranArgs   []string
+}
+
+func newTestTree() *testTree {
+	tt := &testTree{}
+	tt.root = &Command{Use: "qikchain", Short: "test root", SilenceUsage: true, SilenceErrors: true}
+	tt.root.PersistentFlags().StringVar(&tt.rpc, "rpc", "http://127.0.0.1:8545", "JSON-RPC endpoint URL")
+	tt.root.PersistentFlags().DurationVar(&tt.timeout, "timeout", 5*time.Second, "RPC request timeout")
+	tt.root.PersistentFlags().BoolVar(&tt.jsonOut, "json", false, "Output JSON")
+
+	genesis := &Command{Use: "genesis", Short: "Genesis commands"}
+	build := &Command{
+		Use:  "build",
+		Args: NoArgs,
+		RunE: func(cmd *Command, args []string) error {
+			tt.ranArgs = args
+			return nil
+		},
+	}
+	build.Flags().StringVar(&tt.consensus, "consensus", "poa", "consensus mode (poa|pos)")
+	build.Flags().IntVar(&tt.chainID, "chain-id", 0, "chain id")
+	build.Flags().Uint64Var(&tt.block, "block", 0, "block number")
+	build.Flags().StringSliceVarP(&tt.tags, "tag", "t", nil, "tags")
+	build.Flags().BigIntVar(&tt.amount, "amount", big.NewInt(0), "amount in wei")
+	build.Flags().BoolVarP(&tt.verbose, "verbose", "v", false, "verbose output")
+	_ = build.RegisterFlagCompletionFunc("consensus", FixedCompletions([]string{"poa", "pos"}, ShellCompDirectiveNoFileComp))
+
+	get := &Command{
+		Use:  "get <number>",
+		Args: ExactArgs(1),
+		RunE: func(cmd *Command, args []string) error {
+			tt.ranArgs = args
+			return nil
+		},
+	}
+	genesis.AddCommand(build, get)
+	tt.root.AddCommand(genesis)
+	tt.root.InitDefaultCompletionCmd()
+	return tt
+}
+
+func (tt *testTree) run(args ...string) (string, error) {
+	var out bytes.Buffer
+	tt.root.SetOut(&out)
+	tt.root.SetErr(&out)
+	tt.root.SetArgs(args)
+	err := tt.root.Execute()
+	return out.String(), err
+}
+
+func TestLocalAndPersistentFlagTypes(t *testing.T) {
+	tt := newTestTree()
+	_, err := tt.run("genesis", "--rpc", "http://node:8545", "build", "--consensus=pos", "--chain-id", "0x64",
+		"--block", "42", "-t", "a,b", "--tag", "c", "--amount", "0xde0b6b3a7640000", "-v", "--json", "--timeout=2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tt.rpc != "http://node:8545" || tt.consensus != "pos" || tt.chainID != 100 || tt.block != 42 {
+		t.Fatalf("unexpected values rpc=%s consensus=%s chainID=%d block=%d", tt.rpc, tt.consensus, tt.chainID, tt.block)
+	}
+	if strings.Join(tt.tags, ",") != "a,b,c" {
+		t.Fatalf("unexpected tags: %v", tt.tags)
+	}
+	if tt.amount.String() != "1000000000000000000" {
+		t.Fatalf("unexpected amount: %s", tt.amount.String())
+	}
+	if !tt.verbose || !tt.jsonOut || tt.timeout != 2*time.Second {
+		t.Fatalf("unexpected bools/duration verbose=%t json=%t timeout=%s", tt.verbose, tt.jsonOut, tt.timeout)
+	}
+}
+
+func TestUnknownAndLocalFlagScoping(t *testing.T) {
+	tt := newTestTree()
+	if _, err := tt.run("genesis", "get", "1", "--consensus", "pos"); err == nil || !strings.Contains(err.Error(), "unknown flag: --consensus") {
+		t.Fatalf("expected unknown flag error, got %v", err)
+	}
+	if _, err := tt.run("genesis", "build", "-x"); err == nil || !strings.Contains(err.Error(), "unknown shorthand flag") {
+		t.Fatalf("expected unknown shorthand error, got %v", err)
+	}
+	if _, err := tt.run("genesis", "build", "--chain-id", "abc"); err == nil || !strings.Contains(err.Error(), "invalid argument") {
+		t.Fatalf("expected invalid argument error, got %v", err)
+	}
+}
+
+func TestArgsValidatorsAndRequiredFlags(t *testing.T) {
+	tt := newTestTree()
+	if _, err := tt.run("genesis", "get"); err == nil || !strings.Contains(err.Error(), "accepts 1 arg(s)") {
+		t.Fatalf("expected ExactArgs error, got %v", err)
+	}
+	if _, err := tt.run("genesis", "get", "--", "-1"); err != nil || tt.ranArgs[0] != "-1" {
+		t.Fatalf("expected -- to end flag parsing, got %v %v", err, tt.ranArgs)
+	}
+	if _, err := tt.run("genesis", "build", "extra"); err == nil || !strings.Contains(err.Error(), "unknown command") {
+		t.Fatalf("expected NoArgs error, got %v", err)
+	}
+	if _, err := tt.run("genesis", "nope"); err == nil || !strings.Contains(err.Error(), `unknown command "nope"`) {
+		t.Fatalf("expected unknown command error, got %v", err)
+	}
+
+	tt = newTestTree()
+	build, _ := tt.root.Find([]string{"genesis", "build"})
+	if err := build.MarkFlagRequired("chain-id"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tt.run("genesis", "build"); err == nil || !strings.Contains(err.Error(), `required flag(s) "chain-id" not set`) {
+		t.Fatalf("expected required flag error, got %v", err)
+	}
+}
+
+func TestHelpOutput(t *testing.T) {
+	tt := newTestTree()
+	out, err := tt.run("genesis", "build", "--help")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Usage:\n  qikchain genesis build [flags]", "--consensus string", `(default "poa")`, "-v, --verbose", "Global Flags:", "--rpc string"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("help missing %q:\n%s", want, out)
+		}
+	}
+	out, err = tt.run("help", "genesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Available Commands:") || !strings.Contains(out, "build") {
+		t.Fatalf("unexpected help output:\n%s", out)
+	}
+}
+
+func TestCompleteFlagValuesAndSubcommands(t *testing.T) {
+	tt := newTestTree()
+	out, err := tt.run(ShellCompRequestCmd, "genesis", "build", "--consensus", "p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "poa\npos\n:4\n" {
+		t.Fatalf("unexpected completion output %q", out)
+	}
+	out, _ = tt.run(ShellCompRequestCmd, "genesis", "build", "--consensus=po")
+	if out != "--consensus=poa\n--consensus=pos\n:4\n" {
+		t.Fatalf("unexpected inline completion output %q", out)
+	}
+	out, _ = tt.run(ShellCompRequestCmd, "gen")
+	if !strings.HasPrefix(out, "genesis\tGenesis commands\n") {
+		t.Fatalf("unexpected subcommand completion %q", out)
+	}
+	out, _ = tt.run(ShellCompRequestCmd, "genesis", "build", "--cha")
+	if !strings.HasPrefix(out, "--chain-id\tchain id\n") {
+		t.Fatalf("unexpected flag name completion %q", out)
+	}
+}
+
+func TestCompletionScripts(t *testing.T) {
+	headers := map[string]string{"bash": "# bash completion for qikchain", "zsh": "#compdef qikchain", "fish": "# fish completion for qikchain"}
+	for shell, header := range headers {
+		tt := newTestTree()
+		out, err := tt.run("completion", shell)
+		if err != nil {
+			t.Fatalf("%s: %v", shell, err)
+		}
+		if !strings.HasPrefix(out, header) || !strings.Contains(out, ShellCompRequestCmd) {
+			t.Fatalf("unexpected %s script:\n%s", shell, out)
+		}
+	}
+}
@@ -0,0 +1,365 @@
+package cobra
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+type ShellCompDirective int
+
+const (
+	ShellCompDirectiveError ShellCompDirective = 1 << iota
+	ShellCompDirectiveNoSpace
+	ShellCompDirectiveNoFileComp
+	ShellCompDirectiveFilterFileExt
+	ShellCompDirectiveFilterDirs
+	ShellCompDirectiveKeepOrder
+
+	ShellCompDirectiveDefault ShellCompDirective = 0
+)
+
+type completionFunc func(cmd *Command, args []string, toComplete string) ([]string, ShellCompDirective)
+
+func FixedCompletions(choices []string, directive ShellCompDirective) func(cmd *Command, args []string, toComplete string) ([]string, ShellCompDirective) {
+	return func(cmd *Command, args []string, toComplete string) ([]string, ShellCompDirective) {
+		return choices, directive
+	}
+}
+
+func NoFileCompletions(cmd *Command, args []string, toComplete string) ([]string, ShellCompDirective) {
+	return nil, ShellCompDirectiveNoFileComp
+}
+
+func (c *Command) RegisterFlagCompletionFunc(flagName string, f func(cmd *Command, args []string, toComplete string) ([]string, ShellCompDirective)) error {
+	flag := c.allFlags().Lookup(flagName)
+	if flag == nil || flagName == "help" {
+		return fmt.Errorf("RegisterFlagCompletionFunc: flag '%s' does not exist", flagName)
+	}
+	if c.flagCompletions == nil {
+		c.flagCompletions = map[*Flag]completionFunc{}
+	}
+	if _, exists := c.flagCompletions[flag]; exists {
+		return fmt.Errorf("RegisterFlagCompletionFunc: flag '%s' already registered", flagName)
+	}
+	c.flagCompletions[flag] = f
+	return nil
+}
+
+func (c *Command) flagCompletion(flag *Flag) completionFunc {
+	for p := c; p != nil; p = p.parent {
+		if fn, ok := p.flagCompletions[flag]; ok {
+			return fn
+		}
+	}
+	return nil
+}
+
+func (c *Command) writeCompletions(args []string) error {
+	completions, directive := c.getCompletions(args)
+	out := c.OutOrStdout()
+	for _, comp := range completions {
+		fmt.Fprintln(out, comp)
+	}
+	fmt.Fprintf(out, ":%d\n", directive)
+	return nil
+}
+
+func (c *Command) getCompletions(args []string) ([]string, ShellCompDirective) {
+	toComplete := ""
+	if len(args) > 0 {
+		toComplete = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+	cmd, rest := c.Find(args)
+	flags := cmd.allFlags()
+
+	var (
+		valueFlag   *Flag
+		valuePrefix string
+	)
+	switch {
+	case strings.HasPrefix(toComplete, "--") && strings.Contains(toComplete, "="):
+		idx := strings.Index(toComplete, "=")
+		valueFlag = flags.Lookup(toComplete[2:idx])
+		valuePrefix = toComplete[:idx+1]
+		toComplete = toComplete[idx+1:]
+	case strings.HasPrefix(toComplete, "-"):
+		return flagNameCompletions(flags, toComplete), ShellCompDirectiveNoFileComp
+	case len(rest) > 0 && flags.takesValue(rest[len(rest)-1]):
+		last := rest[len(rest)-1]
+		if strings.HasPrefix(last, "--") {
+			valueFlag = flags.Lookup(last[2:])
+		} else {
+			valueFlag = flags.ShorthandLookup(last[len(last)-1:])
+		}
+		rest = rest[:len(rest)-1]
+	}
+
+	positional, err := flags.parse(rest)
+	if err != nil {
+		positional = nil
+	}
+
+	if valueFlag != nil {
+		fn := cmd.flagCompletion(valueFlag)
+		if fn == nil {
+			return nil, ShellCompDirectiveDefault
+		}
+		choices, directive := fn(cmd, positional, toComplete)
+		out := make([]string, 0, len(choices))
+		for _, choice := range filterPrefix(choices, toComplete) {
+			out = append(out, valuePrefix+choice)
+		}
+		return out, directive
+	}
+
+	if cmd.HasSubCommands() && len(positional) == 0 {
+		out := []string{}
+		for _, sub := range cmd.subcommands {
+			if sub.Hidden || !strings.HasPrefix(sub.Name(), toComplete) {
+				continue
+			}
+			out = append(out, sub.Name()+"\t"+sub.Short)
+		}
+		return out, ShellCompDirectiveNoFileComp
+	}
+	if len(cmd.ValidArgs) > 0 {
+		return filterPrefix(cmd.ValidArgs, toComplete), ShellCompDirectiveNoFileComp
+	}
+	if cmd.ValidArgsFunction != nil {
+		choices, directive := cmd.ValidArgsFunction(cmd, positional, toComplete)
+		return filterPrefix(choices, toComplete), directive
+	}
+	return nil, ShellCompDirectiveDefault
+}
+
+func flagNameCompletions(flags *FlagSet, toComplete string) []string {
+	out := []string{}
+	flags.VisitAll(func(flag *Flag) {
+		if flag.Hidden {
+			return
+		}
+		name := "--" + flag.Name
+		if strings.HasPrefix(name, toComplete) {
+			out = append(out, name+"\t"+flag.Usage)
+		}
+	})
+	return out
+}
+
+func filterPrefix(choices []string, prefix string) []string {
+	out := make([]string, 0, len(choices))
+	for _, choice := range choices {
+		if strings.HasPrefix(choice, prefix) {
+			out = append(out, choice)
+		}
+	}
+	return out
+}
+
+func (c *Command) InitDefaultCompletionCmd() {
+	if c.findSub("completion") != nil {
+		return
+	}
+	name := c.Root().Name()
+	completion := &Command{
+		Use:   "completion",
+		Short: "Generate the autocompletion script for the specified shell",
+		Long:  fmt.Sprintf("Generate the autocompletion script for %s for the specified shell.\nSee each sub-command's help for details on how to use the generated script.", name),
+		Args:  NoArgs,
+	}
+	bash := &Command{
+		Use:   "bash",
+		Short: "Generate the autocompletion script for bash",
+		Long:  fmt.Sprintf("To load completions in your current shell session:\n\n  source <(%[1]s completion bash)\n\nTo load completions for every new session, write the output to\n/etc/bash_completion.d/%[1]s or ~/.local/share/bash-completion/completions/%[1]s.", name),
+		Args:  NoArgs,
+		RunE: func(cmd *Command, args []string) error {
+			return cmd.Root().GenBashCompletion(cmd.OutOrStdout())
+		},
+	}
+	zsh := &Command{
+		Use:   "zsh",
+		Short: "Generate the autocompletion script for zsh",
+		Long:  fmt.Sprintf("To load completions in your current shell session:\n\n  source <(%[1]s completion zsh)\n\nTo load completions for every new session, write the output to a\ndirectory on your $fpath as _%[1]s.", name),
+		Args:  NoArgs,
+		RunE: func(cmd *Command, args []string) error {
+			return cmd.Root().GenZshCompletion(cmd.OutOrStdout())
+		},
+	}
+	noDesc := false
+	fish := &Command{
+		Use:   "fish",
+		Short: "Generate the autocompletion script for fish",
+		Long:  fmt.Sprintf("To load completions in your current shell session:\n\n  %[1]s completion fish | source\n\nTo load completions for every new session, write the output to\n~/.config/fish/completions/%[1]s.fish.", name),
+		Args:  NoArgs,
+		RunE: func(cmd *Command, args []string) error {
+			return cmd.Root().GenFishCompletion(cmd.OutOrStdout(), !noDesc)
+		},
+	}
+	fish.Flags().BoolVar(&noDesc, "no-descriptions", false, "disable completion descriptions")
+	completion.AddCommand(bash, zsh, fish)
+	c.AddCommand(completion)
+}
+
+func (c *Command) GenBashCompletion(w io.Writer) error {
+	name := c.Root().Name()
+	fn := shellFuncName(name)
+	_, err := fmt.Fprintf(w, `# bash completion for %[1]s
+
+__%[2]s_complete() {
+    local line="${COMP_LINE:0:COMP_POINT}"
+    local -a words
+    read -ra words <<< "$line"
+    if [[ -z "$line" || "$line" =~ [[:space:]]$ ]]; then
+        words+=("")
+    fi
+
+    local out
+    out=$("${words[0]}" %[3]s "${words[@]:1}" 2>/dev/null) || return
+    local directive="${out##*:}"
+    out="${out%%:*}"
+    out="${out%%$'\n'}"
+    if (( directive & %[4]d )); then
+        return
+    fi
+
+    local token="${words[${#words[@]}-1]}"
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    local prefix="${token%%"$cur"}"
+
+    local -a comps=()
+    local comp
+    while IFS= read -r comp; do
+        [[ -z "$comp" ]] && continue
+        comp="${comp%%%%$'\t'*}"
+        comps+=("${comp#"$prefix"}")
+    done <<< "$out"
+
+    if (( directive & %[6]d )); then
+        local ext
+        ext="$(printf '%%s|' "${comps[@]}")"
+        COMPREPLY=($(compgen -f -X "!*.@(${ext%%|})" -- "$cur"))
+        return
+    fi
+    if (( directive & %[7]d )); then
+        COMPREPLY=($(compgen -d -- "$cur"))
+        return
+    fi
+
+    COMPREPLY=("${comps[@]}")
+    if (( directive & %[5]d )); then
+        compopt -o nospace 2>/dev/null
+    fi
+    if (( ${#COMPREPLY[@]} == 0 && (directive & %[8]d) == 0 )); then
+        compopt -o default 2>/dev/null
+    fi
+}
+
+complete -o bashdefault -F __%[2]s_complete %[1]s
+`, name, fn, ShellCompRequestCmd, ShellCompDirectiveError, ShellCompDirectiveNoSpace, ShellCompDirectiveFilterFileExt, ShellCompDirectiveFilterDirs, ShellCompDirectiveNoFileComp)
+	return err
+}
+
+func (c *Command) GenZshCompletion(w io.Writer) error {
+	name := c.Root().Name()
+	fn := shellFuncName(name)
+	_, err := fmt.Fprintf(w, `#compdef %[1]s
+# zsh completion for %[1]s
+
+_%[2]s() {
+    local out directive line value desc
+    local -a lines described
+    out=$(${words[1]} %[3]s "${(@)words[2,$CURRENT]}" 2>/dev/null) || return 1
+    lines=("${(@f)out}")
+    directive=${lines[-1]#:}
+    lines=("${(@)lines[1,-2]}")
+    if (( directive & %[4]d )); then
+        return 1
+    fi
+    if (( directive & %[5]d )); then
+        _files -g "*.(${(j:|:)lines})"
+        return
+    fi
+    if (( directive & %[6]d )); then
+        _files -/
+        return
+    fi
+
+    for line in $lines; do
+        [[ -z "$line" ]] && continue
+        value=${line%%%%$'\t'*}
+        desc=""
+        [[ "$line" == *$'\t'* ]] && desc=${line#*$'\t'}
+        value=${value//:/\\:}
+        if [[ -n "$desc" ]]; then
+            described+=("${value}:${desc}")
+        else
+            described+=("${value}")
+        fi
+    done
+
+    if (( ${#described} > 0 )); then
+        if (( directive & %[7]d )); then
+            _describe '%[1]s' described -S ''
+        else
+            _describe '%[1]s' described
+        fi
+        return
+    fi
+    if (( (directive & %[8]d) == 0 )); then
+        _files
+    fi
+}
+
+if [[ "$funcstack[1]" == "_%[2]s" ]]; then
+    _%[2]s "$@"
+else
+    compdef _%[2]s %[1]s
+fi
+`, name, fn, ShellCompRequestCmd, ShellCompDirectiveError, ShellCompDirectiveFilterFileExt, ShellCompDirectiveFilterDirs, ShellCompDirectiveNoSpace, ShellCompDirectiveNoFileComp)
+	return err
+}
+
+func (c *Command) GenFishCompletion(w io.Writer, includeDesc bool) error {
+	name := c.Root().Name()
+	fn := shellFuncName(name)
+	strip := ""
+	if !includeDesc {
+		strip = "\n        set line (string split -m1 \\t -- $line)[1]"
+	}
+	_, err := fmt.Fprintf(w, `# fish completion for %[1]s
+
+function __%[2]s_results
+    set -l args (commandline -opc)
+    set -l cur (commandline -ct)
+    $args[1] %[3]s $args[2..-1] $cur 2>/dev/null
+end
+
+function __%[2]s_complete
+    set -l out (__%[2]s_results)
+    set -l directive (string replace -r '^:' '' -- $out[-1])
+    set -e out[-1]
+    if test (math "bitand($directive, %[4]d)") -ne 0
+        return
+    end
+    for line in $out%[5]s
+        echo $line
+    end
+end
+
+function __%[2]s_wants_files
+    set -l out (__%[2]s_results)
+    set -l directive (string replace -r '^:' '' -- $out[-1])
+    test (count $out) -eq 1; and test (math "bitand($directive, %[6]d)") -eq 0
+end
+
+complete -c %[1]s -f -a '(__%[2]s_complete)'
+complete -c %[1]s -n '__%[2]s_wants_files' -F
+`, name, fn, ShellCompRequestCmd, ShellCompDirectiveError, strip, ShellCompDirectiveNoFileComp)
+	return err
+}
+
+func shellFuncName(name string) string {
+	return strings.NewReplacer("-", "_", ":", "_", ".", "_").Replace(name)
+}
@@ -0,0 +1,502 @@
+package cobra
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const requiredAnnotation = "cobra_annotation_bash_completion_one_required_flag"
+
+type Value interface {
+	String() string
+	Set(string) error
+	Type() string
+}
+
+type Flag struct {
+	Name        string
+	Shorthand   string
+	Usage       string
+	Value       Value
+	DefValue    string
+	Changed     bool
+	NoOptDefVal string
+	Hidden      bool
+	Annotations map[string][]string
+}
+
+type FlagSet struct {
+	formal     map[string]*Flag
+	shorthands map[string]*Flag
+	order      []*Flag
+}
+
+func newFlagSet() *FlagSet {
+	return &FlagSet{
+		formal:     map[string]*Flag{},
+		shorthands: map[string]*Flag{},
+	}
+}
+
+func (f *FlagSet) Var(value Value, name, usage string) {
+	f.VarP(value, name, "", usage)
+}
+
+func (f *FlagSet) VarP(value Value, name, shorthand, usage string) {
+	flag := &Flag{Name: name, Shorthand: shorthand, Usage: usage, Value: value, DefValue: value.String()}
+	if _, ok := value.(*boolValue); ok {
+		flag.NoOptDefVal = "true"
+	}
+	f.AddFlag(flag)
+}
+
+func (f *FlagSet) AddFlag(flag *Flag) {
+	if _, exists := f.formal[flag.Name]; exists {
+		panic(fmt.Sprintf("flag redefined: %s", flag.Name))
+	}
+	f.formal[flag.Name] = flag
+	if flag.Shorthand != "" {
+		if len(flag.Shorthand) != 1 {
+			panic(fmt.Sprintf("%q shorthand is more than one ASCII character", flag.Shorthand))
+		}
+		if prev, exists := f.shorthands[flag.Shorthand]; exists {
+			panic(fmt.Sprintf("unable to redefine %q shorthand in %q flagset: it's already used for %q flag", flag.Shorthand, flag.Name, prev.Name))
+		}
+		f.shorthands[flag.Shorthand] = flag
+	}
+	f.order = append(f.order, flag)
+}
+
+func (f *FlagSet) AddFlagSet(other *FlagSet) {
+	if other == nil {
+		return
+	}
+	for _, flag := range other.order {
+		if f.Lookup(flag.Name) == nil {
+			f.AddFlag(flag)
+		}
+	}
+}
+
+func (f *FlagSet) Lookup(name string) *Flag {
+	return f.formal[name]
+}
+
+func (f *FlagSet) ShorthandLookup(name string) *Flag {
+	return f.shorthands[name]
+}
+
+func (f *FlagSet) Changed(name string) bool {
+	flag := f.Lookup(name)
+	return flag != nil && flag.Changed
+}
+
+func (f *FlagSet) Set(name, value string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("no such flag -%v", name)
+	}
+	if err := flag.Value.Set(value); err != nil {
+		return fmt.Errorf("invalid argument %q for %q flag: %v", value, flagDisplayName(flag), err)
+	}
+	flag.Changed = true
+	return nil
+}
+
+func (f *FlagSet) VisitAll(fn func(*Flag)) {
+	for _, flag := range f.order {
+		fn(flag)
+	}
+}
+
+func (f *FlagSet) HasFlags() bool {
+	return len(f.order) > 0
+}
+
+func (f *FlagSet) HasAvailableFlags() bool {
+	for _, flag := range f.order {
+		if !flag.Hidden {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FlagSet) MarkHidden(name string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("flag %q does not exist", name)
+	}
+	flag.Hidden = true
+	return nil
+}
+
+func (f *FlagSet) SetAnnotation(name, key string, values []string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return fmt.Errorf("no such flag -%v", name)
+	}
+	if flag.Annotations == nil {
+		flag.Annotations = map[string][]string{}
+	}
+	flag.Annotations[key] = values
+	return nil
+}
+
+func (f *FlagSet) StringVar(p *string, name, value, usage string) {
+	f.StringVarP(p, name, "", value, usage)
+}
+
+func (f *FlagSet) StringVarP(p *string, name, shorthand, value, usage string) {
+	*p = value
+	f.VarP((*stringValue)(p), name, shorthand, usage)
+}
+
+func (f *FlagSet) String(name, value, usage string) *string {
+	p := new(string)
+	f.StringVar(p, name, value, usage)
+	return p
+}
+
+func (f *FlagSet) BoolVar(p *bool, name string, value bool, usage string) {
+	f.BoolVarP(p, name, "", value, usage)
+}
+
+func (f *FlagSet) BoolVarP(p *bool, name, shorthand string, value bool, usage string) {
+	*p = value
+	f.VarP((*boolValue)(p), name, shorthand, usage)
+}
+
+func (f *FlagSet) Bool(name string, value bool, usage string) *bool {
+	p := new(bool)
+	f.BoolVar(p, name, value, usage)
+	return p
+}
+
+func (f *FlagSet) DurationVar(p *time.Duration, name string, value time.Duration, usage string) {
+	f.DurationVarP(p, name, "", value, usage)
+}
+
+func (f *FlagSet) DurationVarP(p *time.Duration, name, shorthand string, value time.Duration, usage string) {
+	*p = value
+	f.VarP((*durationValue)(p), name, shorthand, usage)
+}
+
+func (f *FlagSet) IntVar(p *int, name string, value int, usage string) {
+	f.IntVarP(p, name, "", value, usage)
+}
+
+func (f *FlagSet) IntVarP(p *int, name, shorthand string, value int, usage string) {
+	*p = value
+	f.VarP((*intValue)(p), name, shorthand, usage)
+}
+
+func (f *FlagSet) Uint64Var(p *uint64, name string, value uint64, usage string) {
+	f.Uint64VarP(p, name, "", value, usage)
+}
+
+func (f *FlagSet) Uint64VarP(p *uint64, name, shorthand string, value uint64, usage string) {
+	*p = value
+	f.VarP((*uint64Value)(p), name, shorthand, usage)
+}
+
+func (f *FlagSet) Float64Var(p *float64, name string, value float64, usage string) {
+	f.Float64VarP(p, name, "", value, usage)
+}
+
+func (f *FlagSet) Float64VarP(p *float64, name, shorthand string, value float64, usage string) {
+	*p = value
+	f.VarP((*float64Value)(p), name, shorthand, usage)
+}
+
+func (f *FlagSet) StringSliceVar(p *[]string, name string, value []string, usage string) {
+	f.StringSliceVarP(p, name, "", value, usage)
+}
+
+func (f *FlagSet) StringSliceVarP(p *[]string, name, shorthand string, value []string, usage string) {
+	*p = append([]string(nil), value...)
+	f.VarP(&stringSliceValue{value: p}, name, shorthand, usage)
+}
+
+// BigIntVar has no pflag counterpart; values accept base-10 or 0x-prefixed
+// hex so wei amounts and hex quantities share a single flag type.
+func (f *FlagSet) BigIntVar(p *big.Int, name string, value *big.Int, usage string) {
+	f.BigIntVarP(p, name, "", value, usage)
+}
+
+func (f *FlagSet) BigIntVarP(p *big.Int, name, shorthand string, value *big.Int, usage string) {
+	if value != nil {
+		p.Set(value)
+	}
+	f.VarP((*bigIntValue)(p), name, shorthand, usage)
+}
+
+func (f *FlagSet) parse(args []string) ([]string, error) {
+	positional := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--":
+			return append(positional, args[i+1:]...), nil
+		case strings.HasPrefix(a, "--"):
+			consumed, err := f.parseLong(a, args[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			i += consumed
+		case strings.HasPrefix(a, "-") && len(a) > 1:
+			consumed, err := f.parseShort(a, args[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			i += consumed
+		default:
+			positional = append(positional, a)
+		}
+	}
+	return positional, nil
+}
+
+func (f *FlagSet) parseLong(arg string, rest []string) (int, error) {
+	name := strings.TrimPrefix(arg, "--")
+	value := ""
+	hasValue := false
+	if idx := strings.Index(name, "="); idx >= 0 {
+		name, value, hasValue = name[:idx], name[idx+1:], true
+	}
+	flag := f.Lookup(name)
+	if flag == nil {
+		return 0, fmt.Errorf("unknown flag: --%s", name)
+	}
+	consumed := 0
+	switch {
+	case hasValue:
+	case flag.NoOptDefVal != "":
+		value = flag.NoOptDefVal
+	case len(rest) > 0:
+		value = rest[0]
+		consumed = 1
+	default:
+		return 0, fmt.Errorf("flag needs an argument: --%s", name)
+	}
+	return consumed, f.Set(flag.Name, value)
+}
+
+func (f *FlagSet) parseShort(arg string, rest []string) (int, error) {
+	shorthands := arg[1:]
+	for len(shorthands) > 0 {
+		c := shorthands[:1]
+		flag := f.ShorthandLookup(c)
+		if flag == nil {
+			return 0, fmt.Errorf("unknown shorthand flag: %q in -%s", c, arg[1:])
+		}
+		remaining := shorthands[1:]
+		switch {
+		case strings.HasPrefix(remaining, "="):
+			return 0, f.Set(flag.Name, remaining[1:])
+		case flag.NoOptDefVal != "":
+			if err := f.Set(flag.Name, flag.NoOptDefVal); err != nil {
+				return 0, err
+			}
+			shorthands = remaining
+		case remaining != "":
+			return 0, f.Set(flag.Name, remaining)
+		case len(rest) > 0:
+			return 1, f.Set(flag.Name, rest[0])
+		default:
+			return 0, fmt.Errorf("flag needs an argument: %q in -%s", c, arg[1:])
+		}
+	}
+	return 0, nil
+}
+
+// takesValue reports whether the flag token consumes the next argument. It
+// is used while locating subcommands, before the full flag set is parsed.
+func (f *FlagSet) takesValue(arg string) bool {
+	if strings.Contains(arg, "=") {
+		return false
+	}
+	var flag *Flag
+	if strings.HasPrefix(arg, "--") {
+		flag = f.Lookup(arg[2:])
+	} else if len(arg) > 1 {
+		flag = f.ShorthandLookup(arg[len(arg)-1:])
+	}
+	return flag != nil && flag.NoOptDefVal == ""
+}
+
+func (f *FlagSet) FlagUsages() string {
+	type line struct {
+		head  string
+		usage string
+	}
+	lines := make([]line, 0, len(f.order))
+	width := 0
+	sorted := append([]*Flag(nil), f.order...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	for _, flag := range sorted {
+		if flag.Hidden {
+			continue
+		}
+		head := "      --" + flag.Name
+		if flag.Shorthand != "" {
+			head = "  -" + flag.Shorthand + ", --" + flag.Name
+		}
+		if typ := flag.Value.Type(); typ != "bool" {
+			head += " " + typ
+		}
+		usage := flag.Usage
+		if !isZeroValue(flag) {
+			if flag.Value.Type() == "string" {
+				usage += fmt.Sprintf(" (default %q)", flag.DefValue)
+			} else {
+				usage += fmt.Sprintf(" (default %s)", flag.DefValue)
+			}
+		}
+		if len(head) > width {
+			width = len(head)
+		}
+		lines = append(lines, line{head: head, usage: usage})
+	}
+	var sb strings.Builder
+	for _, l := range lines {
+		fmt.Fprintf(&sb, "%-*s   %s\n", width, l.head, l.usage)
+	}
+	return sb.String()
+}
+
+func isZeroValue(flag *Flag) bool {
+	if flag.Value.Type() == "string" {
+		return flag.DefValue == ""
+	}
+	switch flag.DefValue {
+	case "", "false", "0", "0s", "[]", "<nil>":
+		return true
+	}
+	return false
+}
+
+func flagDisplayName(flag *Flag) string {
+	if flag.Shorthand != "" {
+		return "-" + flag.Shorthand + ", --" + flag.Name
+	}
+	return "--" + flag.Name
+}
+
+type stringValue string
+
+func (s *stringValue) Set(v string) error { *s = stringValue(v); return nil }
+func (s *stringValue) Type() string       { return "string" }
+func (s *stringValue) String() string     { return string(*s) }
+
+type boolValue bool
+
+func (b *boolValue) Set(v string) error {
+	parsed, err := strconv.ParseBool(v)
+	if err != nil {
+		return err
+	}
+	*b = boolValue(parsed)
+	return nil
+}
+func (b *boolValue) Type() string   { return "bool" }
+func (b *boolValue) String() string { return strconv.FormatBool(bool(*b)) }
+
+type durationValue time.Duration
+
+func (d *durationValue) Set(v string) error {
+	parsed, err := time.ParseDuration(v)
+	if err != nil {
+		return err
+	}
+	*d = durationValue(parsed)
+	return nil
+}
+func (d *durationValue) Type() string   { return "duration" }
+func (d *durationValue) String() string { return time.Duration(*d).String() }
+
+type intValue int
+
+func (n *intValue) Set(v string) error {
+	parsed, err := strconv.ParseInt(v, 0, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	*n = intValue(parsed)
+	return nil
+}
+func (n *intValue) Type() string   { return "int" }
+func (n *intValue) String() string { return strconv.Itoa(int(*n)) }
+
+type uint64Value uint64
+
+func (n *uint64Value) Set(v string) error {
+	parsed, err := strconv.ParseUint(v, 0, 64)
+	if err != nil {
+		return err
+	}
+	*n = uint64Value(parsed)
+	return nil
+}
+func (n *uint64Value) Type() string   { return "uint64" }
+func (n *uint64Value) String() string { return strconv.FormatUint(uint64(*n), 10) }
+
+type float64Value float64
+
+func (n *float64Value) Set(v string) error {
+	parsed, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return err
+	}
+	*n = float64Value(parsed)
+	return nil
+}
+func (n *float64Value) Type() string   { return "float64" }
+func (n *float64Value) String() string { return strconv.FormatFloat(float64(*n), 'g', -1, 64) }
+
+type stringSliceValue struct {
+	value   *[]string
+	changed bool
+}
+
+func (s *stringSliceValue) Set(v string) error {
+	parts := []string{}
+	if v != "" {
+		parts = strings.Split(v, ",")
+	}
+	if !s.changed {
+		*s.value = parts
+		s.changed = true
+		return nil
+	}
+	*s.value = append(*s.value, parts...)
+	return nil
+}
+func (s *stringSliceValue) Type() string   { return "strings" }
+func (s *stringSliceValue) String() string { return "[" + strings.Join(*s.value, ",") + "]" }
+
+type bigIntValue big.Int
+
+func (b *bigIntValue) Set(v string) error {
+	v = strings.TrimSpace(v)
+	var (
+		parsed *big.Int
+		ok     bool
+	)
+	if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+		parsed, ok = new(big.Int).SetString(v[2:], 16)
+	} else {
+		parsed, ok = new(big.Int).SetString(v, 10)
+	}
+	if !ok {
+		return fmt.Errorf("must be a base-10 or 0x-hex integer")
+	}
+	(*big.Int)(b).Set(parsed)
+	return nil
+}
+func (b *bigIntValue) Type() string   { return "bigInt" }
+func (b *bigIntValue) String() string { return (*big.Int)(b).String() }
@@ -0,0 +1,40 @@
+package contracts
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RPCCaller runs the generated callers over an rpc.Client. The client's own
+// timeout applies; the context is ignored.
+type RPCCaller struct {
+	Client *rpc.Client
+}
+
+func (c RPCCaller) CodeAt(_ context.Context, addr common.Address, block *big.Int) ([]byte, error) {
+	return c.Client.CodeAt(addr, blockArg(block))
+}
+
+func (c RPCCaller) CallContract(_ context.Context, call ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	msg := rpc.CallMsg{From: call.From, To: call.To, Data: call.Data}
+	if call.Gas > 0 {
+		gas := hexutil.Uint64(call.Gas)
+		msg.Gas = &gas
+	}
+	if call.Value != nil {
+		msg.Value = (*hexutil.Big)(call.Value)
+	}
+	return c.Client.CallContract(msg, blockArg(block))
+}
+
+func blockArg(block *big.Int) any {
+	if block == nil {
+		return "latest"
+	}
+	return hexutil.EncodeBig(block)
+}
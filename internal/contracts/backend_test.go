@@ -0,0 +1,61 @@
+package contracts
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestRPCCaller(t *testing.T) {
+	parsed, err := IQikStakingMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	staking := common.HexToAddress("0x10000000000000000000000000000000000000aa")
+	operator := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	want, _ := parsed.Methods["totalStakeOf"].Outputs.Pack(big.NewInt(5))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		var msg rpc.CallMsg
+		var block string
+		if req.Method != "eth_call" || json.Unmarshal(req.Params[0], &msg) != nil || json.Unmarshal(req.Params[1], &block) != nil {
+			t.Fatalf("unexpected request %s %s", req.Method, req.Params)
+		}
+		if *msg.To != staking || block != "0x7" {
+			t.Fatalf("call to %s at %s", msg.To, block)
+		}
+		in, _ := parsed.Pack("totalStakeOf", operator)
+		if hexutil.Encode(msg.Data) != hexutil.Encode(in) {
+			t.Fatalf("data %x", msg.Data)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": hexutil.Encode(want)})
+	}))
+	defer srv.Close()
+
+	caller, err := NewIQikStakingCaller(staking, RPCCaller{Client: rpc.NewClient(srv.URL, 2*time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := caller.TotalStakeOf(&bind.CallOpts{BlockNumber: big.NewInt(7)}, operator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Int64() != 5 {
+		t.Fatalf("totalStakeOf = %s", got)
+	}
+}
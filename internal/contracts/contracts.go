@@ -0,0 +1,53 @@
+// Package contracts holds typed Go bindings for the QikChain system contracts.
+// The bindings are generated from abi/*.json; regenerate them with
+//
+//	go generate ./internal/contracts
+//
+// after changing a contract and its ABI. Tests fail when a binding, its ABI
+// and the Solidity source disagree.
+package contracts
+
+//go:generate go run ./gen -abi ../../abi -out .
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// Binding describes one generated binding.
+type Binding struct {
+	Type   string // Go type name and ABI file stem
+	Source string // Solidity source, relative to the repository root
+}
+
+var Bindings = []Binding{
+	{Type: "IQikStaking", Source: "contracts/interfaces/IQikStaking.sol"},
+	{Type: "IQikValidatorSet", Source: "contracts/interfaces/IQikValidatorSet.sol"},
+	{Type: "IQikGov", Source: "contracts/interfaces/IQikGov.sol"},
+	{Type: "EpochManager", Source: "contracts/pos/EpochManager.sol"},
+	{Type: "StakeManager", Source: "contracts/pos/StakeManager.sol"},
+	{Type: "ValidatorRegistry", Source: "contracts/pos/ValidatorRegistry.sol"},
+	{Type: "QIKToken", Source: "contracts/pos/QIKToken.sol"},
+}
+
+// FileName is the generated Go file for b.
+func (b Binding) FileName() string {
+	return strings.ToLower(b.Type) + ".go"
+}
+
+// Generate renders the binding for b from abiDir/<Type>.json.
+func Generate(b Binding, abiDir string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(abiDir, b.Type+".json"))
+	if err != nil {
+		return nil, err
+	}
+	code, err := bind.Bind([]string{b.Type}, []string{string(data)}, []string{""}, nil, "contracts", bind.LangGo, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bind %s: %w", b.Type, err)
+	}
+	return []byte(code), nil
+}
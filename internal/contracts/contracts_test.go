@@ -0,0 +1,248 @@
+package contracts
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const repoRoot = "../.."
+
+func TestBindingsUpToDate(t *testing.T) {
+	for _, b := range Bindings {
+		want, err := Generate(b, filepath.Join(repoRoot, "abi"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(b.FileName())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is stale; run go generate ./internal/contracts", b.FileName())
+		}
+	}
+}
+
+// Members the OpenZeppelin base contracts add to the ABI.
+var inherited = map[string][]string{
+	"Ownable":         {"owner()", "renounceOwnership()", "transferOwnership(address)", "event OwnershipTransferred(address,address)"},
+	"ERC20":           {"name()", "symbol()", "decimals()", "totalSupply()", "balanceOf(address)", "transfer(address,uint256)", "allowance(address,address)", "approve(address,uint256)", "transferFrom(address,address,uint256)", "event Transfer(address,address,uint256)", "event Approval(address,address,uint256)"},
+	"ReentrancyGuard": nil,
+}
+
+func TestABIMatchesSolidity(t *testing.T) {
+	for _, b := range Bindings {
+		src, err := os.ReadFile(filepath.Join(repoRoot, b.Source))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromSource, bases, err := soliditySignatures(string(src), b.Type)
+		if err != nil {
+			t.Fatalf("%s: %v", b.Source, err)
+		}
+		for _, base := range bases {
+			members, ok := inherited[base]
+			if !ok {
+				t.Fatalf("%s: unknown base contract %s", b.Source, base)
+			}
+			fromSource = append(fromSource, members...)
+		}
+
+		data, err := os.ReadFile(filepath.Join(repoRoot, "abi", b.Type+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := abi.JSON(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromABI := abiSignatures(&parsed)
+
+		if diff := symmetricDiff(fromSource, fromABI); diff != "" {
+			t.Errorf("abi/%s.json drifted from %s:\n%s", b.Type, b.Source, diff)
+		}
+	}
+}
+
+func abiSignatures(a *abi.ABI) []string {
+	var out []string
+	for _, m := range a.Methods {
+		out = append(out, m.Sig)
+	}
+	for _, e := range a.Events {
+		out = append(out, "event "+e.Sig)
+	}
+	if len(a.Constructor.Inputs) > 0 {
+		out = append(out, "constructor"+argSig(a.Constructor.Inputs))
+	}
+	return out
+}
+
+func argSig(args abi.Arguments) string {
+	types := make([]string, len(args))
+	for i, arg := range args {
+		types[i] = arg.Type.String()
+	}
+	return "(" + strings.Join(types, ",") + ")"
+}
+
+func symmetricDiff(source, fromABI []string) string {
+	inSource := map[string]bool{}
+	for _, s := range source {
+		inSource[s] = true
+	}
+	inABI := map[string]bool{}
+	for _, s := range fromABI {
+		inABI[s] = true
+	}
+	var lines []string
+	for s := range inSource {
+		if !inABI[s] {
+			lines = append(lines, "  missing from ABI: "+s)
+		}
+	}
+	for s := range inABI {
+		if !inSource[s] {
+			lines = append(lines, "  not in source:    "+s)
+		}
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+var (
+	commentRe    = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	contractRe   = regexp.MustCompile(`(?:contract|interface)\s+(\w+)(?:\s+is\s+([^{]+))?\s*\{`)
+	structRe     = regexp.MustCompile(`struct\s+(\w+)\s*\{([^}]*)\}`)
+	functionRe   = regexp.MustCompile(`function\s+(\w+)\s*\(([^)]*)\)([^{;]*)`)
+	eventRe      = regexp.MustCompile(`event\s+(\w+)\s*\(([^)]*)\)`)
+	ctorRe       = regexp.MustCompile(`constructor\s*\(([^)]*)\)`)
+	stateVarRe   = regexp.MustCompile(`(?m)^\s*((?:mapping\s*\(.*\))|[\w\[\]]+)\s+public\b[^;=]*?\b(\w+)\s*[;=]`)
+	mappingKeyRe = regexp.MustCompile(`mapping\s*\(\s*(\w+)`)
+	baseNameRe   = regexp.MustCompile(`^\s*(\w+)`)
+)
+
+// soliditySignatures extracts the external interface of contract name from
+// src: public and external functions, public state variable getters, events
+// and the constructor. It also returns the names of the base contracts.
+func soliditySignatures(src, name string) ([]string, []string, error) {
+	src = commentRe.ReplaceAllString(src, "")
+
+	var body string
+	var bases []string
+	for _, m := range contractRe.FindAllStringSubmatchIndex(src, -1) {
+		if src[m[2]:m[3]] != name {
+			continue
+		}
+		if m[4] >= 0 {
+			for _, base := range strings.Split(src[m[4]:m[5]], ",") {
+				if bm := baseNameRe.FindStringSubmatch(base); bm != nil {
+					bases = append(bases, bm[1])
+				}
+			}
+		}
+		end, depth := m[1], 1
+		for ; end < len(src) && depth > 0; end++ {
+			switch src[end] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			}
+		}
+		body = src[m[1] : end-1]
+	}
+	if body == "" {
+		return nil, nil, errNoContract(name)
+	}
+
+	structs := map[string]string{}
+	for _, m := range structRe.FindAllStringSubmatch(body, -1) {
+		var fields []string
+		for _, field := range strings.Split(m[2], ";") {
+			if f := strings.Fields(field); len(f) > 0 {
+				fields = append(fields, f[0])
+			}
+		}
+		structs[m[1]] = strings.Join(fields, ",")
+	}
+	canonical := func(typ string) string {
+		suffix := ""
+		if i := strings.Index(typ, "["); i >= 0 {
+			typ, suffix = typ[:i], typ[i:]
+		}
+		switch {
+		case typ == "uint":
+			typ = "uint256"
+		case typ == "int":
+			typ = "int256"
+		case structs[typ] != "":
+			typ = "(" + structs[typ] + ")"
+		case typ != "" && typ[0] >= 'A' && typ[0] <= 'Z':
+			typ = "address" // contract and interface types
+		}
+		return typ + suffix
+	}
+	params := func(list string) string {
+		var types []string
+		for _, p := range strings.Split(list, ",") {
+			if f := strings.Fields(p); len(f) > 0 {
+				types = append(types, canonical(f[0]))
+			}
+		}
+		return "(" + strings.Join(types, ",") + ")"
+	}
+
+	// Drop function bodies so only declarations remain.
+	var decls strings.Builder
+	depth := 0
+	for _, c := range body {
+		switch {
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				decls.WriteString(";\n")
+			}
+		case depth == 0:
+			decls.WriteRune(c)
+		}
+	}
+	text := decls.String()
+
+	var out []string
+	for _, m := range functionRe.FindAllStringSubmatch(text, -1) {
+		if strings.Contains(m[3], "external") || strings.Contains(m[3], "public") {
+			out = append(out, m[1]+params(m[2]))
+		}
+	}
+	for _, m := range eventRe.FindAllStringSubmatch(text, -1) {
+		out = append(out, "event "+m[1]+params(m[2]))
+	}
+	if m := ctorRe.FindStringSubmatch(text); m != nil && strings.TrimSpace(m[1]) != "" {
+		out = append(out, "constructor"+params(m[1]))
+	}
+	for _, m := range stateVarRe.FindAllStringSubmatch(text, -1) {
+		var keys []string
+		for _, k := range mappingKeyRe.FindAllStringSubmatch(m[1], -1) {
+			keys = append(keys, canonical(k[1]))
+		}
+		if strings.HasSuffix(m[1], "[]") {
+			keys = append(keys, "uint256")
+		}
+		out = append(out, m[2]+"("+strings.Join(keys, ",")+")")
+	}
+	return out, bases, nil
+}
+
+type errNoContract string
+
+func (e errNoContract) Error() string { return "no contract or interface named " + string(e) }
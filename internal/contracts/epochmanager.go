@@ -0,0 +1,760 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// EpochManagerMetaData contains all meta data concerning the EpochManager contract.
+var EpochManagerMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"initialOwner\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"epochLengthBlocks_\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"EPOCH_LENGTH_BLOCKS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"epoch\",\"type\":\"uint256\"}],\"name\":\"activeSetHash\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"hash\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"currentEpoch\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"blockNum\",\"type\":\"uint256\"}],\"name\":\"epochAtBlock\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"epoch\",\"type\":\"uint256\"}],\"name\":\"getActiveSet\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"blockNum\",\"type\":\"uint256\"}],\"name\":\"isEpochBoundary\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"epoch\",\"type\":\"uint256\"},{\"internalType\":\"address[]\",\"name\":\"operators\",\"type\":\"address[]\"}],\"name\":\"snapshotActiveSet\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"epoch\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"activeSetHash\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"operatorCount\",\"type\":\"uint256\"}],\"name\":\"ActiveSetSnapshotted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"OwnableInvalidOwner\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"OwnableUnauthorizedAccount\",\"type\":\"error\"}]",
+}
+
+// EpochManagerABI is the input ABI used to generate the binding from.
+// Deprecated: Use EpochManagerMetaData.ABI instead.
+var EpochManagerABI = EpochManagerMetaData.ABI
+
+// EpochManager is an auto generated Go binding around an Ethereum contract.
+type EpochManager struct {
+	EpochManagerCaller     // Read-only binding to the contract
+	EpochManagerTransactor // Write-only binding to the contract
+	EpochManagerFilterer   // Log filterer for contract events
+}
+
+// EpochManagerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type EpochManagerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EpochManagerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type EpochManagerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EpochManagerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type EpochManagerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EpochManagerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type EpochManagerSession struct {
+	Contract     *EpochManager     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// EpochManagerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type EpochManagerCallerSession struct {
+	Contract *EpochManagerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// EpochManagerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type EpochManagerTransactorSession struct {
+	Contract     *EpochManagerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// EpochManagerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type EpochManagerRaw struct {
+	Contract *EpochManager // Generic contract binding to access the raw methods on
+}
+
+// EpochManagerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type EpochManagerCallerRaw struct {
+	Contract *EpochManagerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// EpochManagerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type EpochManagerTransactorRaw struct {
+	Contract *EpochManagerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewEpochManager creates a new instance of EpochManager, bound to a specific deployed contract.
+func NewEpochManager(address common.Address, backend bind.ContractBackend) (*EpochManager, error) {
+	contract, err := bindEpochManager(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &EpochManager{EpochManagerCaller: EpochManagerCaller{contract: contract}, EpochManagerTransactor: EpochManagerTransactor{contract: contract}, EpochManagerFilterer: EpochManagerFilterer{contract: contract}}, nil
+}
+
+// NewEpochManagerCaller creates a new read-only instance of EpochManager, bound to a specific deployed contract.
+func NewEpochManagerCaller(address common.Address, caller bind.ContractCaller) (*EpochManagerCaller, error) {
+	contract, err := bindEpochManager(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EpochManagerCaller{contract: contract}, nil
+}
+
+// NewEpochManagerTransactor creates a new write-only instance of EpochManager, bound to a specific deployed contract.
+func NewEpochManagerTransactor(address common.Address, transactor bind.ContractTransactor) (*EpochManagerTransactor, error) {
+	contract, err := bindEpochManager(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EpochManagerTransactor{contract: contract}, nil
+}
+
+// NewEpochManagerFilterer creates a new log filterer instance of EpochManager, bound to a specific deployed contract.
+func NewEpochManagerFilterer(address common.Address, filterer bind.ContractFilterer) (*EpochManagerFilterer, error) {
+	contract, err := bindEpochManager(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &EpochManagerFilterer{contract: contract}, nil
+}
+
+// bindEpochManager binds a generic wrapper to an already deployed contract.
+func bindEpochManager(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := EpochManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_EpochManager *EpochManagerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _EpochManager.Contract.EpochManagerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_EpochManager *EpochManagerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EpochManager.Contract.EpochManagerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_EpochManager *EpochManagerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _EpochManager.Contract.EpochManagerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_EpochManager *EpochManagerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _EpochManager.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_EpochManager *EpochManagerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EpochManager.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_EpochManager *EpochManagerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _EpochManager.Contract.contract.Transact(opts, method, params...)
+}
+
+// EPOCHLENGTHBLOCKS is a free data retrieval call binding the contract method 0x41cc9a29.
+//
+// Solidity: function EPOCH_LENGTH_BLOCKS() view returns(uint256)
+func (_EpochManager *EpochManagerCaller) EPOCHLENGTHBLOCKS(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _EpochManager.contract.Call(opts, &out, "EPOCH_LENGTH_BLOCKS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// EPOCHLENGTHBLOCKS is a free data retrieval call binding the contract method 0x41cc9a29.
+//
+// Solidity: function EPOCH_LENGTH_BLOCKS() view returns(uint256)
+func (_EpochManager *EpochManagerSession) EPOCHLENGTHBLOCKS() (*big.Int, error) {
+	return _EpochManager.Contract.EPOCHLENGTHBLOCKS(&_EpochManager.CallOpts)
+}
+
+// EPOCHLENGTHBLOCKS is a free data retrieval call binding the contract method 0x41cc9a29.
+//
+// Solidity: function EPOCH_LENGTH_BLOCKS() view returns(uint256)
+func (_EpochManager *EpochManagerCallerSession) EPOCHLENGTHBLOCKS() (*big.Int, error) {
+	return _EpochManager.Contract.EPOCHLENGTHBLOCKS(&_EpochManager.CallOpts)
+}
+
+// ActiveSetHash is a free data retrieval call binding the contract method 0x509e610f.
+//
+// Solidity: function activeSetHash(uint256 epoch) view returns(bytes32 hash)
+func (_EpochManager *EpochManagerCaller) ActiveSetHash(opts *bind.CallOpts, epoch *big.Int) ([32]byte, error) {
+	var out []interface{}
+	err := _EpochManager.contract.Call(opts, &out, "activeSetHash", epoch)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// ActiveSetHash is a free data retrieval call binding the contract method 0x509e610f.
+//
+// Solidity: function activeSetHash(uint256 epoch) view returns(bytes32 hash)
+func (_EpochManager *EpochManagerSession) ActiveSetHash(epoch *big.Int) ([32]byte, error) {
+	return _EpochManager.Contract.ActiveSetHash(&_EpochManager.CallOpts, epoch)
+}
+
+// ActiveSetHash is a free data retrieval call binding the contract method 0x509e610f.
+//
+// Solidity: function activeSetHash(uint256 epoch) view returns(bytes32 hash)
+func (_EpochManager *EpochManagerCallerSession) ActiveSetHash(epoch *big.Int) ([32]byte, error) {
+	return _EpochManager.Contract.ActiveSetHash(&_EpochManager.CallOpts, epoch)
+}
+
+// CurrentEpoch is a free data retrieval call binding the contract method 0x76671808.
+//
+// Solidity: function currentEpoch() view returns(uint256)
+func (_EpochManager *EpochManagerCaller) CurrentEpoch(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _EpochManager.contract.Call(opts, &out, "currentEpoch")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CurrentEpoch is a free data retrieval call binding the contract method 0x76671808.
+//
+// Solidity: function currentEpoch() view returns(uint256)
+func (_EpochManager *EpochManagerSession) CurrentEpoch() (*big.Int, error) {
+	return _EpochManager.Contract.CurrentEpoch(&_EpochManager.CallOpts)
+}
+
+// CurrentEpoch is a free data retrieval call binding the contract method 0x76671808.
+//
+// Solidity: function currentEpoch() view returns(uint256)
+func (_EpochManager *EpochManagerCallerSession) CurrentEpoch() (*big.Int, error) {
+	return _EpochManager.Contract.CurrentEpoch(&_EpochManager.CallOpts)
+}
+
+// EpochAtBlock is a free data retrieval call binding the contract method 0xfc740320.
+//
+// Solidity: function epochAtBlock(uint256 blockNum) view returns(uint256)
+func (_EpochManager *EpochManagerCaller) EpochAtBlock(opts *bind.CallOpts, blockNum *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _EpochManager.contract.Call(opts, &out, "epochAtBlock", blockNum)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// EpochAtBlock is a free data retrieval call binding the contract method 0xfc740320.
+//
+// Solidity: function epochAtBlock(uint256 blockNum) view returns(uint256)
+func (_EpochManager *EpochManagerSession) EpochAtBlock(blockNum *big.Int) (*big.Int, error) {
+	return _EpochManager.Contract.EpochAtBlock(&_EpochManager.CallOpts, blockNum)
+}
+
+// EpochAtBlock is a free data retrieval call binding the contract method 0xfc740320.
+//
+// Solidity: function epochAtBlock(uint256 blockNum) view returns(uint256)
+func (_EpochManager *EpochManagerCallerSession) EpochAtBlock(blockNum *big.Int) (*big.Int, error) {
+	return _EpochManager.Contract.EpochAtBlock(&_EpochManager.CallOpts, blockNum)
+}
+
+// GetActiveSet is a free data retrieval call binding the contract method 0x61cc9266.
+//
+// Solidity: function getActiveSet(uint256 epoch) view returns(address[])
+func (_EpochManager *EpochManagerCaller) GetActiveSet(opts *bind.CallOpts, epoch *big.Int) ([]common.Address, error) {
+	var out []interface{}
+	err := _EpochManager.contract.Call(opts, &out, "getActiveSet", epoch)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetActiveSet is a free data retrieval call binding the contract method 0x61cc9266.
+//
+// Solidity: function getActiveSet(uint256 epoch) view returns(address[])
+func (_EpochManager *EpochManagerSession) GetActiveSet(epoch *big.Int) ([]common.Address, error) {
+	return _EpochManager.Contract.GetActiveSet(&_EpochManager.CallOpts, epoch)
+}
+
+// GetActiveSet is a free data retrieval call binding the contract method 0x61cc9266.
+//
+// Solidity: function getActiveSet(uint256 epoch) view returns(address[])
+func (_EpochManager *EpochManagerCallerSession) GetActiveSet(epoch *big.Int) ([]common.Address, error) {
+	return _EpochManager.Contract.GetActiveSet(&_EpochManager.CallOpts, epoch)
+}
+
+// IsEpochBoundary is a free data retrieval call binding the contract method 0x65d04658.
+//
+// Solidity: function isEpochBoundary(uint256 blockNum) view returns(bool)
+func (_EpochManager *EpochManagerCaller) IsEpochBoundary(opts *bind.CallOpts, blockNum *big.Int) (bool, error) {
+	var out []interface{}
+	err := _EpochManager.contract.Call(opts, &out, "isEpochBoundary", blockNum)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsEpochBoundary is a free data retrieval call binding the contract method 0x65d04658.
+//
+// Solidity: function isEpochBoundary(uint256 blockNum) view returns(bool)
+func (_EpochManager *EpochManagerSession) IsEpochBoundary(blockNum *big.Int) (bool, error) {
+	return _EpochManager.Contract.IsEpochBoundary(&_EpochManager.CallOpts, blockNum)
+}
+
+// IsEpochBoundary is a free data retrieval call binding the contract method 0x65d04658.
+//
+// Solidity: function isEpochBoundary(uint256 blockNum) view returns(bool)
+func (_EpochManager *EpochManagerCallerSession) IsEpochBoundary(blockNum *big.Int) (bool, error) {
+	return _EpochManager.Contract.IsEpochBoundary(&_EpochManager.CallOpts, blockNum)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_EpochManager *EpochManagerCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _EpochManager.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_EpochManager *EpochManagerSession) Owner() (common.Address, error) {
+	return _EpochManager.Contract.Owner(&_EpochManager.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_EpochManager *EpochManagerCallerSession) Owner() (common.Address, error) {
+	return _EpochManager.Contract.Owner(&_EpochManager.CallOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_EpochManager *EpochManagerTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EpochManager.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_EpochManager *EpochManagerSession) RenounceOwnership() (*types.Transaction, error) {
+	return _EpochManager.Contract.RenounceOwnership(&_EpochManager.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_EpochManager *EpochManagerTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _EpochManager.Contract.RenounceOwnership(&_EpochManager.TransactOpts)
+}
+
+// SnapshotActiveSet is a paid mutator transaction binding the contract method 0x80da5cb7.
+//
+// Solidity: function snapshotActiveSet(uint256 epoch, address[] operators) returns()
+func (_EpochManager *EpochManagerTransactor) SnapshotActiveSet(opts *bind.TransactOpts, epoch *big.Int, operators []common.Address) (*types.Transaction, error) {
+	return _EpochManager.contract.Transact(opts, "snapshotActiveSet", epoch, operators)
+}
+
+// SnapshotActiveSet is a paid mutator transaction binding the contract method 0x80da5cb7.
+//
+// Solidity: function snapshotActiveSet(uint256 epoch, address[] operators) returns()
+func (_EpochManager *EpochManagerSession) SnapshotActiveSet(epoch *big.Int, operators []common.Address) (*types.Transaction, error) {
+	return _EpochManager.Contract.SnapshotActiveSet(&_EpochManager.TransactOpts, epoch, operators)
+}
+
+// SnapshotActiveSet is a paid mutator transaction binding the contract method 0x80da5cb7.
+//
+// Solidity: function snapshotActiveSet(uint256 epoch, address[] operators) returns()
+func (_EpochManager *EpochManagerTransactorSession) SnapshotActiveSet(epoch *big.Int, operators []common.Address) (*types.Transaction, error) {
+	return _EpochManager.Contract.SnapshotActiveSet(&_EpochManager.TransactOpts, epoch, operators)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_EpochManager *EpochManagerTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _EpochManager.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_EpochManager *EpochManagerSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _EpochManager.Contract.TransferOwnership(&_EpochManager.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_EpochManager *EpochManagerTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _EpochManager.Contract.TransferOwnership(&_EpochManager.TransactOpts, newOwner)
+}
+
+// EpochManagerActiveSetSnapshottedIterator is returned from FilterActiveSetSnapshotted and is used to iterate over the raw logs and unpacked data for ActiveSetSnapshotted events raised by the EpochManager contract.
+type EpochManagerActiveSetSnapshottedIterator struct {
+	Event *EpochManagerActiveSetSnapshotted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EpochManagerActiveSetSnapshottedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EpochManagerActiveSetSnapshotted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EpochManagerActiveSetSnapshotted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EpochManagerActiveSetSnapshottedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EpochManagerActiveSetSnapshottedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EpochManagerActiveSetSnapshotted represents a ActiveSetSnapshotted event raised by the EpochManager contract.
+type EpochManagerActiveSetSnapshotted struct {
+	Epoch         *big.Int
+	ActiveSetHash [32]byte
+	OperatorCount *big.Int
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterActiveSetSnapshotted is a free log retrieval operation binding the contract event 0xa80ebaff81774aadb42c80268d14f8f3294e8ce93baab981abea1a9ba695bab6.
+//
+// Solidity: event ActiveSetSnapshotted(uint256 indexed epoch, bytes32 activeSetHash, uint256 operatorCount)
+func (_EpochManager *EpochManagerFilterer) FilterActiveSetSnapshotted(opts *bind.FilterOpts, epoch []*big.Int) (*EpochManagerActiveSetSnapshottedIterator, error) {
+
+	var epochRule []interface{}
+	for _, epochItem := range epoch {
+		epochRule = append(epochRule, epochItem)
+	}
+
+	logs, sub, err := _EpochManager.contract.FilterLogs(opts, "ActiveSetSnapshotted", epochRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EpochManagerActiveSetSnapshottedIterator{contract: _EpochManager.contract, event: "ActiveSetSnapshotted", logs: logs, sub: sub}, nil
+}
+
+// WatchActiveSetSnapshotted is a free log subscription operation binding the contract event 0xa80ebaff81774aadb42c80268d14f8f3294e8ce93baab981abea1a9ba695bab6.
+//
+// Solidity: event ActiveSetSnapshotted(uint256 indexed epoch, bytes32 activeSetHash, uint256 operatorCount)
+func (_EpochManager *EpochManagerFilterer) WatchActiveSetSnapshotted(opts *bind.WatchOpts, sink chan<- *EpochManagerActiveSetSnapshotted, epoch []*big.Int) (event.Subscription, error) {
+
+	var epochRule []interface{}
+	for _, epochItem := range epoch {
+		epochRule = append(epochRule, epochItem)
+	}
+
+	logs, sub, err := _EpochManager.contract.WatchLogs(opts, "ActiveSetSnapshotted", epochRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EpochManagerActiveSetSnapshotted)
+				if err := _EpochManager.contract.UnpackLog(event, "ActiveSetSnapshotted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseActiveSetSnapshotted is a log parse operation binding the contract event 0xa80ebaff81774aadb42c80268d14f8f3294e8ce93baab981abea1a9ba695bab6.
+//
+// Solidity: event ActiveSetSnapshotted(uint256 indexed epoch, bytes32 activeSetHash, uint256 operatorCount)
+func (_EpochManager *EpochManagerFilterer) ParseActiveSetSnapshotted(log types.Log) (*EpochManagerActiveSetSnapshotted, error) {
+	event := new(EpochManagerActiveSetSnapshotted)
+	if err := _EpochManager.contract.UnpackLog(event, "ActiveSetSnapshotted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EpochManagerOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the EpochManager contract.
+type EpochManagerOwnershipTransferredIterator struct {
+	Event *EpochManagerOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EpochManagerOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EpochManagerOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EpochManagerOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EpochManagerOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EpochManagerOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EpochManagerOwnershipTransferred represents a OwnershipTransferred event raised by the EpochManager contract.
+type EpochManagerOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_EpochManager *EpochManagerFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*EpochManagerOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _EpochManager.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EpochManagerOwnershipTransferredIterator{contract: _EpochManager.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_EpochManager *EpochManagerFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *EpochManagerOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _EpochManager.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EpochManagerOwnershipTransferred)
+				if err := _EpochManager.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_EpochManager *EpochManagerFilterer) ParseOwnershipTransferred(log types.Log) (*EpochManagerOwnershipTransferred, error) {
+	event := new(EpochManagerOwnershipTransferred)
+	if err := _EpochManager.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
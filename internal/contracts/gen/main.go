@@ -0,0 +1,29 @@
+// Command gen regenerates the bindings in internal/contracts.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/BioMark3r/qikchain/internal/contracts"
+)
+
+func main() {
+	abiDir := flag.String("abi", "abi", "directory holding <Type>.json ABI files")
+	outDir := flag.String("out", "internal/contracts", "output directory")
+	flag.Parse()
+
+	for _, b := range contracts.Bindings {
+		code, err := contracts.Generate(b, *abiDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		path := filepath.Join(*outDir, b.FileName())
+		if err := os.WriteFile(path, code, 0o644); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("wrote %s", path)
+	}
+}
@@ -0,0 +1,262 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// IQikGovMetaData contains all meta data concerning the IQikGov contract.
+var IQikGovMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"requireOwner\",\"outputs\":[],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// IQikGovABI is the input ABI used to generate the binding from.
+// Deprecated: Use IQikGovMetaData.ABI instead.
+var IQikGovABI = IQikGovMetaData.ABI
+
+// IQikGov is an auto generated Go binding around an Ethereum contract.
+type IQikGov struct {
+	IQikGovCaller     // Read-only binding to the contract
+	IQikGovTransactor // Write-only binding to the contract
+	IQikGovFilterer   // Log filterer for contract events
+}
+
+// IQikGovCaller is an auto generated read-only Go binding around an Ethereum contract.
+type IQikGovCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IQikGovTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type IQikGovTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IQikGovFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IQikGovFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IQikGovSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IQikGovSession struct {
+	Contract     *IQikGov          // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IQikGovCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IQikGovCallerSession struct {
+	Contract *IQikGovCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts  // Call options to use throughout this session
+}
+
+// IQikGovTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IQikGovTransactorSession struct {
+	Contract     *IQikGovTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// IQikGovRaw is an auto generated low-level Go binding around an Ethereum contract.
+type IQikGovRaw struct {
+	Contract *IQikGov // Generic contract binding to access the raw methods on
+}
+
+// IQikGovCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IQikGovCallerRaw struct {
+	Contract *IQikGovCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// IQikGovTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IQikGovTransactorRaw struct {
+	Contract *IQikGovTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIQikGov creates a new instance of IQikGov, bound to a specific deployed contract.
+func NewIQikGov(address common.Address, backend bind.ContractBackend) (*IQikGov, error) {
+	contract, err := bindIQikGov(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikGov{IQikGovCaller: IQikGovCaller{contract: contract}, IQikGovTransactor: IQikGovTransactor{contract: contract}, IQikGovFilterer: IQikGovFilterer{contract: contract}}, nil
+}
+
+// NewIQikGovCaller creates a new read-only instance of IQikGov, bound to a specific deployed contract.
+func NewIQikGovCaller(address common.Address, caller bind.ContractCaller) (*IQikGovCaller, error) {
+	contract, err := bindIQikGov(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikGovCaller{contract: contract}, nil
+}
+
+// NewIQikGovTransactor creates a new write-only instance of IQikGov, bound to a specific deployed contract.
+func NewIQikGovTransactor(address common.Address, transactor bind.ContractTransactor) (*IQikGovTransactor, error) {
+	contract, err := bindIQikGov(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikGovTransactor{contract: contract}, nil
+}
+
+// NewIQikGovFilterer creates a new log filterer instance of IQikGov, bound to a specific deployed contract.
+func NewIQikGovFilterer(address common.Address, filterer bind.ContractFilterer) (*IQikGovFilterer, error) {
+	contract, err := bindIQikGov(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikGovFilterer{contract: contract}, nil
+}
+
+// bindIQikGov binds a generic wrapper to an already deployed contract.
+func bindIQikGov(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := IQikGovMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IQikGov *IQikGovRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IQikGov.Contract.IQikGovCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IQikGov *IQikGovRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IQikGov.Contract.IQikGovTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IQikGov *IQikGovRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IQikGov.Contract.IQikGovTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IQikGov *IQikGovCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IQikGov.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IQikGov *IQikGovTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IQikGov.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IQikGov *IQikGovTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IQikGov.Contract.contract.Transact(opts, method, params...)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_IQikGov *IQikGovCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _IQikGov.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_IQikGov *IQikGovSession) Owner() (common.Address, error) {
+	return _IQikGov.Contract.Owner(&_IQikGov.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_IQikGov *IQikGovCallerSession) Owner() (common.Address, error) {
+	return _IQikGov.Contract.Owner(&_IQikGov.CallOpts)
+}
+
+// RequireOwner is a free data retrieval call binding the contract method 0x55f11369.
+//
+// Solidity: function requireOwner() view returns()
+func (_IQikGov *IQikGovCaller) RequireOwner(opts *bind.CallOpts) error {
+	var out []interface{}
+	err := _IQikGov.contract.Call(opts, &out, "requireOwner")
+
+	if err != nil {
+		return err
+	}
+
+	return err
+
+}
+
+// RequireOwner is a free data retrieval call binding the contract method 0x55f11369.
+//
+// Solidity: function requireOwner() view returns()
+func (_IQikGov *IQikGovSession) RequireOwner() error {
+	return _IQikGov.Contract.RequireOwner(&_IQikGov.CallOpts)
+}
+
+// RequireOwner is a free data retrieval call binding the contract method 0x55f11369.
+//
+// Solidity: function requireOwner() view returns()
+func (_IQikGov *IQikGovCallerSession) RequireOwner() error {
+	return _IQikGov.Contract.RequireOwner(&_IQikGov.CallOpts)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_IQikGov *IQikGovTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _IQikGov.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_IQikGov *IQikGovSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _IQikGov.Contract.TransferOwnership(&_IQikGov.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_IQikGov *IQikGovTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _IQikGov.Contract.TransferOwnership(&_IQikGov.TransactOpts, newOwner)
+}
@@ -0,0 +1,1927 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// IQikStakingOperatorInfo is an auto generated low-level Go binding around an user-defined struct.
+type IQikStakingOperatorInfo struct {
+	Operator     common.Address
+	Payout       common.Address
+	ConsensusKey []byte
+	TotalStake   *big.Int
+	Registered   bool
+	Jailed       bool
+}
+
+// IQikStakingUnbonding is an auto generated low-level Go binding around an user-defined struct.
+type IQikStakingUnbonding struct {
+	Amount     *big.Int
+	UnlockTime *big.Int
+}
+
+// IQikStakingMetaData contains all meta data concerning the IQikStaking contract.
+var IQikStakingMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"consensusKey\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"payout\",\"type\":\"address\"}],\"name\":\"OperatorRegistered\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"newKey\",\"type\":\"bytes\"}],\"name\":\"ConsensusKeyUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"payout\",\"type\":\"address\"}],\"name\":\"PayoutUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"staker\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"Staked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"staker\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"unlockTime\",\"type\":\"uint256\"}],\"name\":\"UnstakeRequested\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"staker\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"UnstakedWithdrawn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"jailed\",\"type\":\"bool\"}],\"name\":\"OperatorJailed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"param\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"ParamsUpdated\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"consensusKey\",\"type\":\"bytes\"},{\"internalType\":\"address\",\"name\":\"payout\",\"type\":\"address\"}],\"name\":\"registerOperator\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"newKey\",\"type\":\"bytes\"}],\"name\":\"updateConsensusKey\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newPayout\",\"type\":\"address\"}],\"name\":\"updatePayout\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"stake\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"staker\",\"type\":\"address\"}],\"name\":\"stakeFor\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"requestUnstake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"withdrawUnstaked\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"jailed\",\"type\":\"bool\"}],\"name\":\"setJailed\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"newMin\",\"type\":\"uint256\"}],\"name\":\"setMinStake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"newMax\",\"type\":\"uint256\"}],\"name\":\"setMaxValidators\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"seconds_\",\"type\":\"uint256\"}],\"name\":\"setUnbondingPeriod\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"minStake\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"maxValidators\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unbondingPeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"getOperator\",\"outputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"payout\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"consensusKey\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"totalStake\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"registered\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"jailed\",\"type\":\"bool\"}],\"internalType\":\"structIQikStaking.OperatorInfo\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"staker\",\"type\":\"address\"}],\"name\":\"stakeOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"totalStakeOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"staker\",\"type\":\"address\"}],\"name\":\"getUnbondings\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unlockTime\",\"type\":\"uint256\"}],\"internalType\":\"structIQikStaking.Unbonding[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getActiveOperators\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getActiveConsensusKeys\",\"outputs\":[{\"internalType\":\"bytes[]\",\"name\":\"\",\"type\":\"bytes[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"isActiveOperator\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// IQikStakingABI is the input ABI used to generate the binding from.
+// Deprecated: Use IQikStakingMetaData.ABI instead.
+var IQikStakingABI = IQikStakingMetaData.ABI
+
+// IQikStaking is an auto generated Go binding around an Ethereum contract.
+type IQikStaking struct {
+	IQikStakingCaller     // Read-only binding to the contract
+	IQikStakingTransactor // Write-only binding to the contract
+	IQikStakingFilterer   // Log filterer for contract events
+}
+
+// IQikStakingCaller is an auto generated read-only Go binding around an Ethereum contract.
+type IQikStakingCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IQikStakingTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type IQikStakingTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IQikStakingFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IQikStakingFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IQikStakingSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IQikStakingSession struct {
+	Contract     *IQikStaking      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IQikStakingCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IQikStakingCallerSession struct {
+	Contract *IQikStakingCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// IQikStakingTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IQikStakingTransactorSession struct {
+	Contract     *IQikStakingTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// IQikStakingRaw is an auto generated low-level Go binding around an Ethereum contract.
+type IQikStakingRaw struct {
+	Contract *IQikStaking // Generic contract binding to access the raw methods on
+}
+
+// IQikStakingCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IQikStakingCallerRaw struct {
+	Contract *IQikStakingCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// IQikStakingTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IQikStakingTransactorRaw struct {
+	Contract *IQikStakingTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIQikStaking creates a new instance of IQikStaking, bound to a specific deployed contract.
+func NewIQikStaking(address common.Address, backend bind.ContractBackend) (*IQikStaking, error) {
+	contract, err := bindIQikStaking(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStaking{IQikStakingCaller: IQikStakingCaller{contract: contract}, IQikStakingTransactor: IQikStakingTransactor{contract: contract}, IQikStakingFilterer: IQikStakingFilterer{contract: contract}}, nil
+}
+
+// NewIQikStakingCaller creates a new read-only instance of IQikStaking, bound to a specific deployed contract.
+func NewIQikStakingCaller(address common.Address, caller bind.ContractCaller) (*IQikStakingCaller, error) {
+	contract, err := bindIQikStaking(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStakingCaller{contract: contract}, nil
+}
+
+// NewIQikStakingTransactor creates a new write-only instance of IQikStaking, bound to a specific deployed contract.
+func NewIQikStakingTransactor(address common.Address, transactor bind.ContractTransactor) (*IQikStakingTransactor, error) {
+	contract, err := bindIQikStaking(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStakingTransactor{contract: contract}, nil
+}
+
+// NewIQikStakingFilterer creates a new log filterer instance of IQikStaking, bound to a specific deployed contract.
+func NewIQikStakingFilterer(address common.Address, filterer bind.ContractFilterer) (*IQikStakingFilterer, error) {
+	contract, err := bindIQikStaking(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStakingFilterer{contract: contract}, nil
+}
+
+// bindIQikStaking binds a generic wrapper to an already deployed contract.
+func bindIQikStaking(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := IQikStakingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IQikStaking *IQikStakingRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IQikStaking.Contract.IQikStakingCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IQikStaking *IQikStakingRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IQikStaking.Contract.IQikStakingTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IQikStaking *IQikStakingRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IQikStaking.Contract.IQikStakingTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IQikStaking *IQikStakingCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IQikStaking.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IQikStaking *IQikStakingTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IQikStaking.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IQikStaking *IQikStakingTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IQikStaking.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetActiveConsensusKeys is a free data retrieval call binding the contract method 0xaa602bd4.
+//
+// Solidity: function getActiveConsensusKeys() view returns(bytes[])
+func (_IQikStaking *IQikStakingCaller) GetActiveConsensusKeys(opts *bind.CallOpts) ([][]byte, error) {
+	var out []interface{}
+	err := _IQikStaking.contract.Call(opts, &out, "getActiveConsensusKeys")
+
+	if err != nil {
+		return *new([][]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([][]byte)).(*[][]byte)
+
+	return out0, err
+
+}
+
+// GetActiveConsensusKeys is a free data retrieval call binding the contract method 0xaa602bd4.
+//
+// Solidity: function getActiveConsensusKeys() view returns(bytes[])
+func (_IQikStaking *IQikStakingSession) GetActiveConsensusKeys() ([][]byte, error) {
+	return _IQikStaking.Contract.GetActiveConsensusKeys(&_IQikStaking.CallOpts)
+}
+
+// GetActiveConsensusKeys is a free data retrieval call binding the contract method 0xaa602bd4.
+//
+// Solidity: function getActiveConsensusKeys() view returns(bytes[])
+func (_IQikStaking *IQikStakingCallerSession) GetActiveConsensusKeys() ([][]byte, error) {
+	return _IQikStaking.Contract.GetActiveConsensusKeys(&_IQikStaking.CallOpts)
+}
+
+// GetActiveOperators is a free data retrieval call binding the contract method 0x64bdc67e.
+//
+// Solidity: function getActiveOperators() view returns(address[])
+func (_IQikStaking *IQikStakingCaller) GetActiveOperators(opts *bind.CallOpts) ([]common.Address, error) {
+	var out []interface{}
+	err := _IQikStaking.contract.Call(opts, &out, "getActiveOperators")
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetActiveOperators is a free data retrieval call binding the contract method 0x64bdc67e.
+//
+// Solidity: function getActiveOperators() view returns(address[])
+func (_IQikStaking *IQikStakingSession) GetActiveOperators() ([]common.Address, error) {
+	return _IQikStaking.Contract.GetActiveOperators(&_IQikStaking.CallOpts)
+}
+
+// GetActiveOperators is a free data retrieval call binding the contract method 0x64bdc67e.
+//
+// Solidity: function getActiveOperators() view returns(address[])
+func (_IQikStaking *IQikStakingCallerSession) GetActiveOperators() ([]common.Address, error) {
+	return _IQikStaking.Contract.GetActiveOperators(&_IQikStaking.CallOpts)
+}
+
+// GetOperator is a free data retrieval call binding the contract method 0x5865c60c.
+//
+// Solidity: function getOperator(address operator) view returns((address,address,bytes,uint256,bool,bool))
+func (_IQikStaking *IQikStakingCaller) GetOperator(opts *bind.CallOpts, operator common.Address) (IQikStakingOperatorInfo, error) {
+	var out []interface{}
+	err := _IQikStaking.contract.Call(opts, &out, "getOperator", operator)
+
+	if err != nil {
+		return *new(IQikStakingOperatorInfo), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(IQikStakingOperatorInfo)).(*IQikStakingOperatorInfo)
+
+	return out0, err
+
+}
+
+// GetOperator is a free data retrieval call binding the contract method 0x5865c60c.
+//
+// Solidity: function getOperator(address operator) view returns((address,address,bytes,uint256,bool,bool))
+func (_IQikStaking *IQikStakingSession) GetOperator(operator common.Address) (IQikStakingOperatorInfo, error) {
+	return _IQikStaking.Contract.GetOperator(&_IQikStaking.CallOpts, operator)
+}
+
+// GetOperator is a free data retrieval call binding the contract method 0x5865c60c.
+//
+// Solidity: function getOperator(address operator) view returns((address,address,bytes,uint256,bool,bool))
+func (_IQikStaking *IQikStakingCallerSession) GetOperator(operator common.Address) (IQikStakingOperatorInfo, error) {
+	return _IQikStaking.Contract.GetOperator(&_IQikStaking.CallOpts, operator)
+}
+
+// GetUnbondings is a free data retrieval call binding the contract method 0xd6eab741.
+//
+// Solidity: function getUnbondings(address operator, address staker) view returns((uint256,uint256)[])
+func (_IQikStaking *IQikStakingCaller) GetUnbondings(opts *bind.CallOpts, operator common.Address, staker common.Address) ([]IQikStakingUnbonding, error) {
+	var out []interface{}
+	err := _IQikStaking.contract.Call(opts, &out, "getUnbondings", operator, staker)
+
+	if err != nil {
+		return *new([]IQikStakingUnbonding), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]IQikStakingUnbonding)).(*[]IQikStakingUnbonding)
+
+	return out0, err
+
+}
+
+// GetUnbondings is a free data retrieval call binding the contract method 0xd6eab741.
+//
+// Solidity: function getUnbondings(address operator, address staker) view returns((uint256,uint256)[])
+func (_IQikStaking *IQikStakingSession) GetUnbondings(operator common.Address, staker common.Address) ([]IQikStakingUnbonding, error) {
+	return _IQikStaking.Contract.GetUnbondings(&_IQikStaking.CallOpts, operator, staker)
+}
+
+// GetUnbondings is a free data retrieval call binding the contract method 0xd6eab741.
+//
+// Solidity: function getUnbondings(address operator, address staker) view returns((uint256,uint256)[])
+func (_IQikStaking *IQikStakingCallerSession) GetUnbondings(operator common.Address, staker common.Address) ([]IQikStakingUnbonding, error) {
+	return _IQikStaking.Contract.GetUnbondings(&_IQikStaking.CallOpts, operator, staker)
+}
+
+// IsActiveOperator is a free data retrieval call binding the contract method 0x3367cca5.
+//
+// Solidity: function isActiveOperator(address operator) view returns(bool)
+func (_IQikStaking *IQikStakingCaller) IsActiveOperator(opts *bind.CallOpts, operator common.Address) (bool, error) {
+	var out []interface{}
+	err := _IQikStaking.contract.Call(opts, &out, "isActiveOperator", operator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsActiveOperator is a free data retrieval call binding the contract method 0x3367cca5.
+//
+// Solidity: function isActiveOperator(address operator) view returns(bool)
+func (_IQikStaking *IQikStakingSession) IsActiveOperator(operator common.Address) (bool, error) {
+	return _IQikStaking.Contract.IsActiveOperator(&_IQikStaking.CallOpts, operator)
+}
+
+// IsActiveOperator is a free data retrieval call binding the contract method 0x3367cca5.
+//
+// Solidity: function isActiveOperator(address operator) view returns(bool)
+func (_IQikStaking *IQikStakingCallerSession) IsActiveOperator(operator common.Address) (bool, error) {
+	return _IQikStaking.Contract.IsActiveOperator(&_IQikStaking.CallOpts, operator)
+}
+
+// MaxValidators is a free data retrieval call binding the contract method 0x08ac5256.
+//
+// Solidity: function maxValidators() view returns(uint256)
+func (_IQikStaking *IQikStakingCaller) MaxValidators(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IQikStaking.contract.Call(opts, &out, "maxValidators")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MaxValidators is a free data retrieval call binding the contract method 0x08ac5256.
+//
+// Solidity: function maxValidators() view returns(uint256)
+func (_IQikStaking *IQikStakingSession) MaxValidators() (*big.Int, error) {
+	return _IQikStaking.Contract.MaxValidators(&_IQikStaking.CallOpts)
+}
+
+// MaxValidators is a free data retrieval call binding the contract method 0x08ac5256.
+//
+// Solidity: function maxValidators() view returns(uint256)
+func (_IQikStaking *IQikStakingCallerSession) MaxValidators() (*big.Int, error) {
+	return _IQikStaking.Contract.MaxValidators(&_IQikStaking.CallOpts)
+}
+
+// MinStake is a free data retrieval call binding the contract method 0x375b3c0a.
+//
+// Solidity: function minStake() view returns(uint256)
+func (_IQikStaking *IQikStakingCaller) MinStake(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IQikStaking.contract.Call(opts, &out, "minStake")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MinStake is a free data retrieval call binding the contract method 0x375b3c0a.
+//
+// Solidity: function minStake() view returns(uint256)
+func (_IQikStaking *IQikStakingSession) MinStake() (*big.Int, error) {
+	return _IQikStaking.Contract.MinStake(&_IQikStaking.CallOpts)
+}
+
+// MinStake is a free data retrieval call binding the contract method 0x375b3c0a.
+//
+// Solidity: function minStake() view returns(uint256)
+func (_IQikStaking *IQikStakingCallerSession) MinStake() (*big.Int, error) {
+	return _IQikStaking.Contract.MinStake(&_IQikStaking.CallOpts)
+}
+
+// StakeOf is a free data retrieval call binding the contract method 0xce4cb876.
+//
+// Solidity: function stakeOf(address operator, address staker) view returns(uint256)
+func (_IQikStaking *IQikStakingCaller) StakeOf(opts *bind.CallOpts, operator common.Address, staker common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _IQikStaking.contract.Call(opts, &out, "stakeOf", operator, staker)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// StakeOf is a free data retrieval call binding the contract method 0xce4cb876.
+//
+// Solidity: function stakeOf(address operator, address staker) view returns(uint256)
+func (_IQikStaking *IQikStakingSession) StakeOf(operator common.Address, staker common.Address) (*big.Int, error) {
+	return _IQikStaking.Contract.StakeOf(&_IQikStaking.CallOpts, operator, staker)
+}
+
+// StakeOf is a free data retrieval call binding the contract method 0xce4cb876.
+//
+// Solidity: function stakeOf(address operator, address staker) view returns(uint256)
+func (_IQikStaking *IQikStakingCallerSession) StakeOf(operator common.Address, staker common.Address) (*big.Int, error) {
+	return _IQikStaking.Contract.StakeOf(&_IQikStaking.CallOpts, operator, staker)
+}
+
+// TotalStakeOf is a free data retrieval call binding the contract method 0xe3f56eaa.
+//
+// Solidity: function totalStakeOf(address operator) view returns(uint256)
+func (_IQikStaking *IQikStakingCaller) TotalStakeOf(opts *bind.CallOpts, operator common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _IQikStaking.contract.Call(opts, &out, "totalStakeOf", operator)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalStakeOf is a free data retrieval call binding the contract method 0xe3f56eaa.
+//
+// Solidity: function totalStakeOf(address operator) view returns(uint256)
+func (_IQikStaking *IQikStakingSession) TotalStakeOf(operator common.Address) (*big.Int, error) {
+	return _IQikStaking.Contract.TotalStakeOf(&_IQikStaking.CallOpts, operator)
+}
+
+// TotalStakeOf is a free data retrieval call binding the contract method 0xe3f56eaa.
+//
+// Solidity: function totalStakeOf(address operator) view returns(uint256)
+func (_IQikStaking *IQikStakingCallerSession) TotalStakeOf(operator common.Address) (*big.Int, error) {
+	return _IQikStaking.Contract.TotalStakeOf(&_IQikStaking.CallOpts, operator)
+}
+
+// UnbondingPeriod is a free data retrieval call binding the contract method 0x6cf6d675.
+//
+// Solidity: function unbondingPeriod() view returns(uint256)
+func (_IQikStaking *IQikStakingCaller) UnbondingPeriod(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IQikStaking.contract.Call(opts, &out, "unbondingPeriod")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UnbondingPeriod is a free data retrieval call binding the contract method 0x6cf6d675.
+//
+// Solidity: function unbondingPeriod() view returns(uint256)
+func (_IQikStaking *IQikStakingSession) UnbondingPeriod() (*big.Int, error) {
+	return _IQikStaking.Contract.UnbondingPeriod(&_IQikStaking.CallOpts)
+}
+
+// UnbondingPeriod is a free data retrieval call binding the contract method 0x6cf6d675.
+//
+// Solidity: function unbondingPeriod() view returns(uint256)
+func (_IQikStaking *IQikStakingCallerSession) UnbondingPeriod() (*big.Int, error) {
+	return _IQikStaking.Contract.UnbondingPeriod(&_IQikStaking.CallOpts)
+}
+
+// RegisterOperator is a paid mutator transaction binding the contract method 0x33fcf853.
+//
+// Solidity: function registerOperator(bytes consensusKey, address payout) returns()
+func (_IQikStaking *IQikStakingTransactor) RegisterOperator(opts *bind.TransactOpts, consensusKey []byte, payout common.Address) (*types.Transaction, error) {
+	return _IQikStaking.contract.Transact(opts, "registerOperator", consensusKey, payout)
+}
+
+// RegisterOperator is a paid mutator transaction binding the contract method 0x33fcf853.
+//
+// Solidity: function registerOperator(bytes consensusKey, address payout) returns()
+func (_IQikStaking *IQikStakingSession) RegisterOperator(consensusKey []byte, payout common.Address) (*types.Transaction, error) {
+	return _IQikStaking.Contract.RegisterOperator(&_IQikStaking.TransactOpts, consensusKey, payout)
+}
+
+// RegisterOperator is a paid mutator transaction binding the contract method 0x33fcf853.
+//
+// Solidity: function registerOperator(bytes consensusKey, address payout) returns()
+func (_IQikStaking *IQikStakingTransactorSession) RegisterOperator(consensusKey []byte, payout common.Address) (*types.Transaction, error) {
+	return _IQikStaking.Contract.RegisterOperator(&_IQikStaking.TransactOpts, consensusKey, payout)
+}
+
+// RequestUnstake is a paid mutator transaction binding the contract method 0x710ba631.
+//
+// Solidity: function requestUnstake(address operator, uint256 amount) returns()
+func (_IQikStaking *IQikStakingTransactor) RequestUnstake(opts *bind.TransactOpts, operator common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.contract.Transact(opts, "requestUnstake", operator, amount)
+}
+
+// RequestUnstake is a paid mutator transaction binding the contract method 0x710ba631.
+//
+// Solidity: function requestUnstake(address operator, uint256 amount) returns()
+func (_IQikStaking *IQikStakingSession) RequestUnstake(operator common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.Contract.RequestUnstake(&_IQikStaking.TransactOpts, operator, amount)
+}
+
+// RequestUnstake is a paid mutator transaction binding the contract method 0x710ba631.
+//
+// Solidity: function requestUnstake(address operator, uint256 amount) returns()
+func (_IQikStaking *IQikStakingTransactorSession) RequestUnstake(operator common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.Contract.RequestUnstake(&_IQikStaking.TransactOpts, operator, amount)
+}
+
+// SetJailed is a paid mutator transaction binding the contract method 0xefc84a76.
+//
+// Solidity: function setJailed(address operator, bool jailed) returns()
+func (_IQikStaking *IQikStakingTransactor) SetJailed(opts *bind.TransactOpts, operator common.Address, jailed bool) (*types.Transaction, error) {
+	return _IQikStaking.contract.Transact(opts, "setJailed", operator, jailed)
+}
+
+// SetJailed is a paid mutator transaction binding the contract method 0xefc84a76.
+//
+// Solidity: function setJailed(address operator, bool jailed) returns()
+func (_IQikStaking *IQikStakingSession) SetJailed(operator common.Address, jailed bool) (*types.Transaction, error) {
+	return _IQikStaking.Contract.SetJailed(&_IQikStaking.TransactOpts, operator, jailed)
+}
+
+// SetJailed is a paid mutator transaction binding the contract method 0xefc84a76.
+//
+// Solidity: function setJailed(address operator, bool jailed) returns()
+func (_IQikStaking *IQikStakingTransactorSession) SetJailed(operator common.Address, jailed bool) (*types.Transaction, error) {
+	return _IQikStaking.Contract.SetJailed(&_IQikStaking.TransactOpts, operator, jailed)
+}
+
+// SetMaxValidators is a paid mutator transaction binding the contract method 0x9bb2ea5a.
+//
+// Solidity: function setMaxValidators(uint256 newMax) returns()
+func (_IQikStaking *IQikStakingTransactor) SetMaxValidators(opts *bind.TransactOpts, newMax *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.contract.Transact(opts, "setMaxValidators", newMax)
+}
+
+// SetMaxValidators is a paid mutator transaction binding the contract method 0x9bb2ea5a.
+//
+// Solidity: function setMaxValidators(uint256 newMax) returns()
+func (_IQikStaking *IQikStakingSession) SetMaxValidators(newMax *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.Contract.SetMaxValidators(&_IQikStaking.TransactOpts, newMax)
+}
+
+// SetMaxValidators is a paid mutator transaction binding the contract method 0x9bb2ea5a.
+//
+// Solidity: function setMaxValidators(uint256 newMax) returns()
+func (_IQikStaking *IQikStakingTransactorSession) SetMaxValidators(newMax *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.Contract.SetMaxValidators(&_IQikStaking.TransactOpts, newMax)
+}
+
+// SetMinStake is a paid mutator transaction binding the contract method 0x8c80fd90.
+//
+// Solidity: function setMinStake(uint256 newMin) returns()
+func (_IQikStaking *IQikStakingTransactor) SetMinStake(opts *bind.TransactOpts, newMin *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.contract.Transact(opts, "setMinStake", newMin)
+}
+
+// SetMinStake is a paid mutator transaction binding the contract method 0x8c80fd90.
+//
+// Solidity: function setMinStake(uint256 newMin) returns()
+func (_IQikStaking *IQikStakingSession) SetMinStake(newMin *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.Contract.SetMinStake(&_IQikStaking.TransactOpts, newMin)
+}
+
+// SetMinStake is a paid mutator transaction binding the contract method 0x8c80fd90.
+//
+// Solidity: function setMinStake(uint256 newMin) returns()
+func (_IQikStaking *IQikStakingTransactorSession) SetMinStake(newMin *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.Contract.SetMinStake(&_IQikStaking.TransactOpts, newMin)
+}
+
+// SetUnbondingPeriod is a paid mutator transaction binding the contract method 0x114eaf55.
+//
+// Solidity: function setUnbondingPeriod(uint256 seconds_) returns()
+func (_IQikStaking *IQikStakingTransactor) SetUnbondingPeriod(opts *bind.TransactOpts, seconds_ *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.contract.Transact(opts, "setUnbondingPeriod", seconds_)
+}
+
+// SetUnbondingPeriod is a paid mutator transaction binding the contract method 0x114eaf55.
+//
+// Solidity: function setUnbondingPeriod(uint256 seconds_) returns()
+func (_IQikStaking *IQikStakingSession) SetUnbondingPeriod(seconds_ *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.Contract.SetUnbondingPeriod(&_IQikStaking.TransactOpts, seconds_)
+}
+
+// SetUnbondingPeriod is a paid mutator transaction binding the contract method 0x114eaf55.
+//
+// Solidity: function setUnbondingPeriod(uint256 seconds_) returns()
+func (_IQikStaking *IQikStakingTransactorSession) SetUnbondingPeriod(seconds_ *big.Int) (*types.Transaction, error) {
+	return _IQikStaking.Contract.SetUnbondingPeriod(&_IQikStaking.TransactOpts, seconds_)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0x26476204.
+//
+// Solidity: function stake(address operator) payable returns()
+func (_IQikStaking *IQikStakingTransactor) Stake(opts *bind.TransactOpts, operator common.Address) (*types.Transaction, error) {
+	return _IQikStaking.contract.Transact(opts, "stake", operator)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0x26476204.
+//
+// Solidity: function stake(address operator) payable returns()
+func (_IQikStaking *IQikStakingSession) Stake(operator common.Address) (*types.Transaction, error) {
+	return _IQikStaking.Contract.Stake(&_IQikStaking.TransactOpts, operator)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0x26476204.
+//
+// Solidity: function stake(address operator) payable returns()
+func (_IQikStaking *IQikStakingTransactorSession) Stake(operator common.Address) (*types.Transaction, error) {
+	return _IQikStaking.Contract.Stake(&_IQikStaking.TransactOpts, operator)
+}
+
+// StakeFor is a paid mutator transaction binding the contract method 0x76602dac.
+//
+// Solidity: function stakeFor(address operator, address staker) payable returns()
+func (_IQikStaking *IQikStakingTransactor) StakeFor(opts *bind.TransactOpts, operator common.Address, staker common.Address) (*types.Transaction, error) {
+	return _IQikStaking.contract.Transact(opts, "stakeFor", operator, staker)
+}
+
+// StakeFor is a paid mutator transaction binding the contract method 0x76602dac.
+//
+// Solidity: function stakeFor(address operator, address staker) payable returns()
+func (_IQikStaking *IQikStakingSession) StakeFor(operator common.Address, staker common.Address) (*types.Transaction, error) {
+	return _IQikStaking.Contract.StakeFor(&_IQikStaking.TransactOpts, operator, staker)
+}
+
+// StakeFor is a paid mutator transaction binding the contract method 0x76602dac.
+//
+// Solidity: function stakeFor(address operator, address staker) payable returns()
+func (_IQikStaking *IQikStakingTransactorSession) StakeFor(operator common.Address, staker common.Address) (*types.Transaction, error) {
+	return _IQikStaking.Contract.StakeFor(&_IQikStaking.TransactOpts, operator, staker)
+}
+
+// UpdateConsensusKey is a paid mutator transaction binding the contract method 0xeb02f8a4.
+//
+// Solidity: function updateConsensusKey(bytes newKey) returns()
+func (_IQikStaking *IQikStakingTransactor) UpdateConsensusKey(opts *bind.TransactOpts, newKey []byte) (*types.Transaction, error) {
+	return _IQikStaking.contract.Transact(opts, "updateConsensusKey", newKey)
+}
+
+// UpdateConsensusKey is a paid mutator transaction binding the contract method 0xeb02f8a4.
+//
+// Solidity: function updateConsensusKey(bytes newKey) returns()
+func (_IQikStaking *IQikStakingSession) UpdateConsensusKey(newKey []byte) (*types.Transaction, error) {
+	return _IQikStaking.Contract.UpdateConsensusKey(&_IQikStaking.TransactOpts, newKey)
+}
+
+// UpdateConsensusKey is a paid mutator transaction binding the contract method 0xeb02f8a4.
+//
+// Solidity: function updateConsensusKey(bytes newKey) returns()
+func (_IQikStaking *IQikStakingTransactorSession) UpdateConsensusKey(newKey []byte) (*types.Transaction, error) {
+	return _IQikStaking.Contract.UpdateConsensusKey(&_IQikStaking.TransactOpts, newKey)
+}
+
+// UpdatePayout is a paid mutator transaction binding the contract method 0xedcb9e9e.
+//
+// Solidity: function updatePayout(address newPayout) returns()
+func (_IQikStaking *IQikStakingTransactor) UpdatePayout(opts *bind.TransactOpts, newPayout common.Address) (*types.Transaction, error) {
+	return _IQikStaking.contract.Transact(opts, "updatePayout", newPayout)
+}
+
+// UpdatePayout is a paid mutator transaction binding the contract method 0xedcb9e9e.
+//
+// Solidity: function updatePayout(address newPayout) returns()
+func (_IQikStaking *IQikStakingSession) UpdatePayout(newPayout common.Address) (*types.Transaction, error) {
+	return _IQikStaking.Contract.UpdatePayout(&_IQikStaking.TransactOpts, newPayout)
+}
+
+// UpdatePayout is a paid mutator transaction binding the contract method 0xedcb9e9e.
+//
+// Solidity: function updatePayout(address newPayout) returns()
+func (_IQikStaking *IQikStakingTransactorSession) UpdatePayout(newPayout common.Address) (*types.Transaction, error) {
+	return _IQikStaking.Contract.UpdatePayout(&_IQikStaking.TransactOpts, newPayout)
+}
+
+// WithdrawUnstaked is a paid mutator transaction binding the contract method 0xf2364edf.
+//
+// Solidity: function withdrawUnstaked(address operator) returns()
+func (_IQikStaking *IQikStakingTransactor) WithdrawUnstaked(opts *bind.TransactOpts, operator common.Address) (*types.Transaction, error) {
+	return _IQikStaking.contract.Transact(opts, "withdrawUnstaked", operator)
+}
+
+// WithdrawUnstaked is a paid mutator transaction binding the contract method 0xf2364edf.
+//
+// Solidity: function withdrawUnstaked(address operator) returns()
+func (_IQikStaking *IQikStakingSession) WithdrawUnstaked(operator common.Address) (*types.Transaction, error) {
+	return _IQikStaking.Contract.WithdrawUnstaked(&_IQikStaking.TransactOpts, operator)
+}
+
+// WithdrawUnstaked is a paid mutator transaction binding the contract method 0xf2364edf.
+//
+// Solidity: function withdrawUnstaked(address operator) returns()
+func (_IQikStaking *IQikStakingTransactorSession) WithdrawUnstaked(operator common.Address) (*types.Transaction, error) {
+	return _IQikStaking.Contract.WithdrawUnstaked(&_IQikStaking.TransactOpts, operator)
+}
+
+// IQikStakingConsensusKeyUpdatedIterator is returned from FilterConsensusKeyUpdated and is used to iterate over the raw logs and unpacked data for ConsensusKeyUpdated events raised by the IQikStaking contract.
+type IQikStakingConsensusKeyUpdatedIterator struct {
+	Event *IQikStakingConsensusKeyUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IQikStakingConsensusKeyUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IQikStakingConsensusKeyUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IQikStakingConsensusKeyUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IQikStakingConsensusKeyUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IQikStakingConsensusKeyUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IQikStakingConsensusKeyUpdated represents a ConsensusKeyUpdated event raised by the IQikStaking contract.
+type IQikStakingConsensusKeyUpdated struct {
+	Operator common.Address
+	NewKey   []byte
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterConsensusKeyUpdated is a free log retrieval operation binding the contract event 0x32332ce775c2c64facb137196f3aa728ff00af68694dcf278e246f25c7915fd1.
+//
+// Solidity: event ConsensusKeyUpdated(address indexed operator, bytes newKey)
+func (_IQikStaking *IQikStakingFilterer) FilterConsensusKeyUpdated(opts *bind.FilterOpts, operator []common.Address) (*IQikStakingConsensusKeyUpdatedIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.FilterLogs(opts, "ConsensusKeyUpdated", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStakingConsensusKeyUpdatedIterator{contract: _IQikStaking.contract, event: "ConsensusKeyUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchConsensusKeyUpdated is a free log subscription operation binding the contract event 0x32332ce775c2c64facb137196f3aa728ff00af68694dcf278e246f25c7915fd1.
+//
+// Solidity: event ConsensusKeyUpdated(address indexed operator, bytes newKey)
+func (_IQikStaking *IQikStakingFilterer) WatchConsensusKeyUpdated(opts *bind.WatchOpts, sink chan<- *IQikStakingConsensusKeyUpdated, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.WatchLogs(opts, "ConsensusKeyUpdated", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IQikStakingConsensusKeyUpdated)
+				if err := _IQikStaking.contract.UnpackLog(event, "ConsensusKeyUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseConsensusKeyUpdated is a log parse operation binding the contract event 0x32332ce775c2c64facb137196f3aa728ff00af68694dcf278e246f25c7915fd1.
+//
+// Solidity: event ConsensusKeyUpdated(address indexed operator, bytes newKey)
+func (_IQikStaking *IQikStakingFilterer) ParseConsensusKeyUpdated(log types.Log) (*IQikStakingConsensusKeyUpdated, error) {
+	event := new(IQikStakingConsensusKeyUpdated)
+	if err := _IQikStaking.contract.UnpackLog(event, "ConsensusKeyUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IQikStakingOperatorJailedIterator is returned from FilterOperatorJailed and is used to iterate over the raw logs and unpacked data for OperatorJailed events raised by the IQikStaking contract.
+type IQikStakingOperatorJailedIterator struct {
+	Event *IQikStakingOperatorJailed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IQikStakingOperatorJailedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IQikStakingOperatorJailed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IQikStakingOperatorJailed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IQikStakingOperatorJailedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IQikStakingOperatorJailedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IQikStakingOperatorJailed represents a OperatorJailed event raised by the IQikStaking contract.
+type IQikStakingOperatorJailed struct {
+	Operator common.Address
+	Jailed   bool
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterOperatorJailed is a free log retrieval operation binding the contract event 0x800a4e6239fe166c3238bf4809662acbf201a58bb0d0f7f0d1970b93d7021f46.
+//
+// Solidity: event OperatorJailed(address indexed operator, bool jailed)
+func (_IQikStaking *IQikStakingFilterer) FilterOperatorJailed(opts *bind.FilterOpts, operator []common.Address) (*IQikStakingOperatorJailedIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.FilterLogs(opts, "OperatorJailed", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStakingOperatorJailedIterator{contract: _IQikStaking.contract, event: "OperatorJailed", logs: logs, sub: sub}, nil
+}
+
+// WatchOperatorJailed is a free log subscription operation binding the contract event 0x800a4e6239fe166c3238bf4809662acbf201a58bb0d0f7f0d1970b93d7021f46.
+//
+// Solidity: event OperatorJailed(address indexed operator, bool jailed)
+func (_IQikStaking *IQikStakingFilterer) WatchOperatorJailed(opts *bind.WatchOpts, sink chan<- *IQikStakingOperatorJailed, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.WatchLogs(opts, "OperatorJailed", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IQikStakingOperatorJailed)
+				if err := _IQikStaking.contract.UnpackLog(event, "OperatorJailed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOperatorJailed is a log parse operation binding the contract event 0x800a4e6239fe166c3238bf4809662acbf201a58bb0d0f7f0d1970b93d7021f46.
+//
+// Solidity: event OperatorJailed(address indexed operator, bool jailed)
+func (_IQikStaking *IQikStakingFilterer) ParseOperatorJailed(log types.Log) (*IQikStakingOperatorJailed, error) {
+	event := new(IQikStakingOperatorJailed)
+	if err := _IQikStaking.contract.UnpackLog(event, "OperatorJailed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IQikStakingOperatorRegisteredIterator is returned from FilterOperatorRegistered and is used to iterate over the raw logs and unpacked data for OperatorRegistered events raised by the IQikStaking contract.
+type IQikStakingOperatorRegisteredIterator struct {
+	Event *IQikStakingOperatorRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IQikStakingOperatorRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IQikStakingOperatorRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IQikStakingOperatorRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IQikStakingOperatorRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IQikStakingOperatorRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IQikStakingOperatorRegistered represents a OperatorRegistered event raised by the IQikStaking contract.
+type IQikStakingOperatorRegistered struct {
+	Operator     common.Address
+	ConsensusKey []byte
+	Payout       common.Address
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterOperatorRegistered is a free log retrieval operation binding the contract event 0x92372d033e9a8049fa7702ffccb1b676f63a3fd37eaa9f64834b26da340efa2e.
+//
+// Solidity: event OperatorRegistered(address indexed operator, bytes consensusKey, address payout)
+func (_IQikStaking *IQikStakingFilterer) FilterOperatorRegistered(opts *bind.FilterOpts, operator []common.Address) (*IQikStakingOperatorRegisteredIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.FilterLogs(opts, "OperatorRegistered", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStakingOperatorRegisteredIterator{contract: _IQikStaking.contract, event: "OperatorRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchOperatorRegistered is a free log subscription operation binding the contract event 0x92372d033e9a8049fa7702ffccb1b676f63a3fd37eaa9f64834b26da340efa2e.
+//
+// Solidity: event OperatorRegistered(address indexed operator, bytes consensusKey, address payout)
+func (_IQikStaking *IQikStakingFilterer) WatchOperatorRegistered(opts *bind.WatchOpts, sink chan<- *IQikStakingOperatorRegistered, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.WatchLogs(opts, "OperatorRegistered", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IQikStakingOperatorRegistered)
+				if err := _IQikStaking.contract.UnpackLog(event, "OperatorRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOperatorRegistered is a log parse operation binding the contract event 0x92372d033e9a8049fa7702ffccb1b676f63a3fd37eaa9f64834b26da340efa2e.
+//
+// Solidity: event OperatorRegistered(address indexed operator, bytes consensusKey, address payout)
+func (_IQikStaking *IQikStakingFilterer) ParseOperatorRegistered(log types.Log) (*IQikStakingOperatorRegistered, error) {
+	event := new(IQikStakingOperatorRegistered)
+	if err := _IQikStaking.contract.UnpackLog(event, "OperatorRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IQikStakingParamsUpdatedIterator is returned from FilterParamsUpdated and is used to iterate over the raw logs and unpacked data for ParamsUpdated events raised by the IQikStaking contract.
+type IQikStakingParamsUpdatedIterator struct {
+	Event *IQikStakingParamsUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IQikStakingParamsUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IQikStakingParamsUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IQikStakingParamsUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IQikStakingParamsUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IQikStakingParamsUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IQikStakingParamsUpdated represents a ParamsUpdated event raised by the IQikStaking contract.
+type IQikStakingParamsUpdated struct {
+	Param [32]byte
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterParamsUpdated is a free log retrieval operation binding the contract event 0x5012d6ec6204055f3280c526a7cc3167d284b898bdedc5ecff9f267cd82ed2e9.
+//
+// Solidity: event ParamsUpdated(bytes32 indexed param, uint256 value)
+func (_IQikStaking *IQikStakingFilterer) FilterParamsUpdated(opts *bind.FilterOpts, param [][32]byte) (*IQikStakingParamsUpdatedIterator, error) {
+
+	var paramRule []interface{}
+	for _, paramItem := range param {
+		paramRule = append(paramRule, paramItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.FilterLogs(opts, "ParamsUpdated", paramRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStakingParamsUpdatedIterator{contract: _IQikStaking.contract, event: "ParamsUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchParamsUpdated is a free log subscription operation binding the contract event 0x5012d6ec6204055f3280c526a7cc3167d284b898bdedc5ecff9f267cd82ed2e9.
+//
+// Solidity: event ParamsUpdated(bytes32 indexed param, uint256 value)
+func (_IQikStaking *IQikStakingFilterer) WatchParamsUpdated(opts *bind.WatchOpts, sink chan<- *IQikStakingParamsUpdated, param [][32]byte) (event.Subscription, error) {
+
+	var paramRule []interface{}
+	for _, paramItem := range param {
+		paramRule = append(paramRule, paramItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.WatchLogs(opts, "ParamsUpdated", paramRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IQikStakingParamsUpdated)
+				if err := _IQikStaking.contract.UnpackLog(event, "ParamsUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseParamsUpdated is a log parse operation binding the contract event 0x5012d6ec6204055f3280c526a7cc3167d284b898bdedc5ecff9f267cd82ed2e9.
+//
+// Solidity: event ParamsUpdated(bytes32 indexed param, uint256 value)
+func (_IQikStaking *IQikStakingFilterer) ParseParamsUpdated(log types.Log) (*IQikStakingParamsUpdated, error) {
+	event := new(IQikStakingParamsUpdated)
+	if err := _IQikStaking.contract.UnpackLog(event, "ParamsUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IQikStakingPayoutUpdatedIterator is returned from FilterPayoutUpdated and is used to iterate over the raw logs and unpacked data for PayoutUpdated events raised by the IQikStaking contract.
+type IQikStakingPayoutUpdatedIterator struct {
+	Event *IQikStakingPayoutUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IQikStakingPayoutUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IQikStakingPayoutUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IQikStakingPayoutUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IQikStakingPayoutUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IQikStakingPayoutUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IQikStakingPayoutUpdated represents a PayoutUpdated event raised by the IQikStaking contract.
+type IQikStakingPayoutUpdated struct {
+	Operator common.Address
+	Payout   common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterPayoutUpdated is a free log retrieval operation binding the contract event 0x0f5e7e8f9a21dde5bc3248632fb614300e3f87c62f100f2fcb76caaedd731603.
+//
+// Solidity: event PayoutUpdated(address indexed operator, address payout)
+func (_IQikStaking *IQikStakingFilterer) FilterPayoutUpdated(opts *bind.FilterOpts, operator []common.Address) (*IQikStakingPayoutUpdatedIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.FilterLogs(opts, "PayoutUpdated", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStakingPayoutUpdatedIterator{contract: _IQikStaking.contract, event: "PayoutUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchPayoutUpdated is a free log subscription operation binding the contract event 0x0f5e7e8f9a21dde5bc3248632fb614300e3f87c62f100f2fcb76caaedd731603.
+//
+// Solidity: event PayoutUpdated(address indexed operator, address payout)
+func (_IQikStaking *IQikStakingFilterer) WatchPayoutUpdated(opts *bind.WatchOpts, sink chan<- *IQikStakingPayoutUpdated, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.WatchLogs(opts, "PayoutUpdated", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IQikStakingPayoutUpdated)
+				if err := _IQikStaking.contract.UnpackLog(event, "PayoutUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePayoutUpdated is a log parse operation binding the contract event 0x0f5e7e8f9a21dde5bc3248632fb614300e3f87c62f100f2fcb76caaedd731603.
+//
+// Solidity: event PayoutUpdated(address indexed operator, address payout)
+func (_IQikStaking *IQikStakingFilterer) ParsePayoutUpdated(log types.Log) (*IQikStakingPayoutUpdated, error) {
+	event := new(IQikStakingPayoutUpdated)
+	if err := _IQikStaking.contract.UnpackLog(event, "PayoutUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IQikStakingStakedIterator is returned from FilterStaked and is used to iterate over the raw logs and unpacked data for Staked events raised by the IQikStaking contract.
+type IQikStakingStakedIterator struct {
+	Event *IQikStakingStaked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IQikStakingStakedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IQikStakingStaked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IQikStakingStaked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IQikStakingStakedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IQikStakingStakedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IQikStakingStaked represents a Staked event raised by the IQikStaking contract.
+type IQikStakingStaked struct {
+	Staker   common.Address
+	Operator common.Address
+	Amount   *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterStaked is a free log retrieval operation binding the contract event 0x5dac0c1b1112564a045ba943c9d50270893e8e826c49be8e7073adc713ab7bd7.
+//
+// Solidity: event Staked(address indexed staker, address indexed operator, uint256 amount)
+func (_IQikStaking *IQikStakingFilterer) FilterStaked(opts *bind.FilterOpts, staker []common.Address, operator []common.Address) (*IQikStakingStakedIterator, error) {
+
+	var stakerRule []interface{}
+	for _, stakerItem := range staker {
+		stakerRule = append(stakerRule, stakerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.FilterLogs(opts, "Staked", stakerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStakingStakedIterator{contract: _IQikStaking.contract, event: "Staked", logs: logs, sub: sub}, nil
+}
+
+// WatchStaked is a free log subscription operation binding the contract event 0x5dac0c1b1112564a045ba943c9d50270893e8e826c49be8e7073adc713ab7bd7.
+//
+// Solidity: event Staked(address indexed staker, address indexed operator, uint256 amount)
+func (_IQikStaking *IQikStakingFilterer) WatchStaked(opts *bind.WatchOpts, sink chan<- *IQikStakingStaked, staker []common.Address, operator []common.Address) (event.Subscription, error) {
+
+	var stakerRule []interface{}
+	for _, stakerItem := range staker {
+		stakerRule = append(stakerRule, stakerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.WatchLogs(opts, "Staked", stakerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IQikStakingStaked)
+				if err := _IQikStaking.contract.UnpackLog(event, "Staked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStaked is a log parse operation binding the contract event 0x5dac0c1b1112564a045ba943c9d50270893e8e826c49be8e7073adc713ab7bd7.
+//
+// Solidity: event Staked(address indexed staker, address indexed operator, uint256 amount)
+func (_IQikStaking *IQikStakingFilterer) ParseStaked(log types.Log) (*IQikStakingStaked, error) {
+	event := new(IQikStakingStaked)
+	if err := _IQikStaking.contract.UnpackLog(event, "Staked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IQikStakingUnstakeRequestedIterator is returned from FilterUnstakeRequested and is used to iterate over the raw logs and unpacked data for UnstakeRequested events raised by the IQikStaking contract.
+type IQikStakingUnstakeRequestedIterator struct {
+	Event *IQikStakingUnstakeRequested // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IQikStakingUnstakeRequestedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IQikStakingUnstakeRequested)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IQikStakingUnstakeRequested)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IQikStakingUnstakeRequestedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IQikStakingUnstakeRequestedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IQikStakingUnstakeRequested represents a UnstakeRequested event raised by the IQikStaking contract.
+type IQikStakingUnstakeRequested struct {
+	Staker     common.Address
+	Operator   common.Address
+	Amount     *big.Int
+	UnlockTime *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnstakeRequested is a free log retrieval operation binding the contract event 0xfe07ce9fff39f8420b3de5fbc6909ce08f809e2572b62f9df35c25f56d610bb0.
+//
+// Solidity: event UnstakeRequested(address indexed staker, address indexed operator, uint256 amount, uint256 unlockTime)
+func (_IQikStaking *IQikStakingFilterer) FilterUnstakeRequested(opts *bind.FilterOpts, staker []common.Address, operator []common.Address) (*IQikStakingUnstakeRequestedIterator, error) {
+
+	var stakerRule []interface{}
+	for _, stakerItem := range staker {
+		stakerRule = append(stakerRule, stakerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.FilterLogs(opts, "UnstakeRequested", stakerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStakingUnstakeRequestedIterator{contract: _IQikStaking.contract, event: "UnstakeRequested", logs: logs, sub: sub}, nil
+}
+
+// WatchUnstakeRequested is a free log subscription operation binding the contract event 0xfe07ce9fff39f8420b3de5fbc6909ce08f809e2572b62f9df35c25f56d610bb0.
+//
+// Solidity: event UnstakeRequested(address indexed staker, address indexed operator, uint256 amount, uint256 unlockTime)
+func (_IQikStaking *IQikStakingFilterer) WatchUnstakeRequested(opts *bind.WatchOpts, sink chan<- *IQikStakingUnstakeRequested, staker []common.Address, operator []common.Address) (event.Subscription, error) {
+
+	var stakerRule []interface{}
+	for _, stakerItem := range staker {
+		stakerRule = append(stakerRule, stakerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.WatchLogs(opts, "UnstakeRequested", stakerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IQikStakingUnstakeRequested)
+				if err := _IQikStaking.contract.UnpackLog(event, "UnstakeRequested", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnstakeRequested is a log parse operation binding the contract event 0xfe07ce9fff39f8420b3de5fbc6909ce08f809e2572b62f9df35c25f56d610bb0.
+//
+// Solidity: event UnstakeRequested(address indexed staker, address indexed operator, uint256 amount, uint256 unlockTime)
+func (_IQikStaking *IQikStakingFilterer) ParseUnstakeRequested(log types.Log) (*IQikStakingUnstakeRequested, error) {
+	event := new(IQikStakingUnstakeRequested)
+	if err := _IQikStaking.contract.UnpackLog(event, "UnstakeRequested", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IQikStakingUnstakedWithdrawnIterator is returned from FilterUnstakedWithdrawn and is used to iterate over the raw logs and unpacked data for UnstakedWithdrawn events raised by the IQikStaking contract.
+type IQikStakingUnstakedWithdrawnIterator struct {
+	Event *IQikStakingUnstakedWithdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IQikStakingUnstakedWithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IQikStakingUnstakedWithdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IQikStakingUnstakedWithdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IQikStakingUnstakedWithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IQikStakingUnstakedWithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IQikStakingUnstakedWithdrawn represents a UnstakedWithdrawn event raised by the IQikStaking contract.
+type IQikStakingUnstakedWithdrawn struct {
+	Staker   common.Address
+	Operator common.Address
+	Amount   *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnstakedWithdrawn is a free log retrieval operation binding the contract event 0x23f59b842e450a72cbba160d54f5009a3a237b271559806ab858afe4f1b15b15.
+//
+// Solidity: event UnstakedWithdrawn(address indexed staker, address indexed operator, uint256 amount)
+func (_IQikStaking *IQikStakingFilterer) FilterUnstakedWithdrawn(opts *bind.FilterOpts, staker []common.Address, operator []common.Address) (*IQikStakingUnstakedWithdrawnIterator, error) {
+
+	var stakerRule []interface{}
+	for _, stakerItem := range staker {
+		stakerRule = append(stakerRule, stakerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.FilterLogs(opts, "UnstakedWithdrawn", stakerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikStakingUnstakedWithdrawnIterator{contract: _IQikStaking.contract, event: "UnstakedWithdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchUnstakedWithdrawn is a free log subscription operation binding the contract event 0x23f59b842e450a72cbba160d54f5009a3a237b271559806ab858afe4f1b15b15.
+//
+// Solidity: event UnstakedWithdrawn(address indexed staker, address indexed operator, uint256 amount)
+func (_IQikStaking *IQikStakingFilterer) WatchUnstakedWithdrawn(opts *bind.WatchOpts, sink chan<- *IQikStakingUnstakedWithdrawn, staker []common.Address, operator []common.Address) (event.Subscription, error) {
+
+	var stakerRule []interface{}
+	for _, stakerItem := range staker {
+		stakerRule = append(stakerRule, stakerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _IQikStaking.contract.WatchLogs(opts, "UnstakedWithdrawn", stakerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IQikStakingUnstakedWithdrawn)
+				if err := _IQikStaking.contract.UnpackLog(event, "UnstakedWithdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnstakedWithdrawn is a log parse operation binding the contract event 0x23f59b842e450a72cbba160d54f5009a3a237b271559806ab858afe4f1b15b15.
+//
+// Solidity: event UnstakedWithdrawn(address indexed staker, address indexed operator, uint256 amount)
+func (_IQikStaking *IQikStakingFilterer) ParseUnstakedWithdrawn(log types.Log) (*IQikStakingUnstakedWithdrawn, error) {
+	event := new(IQikStakingUnstakedWithdrawn)
+	if err := _IQikStaking.contract.UnpackLog(event, "UnstakedWithdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,350 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// IQikValidatorSetMetaData contains all meta data concerning the IQikValidatorSet contract.
+var IQikValidatorSetMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"validatorCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getValidators\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getValidatorKeys\",\"outputs\":[{\"internalType\":\"bytes[]\",\"name\":\"\",\"type\":\"bytes[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"idx\",\"type\":\"uint256\"}],\"name\":\"getValidatorAt\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"consensusKey\",\"type\":\"bytes\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"isValidator\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// IQikValidatorSetABI is the input ABI used to generate the binding from.
+// Deprecated: Use IQikValidatorSetMetaData.ABI instead.
+var IQikValidatorSetABI = IQikValidatorSetMetaData.ABI
+
+// IQikValidatorSet is an auto generated Go binding around an Ethereum contract.
+type IQikValidatorSet struct {
+	IQikValidatorSetCaller     // Read-only binding to the contract
+	IQikValidatorSetTransactor // Write-only binding to the contract
+	IQikValidatorSetFilterer   // Log filterer for contract events
+}
+
+// IQikValidatorSetCaller is an auto generated read-only Go binding around an Ethereum contract.
+type IQikValidatorSetCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IQikValidatorSetTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type IQikValidatorSetTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IQikValidatorSetFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IQikValidatorSetFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IQikValidatorSetSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IQikValidatorSetSession struct {
+	Contract     *IQikValidatorSet // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IQikValidatorSetCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IQikValidatorSetCallerSession struct {
+	Contract *IQikValidatorSetCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts           // Call options to use throughout this session
+}
+
+// IQikValidatorSetTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IQikValidatorSetTransactorSession struct {
+	Contract     *IQikValidatorSetTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts           // Transaction auth options to use throughout this session
+}
+
+// IQikValidatorSetRaw is an auto generated low-level Go binding around an Ethereum contract.
+type IQikValidatorSetRaw struct {
+	Contract *IQikValidatorSet // Generic contract binding to access the raw methods on
+}
+
+// IQikValidatorSetCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IQikValidatorSetCallerRaw struct {
+	Contract *IQikValidatorSetCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// IQikValidatorSetTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IQikValidatorSetTransactorRaw struct {
+	Contract *IQikValidatorSetTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIQikValidatorSet creates a new instance of IQikValidatorSet, bound to a specific deployed contract.
+func NewIQikValidatorSet(address common.Address, backend bind.ContractBackend) (*IQikValidatorSet, error) {
+	contract, err := bindIQikValidatorSet(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikValidatorSet{IQikValidatorSetCaller: IQikValidatorSetCaller{contract: contract}, IQikValidatorSetTransactor: IQikValidatorSetTransactor{contract: contract}, IQikValidatorSetFilterer: IQikValidatorSetFilterer{contract: contract}}, nil
+}
+
+// NewIQikValidatorSetCaller creates a new read-only instance of IQikValidatorSet, bound to a specific deployed contract.
+func NewIQikValidatorSetCaller(address common.Address, caller bind.ContractCaller) (*IQikValidatorSetCaller, error) {
+	contract, err := bindIQikValidatorSet(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikValidatorSetCaller{contract: contract}, nil
+}
+
+// NewIQikValidatorSetTransactor creates a new write-only instance of IQikValidatorSet, bound to a specific deployed contract.
+func NewIQikValidatorSetTransactor(address common.Address, transactor bind.ContractTransactor) (*IQikValidatorSetTransactor, error) {
+	contract, err := bindIQikValidatorSet(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikValidatorSetTransactor{contract: contract}, nil
+}
+
+// NewIQikValidatorSetFilterer creates a new log filterer instance of IQikValidatorSet, bound to a specific deployed contract.
+func NewIQikValidatorSetFilterer(address common.Address, filterer bind.ContractFilterer) (*IQikValidatorSetFilterer, error) {
+	contract, err := bindIQikValidatorSet(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IQikValidatorSetFilterer{contract: contract}, nil
+}
+
+// bindIQikValidatorSet binds a generic wrapper to an already deployed contract.
+func bindIQikValidatorSet(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := IQikValidatorSetMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IQikValidatorSet *IQikValidatorSetRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IQikValidatorSet.Contract.IQikValidatorSetCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IQikValidatorSet *IQikValidatorSetRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IQikValidatorSet.Contract.IQikValidatorSetTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IQikValidatorSet *IQikValidatorSetRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IQikValidatorSet.Contract.IQikValidatorSetTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IQikValidatorSet *IQikValidatorSetCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IQikValidatorSet.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IQikValidatorSet *IQikValidatorSetTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IQikValidatorSet.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IQikValidatorSet *IQikValidatorSetTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IQikValidatorSet.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetValidatorAt is a free data retrieval call binding the contract method 0x9a000e5b.
+//
+// Solidity: function getValidatorAt(uint256 idx) view returns(address operator, bytes consensusKey)
+func (_IQikValidatorSet *IQikValidatorSetCaller) GetValidatorAt(opts *bind.CallOpts, idx *big.Int) (struct {
+	Operator     common.Address
+	ConsensusKey []byte
+}, error) {
+	var out []interface{}
+	err := _IQikValidatorSet.contract.Call(opts, &out, "getValidatorAt", idx)
+
+	outstruct := new(struct {
+		Operator     common.Address
+		ConsensusKey []byte
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Operator = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.ConsensusKey = *abi.ConvertType(out[1], new([]byte)).(*[]byte)
+
+	return *outstruct, err
+
+}
+
+// GetValidatorAt is a free data retrieval call binding the contract method 0x9a000e5b.
+//
+// Solidity: function getValidatorAt(uint256 idx) view returns(address operator, bytes consensusKey)
+func (_IQikValidatorSet *IQikValidatorSetSession) GetValidatorAt(idx *big.Int) (struct {
+	Operator     common.Address
+	ConsensusKey []byte
+}, error) {
+	return _IQikValidatorSet.Contract.GetValidatorAt(&_IQikValidatorSet.CallOpts, idx)
+}
+
+// GetValidatorAt is a free data retrieval call binding the contract method 0x9a000e5b.
+//
+// Solidity: function getValidatorAt(uint256 idx) view returns(address operator, bytes consensusKey)
+func (_IQikValidatorSet *IQikValidatorSetCallerSession) GetValidatorAt(idx *big.Int) (struct {
+	Operator     common.Address
+	ConsensusKey []byte
+}, error) {
+	return _IQikValidatorSet.Contract.GetValidatorAt(&_IQikValidatorSet.CallOpts, idx)
+}
+
+// GetValidatorKeys is a free data retrieval call binding the contract method 0x39f860ca.
+//
+// Solidity: function getValidatorKeys() view returns(bytes[])
+func (_IQikValidatorSet *IQikValidatorSetCaller) GetValidatorKeys(opts *bind.CallOpts) ([][]byte, error) {
+	var out []interface{}
+	err := _IQikValidatorSet.contract.Call(opts, &out, "getValidatorKeys")
+
+	if err != nil {
+		return *new([][]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([][]byte)).(*[][]byte)
+
+	return out0, err
+
+}
+
+// GetValidatorKeys is a free data retrieval call binding the contract method 0x39f860ca.
+//
+// Solidity: function getValidatorKeys() view returns(bytes[])
+func (_IQikValidatorSet *IQikValidatorSetSession) GetValidatorKeys() ([][]byte, error) {
+	return _IQikValidatorSet.Contract.GetValidatorKeys(&_IQikValidatorSet.CallOpts)
+}
+
+// GetValidatorKeys is a free data retrieval call binding the contract method 0x39f860ca.
+//
+// Solidity: function getValidatorKeys() view returns(bytes[])
+func (_IQikValidatorSet *IQikValidatorSetCallerSession) GetValidatorKeys() ([][]byte, error) {
+	return _IQikValidatorSet.Contract.GetValidatorKeys(&_IQikValidatorSet.CallOpts)
+}
+
+// GetValidators is a free data retrieval call binding the contract method 0xb7ab4db5.
+//
+// Solidity: function getValidators() view returns(address[])
+func (_IQikValidatorSet *IQikValidatorSetCaller) GetValidators(opts *bind.CallOpts) ([]common.Address, error) {
+	var out []interface{}
+	err := _IQikValidatorSet.contract.Call(opts, &out, "getValidators")
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetValidators is a free data retrieval call binding the contract method 0xb7ab4db5.
+//
+// Solidity: function getValidators() view returns(address[])
+func (_IQikValidatorSet *IQikValidatorSetSession) GetValidators() ([]common.Address, error) {
+	return _IQikValidatorSet.Contract.GetValidators(&_IQikValidatorSet.CallOpts)
+}
+
+// GetValidators is a free data retrieval call binding the contract method 0xb7ab4db5.
+//
+// Solidity: function getValidators() view returns(address[])
+func (_IQikValidatorSet *IQikValidatorSetCallerSession) GetValidators() ([]common.Address, error) {
+	return _IQikValidatorSet.Contract.GetValidators(&_IQikValidatorSet.CallOpts)
+}
+
+// IsValidator is a free data retrieval call binding the contract method 0xfacd743b.
+//
+// Solidity: function isValidator(address operator) view returns(bool)
+func (_IQikValidatorSet *IQikValidatorSetCaller) IsValidator(opts *bind.CallOpts, operator common.Address) (bool, error) {
+	var out []interface{}
+	err := _IQikValidatorSet.contract.Call(opts, &out, "isValidator", operator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsValidator is a free data retrieval call binding the contract method 0xfacd743b.
+//
+// Solidity: function isValidator(address operator) view returns(bool)
+func (_IQikValidatorSet *IQikValidatorSetSession) IsValidator(operator common.Address) (bool, error) {
+	return _IQikValidatorSet.Contract.IsValidator(&_IQikValidatorSet.CallOpts, operator)
+}
+
+// IsValidator is a free data retrieval call binding the contract method 0xfacd743b.
+//
+// Solidity: function isValidator(address operator) view returns(bool)
+func (_IQikValidatorSet *IQikValidatorSetCallerSession) IsValidator(operator common.Address) (bool, error) {
+	return _IQikValidatorSet.Contract.IsValidator(&_IQikValidatorSet.CallOpts, operator)
+}
+
+// ValidatorCount is a free data retrieval call binding the contract method 0x0f43a677.
+//
+// Solidity: function validatorCount() view returns(uint256)
+func (_IQikValidatorSet *IQikValidatorSetCaller) ValidatorCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IQikValidatorSet.contract.Call(opts, &out, "validatorCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ValidatorCount is a free data retrieval call binding the contract method 0x0f43a677.
+//
+// Solidity: function validatorCount() view returns(uint256)
+func (_IQikValidatorSet *IQikValidatorSetSession) ValidatorCount() (*big.Int, error) {
+	return _IQikValidatorSet.Contract.ValidatorCount(&_IQikValidatorSet.CallOpts)
+}
+
+// ValidatorCount is a free data retrieval call binding the contract method 0x0f43a677.
+//
+// Solidity: function validatorCount() view returns(uint256)
+func (_IQikValidatorSet *IQikValidatorSetCallerSession) ValidatorCount() (*big.Int, error) {
+	return _IQikValidatorSet.Contract.ValidatorCount(&_IQikValidatorSet.CallOpts)
+}
@@ -0,0 +1,950 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// StakeManagerMetaData contains all meta data concerning the StakeManager contract.
+var StakeManagerMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token_\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"validatorRegistry_\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"unbondingBlocks_\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"minStake_\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"UNBONDING_BLOCKS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"beginUnstake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"minStake\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"withdrawalId\",\"type\":\"uint256\"}],\"name\":\"pendingWithdrawals\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unlockBlock\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"withdrawn\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"stake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"stakeOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"stakeAmount\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"stakingToken\",\"outputs\":[{\"internalType\":\"contractIERC20\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalStaked\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"validatorRegistry\",\"outputs\":[{\"internalType\":\"contractIValidatorRegistry\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"withdrawalId\",\"type\":\"uint256\"}],\"name\":\"withdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"withdrawalCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"count\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newStake\",\"type\":\"uint256\"}],\"name\":\"Staked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"unlockBlock\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"withdrawalId\",\"type\":\"uint256\"}],\"name\":\"UnstakeStarted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"withdrawalId\",\"type\":\"uint256\"}],\"name\":\"Withdrawn\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"ReentrancyGuardReentrantCall\",\"type\":\"error\"}]",
+}
+
+// StakeManagerABI is the input ABI used to generate the binding from.
+// Deprecated: Use StakeManagerMetaData.ABI instead.
+var StakeManagerABI = StakeManagerMetaData.ABI
+
+// StakeManager is an auto generated Go binding around an Ethereum contract.
+type StakeManager struct {
+	StakeManagerCaller     // Read-only binding to the contract
+	StakeManagerTransactor // Write-only binding to the contract
+	StakeManagerFilterer   // Log filterer for contract events
+}
+
+// StakeManagerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type StakeManagerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StakeManagerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type StakeManagerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StakeManagerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type StakeManagerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StakeManagerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type StakeManagerSession struct {
+	Contract     *StakeManager     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// StakeManagerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type StakeManagerCallerSession struct {
+	Contract *StakeManagerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// StakeManagerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type StakeManagerTransactorSession struct {
+	Contract     *StakeManagerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// StakeManagerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type StakeManagerRaw struct {
+	Contract *StakeManager // Generic contract binding to access the raw methods on
+}
+
+// StakeManagerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type StakeManagerCallerRaw struct {
+	Contract *StakeManagerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// StakeManagerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type StakeManagerTransactorRaw struct {
+	Contract *StakeManagerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewStakeManager creates a new instance of StakeManager, bound to a specific deployed contract.
+func NewStakeManager(address common.Address, backend bind.ContractBackend) (*StakeManager, error) {
+	contract, err := bindStakeManager(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &StakeManager{StakeManagerCaller: StakeManagerCaller{contract: contract}, StakeManagerTransactor: StakeManagerTransactor{contract: contract}, StakeManagerFilterer: StakeManagerFilterer{contract: contract}}, nil
+}
+
+// NewStakeManagerCaller creates a new read-only instance of StakeManager, bound to a specific deployed contract.
+func NewStakeManagerCaller(address common.Address, caller bind.ContractCaller) (*StakeManagerCaller, error) {
+	contract, err := bindStakeManager(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &StakeManagerCaller{contract: contract}, nil
+}
+
+// NewStakeManagerTransactor creates a new write-only instance of StakeManager, bound to a specific deployed contract.
+func NewStakeManagerTransactor(address common.Address, transactor bind.ContractTransactor) (*StakeManagerTransactor, error) {
+	contract, err := bindStakeManager(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &StakeManagerTransactor{contract: contract}, nil
+}
+
+// NewStakeManagerFilterer creates a new log filterer instance of StakeManager, bound to a specific deployed contract.
+func NewStakeManagerFilterer(address common.Address, filterer bind.ContractFilterer) (*StakeManagerFilterer, error) {
+	contract, err := bindStakeManager(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &StakeManagerFilterer{contract: contract}, nil
+}
+
+// bindStakeManager binds a generic wrapper to an already deployed contract.
+func bindStakeManager(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := StakeManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_StakeManager *StakeManagerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _StakeManager.Contract.StakeManagerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_StakeManager *StakeManagerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _StakeManager.Contract.StakeManagerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_StakeManager *StakeManagerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _StakeManager.Contract.StakeManagerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_StakeManager *StakeManagerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _StakeManager.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_StakeManager *StakeManagerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _StakeManager.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_StakeManager *StakeManagerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _StakeManager.Contract.contract.Transact(opts, method, params...)
+}
+
+// UNBONDINGBLOCKS is a free data retrieval call binding the contract method 0x0f1bcc3d.
+//
+// Solidity: function UNBONDING_BLOCKS() view returns(uint256)
+func (_StakeManager *StakeManagerCaller) UNBONDINGBLOCKS(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _StakeManager.contract.Call(opts, &out, "UNBONDING_BLOCKS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UNBONDINGBLOCKS is a free data retrieval call binding the contract method 0x0f1bcc3d.
+//
+// Solidity: function UNBONDING_BLOCKS() view returns(uint256)
+func (_StakeManager *StakeManagerSession) UNBONDINGBLOCKS() (*big.Int, error) {
+	return _StakeManager.Contract.UNBONDINGBLOCKS(&_StakeManager.CallOpts)
+}
+
+// UNBONDINGBLOCKS is a free data retrieval call binding the contract method 0x0f1bcc3d.
+//
+// Solidity: function UNBONDING_BLOCKS() view returns(uint256)
+func (_StakeManager *StakeManagerCallerSession) UNBONDINGBLOCKS() (*big.Int, error) {
+	return _StakeManager.Contract.UNBONDINGBLOCKS(&_StakeManager.CallOpts)
+}
+
+// MinStake is a free data retrieval call binding the contract method 0x375b3c0a.
+//
+// Solidity: function minStake() view returns(uint256)
+func (_StakeManager *StakeManagerCaller) MinStake(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _StakeManager.contract.Call(opts, &out, "minStake")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MinStake is a free data retrieval call binding the contract method 0x375b3c0a.
+//
+// Solidity: function minStake() view returns(uint256)
+func (_StakeManager *StakeManagerSession) MinStake() (*big.Int, error) {
+	return _StakeManager.Contract.MinStake(&_StakeManager.CallOpts)
+}
+
+// MinStake is a free data retrieval call binding the contract method 0x375b3c0a.
+//
+// Solidity: function minStake() view returns(uint256)
+func (_StakeManager *StakeManagerCallerSession) MinStake() (*big.Int, error) {
+	return _StakeManager.Contract.MinStake(&_StakeManager.CallOpts)
+}
+
+// PendingWithdrawals is a free data retrieval call binding the contract method 0x44dc4970.
+//
+// Solidity: function pendingWithdrawals(address operator, uint256 withdrawalId) view returns(uint256 amount, uint256 unlockBlock, bool withdrawn)
+func (_StakeManager *StakeManagerCaller) PendingWithdrawals(opts *bind.CallOpts, operator common.Address, withdrawalId *big.Int) (struct {
+	Amount      *big.Int
+	UnlockBlock *big.Int
+	Withdrawn   bool
+}, error) {
+	var out []interface{}
+	err := _StakeManager.contract.Call(opts, &out, "pendingWithdrawals", operator, withdrawalId)
+
+	outstruct := new(struct {
+		Amount      *big.Int
+		UnlockBlock *big.Int
+		Withdrawn   bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Amount = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.UnlockBlock = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.Withdrawn = *abi.ConvertType(out[2], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// PendingWithdrawals is a free data retrieval call binding the contract method 0x44dc4970.
+//
+// Solidity: function pendingWithdrawals(address operator, uint256 withdrawalId) view returns(uint256 amount, uint256 unlockBlock, bool withdrawn)
+func (_StakeManager *StakeManagerSession) PendingWithdrawals(operator common.Address, withdrawalId *big.Int) (struct {
+	Amount      *big.Int
+	UnlockBlock *big.Int
+	Withdrawn   bool
+}, error) {
+	return _StakeManager.Contract.PendingWithdrawals(&_StakeManager.CallOpts, operator, withdrawalId)
+}
+
+// PendingWithdrawals is a free data retrieval call binding the contract method 0x44dc4970.
+//
+// Solidity: function pendingWithdrawals(address operator, uint256 withdrawalId) view returns(uint256 amount, uint256 unlockBlock, bool withdrawn)
+func (_StakeManager *StakeManagerCallerSession) PendingWithdrawals(operator common.Address, withdrawalId *big.Int) (struct {
+	Amount      *big.Int
+	UnlockBlock *big.Int
+	Withdrawn   bool
+}, error) {
+	return _StakeManager.Contract.PendingWithdrawals(&_StakeManager.CallOpts, operator, withdrawalId)
+}
+
+// StakeOf is a free data retrieval call binding the contract method 0x42623360.
+//
+// Solidity: function stakeOf(address operator) view returns(uint256 stakeAmount)
+func (_StakeManager *StakeManagerCaller) StakeOf(opts *bind.CallOpts, operator common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _StakeManager.contract.Call(opts, &out, "stakeOf", operator)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// StakeOf is a free data retrieval call binding the contract method 0x42623360.
+//
+// Solidity: function stakeOf(address operator) view returns(uint256 stakeAmount)
+func (_StakeManager *StakeManagerSession) StakeOf(operator common.Address) (*big.Int, error) {
+	return _StakeManager.Contract.StakeOf(&_StakeManager.CallOpts, operator)
+}
+
+// StakeOf is a free data retrieval call binding the contract method 0x42623360.
+//
+// Solidity: function stakeOf(address operator) view returns(uint256 stakeAmount)
+func (_StakeManager *StakeManagerCallerSession) StakeOf(operator common.Address) (*big.Int, error) {
+	return _StakeManager.Contract.StakeOf(&_StakeManager.CallOpts, operator)
+}
+
+// StakingToken is a free data retrieval call binding the contract method 0x72f702f3.
+//
+// Solidity: function stakingToken() view returns(address)
+func (_StakeManager *StakeManagerCaller) StakingToken(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _StakeManager.contract.Call(opts, &out, "stakingToken")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// StakingToken is a free data retrieval call binding the contract method 0x72f702f3.
+//
+// Solidity: function stakingToken() view returns(address)
+func (_StakeManager *StakeManagerSession) StakingToken() (common.Address, error) {
+	return _StakeManager.Contract.StakingToken(&_StakeManager.CallOpts)
+}
+
+// StakingToken is a free data retrieval call binding the contract method 0x72f702f3.
+//
+// Solidity: function stakingToken() view returns(address)
+func (_StakeManager *StakeManagerCallerSession) StakingToken() (common.Address, error) {
+	return _StakeManager.Contract.StakingToken(&_StakeManager.CallOpts)
+}
+
+// TotalStaked is a free data retrieval call binding the contract method 0x817b1cd2.
+//
+// Solidity: function totalStaked() view returns(uint256)
+func (_StakeManager *StakeManagerCaller) TotalStaked(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _StakeManager.contract.Call(opts, &out, "totalStaked")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalStaked is a free data retrieval call binding the contract method 0x817b1cd2.
+//
+// Solidity: function totalStaked() view returns(uint256)
+func (_StakeManager *StakeManagerSession) TotalStaked() (*big.Int, error) {
+	return _StakeManager.Contract.TotalStaked(&_StakeManager.CallOpts)
+}
+
+// TotalStaked is a free data retrieval call binding the contract method 0x817b1cd2.
+//
+// Solidity: function totalStaked() view returns(uint256)
+func (_StakeManager *StakeManagerCallerSession) TotalStaked() (*big.Int, error) {
+	return _StakeManager.Contract.TotalStaked(&_StakeManager.CallOpts)
+}
+
+// ValidatorRegistry is a free data retrieval call binding the contract method 0xf376ebbb.
+//
+// Solidity: function validatorRegistry() view returns(address)
+func (_StakeManager *StakeManagerCaller) ValidatorRegistry(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _StakeManager.contract.Call(opts, &out, "validatorRegistry")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// ValidatorRegistry is a free data retrieval call binding the contract method 0xf376ebbb.
+//
+// Solidity: function validatorRegistry() view returns(address)
+func (_StakeManager *StakeManagerSession) ValidatorRegistry() (common.Address, error) {
+	return _StakeManager.Contract.ValidatorRegistry(&_StakeManager.CallOpts)
+}
+
+// ValidatorRegistry is a free data retrieval call binding the contract method 0xf376ebbb.
+//
+// Solidity: function validatorRegistry() view returns(address)
+func (_StakeManager *StakeManagerCallerSession) ValidatorRegistry() (common.Address, error) {
+	return _StakeManager.Contract.ValidatorRegistry(&_StakeManager.CallOpts)
+}
+
+// WithdrawalCount is a free data retrieval call binding the contract method 0xb3d8bcdc.
+//
+// Solidity: function withdrawalCount(address operator) view returns(uint256 count)
+func (_StakeManager *StakeManagerCaller) WithdrawalCount(opts *bind.CallOpts, operator common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _StakeManager.contract.Call(opts, &out, "withdrawalCount", operator)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// WithdrawalCount is a free data retrieval call binding the contract method 0xb3d8bcdc.
+//
+// Solidity: function withdrawalCount(address operator) view returns(uint256 count)
+func (_StakeManager *StakeManagerSession) WithdrawalCount(operator common.Address) (*big.Int, error) {
+	return _StakeManager.Contract.WithdrawalCount(&_StakeManager.CallOpts, operator)
+}
+
+// WithdrawalCount is a free data retrieval call binding the contract method 0xb3d8bcdc.
+//
+// Solidity: function withdrawalCount(address operator) view returns(uint256 count)
+func (_StakeManager *StakeManagerCallerSession) WithdrawalCount(operator common.Address) (*big.Int, error) {
+	return _StakeManager.Contract.WithdrawalCount(&_StakeManager.CallOpts, operator)
+}
+
+// BeginUnstake is a paid mutator transaction binding the contract method 0x4200800b.
+//
+// Solidity: function beginUnstake(uint256 amount) returns()
+func (_StakeManager *StakeManagerTransactor) BeginUnstake(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return _StakeManager.contract.Transact(opts, "beginUnstake", amount)
+}
+
+// BeginUnstake is a paid mutator transaction binding the contract method 0x4200800b.
+//
+// Solidity: function beginUnstake(uint256 amount) returns()
+func (_StakeManager *StakeManagerSession) BeginUnstake(amount *big.Int) (*types.Transaction, error) {
+	return _StakeManager.Contract.BeginUnstake(&_StakeManager.TransactOpts, amount)
+}
+
+// BeginUnstake is a paid mutator transaction binding the contract method 0x4200800b.
+//
+// Solidity: function beginUnstake(uint256 amount) returns()
+func (_StakeManager *StakeManagerTransactorSession) BeginUnstake(amount *big.Int) (*types.Transaction, error) {
+	return _StakeManager.Contract.BeginUnstake(&_StakeManager.TransactOpts, amount)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0xa694fc3a.
+//
+// Solidity: function stake(uint256 amount) returns()
+func (_StakeManager *StakeManagerTransactor) Stake(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return _StakeManager.contract.Transact(opts, "stake", amount)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0xa694fc3a.
+//
+// Solidity: function stake(uint256 amount) returns()
+func (_StakeManager *StakeManagerSession) Stake(amount *big.Int) (*types.Transaction, error) {
+	return _StakeManager.Contract.Stake(&_StakeManager.TransactOpts, amount)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0xa694fc3a.
+//
+// Solidity: function stake(uint256 amount) returns()
+func (_StakeManager *StakeManagerTransactorSession) Stake(amount *big.Int) (*types.Transaction, error) {
+	return _StakeManager.Contract.Stake(&_StakeManager.TransactOpts, amount)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 withdrawalId) returns()
+func (_StakeManager *StakeManagerTransactor) Withdraw(opts *bind.TransactOpts, withdrawalId *big.Int) (*types.Transaction, error) {
+	return _StakeManager.contract.Transact(opts, "withdraw", withdrawalId)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 withdrawalId) returns()
+func (_StakeManager *StakeManagerSession) Withdraw(withdrawalId *big.Int) (*types.Transaction, error) {
+	return _StakeManager.Contract.Withdraw(&_StakeManager.TransactOpts, withdrawalId)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 withdrawalId) returns()
+func (_StakeManager *StakeManagerTransactorSession) Withdraw(withdrawalId *big.Int) (*types.Transaction, error) {
+	return _StakeManager.Contract.Withdraw(&_StakeManager.TransactOpts, withdrawalId)
+}
+
+// StakeManagerStakedIterator is returned from FilterStaked and is used to iterate over the raw logs and unpacked data for Staked events raised by the StakeManager contract.
+type StakeManagerStakedIterator struct {
+	Event *StakeManagerStaked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakeManagerStakedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakeManagerStaked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakeManagerStaked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakeManagerStakedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakeManagerStakedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakeManagerStaked represents a Staked event raised by the StakeManager contract.
+type StakeManagerStaked struct {
+	Operator common.Address
+	Amount   *big.Int
+	NewStake *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterStaked is a free log retrieval operation binding the contract event 0x1449c6dd7851abc30abf37f57715f492010519147cc2652fbc38202c18a6ee90.
+//
+// Solidity: event Staked(address indexed operator, uint256 amount, uint256 newStake)
+func (_StakeManager *StakeManagerFilterer) FilterStaked(opts *bind.FilterOpts, operator []common.Address) (*StakeManagerStakedIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _StakeManager.contract.FilterLogs(opts, "Staked", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakeManagerStakedIterator{contract: _StakeManager.contract, event: "Staked", logs: logs, sub: sub}, nil
+}
+
+// WatchStaked is a free log subscription operation binding the contract event 0x1449c6dd7851abc30abf37f57715f492010519147cc2652fbc38202c18a6ee90.
+//
+// Solidity: event Staked(address indexed operator, uint256 amount, uint256 newStake)
+func (_StakeManager *StakeManagerFilterer) WatchStaked(opts *bind.WatchOpts, sink chan<- *StakeManagerStaked, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _StakeManager.contract.WatchLogs(opts, "Staked", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakeManagerStaked)
+				if err := _StakeManager.contract.UnpackLog(event, "Staked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStaked is a log parse operation binding the contract event 0x1449c6dd7851abc30abf37f57715f492010519147cc2652fbc38202c18a6ee90.
+//
+// Solidity: event Staked(address indexed operator, uint256 amount, uint256 newStake)
+func (_StakeManager *StakeManagerFilterer) ParseStaked(log types.Log) (*StakeManagerStaked, error) {
+	event := new(StakeManagerStaked)
+	if err := _StakeManager.contract.UnpackLog(event, "Staked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakeManagerUnstakeStartedIterator is returned from FilterUnstakeStarted and is used to iterate over the raw logs and unpacked data for UnstakeStarted events raised by the StakeManager contract.
+type StakeManagerUnstakeStartedIterator struct {
+	Event *StakeManagerUnstakeStarted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakeManagerUnstakeStartedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakeManagerUnstakeStarted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakeManagerUnstakeStarted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakeManagerUnstakeStartedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakeManagerUnstakeStartedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakeManagerUnstakeStarted represents a UnstakeStarted event raised by the StakeManager contract.
+type StakeManagerUnstakeStarted struct {
+	Operator     common.Address
+	Amount       *big.Int
+	UnlockBlock  *big.Int
+	WithdrawalId *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnstakeStarted is a free log retrieval operation binding the contract event 0x8ec12332d7f55affad84a37f2c5655a393f8fc4a4f96e1127a0b3aafdf0ed828.
+//
+// Solidity: event UnstakeStarted(address indexed operator, uint256 amount, uint256 unlockBlock, uint256 withdrawalId)
+func (_StakeManager *StakeManagerFilterer) FilterUnstakeStarted(opts *bind.FilterOpts, operator []common.Address) (*StakeManagerUnstakeStartedIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _StakeManager.contract.FilterLogs(opts, "UnstakeStarted", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakeManagerUnstakeStartedIterator{contract: _StakeManager.contract, event: "UnstakeStarted", logs: logs, sub: sub}, nil
+}
+
+// WatchUnstakeStarted is a free log subscription operation binding the contract event 0x8ec12332d7f55affad84a37f2c5655a393f8fc4a4f96e1127a0b3aafdf0ed828.
+//
+// Solidity: event UnstakeStarted(address indexed operator, uint256 amount, uint256 unlockBlock, uint256 withdrawalId)
+func (_StakeManager *StakeManagerFilterer) WatchUnstakeStarted(opts *bind.WatchOpts, sink chan<- *StakeManagerUnstakeStarted, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _StakeManager.contract.WatchLogs(opts, "UnstakeStarted", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakeManagerUnstakeStarted)
+				if err := _StakeManager.contract.UnpackLog(event, "UnstakeStarted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnstakeStarted is a log parse operation binding the contract event 0x8ec12332d7f55affad84a37f2c5655a393f8fc4a4f96e1127a0b3aafdf0ed828.
+//
+// Solidity: event UnstakeStarted(address indexed operator, uint256 amount, uint256 unlockBlock, uint256 withdrawalId)
+func (_StakeManager *StakeManagerFilterer) ParseUnstakeStarted(log types.Log) (*StakeManagerUnstakeStarted, error) {
+	event := new(StakeManagerUnstakeStarted)
+	if err := _StakeManager.contract.UnpackLog(event, "UnstakeStarted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakeManagerWithdrawnIterator is returned from FilterWithdrawn and is used to iterate over the raw logs and unpacked data for Withdrawn events raised by the StakeManager contract.
+type StakeManagerWithdrawnIterator struct {
+	Event *StakeManagerWithdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakeManagerWithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakeManagerWithdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakeManagerWithdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakeManagerWithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakeManagerWithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakeManagerWithdrawn represents a Withdrawn event raised by the StakeManager contract.
+type StakeManagerWithdrawn struct {
+	Operator     common.Address
+	Amount       *big.Int
+	WithdrawalId *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdrawn is a free log retrieval operation binding the contract event 0x92ccf450a286a957af52509bc1c9939d1a6a481783e142e41e2499f0bb66ebc6.
+//
+// Solidity: event Withdrawn(address indexed operator, uint256 amount, uint256 withdrawalId)
+func (_StakeManager *StakeManagerFilterer) FilterWithdrawn(opts *bind.FilterOpts, operator []common.Address) (*StakeManagerWithdrawnIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _StakeManager.contract.FilterLogs(opts, "Withdrawn", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakeManagerWithdrawnIterator{contract: _StakeManager.contract, event: "Withdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdrawn is a free log subscription operation binding the contract event 0x92ccf450a286a957af52509bc1c9939d1a6a481783e142e41e2499f0bb66ebc6.
+//
+// Solidity: event Withdrawn(address indexed operator, uint256 amount, uint256 withdrawalId)
+func (_StakeManager *StakeManagerFilterer) WatchWithdrawn(opts *bind.WatchOpts, sink chan<- *StakeManagerWithdrawn, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _StakeManager.contract.WatchLogs(opts, "Withdrawn", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakeManagerWithdrawn)
+				if err := _StakeManager.contract.UnpackLog(event, "Withdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdrawn is a log parse operation binding the contract event 0x92ccf450a286a957af52509bc1c9939d1a6a481783e142e41e2499f0bb66ebc6.
+//
+// Solidity: event Withdrawn(address indexed operator, uint256 amount, uint256 withdrawalId)
+func (_StakeManager *StakeManagerFilterer) ParseWithdrawn(log types.Log) (*StakeManagerWithdrawn, error) {
+	event := new(StakeManagerWithdrawn)
+	if err := _StakeManager.contract.UnpackLog(event, "Withdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,590 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ValidatorRegistryValidator is an auto generated low-level Go binding around an user-defined struct.
+type ValidatorRegistryValidator struct {
+	Operator          common.Address
+	BlsPubkey         []byte
+	NodeId            []byte
+	Moniker           string
+	Endpoint          string
+	RegisteredAtBlock *big.Int
+	Exists            bool
+}
+
+// ValidatorRegistryMetaData contains all meta data concerning the ValidatorRegistry contract.
+var ValidatorRegistryMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"getValidator\",\"outputs\":[{\"internalType\":\"structValidatorRegistry.Validator\",\"name\":\"\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"blsPubkey\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"nodeId\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"moniker\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"endpoint\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"registeredAtBlock\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"exists\",\"type\":\"bool\"}]}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"isRegistered\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"blsPubkey\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"nodeId\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"moniker\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"endpoint\",\"type\":\"string\"}],\"name\":\"registerValidator\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"nodeId\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"moniker\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"endpoint\",\"type\":\"string\"}],\"name\":\"updateValidator\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"nodeId\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"moniker\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"endpoint\",\"type\":\"string\"}],\"name\":\"ValidatorRegistered\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"nodeId\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"moniker\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"endpoint\",\"type\":\"string\"}],\"name\":\"ValidatorUpdated\",\"type\":\"event\"}]",
+}
+
+// ValidatorRegistryABI is the input ABI used to generate the binding from.
+// Deprecated: Use ValidatorRegistryMetaData.ABI instead.
+var ValidatorRegistryABI = ValidatorRegistryMetaData.ABI
+
+// ValidatorRegistry is an auto generated Go binding around an Ethereum contract.
+type ValidatorRegistry struct {
+	ValidatorRegistryCaller     // Read-only binding to the contract
+	ValidatorRegistryTransactor // Write-only binding to the contract
+	ValidatorRegistryFilterer   // Log filterer for contract events
+}
+
+// ValidatorRegistryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ValidatorRegistryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ValidatorRegistryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ValidatorRegistryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ValidatorRegistryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ValidatorRegistryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ValidatorRegistrySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ValidatorRegistrySession struct {
+	Contract     *ValidatorRegistry // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts      // Call options to use throughout this session
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// ValidatorRegistryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ValidatorRegistryCallerSession struct {
+	Contract *ValidatorRegistryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts            // Call options to use throughout this session
+}
+
+// ValidatorRegistryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ValidatorRegistryTransactorSession struct {
+	Contract     *ValidatorRegistryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts            // Transaction auth options to use throughout this session
+}
+
+// ValidatorRegistryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ValidatorRegistryRaw struct {
+	Contract *ValidatorRegistry // Generic contract binding to access the raw methods on
+}
+
+// ValidatorRegistryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ValidatorRegistryCallerRaw struct {
+	Contract *ValidatorRegistryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ValidatorRegistryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ValidatorRegistryTransactorRaw struct {
+	Contract *ValidatorRegistryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewValidatorRegistry creates a new instance of ValidatorRegistry, bound to a specific deployed contract.
+func NewValidatorRegistry(address common.Address, backend bind.ContractBackend) (*ValidatorRegistry, error) {
+	contract, err := bindValidatorRegistry(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorRegistry{ValidatorRegistryCaller: ValidatorRegistryCaller{contract: contract}, ValidatorRegistryTransactor: ValidatorRegistryTransactor{contract: contract}, ValidatorRegistryFilterer: ValidatorRegistryFilterer{contract: contract}}, nil
+}
+
+// NewValidatorRegistryCaller creates a new read-only instance of ValidatorRegistry, bound to a specific deployed contract.
+func NewValidatorRegistryCaller(address common.Address, caller bind.ContractCaller) (*ValidatorRegistryCaller, error) {
+	contract, err := bindValidatorRegistry(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorRegistryCaller{contract: contract}, nil
+}
+
+// NewValidatorRegistryTransactor creates a new write-only instance of ValidatorRegistry, bound to a specific deployed contract.
+func NewValidatorRegistryTransactor(address common.Address, transactor bind.ContractTransactor) (*ValidatorRegistryTransactor, error) {
+	contract, err := bindValidatorRegistry(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorRegistryTransactor{contract: contract}, nil
+}
+
+// NewValidatorRegistryFilterer creates a new log filterer instance of ValidatorRegistry, bound to a specific deployed contract.
+func NewValidatorRegistryFilterer(address common.Address, filterer bind.ContractFilterer) (*ValidatorRegistryFilterer, error) {
+	contract, err := bindValidatorRegistry(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorRegistryFilterer{contract: contract}, nil
+}
+
+// bindValidatorRegistry binds a generic wrapper to an already deployed contract.
+func bindValidatorRegistry(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ValidatorRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ValidatorRegistry *ValidatorRegistryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ValidatorRegistry.Contract.ValidatorRegistryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ValidatorRegistry *ValidatorRegistryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ValidatorRegistry.Contract.ValidatorRegistryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ValidatorRegistry *ValidatorRegistryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ValidatorRegistry.Contract.ValidatorRegistryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ValidatorRegistry *ValidatorRegistryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ValidatorRegistry.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ValidatorRegistry *ValidatorRegistryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ValidatorRegistry.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ValidatorRegistry *ValidatorRegistryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ValidatorRegistry.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetValidator is a free data retrieval call binding the contract method 0x1904bb2e.
+//
+// Solidity: function getValidator(address operator) view returns((address,bytes,bytes,string,string,uint256,bool))
+func (_ValidatorRegistry *ValidatorRegistryCaller) GetValidator(opts *bind.CallOpts, operator common.Address) (ValidatorRegistryValidator, error) {
+	var out []interface{}
+	err := _ValidatorRegistry.contract.Call(opts, &out, "getValidator", operator)
+
+	if err != nil {
+		return *new(ValidatorRegistryValidator), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(ValidatorRegistryValidator)).(*ValidatorRegistryValidator)
+
+	return out0, err
+
+}
+
+// GetValidator is a free data retrieval call binding the contract method 0x1904bb2e.
+//
+// Solidity: function getValidator(address operator) view returns((address,bytes,bytes,string,string,uint256,bool))
+func (_ValidatorRegistry *ValidatorRegistrySession) GetValidator(operator common.Address) (ValidatorRegistryValidator, error) {
+	return _ValidatorRegistry.Contract.GetValidator(&_ValidatorRegistry.CallOpts, operator)
+}
+
+// GetValidator is a free data retrieval call binding the contract method 0x1904bb2e.
+//
+// Solidity: function getValidator(address operator) view returns((address,bytes,bytes,string,string,uint256,bool))
+func (_ValidatorRegistry *ValidatorRegistryCallerSession) GetValidator(operator common.Address) (ValidatorRegistryValidator, error) {
+	return _ValidatorRegistry.Contract.GetValidator(&_ValidatorRegistry.CallOpts, operator)
+}
+
+// IsRegistered is a free data retrieval call binding the contract method 0xc3c5a547.
+//
+// Solidity: function isRegistered(address operator) view returns(bool)
+func (_ValidatorRegistry *ValidatorRegistryCaller) IsRegistered(opts *bind.CallOpts, operator common.Address) (bool, error) {
+	var out []interface{}
+	err := _ValidatorRegistry.contract.Call(opts, &out, "isRegistered", operator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsRegistered is a free data retrieval call binding the contract method 0xc3c5a547.
+//
+// Solidity: function isRegistered(address operator) view returns(bool)
+func (_ValidatorRegistry *ValidatorRegistrySession) IsRegistered(operator common.Address) (bool, error) {
+	return _ValidatorRegistry.Contract.IsRegistered(&_ValidatorRegistry.CallOpts, operator)
+}
+
+// IsRegistered is a free data retrieval call binding the contract method 0xc3c5a547.
+//
+// Solidity: function isRegistered(address operator) view returns(bool)
+func (_ValidatorRegistry *ValidatorRegistryCallerSession) IsRegistered(operator common.Address) (bool, error) {
+	return _ValidatorRegistry.Contract.IsRegistered(&_ValidatorRegistry.CallOpts, operator)
+}
+
+// RegisterValidator is a paid mutator transaction binding the contract method 0x39276d1f.
+//
+// Solidity: function registerValidator(bytes blsPubkey, bytes nodeId, string moniker, string endpoint) returns()
+func (_ValidatorRegistry *ValidatorRegistryTransactor) RegisterValidator(opts *bind.TransactOpts, blsPubkey []byte, nodeId []byte, moniker string, endpoint string) (*types.Transaction, error) {
+	return _ValidatorRegistry.contract.Transact(opts, "registerValidator", blsPubkey, nodeId, moniker, endpoint)
+}
+
+// RegisterValidator is a paid mutator transaction binding the contract method 0x39276d1f.
+//
+// Solidity: function registerValidator(bytes blsPubkey, bytes nodeId, string moniker, string endpoint) returns()
+func (_ValidatorRegistry *ValidatorRegistrySession) RegisterValidator(blsPubkey []byte, nodeId []byte, moniker string, endpoint string) (*types.Transaction, error) {
+	return _ValidatorRegistry.Contract.RegisterValidator(&_ValidatorRegistry.TransactOpts, blsPubkey, nodeId, moniker, endpoint)
+}
+
+// RegisterValidator is a paid mutator transaction binding the contract method 0x39276d1f.
+//
+// Solidity: function registerValidator(bytes blsPubkey, bytes nodeId, string moniker, string endpoint) returns()
+func (_ValidatorRegistry *ValidatorRegistryTransactorSession) RegisterValidator(blsPubkey []byte, nodeId []byte, moniker string, endpoint string) (*types.Transaction, error) {
+	return _ValidatorRegistry.Contract.RegisterValidator(&_ValidatorRegistry.TransactOpts, blsPubkey, nodeId, moniker, endpoint)
+}
+
+// UpdateValidator is a paid mutator transaction binding the contract method 0xb7dd9466.
+//
+// Solidity: function updateValidator(bytes nodeId, string moniker, string endpoint) returns()
+func (_ValidatorRegistry *ValidatorRegistryTransactor) UpdateValidator(opts *bind.TransactOpts, nodeId []byte, moniker string, endpoint string) (*types.Transaction, error) {
+	return _ValidatorRegistry.contract.Transact(opts, "updateValidator", nodeId, moniker, endpoint)
+}
+
+// UpdateValidator is a paid mutator transaction binding the contract method 0xb7dd9466.
+//
+// Solidity: function updateValidator(bytes nodeId, string moniker, string endpoint) returns()
+func (_ValidatorRegistry *ValidatorRegistrySession) UpdateValidator(nodeId []byte, moniker string, endpoint string) (*types.Transaction, error) {
+	return _ValidatorRegistry.Contract.UpdateValidator(&_ValidatorRegistry.TransactOpts, nodeId, moniker, endpoint)
+}
+
+// UpdateValidator is a paid mutator transaction binding the contract method 0xb7dd9466.
+//
+// Solidity: function updateValidator(bytes nodeId, string moniker, string endpoint) returns()
+func (_ValidatorRegistry *ValidatorRegistryTransactorSession) UpdateValidator(nodeId []byte, moniker string, endpoint string) (*types.Transaction, error) {
+	return _ValidatorRegistry.Contract.UpdateValidator(&_ValidatorRegistry.TransactOpts, nodeId, moniker, endpoint)
+}
+
+// ValidatorRegistryValidatorRegisteredIterator is returned from FilterValidatorRegistered and is used to iterate over the raw logs and unpacked data for ValidatorRegistered events raised by the ValidatorRegistry contract.
+type ValidatorRegistryValidatorRegisteredIterator struct {
+	Event *ValidatorRegistryValidatorRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ValidatorRegistryValidatorRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ValidatorRegistryValidatorRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ValidatorRegistryValidatorRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ValidatorRegistryValidatorRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ValidatorRegistryValidatorRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ValidatorRegistryValidatorRegistered represents a ValidatorRegistered event raised by the ValidatorRegistry contract.
+type ValidatorRegistryValidatorRegistered struct {
+	Operator common.Address
+	NodeId   []byte
+	Moniker  string
+	Endpoint string
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterValidatorRegistered is a free log retrieval operation binding the contract event 0x5fa04629821aae0149643ac004a8054e077500242599d25ee764e4ec30f8ca51.
+//
+// Solidity: event ValidatorRegistered(address indexed operator, bytes nodeId, string moniker, string endpoint)
+func (_ValidatorRegistry *ValidatorRegistryFilterer) FilterValidatorRegistered(opts *bind.FilterOpts, operator []common.Address) (*ValidatorRegistryValidatorRegisteredIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _ValidatorRegistry.contract.FilterLogs(opts, "ValidatorRegistered", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorRegistryValidatorRegisteredIterator{contract: _ValidatorRegistry.contract, event: "ValidatorRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchValidatorRegistered is a free log subscription operation binding the contract event 0x5fa04629821aae0149643ac004a8054e077500242599d25ee764e4ec30f8ca51.
+//
+// Solidity: event ValidatorRegistered(address indexed operator, bytes nodeId, string moniker, string endpoint)
+func (_ValidatorRegistry *ValidatorRegistryFilterer) WatchValidatorRegistered(opts *bind.WatchOpts, sink chan<- *ValidatorRegistryValidatorRegistered, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _ValidatorRegistry.contract.WatchLogs(opts, "ValidatorRegistered", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ValidatorRegistryValidatorRegistered)
+				if err := _ValidatorRegistry.contract.UnpackLog(event, "ValidatorRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseValidatorRegistered is a log parse operation binding the contract event 0x5fa04629821aae0149643ac004a8054e077500242599d25ee764e4ec30f8ca51.
+//
+// Solidity: event ValidatorRegistered(address indexed operator, bytes nodeId, string moniker, string endpoint)
+func (_ValidatorRegistry *ValidatorRegistryFilterer) ParseValidatorRegistered(log types.Log) (*ValidatorRegistryValidatorRegistered, error) {
+	event := new(ValidatorRegistryValidatorRegistered)
+	if err := _ValidatorRegistry.contract.UnpackLog(event, "ValidatorRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ValidatorRegistryValidatorUpdatedIterator is returned from FilterValidatorUpdated and is used to iterate over the raw logs and unpacked data for ValidatorUpdated events raised by the ValidatorRegistry contract.
+type ValidatorRegistryValidatorUpdatedIterator struct {
+	Event *ValidatorRegistryValidatorUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ValidatorRegistryValidatorUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ValidatorRegistryValidatorUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ValidatorRegistryValidatorUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ValidatorRegistryValidatorUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ValidatorRegistryValidatorUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ValidatorRegistryValidatorUpdated represents a ValidatorUpdated event raised by the ValidatorRegistry contract.
+type ValidatorRegistryValidatorUpdated struct {
+	Operator common.Address
+	NodeId   []byte
+	Moniker  string
+	Endpoint string
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterValidatorUpdated is a free log retrieval operation binding the contract event 0x3658f9d121944bb8a8613492ec761f75444d6cc77c94df2f72290765fd9ac5b2.
+//
+// Solidity: event ValidatorUpdated(address indexed operator, bytes nodeId, string moniker, string endpoint)
+func (_ValidatorRegistry *ValidatorRegistryFilterer) FilterValidatorUpdated(opts *bind.FilterOpts, operator []common.Address) (*ValidatorRegistryValidatorUpdatedIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _ValidatorRegistry.contract.FilterLogs(opts, "ValidatorUpdated", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorRegistryValidatorUpdatedIterator{contract: _ValidatorRegistry.contract, event: "ValidatorUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchValidatorUpdated is a free log subscription operation binding the contract event 0x3658f9d121944bb8a8613492ec761f75444d6cc77c94df2f72290765fd9ac5b2.
+//
+// Solidity: event ValidatorUpdated(address indexed operator, bytes nodeId, string moniker, string endpoint)
+func (_ValidatorRegistry *ValidatorRegistryFilterer) WatchValidatorUpdated(opts *bind.WatchOpts, sink chan<- *ValidatorRegistryValidatorUpdated, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _ValidatorRegistry.contract.WatchLogs(opts, "ValidatorUpdated", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ValidatorRegistryValidatorUpdated)
+				if err := _ValidatorRegistry.contract.UnpackLog(event, "ValidatorUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseValidatorUpdated is a log parse operation binding the contract event 0x3658f9d121944bb8a8613492ec761f75444d6cc77c94df2f72290765fd9ac5b2.
+//
+// Solidity: event ValidatorUpdated(address indexed operator, bytes nodeId, string moniker, string endpoint)
+func (_ValidatorRegistry *ValidatorRegistryFilterer) ParseValidatorUpdated(log types.Log) (*ValidatorRegistryValidatorUpdated, error) {
+	event := new(ValidatorRegistryValidatorUpdated)
+	if err := _ValidatorRegistry.contract.UnpackLog(event, "ValidatorUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
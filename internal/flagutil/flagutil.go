@@ -0,0 +1,20 @@
+// Package flagutil holds the flag helpers shared by the qikchain binaries
+// and the packages that register their own flags.
+package flagutil
+
+import "os"
+
+// FlagSet is satisfied by both flag.FlagSet and cobra's flag set, so a
+// package can register its flags on either.
+type FlagSet interface {
+	StringVar(p *string, name, value, usage string)
+}
+
+// EnvOr returns the environment variable key, or fallback when it is unset
+// or empty. Flags use it for defaults that the environment can override.
+func EnvOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,19 @@
+package flagutil
+
+import (
+	"flag"
+	"testing"
+)
+
+var _ FlagSet = (*flag.FlagSet)(nil)
+
+func TestEnvOr(t *testing.T) {
+	t.Setenv("QIKCHAIN_TEST_ENV_OR", "")
+	if got := EnvOr("QIKCHAIN_TEST_ENV_OR", "text"); got != "text" {
+		t.Fatalf("unset: got %q", got)
+	}
+	t.Setenv("QIKCHAIN_TEST_ENV_OR", "json")
+	if got := EnvOr("QIKCHAIN_TEST_ENV_OR", "text"); got != "json" {
+		t.Fatalf("set: got %q", got)
+	}
+}
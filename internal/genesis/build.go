@@ -1,8 +1,10 @@
 package genesis
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -12,6 +14,9 @@ import (
 	"github.com/BioMark3r/qikchain/internal/chainmeta"
 	"github.com/BioMark3r/qikchain/internal/config"
 	"github.com/BioMark3r/qikchain/internal/edge"
+	"github.com/BioMark3r/qikchain/internal/pos"
+	"github.com/BioMark3r/qikchain/internal/tracing"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 type BuildOptions struct {
@@ -43,6 +48,7 @@ type BuildOptions struct {
 type POSAddresses struct {
 	Staking      string `json:"staking"`
 	ValidatorSet string `json:"validatorSet"`
+	ChainID      uint64 `json:"-"` // chain the contracts were deployed on; 0 when unrecorded
 }
 
 type ForkObject struct {
@@ -60,8 +66,26 @@ type BuildResult struct {
 }
 
 func Build(opts BuildOptions) (BuildResult, error) {
-	var res BuildResult
+	return BuildContext(context.Background(), opts)
+}
+
+// BuildContext is Build with a genesis.build span, a child of the span in
+// ctx, and one child span per stage.
+func BuildContext(ctx context.Context, opts BuildOptions) (res BuildResult, err error) {
+	ctx, span := tracing.Start(ctx, "genesis.build", tracing.String("genesis.consensus", opts.Consensus), tracing.String("genesis.env", opts.Env), tracing.Int("genesis.chain_id", opts.ChainID))
+	var stage *tracing.Span
+	next := func(name string, attrs ...tracing.Attr) {
+		stage.End()
+		_, stage = tracing.Start(ctx, name, attrs...)
+	}
+	defer func() {
+		stage.RecordError(err)
+		stage.End()
+		span.RecordError(err)
+		span.End()
+	}()
 
+	next("genesis.load_token", tracing.String("file.path", opts.TokenPath))
 	token, err := config.LoadTokenConfig(opts.TokenPath)
 	if err != nil {
 		return res, err
@@ -76,6 +100,7 @@ func Build(opts BuildOptions) (BuildResult, error) {
 		return res, fmt.Errorf("token phase1PosRewards must be 0, got %q", token.Phase1PosRewards)
 	}
 
+	next("genesis.verify_allocations", tracing.String("file.path", opts.AllocationsPath))
 	allocCfg, err := config.LoadAllocationConfig(opts.AllocationsPath)
 	if err != nil {
 		return res, err
@@ -83,11 +108,15 @@ func Build(opts BuildOptions) (BuildResult, error) {
 	if _, errs := allocations.Verify(allocCfg, allocations.VerifyOptions{}); len(errs) > 0 {
 		return res, fmt.Errorf("allocation verification failed: %v", errs[0])
 	}
+	next("genesis.render")
 	allocJSON, totalPremine, err := allocations.RenderAllocMapAndTotal(allocCfg)
 	if err != nil {
 		return res, err
 	}
 	res.TotalPremineWei = totalPremine
+	stage.SetAttributes(tracing.Int("genesis.alloc_bytes", len(allocJSON)))
+
+	next("genesis.merge_overlay", tracing.String("genesis.template", opts.TemplatePath))
 
 	base, err := LoadTemplate(opts.TemplatePath)
 	if err != nil {
@@ -112,13 +141,16 @@ func Build(opts BuildOptions) (BuildResult, error) {
 
 	if opts.Consensus == "pos" {
 		res.POSAddressesUsed = true
-		posAddr, err := loadPOSAddresses(opts.POSDeploymentsPath)
+		posAddr, err := LoadPOSAddresses(opts.POSDeploymentsPath)
 		if err != nil {
 			if !opts.AllowMissingPOSAddresses {
 				return res, err
 			}
 			posAddr = POSAddresses{Staking: "{{STAKING_ADDRESS}}", ValidatorSet: "{{VALIDATOR_SET_ADDRESS}}"}
 		}
+		if posAddr.ChainID != 0 && posAddr.ChainID != uint64(opts.ChainID) {
+			return res, fmt.Errorf("pos deployments %s were made on chain %d, not chain %d", opts.POSDeploymentsPath, posAddr.ChainID, opts.ChainID)
+		}
 		res.POSAddresses = posAddr
 		placeholders["STAKING_ADDRESS"] = posAddr.Staking
 		placeholders["VALIDATOR_SET_ADDRESS"] = posAddr.ValidatorSet
@@ -143,6 +175,7 @@ func Build(opts BuildOptions) (BuildResult, error) {
 	chainDoc := cloneMap(combined)
 	chainDoc["genesis"] = "__GENESIS_PATH__"
 
+	next("genesis.validate")
 	v := ValidateChainConfig(chainDoc, ValidateOptions{
 		AllowMissingPOSAddresses: opts.AllowMissingPOSAddresses,
 		Strict:                   opts.Strict,
@@ -152,12 +185,15 @@ func Build(opts BuildOptions) (BuildResult, error) {
 	v.Warnings = append(v.Warnings, ethValidation.Warnings...)
 	v.Errors = append(v.Errors, ethValidation.Errors...)
 	for _, w := range v.Warnings {
-		fmt.Fprintf(os.Stderr, "genesis build warning: %s\n", w)
+		slog.Warn("genesis build warning", "warning", w)
 	}
+	stage.SetAttributes(tracing.Int("genesis.warnings", len(v.Warnings)))
 	if len(v.Errors) > 0 {
 		return res, fmt.Errorf("genesis validation failed: %v", v.Errors[0])
 	}
 
+	next("genesis.encode")
+
 	if opts.Pretty {
 		res.GenesisJSON, err = MarshalCanonicalIndented(combined)
 	} else {
@@ -188,6 +224,7 @@ func Build(opts BuildOptions) (BuildResult, error) {
 		return res, err
 	}
 	res.MetadataJSON = meta
+	stage.SetAttributes(tracing.Int("genesis.bytes", len(res.GenesisJSON)))
 	return res, nil
 }
 
@@ -253,6 +290,16 @@ func removeForbiddenTopLevelKeys(genesis map[string]any) {
 }
 
 func WriteOutputs(opts BuildOptions, res BuildResult) error {
+	return WriteOutputsContext(context.Background(), opts, res)
+}
+
+// WriteOutputsContext is WriteOutputs with a genesis.write span.
+func WriteOutputsContext(ctx context.Context, opts BuildOptions, res BuildResult) (err error) {
+	_, span := tracing.Start(ctx, "genesis.write")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 	combinedPath := opts.OutCombinedPath
 	if combinedPath == "" {
 		if opts.OutPath != "" {
@@ -322,25 +369,16 @@ func WriteOutputs(opts BuildOptions, res BuildResult) error {
 	return nil
 }
 
-func loadPOSAddresses(path string) (POSAddresses, error) {
-	data, err := os.ReadFile(path)
+// LoadPOSAddresses reads the staking and validator-set addresses from a PoS
+// deployment record, along with its chain ID when the record has one.
+func LoadPOSAddresses(path string) (POSAddresses, error) {
+	rec, err := pos.ReadRecord(path)
 	if err != nil {
 		return POSAddresses{}, fmt.Errorf("load pos deployments: %w", err)
 	}
-	var doc struct {
-		Staking struct {
-			Address string `json:"address"`
-		} `json:"staking"`
-		ValidatorSet struct {
-			Address string `json:"address"`
-		} `json:"validatorSet"`
-	}
-	if err := json.Unmarshal(data, &doc); err != nil {
-		return POSAddresses{}, fmt.Errorf("parse pos deployments: %w", err)
-	}
-	addr := POSAddresses{Staking: strings.ToLower(doc.Staking.Address), ValidatorSet: strings.ToLower(doc.ValidatorSet.Address)}
-	if addr.Staking == "" || addr.ValidatorSet == "" {
+	staking, set := rec.Contracts["staking"].Address, rec.Contracts["validatorSet"].Address
+	if staking == (common.Address{}) || set == (common.Address{}) {
 		return POSAddresses{}, fmt.Errorf("pos deployments missing staking/validatorSet addresses")
 	}
-	return addr, nil
+	return POSAddresses{Staking: strings.ToLower(staking.Hex()), ValidatorSet: strings.ToLower(set.Hex()), ChainID: rec.ChainID}, nil
 }
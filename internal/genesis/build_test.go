@@ -2,8 +2,10 @@ package genesis
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/BioMark3r/qikchain/internal/allocations"
@@ -198,3 +200,43 @@ func TestGenesisBuildFiltersUnsupportedForks(t *testing.T) {
 		t.Fatalf("did not expect london")
 	}
 }
+
+func TestPOSBuildChecksDeploymentChainID(t *testing.T) {
+	deployments := filepath.Join(t.TempDir(), "pos.json")
+	record := `{"chainId":%d,"staking":{"address":"0x1000000000000000000000000000000000000001"},"validatorSet":{"address":"0x1000000000000000000000000000000000000002"}}`
+	opts := BuildOptions{Consensus: "pos", Env: "devnet", TemplatePath: "../../config/genesis.template.json", OverlayDir: "../../config/consensus", TokenPath: "../../config/token.json", AllocationsPath: "../../config/allocations/devnet.json", ChainID: 100, GasLimit: "0x1c9c380", Difficulty: "0x1", ExtraData: "0x", MinGasPrice: "0", BaseFeeEnabled: false, POSDeploymentsPath: deployments, Pretty: true, Strict: false, AcceptLegacyConsensus: true}
+	if err := os.WriteFile(deployments, []byte(fmt.Sprintf(record, 101)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Build(opts); err == nil || !strings.Contains(err.Error(), "chain 101, not chain 100") {
+		t.Fatalf("expected chain id mismatch, got %v", err)
+	}
+	if err := os.WriteFile(deployments, []byte(fmt.Sprintf(record, 100)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	res, err := Build(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.POSAddresses.ChainID != 100 || res.POSAddresses.Staking != "0x1000000000000000000000000000000000000001" {
+		t.Fatalf("pos addresses = %+v", res.POSAddresses)
+	}
+}
+
+func TestLoadPOSAddressesReadsDeployerRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pos.json")
+	doc := `{"network":"local","chainId":"100","deployer":"0x90f79bf6eb2c4f870365e785982e1f101e93b906",` +
+		`"staking":{"address":"0x10000000000000000000000000000000000000AA","tx":"0x01","blockNumber":3},` +
+		`"validatorSet":{"address":"0x10000000000000000000000000000000000000bb"}}`
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadPOSAddresses(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := POSAddresses{Staking: "0x10000000000000000000000000000000000000aa", ValidatorSet: "0x10000000000000000000000000000000000000bb", ChainID: 100}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/monitor"
+)
+
+func TestStoreAndSummarize(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "history.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// node2 polled every 5s from t0: blocks every 5s (2s block time on
+	// chain), stuck at block 10 from 50s to 100s, down 120s-135s, no
+	// qikchaind between 150s and 200s.
+	t0 := time.Unix(1700000000, 0)
+	var block uint64
+	for sec := 0; sec <= 220; sec += 5 {
+		if sec > 150 && sec < 200 {
+			continue
+		}
+		up := sec < 120 || sec >= 140
+		if up && (sec < 50 || sec >= 100) {
+			block++
+		}
+		st := monitor.NodeStatus{Name: "node2", Up: up, BlockNumber: block, BlockTime: 1000 + 2*block, PeerCount: 3}
+		if sec >= 140 {
+			st.PeerCount = 1
+		}
+		if !up {
+			st = monitor.NodeStatus{Name: "node2", Error: "connection refused"}
+		}
+		c := monitor.Cluster{NodesTotal: 2, Nodes: []monitor.NodeStatus{st, {Name: "node1", Up: true, BlockNumber: block}}}
+		if err := s.Record(t0.Add(time.Duration(sec)*time.Second), c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Prune(t0.Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := s.Nodes(t0, t0.Add(time.Hour))
+	if err != nil || len(nodes) != 2 || nodes[0] != "node1" {
+		t.Fatalf("nodes %v %v", nodes, err)
+	}
+	samples, err := s.Samples("node2", t0, t0.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 35 || samples[0].Time != t0.Add(5*time.Second).UnixMilli() || samples[0].Block != 2 {
+		t.Fatalf("%d samples, first %+v", len(samples), samples[0])
+	}
+
+	sum := Summarize("node2", samples, 30*time.Second)
+	if sum.UpSamples != 31 || sum.FirstBlock != 2 || sum.LastBlock != 22 || sum.Blocks != 20 || sum.AvgBlockTimeSeconds != 2 {
+		t.Fatalf("summary %+v", sum)
+	}
+	if sum.Peers.Min != 1 || sum.Peers.Max != 3 || sum.Peers.First != 3 || sum.Peers.Last != 1 || sum.Peers.Trend[0] != 3 || sum.Peers.Trend[TrendBuckets-1] != 1 {
+		t.Fatalf("peers %+v", sum.Peers)
+	}
+	want := []Gap{
+		{Reason: GapStalled, Start: "2023-11-14T22:14:05Z", End: "2023-11-14T22:15:00Z", DurationSeconds: 55, Block: 10},
+		{Reason: GapDown, Start: "2023-11-14T22:15:20Z", End: "2023-11-14T22:15:40Z", DurationSeconds: 20, Block: 14},
+		{Reason: GapNoData, Start: "2023-11-14T22:15:50Z", End: "2023-11-14T22:16:40Z", DurationSeconds: 50},
+	}
+	if len(sum.Gaps) != len(want) {
+		t.Fatalf("gaps %+v", sum.Gaps)
+	}
+	for i := range want {
+		if sum.Gaps[i] != want[i] {
+			t.Errorf("gap %d: %+v, want %+v", i, sum.Gaps[i], want[i])
+		}
+	}
+}
+
+func TestSummarizeOngoingStall(t *testing.T) {
+	var samples []Sample
+	for sec := int64(0); sec <= 60; sec += 5 {
+		samples = append(samples, Sample{Time: sec * 1000, Up: true, Block: 7})
+	}
+	sum := Summarize("n", samples, 30*time.Second)
+	if len(sum.Gaps) != 1 || sum.Gaps[0].End != "" || sum.Gaps[0].DurationSeconds != 60 || sum.Blocks != 0 {
+		t.Fatalf("summary %+v", sum)
+	}
+}
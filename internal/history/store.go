@@ -0,0 +1,181 @@
+// Package history keeps a rolling SQLite record of the node statuses that
+// qikchaind run collects, and summarizes it per node.
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/monitor"
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS cycles (
+	time            INTEGER PRIMARY KEY,
+	healthy         INTEGER NOT NULL,
+	nodes_up        INTEGER NOT NULL,
+	nodes_total     INTEGER NOT NULL,
+	head_divergence INTEGER NOT NULL,
+	reasons         TEXT NOT NULL DEFAULT ''
+);
+CREATE TABLE IF NOT EXISTS samples (
+	time       INTEGER NOT NULL,
+	node       TEXT NOT NULL,
+	up         INTEGER NOT NULL,
+	block      INTEGER,
+	block_time INTEGER,
+	peers      INTEGER,
+	chain_id   INTEGER,
+	stalled    INTEGER NOT NULL DEFAULT 0,
+	error      TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (node, time)
+);
+CREATE INDEX IF NOT EXISTS samples_time ON samples (time);
+`
+
+// Sample is one poll of one node. Time is in Unix milliseconds.
+type Sample struct {
+	Time      int64  `json:"time"`
+	Node      string `json:"node"`
+	Up        bool   `json:"up"`
+	Block     uint64 `json:"block,omitempty"`
+	BlockTime uint64 `json:"blockTime,omitempty"`
+	Peers     uint64 `json:"peers,omitempty"`
+	ChainID   uint64 `json:"chainId,omitempty"`
+	Stalled   bool   `json:"stalled,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Store is the SQLite history. It is safe for one writer and concurrent
+// readers.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens or creates the history at path.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open history %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// OpenReadOnly opens an existing history for queries.
+func OpenReadOnly(path string) (*Store, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("open history: %w", err)
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open history %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record stores one poll cycle taken at t.
+func (s *Store) Record(t time.Time, c monitor.Cluster) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	ms := t.UnixMilli()
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO cycles (time, healthy, nodes_up, nodes_total, head_divergence, reasons) VALUES (?, ?, ?, ?, ?, ?)`,
+		ms, c.Healthy, c.NodesUp, c.NodesTotal, c.HeadDivergence, strings.Join(c.Reasons, "; ")); err != nil {
+		return fmt.Errorf("record cycle: %w", err)
+	}
+	for _, st := range c.Nodes {
+		var block, blockTime, peers, chainID any
+		if st.Up {
+			block, blockTime, peers, chainID = st.BlockNumber, st.BlockTime, st.PeerCount, st.ChainID
+		}
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO samples (time, node, up, block, block_time, peers, chain_id, stalled, error) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			ms, st.Name, st.Up, block, blockTime, peers, chainID, st.Stalled, st.Error); err != nil {
+			return fmt.Errorf("record %s: %w", st.Name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Prune deletes everything recorded before t.
+func (s *Store) Prune(t time.Time) error {
+	ms := t.UnixMilli()
+	if _, err := s.db.Exec(`DELETE FROM samples WHERE time < ?`, ms); err != nil {
+		return fmt.Errorf("prune: %w", err)
+	}
+	if _, err := s.db.Exec(`DELETE FROM cycles WHERE time < ?`, ms); err != nil {
+		return fmt.Errorf("prune: %w", err)
+	}
+	return nil
+}
+
+// Nodes lists the nodes with samples in [since, until).
+func (s *Store) Nodes(since, until time.Time) ([]string, error) {
+	rows, err := s.db.Query(`SELECT DISTINCT node FROM samples WHERE time >= ? AND time < ? ORDER BY node`, since.UnixMilli(), until.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []string
+	for rows.Next() {
+		var n string
+		if err := rows.Scan(&n); err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, rows.Err()
+}
+
+// Samples returns the samples of node in [since, until), oldest first.
+func (s *Store) Samples(node string, since, until time.Time) ([]Sample, error) {
+	rows, err := s.db.Query(`SELECT time, node, up, block, block_time, peers, chain_id, stalled, error FROM samples
+		WHERE node = ? AND time >= ? AND time < ? ORDER BY time`, node, since.UnixMilli(), until.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Sample
+	for rows.Next() {
+		var (
+			sm                             Sample
+			block, blockTime, peers, chain sql.NullInt64
+		)
+		if err := rows.Scan(&sm.Time, &sm.Node, &sm.Up, &block, &blockTime, &peers, &chain, &sm.Stalled, &sm.Error); err != nil {
+			return nil, err
+		}
+		sm.Block, sm.BlockTime, sm.Peers, sm.ChainID = uint64(block.Int64), uint64(blockTime.Int64), uint64(peers.Int64), uint64(chain.Int64)
+		out = append(out, sm)
+	}
+	return out, rows.Err()
+}
+
+// Range returns the time of the oldest and newest recorded cycle; ok is
+// false when nothing is recorded.
+func (s *Store) Range() (oldest, newest time.Time, ok bool, err error) {
+	var lo, hi sql.NullInt64
+	if err := s.db.QueryRow(`SELECT MIN(time), MAX(time) FROM cycles`).Scan(&lo, &hi); err != nil {
+		return time.Time{}, time.Time{}, false, err
+	}
+	if !lo.Valid {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	return time.UnixMilli(lo.Int64), time.UnixMilli(hi.Int64), true, nil
+}
@@ -0,0 +1,195 @@
+package history
+
+import (
+	"sort"
+	"time"
+)
+
+// Gap reasons.
+const (
+	GapDown    = "down"
+	GapStalled = "stalled"
+	GapNoData  = "no-data"
+)
+
+// Gap is a period in which a node was down, its head did not advance, or
+// nothing was recorded. End is empty while the gap is ongoing.
+type Gap struct {
+	Reason          string  `json:"reason"`
+	Start           string  `json:"start"`
+	End             string  `json:"end,omitempty"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Block           uint64  `json:"block,omitempty"`
+}
+
+// PeerStats summarizes the peer counts of the up samples. Trend holds the
+// average of each of up to TrendBuckets equal slices of the range, oldest
+// first; a slice without up samples is -1.
+type PeerStats struct {
+	Min   uint64    `json:"min"`
+	Max   uint64    `json:"max"`
+	Avg   float64   `json:"avg"`
+	First uint64    `json:"first"`
+	Last  uint64    `json:"last"`
+	Trend []float64 `json:"trend"`
+}
+
+// TrendBuckets is the number of slices in PeerStats.Trend.
+const TrendBuckets = 12
+
+// Summary describes one node over a range of samples.
+type Summary struct {
+	Node                string    `json:"node"`
+	From                string    `json:"from"`
+	To                  string    `json:"to"`
+	Samples             int       `json:"samples"`
+	UpSamples           int       `json:"upSamples"`
+	Uptime              float64   `json:"uptime"`
+	FirstBlock          uint64    `json:"firstBlock,omitempty"`
+	LastBlock           uint64    `json:"lastBlock,omitempty"`
+	Blocks              uint64    `json:"blocks"`
+	BlocksPerMinute     float64   `json:"blocksPerMinute"`
+	AvgBlockTimeSeconds float64   `json:"avgBlockTimeSeconds,omitempty"`
+	Peers               PeerStats `json:"peers"`
+	Gaps                []Gap     `json:"gaps"`
+}
+
+func formatMillis(ms int64) string {
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
+
+// Summarize describes samples of one node, oldest first. A head that does
+// not advance for minGap, and a run of failed polls of any length, is
+// reported as a gap, as is a pause between samples longer than minGap and
+// three typical poll intervals.
+func Summarize(node string, samples []Sample, minGap time.Duration) Summary {
+	out := Summary{Node: node, Samples: len(samples), Gaps: []Gap{}}
+	if len(samples) == 0 {
+		return out
+	}
+	out.From, out.To = formatMillis(samples[0].Time), formatMillis(samples[len(samples)-1].Time)
+
+	noData := minGap.Milliseconds()
+	if len(samples) > 2 {
+		steps := make([]int64, 0, len(samples)-1)
+		for i := 1; i < len(samples); i++ {
+			steps = append(steps, samples[i].Time-samples[i-1].Time)
+		}
+		sort.Slice(steps, func(i, j int) bool { return steps[i] < steps[j] })
+		if m := 3 * steps[len(steps)/2]; m > noData {
+			noData = m
+		}
+	}
+	gap := func(reason string, start, end int64, block uint64, ongoing bool) {
+		g := Gap{Reason: reason, Start: formatMillis(start), DurationSeconds: float64(end-start) / 1000, Block: block}
+		if !ongoing {
+			g.End = formatMillis(end)
+		}
+		out.Gaps = append(out.Gaps, g)
+	}
+
+	var (
+		first, last *Sample
+		peerSum     uint64
+		downSince   int64 = -1
+		headSince   int64 = -1 // when the current head was first seen
+		head        uint64
+		lastUpTime  int64
+	)
+	for i := range samples {
+		sm := &samples[i]
+		if i > 0 && sm.Time-samples[i-1].Time > noData {
+			// Nothing is known about the head across the pause.
+			gap(GapNoData, samples[i-1].Time, sm.Time, 0, false)
+			headSince = -1
+		}
+		if !sm.Up {
+			if downSince < 0 {
+				downSince = sm.Time
+				if headSince >= 0 && lastUpTime-headSince >= minGap.Milliseconds() {
+					gap(GapStalled, headSince, sm.Time, head, false)
+				}
+				headSince = -1
+			}
+			continue
+		}
+		if downSince >= 0 {
+			gap(GapDown, downSince, sm.Time, head, false)
+			downSince = -1
+		}
+		out.UpSamples++
+		if first == nil {
+			first = sm
+			out.Peers.Min, out.Peers.Max = sm.Peers, sm.Peers
+		}
+		last = sm
+		lastUpTime = sm.Time
+		peerSum += sm.Peers
+		if sm.Peers < out.Peers.Min {
+			out.Peers.Min = sm.Peers
+		}
+		if sm.Peers > out.Peers.Max {
+			out.Peers.Max = sm.Peers
+		}
+		switch {
+		case headSince < 0:
+			headSince, head = sm.Time, sm.Block
+		case sm.Block != head:
+			if sm.Time-headSince >= minGap.Milliseconds() && sm.Block > head {
+				gap(GapStalled, headSince, sm.Time, head, false)
+			}
+			headSince, head = sm.Time, sm.Block
+		}
+	}
+	end := samples[len(samples)-1].Time
+	if downSince >= 0 {
+		gap(GapDown, downSince, end, head, true)
+	} else if headSince >= 0 && end-headSince >= minGap.Milliseconds() {
+		gap(GapStalled, headSince, end, head, true)
+	}
+	sort.SliceStable(out.Gaps, func(i, j int) bool { return out.Gaps[i].Start < out.Gaps[j].Start })
+
+	out.Uptime = float64(out.UpSamples) / float64(out.Samples)
+	if first == nil {
+		return out
+	}
+	out.FirstBlock, out.LastBlock = first.Block, last.Block
+	out.Peers.First, out.Peers.Last = first.Peers, last.Peers
+	out.Peers.Avg = float64(peerSum) / float64(out.UpSamples)
+	if last.Block > first.Block {
+		out.Blocks = last.Block - first.Block
+		if minutes := float64(last.Time-first.Time) / 60000; minutes > 0 {
+			out.BlocksPerMinute = float64(out.Blocks) / minutes
+		}
+		if last.BlockTime > first.BlockTime {
+			out.AvgBlockTimeSeconds = float64(last.BlockTime-first.BlockTime) / float64(out.Blocks)
+		}
+	}
+	out.Peers.Trend = peerTrend(samples)
+	return out
+}
+
+func peerTrend(samples []Sample) []float64 {
+	start, end := samples[0].Time, samples[len(samples)-1].Time
+	sums := make([]float64, TrendBuckets)
+	counts := make([]int, TrendBuckets)
+	for _, sm := range samples {
+		if !sm.Up {
+			continue
+		}
+		b := 0
+		if end > start {
+			b = int((sm.Time - start) * TrendBuckets / (end - start + 1))
+		}
+		sums[b] += float64(sm.Peers)
+		counts[b]++
+	}
+	out := make([]float64, TrendBuckets)
+	for i := range out {
+		out[i] = -1
+		if counts[i] > 0 {
+			out[i] = sums[i] / float64(counts[i])
+		}
+	}
+	return out
+}
@@ -0,0 +1,248 @@
+package ibft
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	ExtraVanity = 32
+
+	// commitMsgType is the IBFT COMMIT message type appended to the block hash
+	// before committed seals are signed.
+	commitMsgType = 2
+)
+
+type ValidatorType string
+
+const (
+	ValidatorECDSA ValidatorType = "ecdsa"
+	ValidatorBLS   ValidatorType = "bls"
+)
+
+// Seals holds either the ECDSA signatures of each signer or, for BLS
+// validator sets, the signer bitmap and aggregated signature.
+type Seals struct {
+	Signatures [][]byte
+	Bitmap     *big.Int
+	Aggregated []byte
+}
+
+func (s Seals) Count() int {
+	if s.Bitmap != nil {
+		n := 0
+		for _, w := range s.Bitmap.Bits() {
+			for ; w != 0; w &= w - 1 {
+				n++
+			}
+		}
+		return n
+	}
+	return len(s.Signatures)
+}
+
+// Extra is the Polygon Edge IBFT header extraData: 32 bytes of vanity
+// followed by the RLP encoded istanbul extra.
+type Extra struct {
+	Vanity               []byte
+	ValidatorType        ValidatorType
+	Validators           []common.Address
+	BLSPublicKeys        [][]byte
+	ProposerSeal         []byte
+	CommittedSeals       Seals
+	ParentCommittedSeals *Seals
+	Round                *uint64
+}
+
+func DecodeExtra(extra []byte) (*Extra, error) {
+	if len(extra) < ExtraVanity {
+		return nil, fmt.Errorf("ibft extra: %d bytes, want at least %d", len(extra), ExtraVanity)
+	}
+	out := &Extra{
+		Vanity:        append([]byte(nil), extra[:ExtraVanity]...),
+		ValidatorType: ValidatorECDSA,
+	}
+
+	content, _, err := rlp.SplitList(extra[ExtraVanity:])
+	if err != nil {
+		return nil, fmt.Errorf("ibft extra: %w", err)
+	}
+
+	validators, rest, err := rlp.SplitList(content)
+	if err != nil {
+		return nil, fmt.Errorf("ibft extra validators: %w", err)
+	}
+	if err := out.decodeValidators(validators); err != nil {
+		return nil, err
+	}
+
+	out.ProposerSeal, rest, err = rlp.SplitString(rest)
+	if err != nil {
+		return nil, fmt.Errorf("ibft extra proposer seal: %w", err)
+	}
+
+	var seals []byte
+	seals, rest, err = rlp.SplitList(rest)
+	if err != nil {
+		return nil, fmt.Errorf("ibft extra committed seals: %w", err)
+	}
+	if out.CommittedSeals, err = out.decodeSeals(seals); err != nil {
+		return nil, fmt.Errorf("ibft extra committed seals: %w", err)
+	}
+
+	if len(rest) == 0 {
+		return out, nil
+	}
+	kind, val, rest, err := rlp.Split(rest)
+	if err != nil {
+		return nil, fmt.Errorf("ibft extra parent committed seals: %w", err)
+	}
+	if kind == rlp.List {
+		parent, err := out.decodeSeals(val)
+		if err != nil {
+			return nil, fmt.Errorf("ibft extra parent committed seals: %w", err)
+		}
+		out.ParentCommittedSeals = &parent
+	}
+
+	if len(rest) == 0 {
+		return out, nil
+	}
+	kind, val, _, err = rlp.Split(rest)
+	if err != nil {
+		return nil, fmt.Errorf("ibft extra round: %w", err)
+	}
+	// Round 0 is RLP encoded as an empty string.
+	if kind != rlp.List {
+		if len(val) > 8 {
+			return nil, errors.New("ibft extra round: value overflows uint64")
+		}
+		round := new(big.Int).SetBytes(val).Uint64()
+		out.Round = &round
+	}
+	return out, nil
+}
+
+func (e *Extra) decodeValidators(b []byte) error {
+	for len(b) > 0 {
+		kind, val, rest, err := rlp.Split(b)
+		if err != nil {
+			return fmt.Errorf("ibft extra validators: %w", err)
+		}
+		b = rest
+		if kind == rlp.List {
+			e.ValidatorType = ValidatorBLS
+			addr, tail, err := rlp.SplitString(val)
+			if err != nil {
+				return fmt.Errorf("ibft extra bls validator: %w", err)
+			}
+			pub, _, err := rlp.SplitString(tail)
+			if err != nil {
+				return fmt.Errorf("ibft extra bls validator: %w", err)
+			}
+			val = addr
+			e.BLSPublicKeys = append(e.BLSPublicKeys, pub)
+		}
+		if len(val) != common.AddressLength {
+			return fmt.Errorf("ibft extra validators: address has %d bytes", len(val))
+		}
+		e.Validators = append(e.Validators, common.BytesToAddress(val))
+	}
+	return nil
+}
+
+func (e *Extra) decodeSeals(b []byte) (Seals, error) {
+	if e.ValidatorType == ValidatorBLS {
+		if len(b) == 0 {
+			return Seals{Bitmap: new(big.Int)}, nil
+		}
+		bitmap, rest, err := rlp.SplitString(b)
+		if err != nil {
+			return Seals{}, err
+		}
+		sig, _, err := rlp.SplitString(rest)
+		if err != nil {
+			return Seals{}, err
+		}
+		return Seals{Bitmap: new(big.Int).SetBytes(bitmap), Aggregated: sig}, nil
+	}
+
+	var out Seals
+	for len(b) > 0 {
+		sig, rest, err := rlp.SplitString(b)
+		if err != nil {
+			return Seals{}, err
+		}
+		out.Signatures = append(out.Signatures, sig)
+		b = rest
+	}
+	return out, nil
+}
+
+// Proposer recovers the block proposer from the proposer seal. blockHash is
+// the IBFT header hash, which Polygon Edge reports as the block hash.
+func (e *Extra) Proposer(blockHash common.Hash) (common.Address, error) {
+	if len(e.ProposerSeal) == 0 {
+		return common.Address{}, errors.New("ibft extra: empty proposer seal")
+	}
+	return ecrecover(crypto.Keccak256(blockHash.Bytes()), e.ProposerSeal)
+}
+
+// CommittedSigners returns the validators whose committed seals are present.
+func (e *Extra) CommittedSigners(blockHash common.Hash) ([]common.Address, error) {
+	return e.signers(e.CommittedSeals, blockHash)
+}
+
+// ParentCommittedSigners returns the signers of the parent block carried in
+// this header. parentHash must be the parent block hash.
+func (e *Extra) ParentCommittedSigners(parentHash common.Hash, parentValidators []common.Address) ([]common.Address, error) {
+	if e.ParentCommittedSeals == nil {
+		return nil, nil
+	}
+	if e.ValidatorType == ValidatorBLS {
+		return bitmapSigners(e.ParentCommittedSeals.Bitmap, parentValidators), nil
+	}
+	return e.signers(*e.ParentCommittedSeals, parentHash)
+}
+
+func (e *Extra) signers(seals Seals, hash common.Hash) ([]common.Address, error) {
+	if seals.Bitmap != nil {
+		return bitmapSigners(seals.Bitmap, e.Validators), nil
+	}
+	commit := crypto.Keccak256(crypto.Keccak256(hash.Bytes(), []byte{commitMsgType}))
+	out := make([]common.Address, 0, len(seals.Signatures))
+	for i, sig := range seals.Signatures {
+		addr, err := ecrecover(commit, sig)
+		if err != nil {
+			return nil, fmt.Errorf("committed seal %d: %w", i, err)
+		}
+		out = append(out, addr)
+	}
+	return out, nil
+}
+
+func bitmapSigners(bitmap *big.Int, validators []common.Address) []common.Address {
+	var out []common.Address
+	for i, v := range validators {
+		if bitmap.Bit(i) == 1 {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func ecrecover(digest, sig []byte) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("signature has %d bytes, want %d", len(sig), crypto.SignatureLength)
+	}
+	pub, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
@@ -0,0 +1,146 @@
+package ibft
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func newKeys(t *testing.T, n int) ([]*ecdsa.PrivateKey, []common.Address) {
+	t.Helper()
+	keys := make([]*ecdsa.PrivateKey, n)
+	addrs := make([]common.Address, n)
+	for i := range keys {
+		k, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = k
+		addrs[i] = crypto.PubkeyToAddress(k.PublicKey)
+	}
+	return keys, addrs
+}
+
+func encodeExtra(t *testing.T, fields []any) []byte {
+	t.Helper()
+	body, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(bytes.Repeat([]byte{0}, ExtraVanity), body...)
+}
+
+func TestDecodeExtraECDSA(t *testing.T) {
+	keys, addrs := newKeys(t, 4)
+	hash := common.HexToHash("0x1234")
+
+	proposerSeal, err := crypto.Sign(crypto.Keccak256(hash.Bytes()), keys[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := crypto.Keccak256(crypto.Keccak256(hash.Bytes(), []byte{commitMsgType}))
+	var seals [][]byte
+	for _, k := range keys[:3] {
+		sig, err := crypto.Sign(commit, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seals = append(seals, sig)
+	}
+
+	raw := encodeExtra(t, []any{addrs, proposerSeal, seals, []byte{}, uint64(2)})
+	extra, err := DecodeExtra(raw)
+	if err != nil {
+		t.Fatalf("DecodeExtra: %v", err)
+	}
+	if extra.ValidatorType != ValidatorECDSA || len(extra.Validators) != 4 || extra.Validators[3] != addrs[3] {
+		t.Fatalf("unexpected validators: %+v", extra.Validators)
+	}
+	if extra.Round == nil || *extra.Round != 2 {
+		t.Fatalf("unexpected round: %v", extra.Round)
+	}
+	if extra.ParentCommittedSeals != nil {
+		t.Fatal("expected no parent committed seals")
+	}
+
+	proposer, err := extra.Proposer(hash)
+	if err != nil {
+		t.Fatalf("Proposer: %v", err)
+	}
+	if proposer != addrs[1] {
+		t.Fatalf("proposer %s, want %s", proposer, addrs[1])
+	}
+
+	signers, err := extra.CommittedSigners(hash)
+	if err != nil {
+		t.Fatalf("CommittedSigners: %v", err)
+	}
+	if len(signers) != 3 || signers[0] != addrs[0] || signers[2] != addrs[2] {
+		t.Fatalf("unexpected signers: %v", signers)
+	}
+}
+
+func TestDecodeExtraRoundZero(t *testing.T) {
+	_, addrs := newKeys(t, 1)
+	extra, err := DecodeExtra(encodeExtra(t, []any{addrs, []byte{}, [][]byte{}, []byte{}, uint64(0)}))
+	if err != nil {
+		t.Fatalf("DecodeExtra: %v", err)
+	}
+	if extra.Round == nil || *extra.Round != 0 {
+		t.Fatalf("unexpected round: %v", extra.Round)
+	}
+}
+
+func TestDecodeExtraLegacyLayout(t *testing.T) {
+	_, addrs := newKeys(t, 1)
+	raw := encodeExtra(t, []any{addrs, []byte{}, [][]byte{}})
+	extra, err := DecodeExtra(raw)
+	if err != nil {
+		t.Fatalf("DecodeExtra: %v", err)
+	}
+	if extra.Round != nil || extra.CommittedSeals.Count() != 0 {
+		t.Fatalf("unexpected extra: %+v", extra)
+	}
+	if _, err := extra.Proposer(common.Hash{}); err == nil {
+		t.Fatal("expected error for empty proposer seal")
+	}
+}
+
+func TestDecodeExtraBLS(t *testing.T) {
+	_, addrs := newKeys(t, 3)
+	validators := make([][]any, len(addrs))
+	for i, a := range addrs {
+		validators[i] = []any{a, bytes.Repeat([]byte{byte(i + 1)}, 48)}
+	}
+	bitmap := big.NewInt(0b101)
+	raw := encodeExtra(t, []any{validators, []byte{1}, []any{bitmap, []byte{9, 9}}})
+
+	extra, err := DecodeExtra(raw)
+	if err != nil {
+		t.Fatalf("DecodeExtra: %v", err)
+	}
+	if extra.ValidatorType != ValidatorBLS || len(extra.BLSPublicKeys) != 3 {
+		t.Fatalf("unexpected extra: %+v", extra)
+	}
+	if extra.CommittedSeals.Count() != 2 {
+		t.Fatalf("seal count %d", extra.CommittedSeals.Count())
+	}
+	signers, err := extra.CommittedSigners(common.Hash{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != 2 || signers[0] != addrs[0] || signers[1] != addrs[2] {
+		t.Fatalf("unexpected signers: %v", signers)
+	}
+}
+
+func TestDecodeExtraShort(t *testing.T) {
+	if _, err := DecodeExtra(make([]byte, 10)); err == nil {
+		t.Fatal("expected error")
+	}
+}
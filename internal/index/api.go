@@ -0,0 +1,109 @@
+package index
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Handler serves the index as JSON:
+//
+//	GET /v1/status
+//	GET /v1/operators
+//	GET /v1/operators/<address>     the operator and the stakes on it
+//	GET /v1/stakers/<address>       the staker's positions
+//	GET /v1/history/<address>       events naming the address, newest first;
+//	                                ?limit=<n> (default 100), ?before=<block>
+func Handler(s *Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/status", func(w http.ResponseWriter, r *http.Request) {
+		st, err := s.Status()
+		reply(w, st, err)
+	})
+	mux.HandleFunc("/v1/operators", func(w http.ResponseWriter, r *http.Request) {
+		ops, err := s.Operators(nil)
+		reply(w, ops, err)
+	})
+	mux.HandleFunc("/v1/operators/", func(w http.ResponseWriter, r *http.Request) {
+		addr, ok := pathAddress(w, r, "/v1/operators/")
+		if !ok {
+			return
+		}
+		ops, err := s.Operators(&addr)
+		if err != nil {
+			reply(w, nil, err)
+			return
+		}
+		if len(ops) == 0 {
+			replyError(w, http.StatusNotFound, "operator "+addr.Hex()+" not indexed")
+			return
+		}
+		stakes, err := s.Positions(addr, true)
+		reply(w, map[string]any{"operator": ops, "stakes": stakes}, err)
+	})
+	mux.HandleFunc("/v1/stakers/", func(w http.ResponseWriter, r *http.Request) {
+		addr, ok := pathAddress(w, r, "/v1/stakers/")
+		if !ok {
+			return
+		}
+		positions, err := s.Positions(addr, false)
+		reply(w, positions, err)
+	})
+	mux.HandleFunc("/v1/history/", func(w http.ResponseWriter, r *http.Request) {
+		addr, ok := pathAddress(w, r, "/v1/history/")
+		if !ok {
+			return
+		}
+		limit, before := 100, uint64(0)
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				replyError(w, http.StatusBadRequest, "invalid limit "+strconv.Quote(v))
+				return
+			}
+			limit = n
+		}
+		if v := r.URL.Query().Get("before"); v != "" {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				replyError(w, http.StatusBadRequest, "invalid before "+strconv.Quote(v))
+				return
+			}
+			before = n
+		}
+		events, err := s.History(addr, before, limit)
+		reply(w, events, err)
+	})
+	return mux
+}
+
+func pathAddress(w http.ResponseWriter, r *http.Request, prefix string) (common.Address, bool) {
+	if r.Method != http.MethodGet {
+		replyError(w, http.StatusMethodNotAllowed, "use GET")
+		return common.Address{}, false
+	}
+	v := strings.TrimPrefix(r.URL.Path, prefix)
+	if !common.IsHexAddress(v) {
+		replyError(w, http.StatusBadRequest, "invalid address "+strconv.Quote(v))
+		return common.Address{}, false
+	}
+	return common.HexToAddress(v), true
+}
+
+func reply(w http.ResponseWriter, v any, err error) {
+	if err != nil {
+		replyError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func replyError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,166 @@
+// Package index keeps a local SQLite index of staking events. It backfills and
+// follows the logs of IQikStaking and StakeManager, rolls back on reorgs and
+// maintains per-operator and per-staker tables derived from the events.
+package index
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/BioMark3r/qikchain/internal/contracts"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Event sources.
+const (
+	SourceStaking      = "staking"
+	SourceStakeManager = "stakeManager"
+)
+
+// Event is one decoded staking log. Args holds every event argument as a
+// string or bool: amounts in wei, addresses and bytes in hex.
+type Event struct {
+	Block     uint64          `json:"block"`
+	BlockHash common.Hash     `json:"blockHash"`
+	Time      uint64          `json:"time"`
+	Tx        common.Hash     `json:"tx"`
+	LogIndex  uint64          `json:"logIndex"`
+	Contract  common.Address  `json:"contract"`
+	Source    string          `json:"source"`
+	Name      string          `json:"event"`
+	Operator  *common.Address `json:"operator,omitempty"`
+	Staker    *common.Address `json:"staker,omitempty"`
+	Amount    string          `json:"amount,omitempty"`
+	Args      map[string]any  `json:"args"`
+}
+
+type source struct {
+	name string
+	abi  *abi.ABI
+}
+
+// Decoder turns logs of the indexed contracts into Events.
+type Decoder struct {
+	contracts map[common.Address]source
+}
+
+// NewDecoder decodes logs of staking and, unless it is the zero address,
+// stakeManager.
+func NewDecoder(staking, stakeManager common.Address) (*Decoder, error) {
+	d := &Decoder{contracts: map[common.Address]source{}}
+	parsed, err := contracts.IQikStakingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	d.contracts[staking] = source{SourceStaking, parsed}
+	if stakeManager != (common.Address{}) {
+		if parsed, err = contracts.StakeManagerMetaData.GetAbi(); err != nil {
+			return nil, err
+		}
+		d.contracts[stakeManager] = source{SourceStakeManager, parsed}
+	}
+	return d, nil
+}
+
+// Contracts returns the indexed contracts by source.
+func (d *Decoder) Contracts() map[string]common.Address {
+	out := map[string]common.Address{}
+	for addr, src := range d.contracts {
+		out[src.name] = addr
+	}
+	return out
+}
+
+// Addresses returns the indexed contract addresses in a stable order.
+func (d *Decoder) Addresses() []common.Address {
+	out := make([]common.Address, 0, len(d.contracts))
+	for addr := range d.contracts {
+		out = append(out, addr)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Hex() < out[j].Hex() })
+	return out
+}
+
+// Decode reports false for logs that are not staking events, such as
+// OwnershipTransferred.
+func (d *Decoder) Decode(l rpc.Log) (Event, bool, error) {
+	src, ok := d.contracts[l.Address]
+	if !ok || len(l.Topics) == 0 {
+		return Event{}, false, nil
+	}
+	ev, err := src.abi.EventByID(l.Topics[0])
+	if err != nil {
+		return Event{}, false, nil
+	}
+	values := map[string]any{}
+	if len(l.Data) > 0 {
+		if err := ev.Inputs.NonIndexed().UnpackIntoMap(values, l.Data); err != nil {
+			return Event{}, false, fmt.Errorf("decode %s log %d in block %d: %w", ev.Name, l.LogIndex, l.BlockNumber, err)
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range ev.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexed, l.Topics[1:]); err != nil {
+		return Event{}, false, fmt.Errorf("decode %s log %d in block %d: %w", ev.Name, l.LogIndex, l.BlockNumber, err)
+	}
+
+	e := Event{
+		Block:     uint64(l.BlockNumber),
+		BlockHash: l.BlockHash,
+		Tx:        l.TransactionHash,
+		LogIndex:  uint64(l.LogIndex),
+		Contract:  l.Address,
+		Source:    src.name,
+		Name:      ev.Name,
+		Args:      map[string]any{},
+	}
+	for k, v := range values {
+		e.Args[k] = normalize(v)
+	}
+	e.fill()
+	return e, true, nil
+}
+
+// fill sets Operator, Staker and Amount from Args. StakeManager operators
+// stake for themselves.
+func (e *Event) fill() {
+	if s, ok := e.Args["operator"].(string); ok && common.IsHexAddress(s) {
+		op := common.HexToAddress(s)
+		e.Operator = &op
+	}
+	if s, ok := e.Args["staker"].(string); ok && common.IsHexAddress(s) {
+		staker := common.HexToAddress(s)
+		e.Staker = &staker
+	}
+	if s, ok := e.Args["amount"].(string); ok {
+		e.Amount = s
+		if e.Staker == nil && e.Source == SourceStakeManager {
+			e.Staker = e.Operator
+		}
+	}
+}
+
+func normalize(v any) any {
+	switch v := v.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	case bool, string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,279 @@
+package index
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BioMark3r/qikchain/internal/contracts"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	stakingAddr = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	managerAddr = common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+	op1         = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	staker1     = common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906")
+)
+
+// fakeChain serves logs per block. Bumping fork[n] changes the hash of every
+// block from n on, as a reorg would.
+type fakeChain struct {
+	head uint64
+	fork map[uint64]byte
+	logs map[uint64][]rpc.Log
+}
+
+func (c *fakeChain) hash(n uint64) common.Hash {
+	var variant byte
+	for from, v := range c.fork {
+		if n >= from && v > variant {
+			variant = v
+		}
+	}
+	return common.Hash{byte(n >> 8), byte(n), variant}
+}
+
+func (c *fakeChain) Head() (uint64, error) { return c.head, nil }
+
+func (c *fakeChain) Header(n uint64) (common.Hash, uint64, error) {
+	return c.hash(n), 1700000000 + n, nil
+}
+
+func (c *fakeChain) Logs(from, to uint64, _ []common.Address) ([]rpc.Log, error) {
+	var out []rpc.Log
+	for n := from; n <= to; n++ {
+		for _, l := range c.logs[n] {
+			l.BlockHash = c.hash(n)
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (c *fakeChain) emit(t *testing.T, parsed *abi.ABI, address common.Address, block uint64, name string, args ...any) {
+	t.Helper()
+	ev := parsed.Events[name]
+	var indexed [][]any
+	var data []any
+	for i, arg := range ev.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, []any{args[i]})
+		} else {
+			data = append(data, args[i])
+		}
+	}
+	topics, err := abi.MakeTopics(indexed...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := rpc.Log{
+		Address:         address,
+		Topics:          []common.Hash{ev.ID},
+		BlockNumber:     hexutil.Uint64(block),
+		LogIndex:        hexutil.Uint64(len(c.logs[block])),
+		TransactionHash: common.Hash{0xaa, byte(block), byte(len(c.logs[block]))},
+	}
+	for _, topic := range topics {
+		l.Topics = append(l.Topics, topic[0])
+	}
+	if l.Data, err = ev.Inputs.NonIndexed().Pack(data...); err != nil {
+		t.Fatal(err)
+	}
+	c.logs[block] = append(c.logs[block], l)
+}
+
+func wei(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18)) }
+
+func weiString(n int64) string { return wei(n).String() }
+
+func setup(t *testing.T) (*Indexer, *fakeChain, *abi.ABI, *abi.ABI) {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "index.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	dec, err := NewDecoder(stakingAddr, managerAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Bind(100, dec.Contracts()); err != nil {
+		t.Fatal(err)
+	}
+	staking, err := contracts.IQikStakingMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager, err := contracts.StakeManagerMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := &fakeChain{head: 20, fork: map[uint64]byte{}, logs: map[uint64][]rpc.Log{}}
+	return &Indexer{Store: store, Chain: chain, Decoder: dec, Batch: 4}, chain, staking, manager
+}
+
+func sync(t *testing.T, ix *Indexer) []string {
+	t.Helper()
+	var got []string
+	err := ix.Sync(func(p Progress) {
+		got = append(got, p.Event)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestIndexer(t *testing.T) {
+	ix, chain, staking, manager := setup(t)
+	key := []byte{0x02, 0x01}
+	chain.emit(t, staking, stakingAddr, 3, "OperatorRegistered", op1, key, op1)
+	chain.emit(t, staking, stakingAddr, 5, "Staked", op1, op1, wei(100))
+	chain.emit(t, staking, stakingAddr, 5, "Staked", staker1, op1, wei(40))
+	chain.emit(t, staking, stakingAddr, 9, "UnstakeRequested", staker1, op1, wei(15), big.NewInt(1700001000))
+	chain.emit(t, staking, stakingAddr, 12, "UnstakedWithdrawn", staker1, op1, wei(15))
+	chain.emit(t, staking, stakingAddr, 14, "OperatorJailed", op1, true)
+	chain.emit(t, manager, managerAddr, 6, "Staked", op1, wei(7), wei(7))
+
+	if got := strings.Join(sync(t, ix), ","); got != "indexed,indexed,indexed,indexed,indexed,indexed" {
+		t.Fatalf("progress %s", got)
+	}
+	st, err := ix.Store.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *st.Block != 20 || st.Events != 7 || st.Operators != 2 || st.Positions != 3 || st.ChainID != 100 {
+		t.Fatalf("status %+v", st)
+	}
+
+	ops, err := ix.Store.Operators(&op1)
+	if err != nil || len(ops) != 2 {
+		t.Fatalf("operators %+v, %v", ops, err)
+	}
+	mgr, stk := ops[0], ops[1]
+	if stk.Source != SourceStaking || stk.Staked != weiString(125) || stk.Unbonding != "0" || stk.Withdrawn != weiString(15) || !stk.Jailed || stk.ConsensusKey != "0x0201" || *stk.RegisteredBlock != 3 {
+		t.Fatalf("staking operator %+v", stk)
+	}
+	if mgr.Source != SourceStakeManager || mgr.Staked != weiString(7) {
+		t.Fatalf("stake manager operator %+v", mgr)
+	}
+	pos, err := ix.Store.Positions(staker1, false)
+	if err != nil || len(pos) != 1 || pos[0].Staked != weiString(25) || pos[0].Withdrawn != weiString(15) {
+		t.Fatalf("positions %+v, %v", pos, err)
+	}
+	events, err := ix.Store.History(staker1, 0, 2)
+	if err != nil || len(events) != 2 || events[0].Name != "UnstakedWithdrawn" || events[1].Name != "UnstakeRequested" || events[1].Args["unlockTime"] != "1700001000" || events[0].Time != 1700000012 {
+		t.Fatalf("history %+v, %v", events, err)
+	}
+
+	// Nothing new: no progress, no duplicates.
+	if got := sync(t, ix); len(got) != 0 {
+		t.Fatalf("progress %v", got)
+	}
+}
+
+func TestIndexerReorg(t *testing.T) {
+	ix, chain, staking, _ := setup(t)
+	chain.emit(t, staking, stakingAddr, 5, "Staked", staker1, op1, wei(40))
+	chain.emit(t, staking, stakingAddr, 18, "Staked", staker1, op1, wei(1))
+	sync(t, ix)
+
+	// Blocks from 17 are replaced; the stake at 18 moves to 19 and doubles.
+	chain.fork[17] = 1
+	chain.logs[18] = nil
+	chain.emit(t, staking, stakingAddr, 19, "Staked", staker1, op1, wei(2))
+	chain.head = 22
+	var reorg Progress
+	if err := ix.Sync(func(p Progress) {
+		if p.Event == "reorg" {
+			reorg = p
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// 15 is the newest recorded block, a batch end, still canonical.
+	if reorg.From != 16 || reorg.To != 20 {
+		t.Fatalf("reorg %+v", reorg)
+	}
+	pos, err := ix.Store.Positions(staker1, false)
+	if err != nil || len(pos) != 1 || pos[0].Staked != weiString(42) {
+		t.Fatalf("positions %+v, %v", pos, err)
+	}
+	events, err := ix.Store.History(op1, 0, 0)
+	if err != nil || len(events) != 2 || events[0].Block != 19 {
+		t.Fatalf("history %+v, %v", events, err)
+	}
+
+	// A reorg below every recorded block reindexes from scratch.
+	chain.fork[0] = 2
+	if got := strings.Join(sync(t, ix), ","); !strings.HasPrefix(got, "reorg,indexed") {
+		t.Fatalf("progress %s", got)
+	}
+	if st, _ := ix.Store.Status(); st.Events != 2 {
+		t.Fatalf("status %+v", st)
+	}
+}
+
+func TestBindRejectsOtherContracts(t *testing.T) {
+	ix, _, _, _ := setup(t)
+	if err := ix.Store.Bind(100, map[string]common.Address{SourceStaking: op1}); err == nil {
+		t.Fatal("bound an index to other contracts")
+	}
+	if err := ix.Store.Bind(101, ix.Decoder.Contracts()); err == nil {
+		t.Fatal("bound an index to another chain")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ix, chain, staking, _ := setup(t)
+	chain.emit(t, staking, stakingAddr, 2, "OperatorRegistered", op1, []byte{1}, op1)
+	chain.emit(t, staking, stakingAddr, 5, "Staked", staker1, op1, wei(40))
+	sync(t, ix)
+	srv := httptest.NewServer(Handler(ix.Store))
+	defer srv.Close()
+
+	get := func(path string, want int, v any) {
+		t.Helper()
+		resp, err := srv.Client().Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Fatalf("GET %s: status %d, want %d", path, resp.StatusCode, want)
+		}
+		if v != nil {
+			if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	var st Status
+	get("/v1/status", 200, &st)
+	if st.Events != 2 || st.Contracts[SourceStaking] != stakingAddr {
+		t.Fatalf("status %+v", st)
+	}
+	var op struct {
+		Operator []Operator `json:"operator"`
+		Stakes   []Position `json:"stakes"`
+	}
+	get("/v1/operators/"+op1.Hex(), 200, &op)
+	if len(op.Operator) != 1 || len(op.Stakes) != 1 || op.Stakes[0].Staker != staker1 {
+		t.Fatalf("operator %+v", op)
+	}
+	var events []Event
+	get("/v1/history/"+strings.ToLower(staker1.Hex())+"?limit=1", 200, &events)
+	if len(events) != 1 || events[0].Amount != weiString(40) {
+		t.Fatalf("history %+v", events)
+	}
+	get("/v1/operators/"+staker1.Hex(), 404, nil)
+	get("/v1/stakers/nope", 400, nil)
+	get("/v1/history/"+op1.Hex()+"?limit=x", 400, nil)
+}
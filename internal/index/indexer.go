@@ -0,0 +1,181 @@
+package index
+
+import (
+	"fmt"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Chain is what the indexer reads from the node.
+type Chain interface {
+	Head() (uint64, error)
+	Header(number uint64) (hash common.Hash, time uint64, err error)
+	Logs(from, to uint64, addresses []common.Address) ([]rpc.Log, error)
+}
+
+// RPCChain implements Chain over JSON-RPC.
+type RPCChain struct{ Client *rpc.Client }
+
+func (c RPCChain) Head() (uint64, error) {
+	v, err := c.Client.CallString("eth_blockNumber")
+	if err != nil {
+		return 0, err
+	}
+	return rpc.HexToUint64(v)
+}
+
+func (c RPCChain) Header(number uint64) (common.Hash, uint64, error) {
+	b, err := c.Client.BlockByNumber(hexutil.EncodeUint64(number), false)
+	if err != nil {
+		return common.Hash{}, 0, fmt.Errorf("block %d: %w", number, err)
+	}
+	return b.Hash, uint64(b.Timestamp), nil
+}
+
+func (c RPCChain) Logs(from, to uint64, addresses []common.Address) ([]rpc.Log, error) {
+	return c.Client.Logs(rpc.FilterQuery{FromBlock: hexutil.EncodeUint64(from), ToBlock: hexutil.EncodeUint64(to), Address: addresses})
+}
+
+// Progress reports one Sync step.
+type Progress struct {
+	Event  string `json:"event"` // "indexed" or "reorg"
+	From   uint64 `json:"from"`
+	To     uint64 `json:"to"`
+	Events int    `json:"events,omitempty"`
+	Head   uint64 `json:"head"`
+}
+
+// Indexer backfills and follows the staking logs into Store. It indexes
+// blocks up to Confirmations behind the head, in ranges of at most Batch
+// blocks, starting at From.
+type Indexer struct {
+	Store         *Store
+	Chain         Chain
+	Decoder       *Decoder
+	From          uint64
+	Confirmations uint64
+	Batch         uint64
+}
+
+// Sync checks the last indexed block for a reorg, rewinding past it, then
+// indexes up to the confirmed head. report is called after every step.
+func (ix *Indexer) Sync(report func(Progress)) error {
+	head, err := ix.Chain.Head()
+	if err != nil {
+		return err
+	}
+	if head < ix.Confirmations {
+		return nil
+	}
+	target := head - ix.Confirmations
+	next, err := ix.rewindReorg(head, report)
+	if err != nil {
+		return err
+	}
+	batch := ix.Batch
+	if batch == 0 {
+		batch = 1000
+	}
+	for from := next; from <= target; {
+		to := from + batch - 1
+		if to > target {
+			to = target
+		}
+		n, err := ix.index(from, to)
+		if err != nil {
+			return err
+		}
+		report(Progress{Event: "indexed", From: from, To: to, Events: n, Head: head})
+		from = to + 1
+	}
+	return nil
+}
+
+// rewindReorg returns the next block to index. When the hash recorded for
+// the cursor is no longer canonical, it rewinds to the newest recorded block
+// that still is, or to From.
+func (ix *Indexer) rewindReorg(head uint64, report func(Progress)) (uint64, error) {
+	cursor, hash, ok, err := ix.Store.Cursor()
+	if err != nil || !ok {
+		return ix.From, err
+	}
+	if cursor < ix.From {
+		return 0, fmt.Errorf("index ends at block %d, before --from %d", cursor, ix.From)
+	}
+	canonical, _, err := ix.Chain.Header(cursor)
+	if err != nil {
+		return 0, err
+	}
+	if canonical == hash {
+		return cursor + 1, nil
+	}
+	recorded, err := ix.Store.RecordedBlocks(cursor)
+	if err != nil {
+		return 0, err
+	}
+	for _, n := range recorded {
+		want, err := ix.Store.BlockHash(n)
+		if err != nil {
+			return 0, err
+		}
+		if got, _, err := ix.Chain.Header(n); err != nil {
+			return 0, err
+		} else if got == want {
+			if err := ix.Store.Rewind(n); err != nil {
+				return 0, err
+			}
+			report(Progress{Event: "reorg", From: n + 1, To: cursor, Head: head})
+			return n + 1, nil
+		}
+	}
+	if err := ix.Store.Reset(); err != nil {
+		return 0, err
+	}
+	report(Progress{Event: "reorg", From: ix.From, To: cursor, Head: head})
+	return ix.From, nil
+}
+
+// index stores the events of from..to. It fails, leaving the store as it
+// was, when the logs and headers disagree because of a reorg in between.
+func (ix *Indexer) index(from, to uint64) (int, error) {
+	logs, err := ix.Chain.Logs(from, to, ix.Decoder.Addresses())
+	if err != nil {
+		return 0, fmt.Errorf("logs %d-%d: %w", from, to, err)
+	}
+	b := Batch{To: to, Hashes: map[uint64]common.Hash{}}
+	times := map[uint64]uint64{}
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+		e, ok, err := ix.Decoder.Decode(l)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+		if _, seen := times[e.Block]; !seen {
+			hash, t, err := ix.Chain.Header(e.Block)
+			if err != nil {
+				return 0, err
+			}
+			b.Hashes[e.Block], times[e.Block] = hash, t
+		}
+		if b.Hashes[e.Block] != e.BlockHash {
+			return 0, fmt.Errorf("block %d changed while indexing; retrying", e.Block)
+		}
+		e.Time = times[e.Block]
+		b.Events = append(b.Events, e)
+	}
+	if _, ok := b.Hashes[to]; !ok {
+		hash, _, err := ix.Chain.Header(to)
+		if err != nil {
+			return 0, err
+		}
+		b.Hashes[to] = hash
+	}
+	return len(b.Events), ix.Store.Commit(b)
+}
@@ -0,0 +1,556 @@
+package index
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS meta (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS blocks (
+	number INTEGER PRIMARY KEY,
+	hash   TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS events (
+	block      INTEGER NOT NULL,
+	block_hash TEXT NOT NULL,
+	time       INTEGER NOT NULL,
+	tx         TEXT NOT NULL,
+	log_index  INTEGER NOT NULL,
+	contract   TEXT NOT NULL,
+	source     TEXT NOT NULL,
+	event      TEXT NOT NULL,
+	operator   TEXT,
+	staker     TEXT,
+	amount     TEXT,
+	args       TEXT NOT NULL,
+	PRIMARY KEY (block, log_index)
+);
+CREATE INDEX IF NOT EXISTS events_operator ON events (operator, block);
+CREATE INDEX IF NOT EXISTS events_staker ON events (staker, block);
+CREATE TABLE IF NOT EXISTS operators (
+	source           TEXT NOT NULL,
+	operator         TEXT NOT NULL,
+	consensus_key    TEXT NOT NULL DEFAULT '',
+	payout           TEXT NOT NULL DEFAULT '',
+	jailed           INTEGER NOT NULL DEFAULT 0,
+	registered_block INTEGER,
+	staked           TEXT NOT NULL DEFAULT '0',
+	unbonding        TEXT NOT NULL DEFAULT '0',
+	withdrawn        TEXT NOT NULL DEFAULT '0',
+	last_block       INTEGER NOT NULL,
+	PRIMARY KEY (source, operator)
+);
+CREATE TABLE IF NOT EXISTS stakes (
+	source     TEXT NOT NULL,
+	staker     TEXT NOT NULL,
+	operator   TEXT NOT NULL,
+	staked     TEXT NOT NULL DEFAULT '0',
+	unbonding  TEXT NOT NULL DEFAULT '0',
+	withdrawn  TEXT NOT NULL DEFAULT '0',
+	last_block INTEGER NOT NULL,
+	PRIMARY KEY (source, staker, operator)
+);
+CREATE INDEX IF NOT EXISTS stakes_operator ON stakes (operator);
+`
+
+// Operator is a row of the per-operator table. Amounts are in wei; staked
+// is the total bonded on the operator by every staker.
+type Operator struct {
+	Source          string         `json:"source"`
+	Operator        common.Address `json:"operator"`
+	ConsensusKey    string         `json:"consensusKey,omitempty"`
+	Payout          string         `json:"payout,omitempty"`
+	Jailed          bool           `json:"jailed"`
+	RegisteredBlock *uint64        `json:"registeredBlock,omitempty"`
+	Staked          string         `json:"staked"`
+	Unbonding       string         `json:"unbonding"`
+	Withdrawn       string         `json:"withdrawn"`
+	LastBlock       uint64         `json:"lastBlock"`
+}
+
+// Position is a row of the per-staker table: what one staker has on one
+// operator.
+type Position struct {
+	Source    string         `json:"source"`
+	Staker    common.Address `json:"staker"`
+	Operator  common.Address `json:"operator"`
+	Staked    string         `json:"staked"`
+	Unbonding string         `json:"unbonding"`
+	Withdrawn string         `json:"withdrawn"`
+	LastBlock uint64         `json:"lastBlock"`
+}
+
+// Status summarizes the index.
+type Status struct {
+	ChainID   uint64                    `json:"chainId"`
+	Contracts map[string]common.Address `json:"contracts"`
+	Block     *uint64                   `json:"block"`
+	BlockHash string                    `json:"blockHash,omitempty"`
+	Events    int                       `json:"events"`
+	Operators int                       `json:"operators"`
+	Positions int                       `json:"positions"`
+}
+
+// Store is the SQLite index. It is safe for one writer and concurrent
+// readers.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens or creates the index at path.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open index %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// OpenReadOnly opens an existing index for queries.
+func OpenReadOnly(path string) (*Store, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("open index: %w", err)
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open index %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error { return s.db.Close() }
+
+func (s *Store) meta(q querier, key string) (string, bool, error) {
+	var v string
+	err := q.QueryRow(`SELECT value FROM meta WHERE key = ?`, key).Scan(&v)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	return v, err == nil, err
+}
+
+func setMeta(q querier, key, value string) error {
+	_, err := q.Exec(`INSERT INTO meta (key, value) VALUES (?, ?) ON CONFLICT (key) DO UPDATE SET value = excluded.value`, key, value)
+	return err
+}
+
+// Bind records the chain and contracts of a new index and rejects an index
+// built for others.
+func (s *Store) Bind(chainID uint64, contracts map[string]common.Address) error {
+	want, err := json.Marshal(contracts)
+	if err != nil {
+		return err
+	}
+	have, ok, err := s.meta(s.db, "contracts")
+	if err != nil {
+		return err
+	}
+	if ok {
+		if have != string(want) {
+			return fmt.Errorf("index was built for contracts %s, not %s; use another database", have, want)
+		}
+		id, _, err := s.meta(s.db, "chainId")
+		if err != nil {
+			return err
+		}
+		if id != fmt.Sprint(chainID) {
+			return fmt.Errorf("index was built for chain %s, not chain %d; use another database", id, chainID)
+		}
+		return nil
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := setMeta(tx, "contracts", string(want)); err != nil {
+		return err
+	}
+	if err := setMeta(tx, "chainId", fmt.Sprint(chainID)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Cursor returns the last indexed block and its hash; ok is false before the
+// first batch.
+func (s *Store) Cursor() (block uint64, hash common.Hash, ok bool, err error) {
+	v, ok, err := s.meta(s.db, "cursor")
+	if err != nil || !ok {
+		return 0, common.Hash{}, false, err
+	}
+	if _, err := fmt.Sscan(v, &block); err != nil {
+		return 0, common.Hash{}, false, fmt.Errorf("index cursor %q: %w", v, err)
+	}
+	hash, err = s.BlockHash(block)
+	return block, hash, true, err
+}
+
+// BlockHash returns the recorded hash of block, or the zero hash.
+func (s *Store) BlockHash(block uint64) (common.Hash, error) {
+	var h string
+	err := s.db.QueryRow(`SELECT hash FROM blocks WHERE number = ?`, block).Scan(&h)
+	if errors.Is(err, sql.ErrNoRows) {
+		return common.Hash{}, nil
+	}
+	return common.HexToHash(h), err
+}
+
+// RecordedBlocks returns the recorded block numbers below block, newest first.
+func (s *Store) RecordedBlocks(below uint64) ([]uint64, error) {
+	rows, err := s.db.Query(`SELECT number FROM blocks WHERE number < ? ORDER BY number DESC`, below)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []uint64
+	for rows.Next() {
+		var n uint64
+		if err := rows.Scan(&n); err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, rows.Err()
+}
+
+// Batch is the result of indexing blocks up to To.
+type Batch struct {
+	To     uint64
+	Hashes map[uint64]common.Hash // hashes of To and of every block with events
+	Events []Event
+}
+
+// Commit stores a batch, applies its events to the derived tables and moves
+// the cursor to b.To, atomically.
+func (s *Store) Commit(b Batch) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for n, h := range b.Hashes {
+		if _, err := tx.Exec(`INSERT INTO blocks (number, hash) VALUES (?, ?) ON CONFLICT (number) DO UPDATE SET hash = excluded.hash`, n, h.Hex()); err != nil {
+			return err
+		}
+	}
+	for _, e := range b.Events {
+		args, err := json.Marshal(e.Args)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO events (block, block_hash, time, tx, log_index, contract, source, event, operator, staker, amount, args) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			e.Block, e.BlockHash.Hex(), e.Time, e.Tx.Hex(), e.LogIndex, e.Contract.Hex(), e.Source, e.Name, addrText(e.Operator), addrText(e.Staker), nullText(e.Amount), string(args)); err != nil {
+			return fmt.Errorf("store %s at %d/%d: %w", e.Name, e.Block, e.LogIndex, err)
+		}
+		if err := apply(tx, e); err != nil {
+			return err
+		}
+	}
+	if err := setMeta(tx, "cursor", fmt.Sprint(b.To)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Rewind drops everything after block, moves the cursor to it and rebuilds
+// the derived tables from the remaining events.
+func (s *Store) Rewind(block uint64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, q := range []string{`DELETE FROM events WHERE block > ?`, `DELETE FROM blocks WHERE number > ?`} {
+		if _, err := tx.Exec(q, block); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM operators; DELETE FROM stakes`); err != nil {
+		return err
+	}
+	events, err := queryEvents(tx, `ORDER BY block, log_index`)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if err := apply(tx, e); err != nil {
+			return err
+		}
+	}
+	if err := setMeta(tx, "cursor", fmt.Sprint(block)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Reset empties the index, for a rewind past its first block.
+func (s *Store) Reset() error {
+	_, err := s.db.Exec(`DELETE FROM events; DELETE FROM blocks; DELETE FROM operators; DELETE FROM stakes; DELETE FROM meta WHERE key = 'cursor'`)
+	return err
+}
+
+// apply updates the derived tables for one event.
+func apply(tx *sql.Tx, e Event) error {
+	if e.Operator == nil {
+		return nil
+	}
+	op := e.Operator.Hex()
+	if _, err := tx.Exec(`INSERT INTO operators (source, operator, last_block) VALUES (?, ?, ?) ON CONFLICT (source, operator) DO UPDATE SET last_block = excluded.last_block`, e.Source, op, e.Block); err != nil {
+		return err
+	}
+	str := func(k string) string { s, _ := e.Args[k].(string); return s }
+	var err error
+	switch e.Name {
+	case "OperatorRegistered":
+		_, err = tx.Exec(`UPDATE operators SET consensus_key = ?, payout = ?, registered_block = ? WHERE source = ? AND operator = ?`, str("consensusKey"), str("payout"), e.Block, e.Source, op)
+	case "ConsensusKeyUpdated":
+		_, err = tx.Exec(`UPDATE operators SET consensus_key = ? WHERE source = ? AND operator = ?`, str("newKey"), e.Source, op)
+	case "PayoutUpdated":
+		_, err = tx.Exec(`UPDATE operators SET payout = ? WHERE source = ? AND operator = ?`, str("payout"), e.Source, op)
+	case "OperatorJailed":
+		jailed, _ := e.Args["jailed"].(bool)
+		_, err = tx.Exec(`UPDATE operators SET jailed = ? WHERE source = ? AND operator = ?`, jailed, e.Source, op)
+	case "Staked":
+		err = move(tx, e, "", "staked")
+	case "UnstakeRequested", "UnstakeStarted":
+		err = move(tx, e, "staked", "unbonding")
+	case "UnstakedWithdrawn", "Withdrawn":
+		err = move(tx, e, "unbonding", "withdrawn")
+	}
+	return err
+}
+
+// move shifts the event amount from one balance column to another, on both
+// the operator and the staker's position.
+func move(tx *sql.Tx, e Event, from, to string) error {
+	amount, ok := new(big.Int).SetString(e.Amount, 10)
+	if !ok || e.Staker == nil {
+		return fmt.Errorf("%s at %d/%d: missing staker or amount", e.Name, e.Block, e.LogIndex)
+	}
+	op, staker := e.Operator.Hex(), e.Staker.Hex()
+	if _, err := tx.Exec(`INSERT INTO stakes (source, staker, operator, last_block) VALUES (?, ?, ?, ?) ON CONFLICT (source, staker, operator) DO UPDATE SET last_block = excluded.last_block`, e.Source, staker, op, e.Block); err != nil {
+		return err
+	}
+	for _, t := range []struct {
+		table string
+		where string
+		args  []any
+	}{
+		{"operators", "source = ? AND operator = ?", []any{e.Source, op}},
+		{"stakes", "source = ? AND staker = ? AND operator = ?", []any{e.Source, staker, op}},
+	} {
+		cols := []string{to}
+		if from != "" {
+			cols = append(cols, from)
+		}
+		values := make([]string, len(cols))
+		dest := make([]any, len(cols))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := tx.QueryRow(`SELECT `+strings.Join(cols, ", ")+` FROM `+t.table+` WHERE `+t.where, t.args...).Scan(dest...); err != nil {
+			return err
+		}
+		set := make([]string, len(cols))
+		update := make([]any, len(cols))
+		for i, col := range cols {
+			v, _ := new(big.Int).SetString(values[i], 10)
+			if v == nil {
+				v = new(big.Int)
+			}
+			if i == 0 {
+				v.Add(v, amount)
+			} else {
+				v.Sub(v, amount)
+			}
+			set[i], update[i] = col+" = ?", v.String()
+		}
+		if _, err := tx.Exec(`UPDATE `+t.table+` SET `+strings.Join(set, ", ")+` WHERE `+t.where, append(update, t.args...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type querier interface {
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+const eventColumns = `block, block_hash, time, tx, log_index, contract, source, event, operator, staker, amount, args`
+
+func queryEvents(q querier, clause string, args ...any) ([]Event, error) {
+	rows, err := q.Query(`SELECT `+eventColumns+` FROM events `+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []Event{}
+	for rows.Next() {
+		var (
+			e                                     Event
+			blockHash, txHash, contract, argsJSON string
+			operator, staker, amount              sql.NullString
+		)
+		if err := rows.Scan(&e.Block, &blockHash, &e.Time, &txHash, &e.LogIndex, &contract, &e.Source, &e.Name, &operator, &staker, &amount, &argsJSON); err != nil {
+			return nil, err
+		}
+		e.BlockHash, e.Tx, e.Contract = common.HexToHash(blockHash), common.HexToHash(txHash), common.HexToAddress(contract)
+		if operator.Valid {
+			a := common.HexToAddress(operator.String)
+			e.Operator = &a
+		}
+		if staker.Valid {
+			a := common.HexToAddress(staker.String)
+			e.Staker = &a
+		}
+		e.Amount = amount.String
+		if err := json.Unmarshal([]byte(argsJSON), &e.Args); err != nil {
+			return nil, fmt.Errorf("event %d/%d args: %w", e.Block, e.LogIndex, err)
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// History returns the events naming addr as operator or staker, newest
+// first. before, when non-zero, only returns events in earlier blocks.
+func (s *Store) History(addr common.Address, before uint64, limit int) ([]Event, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	if before == 0 {
+		before = 1<<63 - 1
+	}
+	a := addr.Hex()
+	return queryEvents(s.db, `WHERE (operator = ? OR staker = ?) AND block < ? ORDER BY block DESC, log_index DESC LIMIT ?`, a, a, before, limit)
+}
+
+// Operators returns every operator, or just addr when it is non-nil.
+func (s *Store) Operators(addr *common.Address) ([]Operator, error) {
+	where, args := "", []any{}
+	if addr != nil {
+		where, args = `WHERE operator = ?`, append(args, addr.Hex())
+	}
+	rows, err := s.db.Query(`SELECT source, operator, consensus_key, payout, jailed, registered_block, staked, unbonding, withdrawn, last_block FROM operators `+where+` ORDER BY operator, source`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []Operator{}
+	for rows.Next() {
+		var (
+			o          Operator
+			operator   string
+			registered sql.NullInt64
+		)
+		if err := rows.Scan(&o.Source, &operator, &o.ConsensusKey, &o.Payout, &o.Jailed, &registered, &o.Staked, &o.Unbonding, &o.Withdrawn, &o.LastBlock); err != nil {
+			return nil, err
+		}
+		o.Operator = common.HexToAddress(operator)
+		if registered.Valid {
+			n := uint64(registered.Int64)
+			o.RegisteredBlock = &n
+		}
+		out = append(out, o)
+	}
+	return out, rows.Err()
+}
+
+// Positions returns the positions of staker, or on operator when byOperator
+// is set.
+func (s *Store) Positions(addr common.Address, byOperator bool) ([]Position, error) {
+	col := "staker"
+	if byOperator {
+		col = "operator"
+	}
+	rows, err := s.db.Query(`SELECT source, staker, operator, staked, unbonding, withdrawn, last_block FROM stakes WHERE `+col+` = ? ORDER BY source, staker, operator`, addr.Hex())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []Position{}
+	for rows.Next() {
+		var (
+			p                Position
+			staker, operator string
+		)
+		if err := rows.Scan(&p.Source, &staker, &operator, &p.Staked, &p.Unbonding, &p.Withdrawn, &p.LastBlock); err != nil {
+			return nil, err
+		}
+		p.Staker, p.Operator = common.HexToAddress(staker), common.HexToAddress(operator)
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (s *Store) Status() (Status, error) {
+	st := Status{Contracts: map[string]common.Address{}}
+	if v, ok, err := s.meta(s.db, "contracts"); err != nil {
+		return st, err
+	} else if ok {
+		if err := json.Unmarshal([]byte(v), &st.Contracts); err != nil {
+			return st, err
+		}
+	}
+	if v, ok, err := s.meta(s.db, "chainId"); err != nil {
+		return st, err
+	} else if ok {
+		fmt.Sscan(v, &st.ChainID)
+	}
+	block, hash, ok, err := s.Cursor()
+	if err != nil {
+		return st, err
+	}
+	if ok {
+		st.Block = &block
+		if hash != (common.Hash{}) {
+			st.BlockHash = hash.Hex()
+		}
+	}
+	for _, c := range []struct {
+		table string
+		n     *int
+	}{{"events", &st.Events}, {"operators", &st.Operators}, {"stakes", &st.Positions}} {
+		if err := s.db.QueryRow(`SELECT COUNT(*) FROM ` + c.table).Scan(c.n); err != nil {
+			return st, err
+		}
+	}
+	return st, nil
+}
+
+func addrText(a *common.Address) any {
+	if a == nil {
+		return nil
+	}
+	return a.Hex()
+}
+
+func nullText(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
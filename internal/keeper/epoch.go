@@ -0,0 +1,206 @@
+// Package keeper performs the owner duties of the PoS contracts that nothing
+// on chain triggers, such as the EpochManager active-set snapshot.
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Event levels.
+const (
+	LevelInfo  = "info"
+	LevelAlert = "alert"
+)
+
+// Event is one line of keeper output.
+type Event struct {
+	Time      time.Time        `json:"time"`
+	Level     string           `json:"level"`
+	Event     string           `json:"event"`
+	Head      uint64           `json:"head"`
+	Epoch     uint64           `json:"epoch"`
+	Boundary  uint64           `json:"boundary"`
+	Operators []common.Address `json:"operators,omitempty"`
+	SetHash   *common.Hash     `json:"activeSetHash,omitempty"`
+	TxHash    *common.Hash     `json:"txHash,omitempty"`
+	Message   string           `json:"message,omitempty"`
+}
+
+// EpochChain is what the epoch keeper reads and sends. Operators are read at
+// a block; hashes and receipts at the latest block.
+type EpochChain interface {
+	ActiveOperators(block uint64) ([]common.Address, error)
+	ActiveSetHash(epoch uint64) (common.Hash, error)
+	SubmitSnapshot(epoch uint64, operators []common.Address) (common.Hash, error)
+	// SnapshotReceipt reports whether tx is mined, and if so in which block
+	// and whether it succeeded.
+	SnapshotReceipt(tx common.Hash) (mined bool, block uint64, ok bool, err error)
+}
+
+// ActiveSetHash is keccak256(abi.encode(operators)), as EpochManager stores it.
+func ActiveSetHash(operators []common.Address) common.Hash {
+	addrs, _ := abi.NewType("address[]", "", nil)
+	enc, err := abi.Arguments{{Type: addrs}}.Pack(operators)
+	if err != nil {
+		panic(err) // address[] always packs
+	}
+	return crypto.Keccak256Hash(enc)
+}
+
+type submission struct {
+	boundary  uint64
+	operators []common.Address
+	hash      common.Hash
+	tx        common.Hash
+}
+
+// EpochKeeper snapshots the staking contract's active set into EpochManager
+// at every epoch boundary. snapshotActiveSet only succeeds when it is mined
+// in the boundary block itself, so the transaction is sent when the head is
+// the block before it.
+type EpochKeeper struct {
+	Chain  EpochChain
+	Length uint64 // EPOCH_LENGTH_BLOCKS
+	Window uint64 // blocks before a boundary in which the set is read and checked
+
+	head     uint64
+	prepared uint64 // boundary whose set was checked in the window
+	pending  *submission
+	checked  uint64 // latest boundary whose snapshot was verified or alerted
+}
+
+// Tick advances the keeper to head and returns what happened. An error means
+// the chain could not be read; the next tick retries.
+func (k *EpochKeeper) Tick(head uint64) ([]Event, error) {
+	if head == k.head && k.head != 0 {
+		return nil, nil
+	}
+	var events []Event
+	emit := func(level, name string, boundary uint64, msg string, mod func(*Event)) {
+		e := Event{Time: time.Now().UTC(), Level: level, Event: name, Head: head, Epoch: boundary / k.Length, Boundary: boundary, Message: msg}
+		if mod != nil {
+			mod(&e)
+		}
+		events = append(events, e)
+	}
+
+	if p := k.pending; p != nil {
+		mined, block, ok, err := k.Chain.SnapshotReceipt(p.tx)
+		if err != nil {
+			return events, err
+		}
+		switch {
+		case mined && ok:
+			onChain, err := k.Chain.ActiveSetHash(p.boundary / k.Length)
+			if err != nil {
+				return events, err
+			}
+			if onChain != p.hash {
+				emit(LevelAlert, "hash-mismatch", p.boundary, fmt.Sprintf("activeSetHash is %s, keeper computed %s", onChain.Hex(), p.hash.Hex()), withTx(p.tx, onChain))
+			} else {
+				emit(LevelInfo, "snapshotted", p.boundary, "", func(e *Event) { e.Operators, e.SetHash, e.TxHash = p.operators, &p.hash, &p.tx })
+			}
+			k.pending, k.checked = nil, p.boundary
+		case mined:
+			emit(LevelAlert, "missed", p.boundary, fmt.Sprintf("snapshot tx reverted in block %d", block), withTx(p.tx, common.Hash{}))
+			k.pending, k.checked = nil, p.boundary
+		case head >= p.boundary:
+			emit(LevelAlert, "missed", p.boundary, "snapshot tx was not mined in the boundary block", withTx(p.tx, common.Hash{}))
+			k.pending, k.checked = nil, p.boundary
+		}
+	}
+
+	if err := k.checkPast(head, emit); err != nil {
+		return events, err
+	}
+
+	next := (head/k.Length + 1) * k.Length
+	if next-head <= k.Window && k.prepared != next {
+		ops, err := k.Chain.ActiveOperators(head)
+		if err != nil {
+			return events, err
+		}
+		k.prepared = next
+		if len(ops) == 0 {
+			emit(LevelAlert, "empty-set", next, "staking contract has no active operators to snapshot", nil)
+		} else {
+			hash := ActiveSetHash(ops)
+			emit(LevelInfo, "prepared", next, "", func(e *Event) { e.Operators, e.SetHash = ops, &hash })
+		}
+	}
+
+	if head+1 == next && (k.pending == nil || k.pending.boundary != next) {
+		ops, err := k.Chain.ActiveOperators(head)
+		if err != nil {
+			return events, err
+		}
+		if len(ops) > 0 {
+			tx, err := k.Chain.SubmitSnapshot(next/k.Length, ops)
+			if err != nil {
+				emit(LevelAlert, "submit-failed", next, err.Error(), nil)
+			} else {
+				p := &submission{boundary: next, operators: ops, hash: ActiveSetHash(ops), tx: tx}
+				k.pending = p
+				emit(LevelInfo, "submitted", next, "", func(e *Event) { e.Operators, e.SetHash, e.TxHash = ops, &p.hash, &p.tx })
+			}
+		}
+	}
+
+	k.head = head
+	return events, nil
+}
+
+// checkPast verifies boundaries at or before head that the keeper did not
+// submit for: at startup the latest one, later any it skipped over. A zero
+// hash is a missed snapshot; another hash is checked against the set the
+// staking contract held in the block before the boundary.
+func (k *EpochKeeper) checkPast(head uint64, emit func(string, string, uint64, string, func(*Event))) error {
+	last := head / k.Length * k.Length
+	if last == 0 || last <= k.checked {
+		return nil
+	}
+	from := last
+	if k.checked != 0 {
+		from = k.checked + k.Length
+	}
+	for b := from; b <= last; b += k.Length {
+		if k.pending != nil && k.pending.boundary == b {
+			continue
+		}
+		onChain, err := k.Chain.ActiveSetHash(b / k.Length)
+		if err != nil {
+			return err
+		}
+		if onChain == (common.Hash{}) {
+			emit(LevelAlert, "missed", b, "no snapshot for this epoch", nil)
+		} else {
+			ops, err := k.Chain.ActiveOperators(b - 1)
+			if err != nil {
+				return err
+			}
+			if want := ActiveSetHash(ops); want != onChain {
+				emit(LevelAlert, "hash-mismatch", b, fmt.Sprintf("activeSetHash is %s, staking set before the boundary hashes to %s", onChain.Hex(), want.Hex()), withTx(common.Hash{}, onChain))
+			} else {
+				emit(LevelInfo, "verified", b, "", func(e *Event) { e.Operators, e.SetHash = ops, &onChain })
+			}
+		}
+		k.checked = b
+	}
+	return nil
+}
+
+func withTx(tx, setHash common.Hash) func(*Event) {
+	return func(e *Event) {
+		if tx != (common.Hash{}) {
+			e.TxHash = &tx
+		}
+		if setHash != (common.Hash{}) {
+			e.SetHash = &setHash
+		}
+	}
+}
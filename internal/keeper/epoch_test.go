@@ -0,0 +1,144 @@
+package keeper
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	op1 = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	op2 = common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+)
+
+func TestActiveSetHash(t *testing.T) {
+	// abi.encode(address[]): offset, length, then each address in a word.
+	enc := make([]byte, 4*32)
+	enc[31], enc[63] = 0x20, 2
+	copy(enc[64+12:], op1.Bytes())
+	copy(enc[96+12:], op2.Bytes())
+	if got, want := ActiveSetHash([]common.Address{op1, op2}), crypto.Keccak256Hash(enc); got != want {
+		t.Fatalf("hash = %s, want %s", got.Hex(), want.Hex())
+	}
+}
+
+// fakeEpochChain mines a submitted snapshot at minedAt, reverting it unless
+// that block is the boundary.
+type fakeEpochChain struct {
+	ops      []common.Address
+	hashes   map[uint64]common.Hash
+	sent     []uint64
+	minedAt  uint64
+	txs      map[common.Hash]uint64
+	boundary map[common.Hash]uint64
+}
+
+func newFakeEpochChain() *fakeEpochChain {
+	return &fakeEpochChain{ops: []common.Address{op1, op2}, hashes: map[uint64]common.Hash{}, txs: map[common.Hash]uint64{}, boundary: map[common.Hash]uint64{}}
+}
+
+func (f *fakeEpochChain) ActiveOperators(uint64) ([]common.Address, error) { return f.ops, nil }
+
+func (f *fakeEpochChain) ActiveSetHash(epoch uint64) (common.Hash, error) {
+	return f.hashes[epoch], nil
+}
+
+func (f *fakeEpochChain) SubmitSnapshot(epoch uint64, ops []common.Address) (common.Hash, error) {
+	f.sent = append(f.sent, epoch)
+	tx := common.Hash{byte(len(f.sent))}
+	f.boundary[tx] = epoch * 10
+	if f.minedAt != 0 {
+		f.txs[tx] = f.minedAt
+		if f.minedAt == epoch*10 {
+			f.hashes[epoch] = ActiveSetHash(ops)
+		}
+	}
+	return tx, nil
+}
+
+func (f *fakeEpochChain) SnapshotReceipt(tx common.Hash) (bool, uint64, bool, error) {
+	block, ok := f.txs[tx]
+	return ok, block, ok && block == f.boundary[tx], nil
+}
+
+func run(t *testing.T, k *EpochKeeper, heads ...uint64) []string {
+	t.Helper()
+	var got []string
+	for _, h := range heads {
+		events, err := k.Tick(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range events {
+			got = append(got, fmt.Sprintf("%s@%d", e.Event, e.Boundary))
+		}
+	}
+	return got
+}
+
+func TestEpochKeeper(t *testing.T) {
+	cases := []struct {
+		name    string
+		minedAt uint64
+		heads   []uint64
+		want    string
+	}{
+		{"on time", 20, []uint64{15, 16, 17, 18, 19, 20, 21}, "verified@10,prepared@20,submitted@20,snapshotted@20"},
+		{"mined late", 21, []uint64{19, 20, 21}, "verified@10,prepared@20,submitted@20,missed@20"},
+		{"never mined", 0, []uint64{19, 20}, "verified@10,prepared@20,submitted@20,missed@20"},
+		{"head skipped the window", 20, []uint64{16, 20}, "verified@10,missed@20"},
+	}
+	for _, tc := range cases {
+		f := newFakeEpochChain()
+		f.minedAt = tc.minedAt
+		f.hashes[1] = ActiveSetHash(f.ops)
+		k := &EpochKeeper{Chain: f, Length: 10, Window: 3}
+		if got := strings.Join(run(t, k, tc.heads...), ","); got != tc.want {
+			t.Errorf("%s: events %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestEpochKeeperStartupChecks(t *testing.T) {
+	f := newFakeEpochChain()
+	f.hashes[2] = ActiveSetHash(f.ops)
+	k := &EpochKeeper{Chain: f, Length: 10, Window: 3}
+	if got := strings.Join(run(t, k, 22), ","); got != "verified@20" {
+		t.Fatalf("events %s", got)
+	}
+
+	f.hashes[3] = common.Hash{1}
+	if got := strings.Join(run(t, k, 35), ","); got != "hash-mismatch@30" {
+		t.Fatalf("events %s", got)
+	}
+
+	f.ops = nil
+	if got := strings.Join(run(t, k, 38, 39), ","); got != "empty-set@40" {
+		t.Fatalf("events %s", got)
+	}
+	if len(f.sent) != 0 {
+		t.Fatalf("sent %v with an empty set", f.sent)
+	}
+}
+
+type failingChain struct{ fakeEpochChain }
+
+func (f *failingChain) SubmitSnapshot(uint64, []common.Address) (common.Hash, error) {
+	return common.Hash{}, errors.New("execution reverted: Ownable: caller is not the owner")
+}
+
+func TestEpochKeeperSubmitFailure(t *testing.T) {
+	f := &failingChain{*newFakeEpochChain()}
+	k := &EpochKeeper{Chain: f, Length: 10, Window: 1}
+	events, err := k.Tick(9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[1].Event != "submit-failed" || events[1].Level != LevelAlert || !strings.Contains(events[1].Message, "not the owner") {
+		t.Fatalf("events %+v", events)
+	}
+}
@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/contracts"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/BioMark3r/qikchain/internal/signer"
+	"github.com/BioMark3r/qikchain/internal/txbuild"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// RPCEpochChain implements EpochChain over JSON-RPC, signing snapshots with
+// Signer, which must own the EpochManager.
+type RPCEpochChain struct {
+	Client       *rpc.Client
+	Signer       signer.Signer
+	EpochManager common.Address
+	Fees         txbuild.FeeOptions
+
+	chainID *big.Int
+	epochs  *contracts.EpochManagerCaller
+	staking *contracts.IQikStakingCaller
+	abi     *abi.ABI
+}
+
+// NewRPCEpochChain checks that the signer owns epochManager and returns the
+// chain with the epoch length read from the contract.
+func NewRPCEpochChain(client *rpc.Client, s signer.Signer, epochManager, staking common.Address, fees txbuild.FeeOptions) (*RPCEpochChain, uint64, error) {
+	caller := contracts.RPCCaller{Client: client}
+	c := &RPCEpochChain{Client: client, Signer: s, EpochManager: epochManager, Fees: fees}
+	var err error
+	if c.epochs, err = contracts.NewEpochManagerCaller(epochManager, caller); err != nil {
+		return nil, 0, err
+	}
+	if c.staking, err = contracts.NewIQikStakingCaller(staking, caller); err != nil {
+		return nil, 0, err
+	}
+	if c.abi, err = contracts.EpochManagerMetaData.GetAbi(); err != nil {
+		return nil, 0, err
+	}
+	if c.chainID, err = client.ChainID(); err != nil {
+		return nil, 0, err
+	}
+	length, err := c.epochs.EPOCHLENGTHBLOCKS(nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("EPOCH_LENGTH_BLOCKS: %w", err)
+	}
+	if length.Sign() <= 0 || !length.IsUint64() {
+		return nil, 0, fmt.Errorf("EPOCH_LENGTH_BLOCKS: unusable value %s", length)
+	}
+	owner, err := c.epochs.Owner(nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("owner: %w", err)
+	}
+	if owner != s.Address() {
+		return nil, 0, fmt.Errorf("epoch manager %s is owned by %s, not the keeper %s", epochManager.Hex(), owner.Hex(), s.Address().Hex())
+	}
+	return c, length.Uint64(), nil
+}
+
+func (c *RPCEpochChain) ActiveOperators(block uint64) ([]common.Address, error) {
+	ops, err := c.staking.GetActiveOperators(&bind.CallOpts{BlockNumber: new(big.Int).SetUint64(block)})
+	if err != nil {
+		return nil, fmt.Errorf("getActiveOperators at %d: %w", block, err)
+	}
+	return ops, nil
+}
+
+func (c *RPCEpochChain) ActiveSetHash(epoch uint64) (common.Hash, error) {
+	h, err := c.epochs.ActiveSetHash(nil, new(big.Int).SetUint64(epoch))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("activeSetHash(%d): %w", epoch, err)
+	}
+	return h, nil
+}
+
+// SubmitSnapshot signs and broadcasts snapshotActiveSet. Gas is estimated
+// against the pending block, which fails unless that block is the boundary.
+func (c *RPCEpochChain) SubmitSnapshot(epoch uint64, operators []common.Address) (common.Hash, error) {
+	data, err := c.abi.Pack("snapshotActiveSet", new(big.Int).SetUint64(epoch), operators)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	from := c.Signer.Address()
+	head, err := c.Client.BlockByNumber("latest", false)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var baseFee *big.Int
+	if head.BaseFee != nil && head.BaseFee.ToInt().Sign() > 0 {
+		baseFee = head.BaseFee.ToInt()
+	}
+	txType, err := txbuild.SelectType("auto", baseFee)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	fees, err := txbuild.SuggestFees(c.Client, txType, baseFee, c.Fees)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	nonce, err := c.Client.NonceAt(from, "pending")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("fetch nonce: %w", err)
+	}
+	gas, err := c.Client.EstimateGas(rpc.CallMsg{From: from, To: &c.EpochManager, Data: data})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("estimate gas: %w", err)
+	}
+	tx, err := txbuild.Build(txbuild.Request{ChainID: c.chainID, Type: txType, Nonce: nonce, To: &c.EpochManager, Value: new(big.Int), Data: data, Gas: gas, Fees: fees})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	signed, err := c.Signer.SignTx(ctx, tx, c.chainID)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("sign: %w", err)
+	}
+	if _, err := c.Client.SendRawTransaction(signed); err != nil {
+		return common.Hash{}, fmt.Errorf("send: %w", err)
+	}
+	return signed.Hash(), nil
+}
+
+func (c *RPCEpochChain) SnapshotReceipt(hash common.Hash) (bool, uint64, bool, error) {
+	receipt, err := c.Client.TransactionReceipt(hash)
+	if errors.Is(err, rpc.ErrNotFound) {
+		return false, 0, false, nil
+	}
+	if err != nil {
+		return false, 0, false, err
+	}
+	ok := receipt.Status == nil || uint64(*receipt.Status) == types.ReceiptStatusSuccessful
+	return true, uint64(receipt.BlockNumber), ok, nil
+}
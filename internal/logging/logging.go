@@ -0,0 +1,80 @@
+// Package logging sets up the log/slog logger shared by the qikchain
+// binaries. Logs always go to stderr; stdout is left to command output.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/flagutil"
+)
+
+const (
+	EnvFormat = "QIKCHAIN_LOG_FORMAT"
+	EnvLevel  = "QIKCHAIN_LOG_LEVEL"
+)
+
+// Attribute keys used across binaries.
+const (
+	KeyComponent = "component"
+	KeyNode      = "node"
+	KeyRPC       = "rpc"
+	KeyDuration  = "duration"
+	KeyError     = "err"
+)
+
+// Config selects the log format and minimum level.
+type Config struct {
+	Format string // text or json
+	Level  string // debug, info, warn or error
+}
+
+// BindFlags registers --log-format and --log-level, defaulting to
+// QIKCHAIN_LOG_FORMAT and QIKCHAIN_LOG_LEVEL.
+func (c *Config) BindFlags(fs flagutil.FlagSet) {
+	fs.StringVar(&c.Format, "log-format", flagutil.EnvOr(EnvFormat, "text"), "log format on stderr: text|json")
+	fs.StringVar(&c.Level, "log-level", flagutil.EnvOr(EnvLevel, "info"), "minimum log level: debug|info|warn|error")
+}
+
+// New returns a logger writing to w.
+func (c Config) New(w io.Writer) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(c.Level))); err != nil {
+		return nil, fmt.Errorf("--log-level %q: want debug, info, warn or error", c.Level)
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(strings.TrimSpace(c.Format)) {
+	case "", "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("--log-format %q: want text or json", c.Format)
+	}
+}
+
+// Setup builds a stderr logger tagged with component and installs it as the
+// slog default, which also routes the standard log package through it.
+func (c Config) Setup(component string) (*slog.Logger, error) {
+	l, err := c.New(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	l = l.With(KeyComponent, component)
+	slog.SetDefault(l)
+	return l, nil
+}
+
+// Err is the attribute for an error.
+func Err(err error) slog.Attr {
+	return slog.Any(KeyError, err)
+}
+
+// Duration is the attribute for an elapsed time, in seconds.
+func Duration(d time.Duration) slog.Attr {
+	return slog.Float64(KeyDuration, d.Seconds())
+}
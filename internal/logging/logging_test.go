@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	var b strings.Builder
+	l, err := Config{Format: "json", Level: "warn"}.New(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l = l.With(KeyComponent, "run")
+	l.Info("dropped")
+	l.Warn("node down", KeyNode, "n1", KeyRPC, "http://127.0.0.1:8545", Duration(1500*time.Millisecond), Err(errors.New("timeout")))
+
+	var rec map[string]any
+	if err := json.Unmarshal([]byte(b.String()), &rec); err != nil {
+		t.Fatalf("want one JSON line, got %q", b.String())
+	}
+	if rec["level"] != "WARN" || rec["msg"] != "node down" || rec["component"] != "run" || rec["node"] != "n1" ||
+		rec["rpc"] != "http://127.0.0.1:8545" || rec["duration"] != 1.5 || rec["err"] != "timeout" {
+		t.Fatalf("record %v", rec)
+	}
+
+	b.Reset()
+	if l, err = (Config{Format: "TEXT", Level: "debug"}).New(&b); err != nil {
+		t.Fatal(err)
+	}
+	l.Debug("poll", KeyNode, "n2")
+	if got := b.String(); !strings.Contains(got, "level=DEBUG msg=poll node=n2") {
+		t.Fatalf("text %q", got)
+	}
+
+	for _, bad := range []Config{{Format: "xml", Level: "info"}, {Format: "text", Level: "loud"}} {
+		if _, err := bad.New(&b); err == nil {
+			t.Errorf("%+v accepted", bad)
+		}
+	}
+}
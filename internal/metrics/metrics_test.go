@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	r := NewRegistry()
+	head := r.Gauge("qikchain_head_block", "Latest block number.", "node")
+	errs := r.Counter("qikchain_rpc_errors_total", "Failed RPC calls.", "node", "method")
+	lat := r.Histogram("qikchain_rpc_duration_seconds", "RPC latency.", []float64{0.1, 1}, "node")
+	up := r.Gauge("qikchain_up", "Line one\nline two.")
+
+	head.Set(42, "n2")
+	head.Set(40, `n"1`)
+	errs.Inc("n1", "eth_blockNumber")
+	errs.Add(2, "n1", "eth_blockNumber")
+	lat.Observe(0.05, "n1")
+	lat.Observe(0.5, "n1")
+	lat.Observe(3, "n1")
+	up.Set(math.Inf(1))
+
+	var b strings.Builder
+	if err := r.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP qikchain_head_block Latest block number.
+# TYPE qikchain_head_block gauge
+qikchain_head_block{node="n\"1"} 40
+qikchain_head_block{node="n2"} 42
+# HELP qikchain_rpc_errors_total Failed RPC calls.
+# TYPE qikchain_rpc_errors_total counter
+qikchain_rpc_errors_total{node="n1",method="eth_blockNumber"} 3
+# HELP qikchain_rpc_duration_seconds RPC latency.
+# TYPE qikchain_rpc_duration_seconds histogram
+qikchain_rpc_duration_seconds_bucket{node="n1",le="0.1"} 1
+qikchain_rpc_duration_seconds_bucket{node="n1",le="1"} 2
+qikchain_rpc_duration_seconds_bucket{node="n1",le="+Inf"} 3
+qikchain_rpc_duration_seconds_sum{node="n1"} 3.55
+qikchain_rpc_duration_seconds_count{node="n1"} 3
+# HELP qikchain_up Line one\nline two.
+# TYPE qikchain_up gauge
+qikchain_up +Inf
+`
+	if got := b.String(); got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDeletePrefixAndHandler(t *testing.T) {
+	r := NewRegistry()
+	g := r.Gauge("g", "g.", "node", "method")
+	g.Set(1, "a", "x")
+	g.Set(2, "b", "x")
+	g.DeletePrefix("a")
+	if g.Value("a", "x") != 0 || g.Value("b", "x") != 2 {
+		t.Fatal("DeletePrefix removed the wrong series")
+	}
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Fatalf("content type %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `g{node="b",method="x"} 2`) {
+		t.Fatalf("body %s", rec.Body.String())
+	}
+}
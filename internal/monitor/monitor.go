@@ -0,0 +1,284 @@
+// Package monitor polls QikChain nodes over JSON-RPC and evaluates the
+// health of the cluster they form: quorum, head divergence, stalled heads
+// and chain ID mismatches.
+package monitor
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+)
+
+// Node is a monitored node. Name labels its output and metrics.
+type Node struct {
+	Name string `json:"name"`
+	URL  string `json:"rpc"`
+}
+
+// ParseNodes parses a comma-separated list of name=url or bare url entries.
+// A bare url is named after its host and port.
+func ParseNodes(spec string) ([]Node, error) {
+	var out []Node
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		n := Node{URL: item}
+		if name, u, ok := strings.Cut(item, "="); ok && !strings.Contains(name, "://") {
+			n = Node{Name: strings.TrimSpace(name), URL: strings.TrimSpace(u)}
+		}
+		out = append(out, n)
+	}
+	return CheckNodes(out)
+}
+
+// CheckNodes validates nodes and returns a copy in which a node without a
+// name is named after its host and port.
+func CheckNodes(nodes []Node) ([]Node, error) {
+	out := make([]Node, 0, len(nodes))
+	seen := map[string]bool{}
+	for _, n := range nodes {
+		parsed, err := url.Parse(n.URL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("node %q: not an http(s) URL", n.URL)
+		}
+		if n.Name == "" {
+			n.Name = parsed.Host
+		}
+		if seen[n.Name] {
+			return nil, fmt.Errorf("node name %q is used twice", n.Name)
+		}
+		seen[n.Name] = true
+		out = append(out, n)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no nodes given")
+	}
+	return out, nil
+}
+
+// NodeStatus is one poll of one node.
+type NodeStatus struct {
+	Name            string  `json:"name"`
+	RPC             string  `json:"rpc"`
+	Timestamp       string  `json:"timestamp"`
+	Up              bool    `json:"up"`
+	ChainID         uint64  `json:"chainId,omitempty"`
+	ChainHex        string  `json:"chainHex,omitempty"`
+	BlockNumber     uint64  `json:"blockNumber,omitempty"`
+	BlockHex        string  `json:"blockHex,omitempty"`
+	BlockTime       uint64  `json:"blockTime,omitempty"`
+	PeerCount       uint64  `json:"peerCount,omitempty"`
+	PeerHex         string  `json:"peerHex,omitempty"`
+	HeadAgeSeconds  float64 `json:"headAgeSeconds,omitempty"`
+	ChainIDMismatch bool    `json:"chainIdMismatch,omitempty"`
+	Stalled         bool    `json:"stalled,omitempty"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// Collect polls one node: chain ID, latest block and peer count.
+func Collect(c *rpc.Client, n Node) NodeStatus {
+	now := time.Now()
+	st := NodeStatus{Name: n.Name, RPC: n.URL, Timestamp: now.UTC().Format(time.RFC3339)}
+	fail := func(err error) NodeStatus {
+		st.Error = err.Error()
+		return st
+	}
+	chainHex, err := c.CallString("eth_chainId")
+	if err != nil {
+		return fail(fmt.Errorf("eth_chainId: %w", err))
+	}
+	chainID, err := rpc.HexToUint64(chainHex)
+	if err != nil {
+		return fail(fmt.Errorf("eth_chainId decode: %w", err))
+	}
+	head, err := c.BlockByNumber("latest", false)
+	if err != nil {
+		return fail(fmt.Errorf("eth_getBlockByNumber: %w", err))
+	}
+	peerHex, err := c.CallString("net_peerCount")
+	if err != nil {
+		return fail(fmt.Errorf("net_peerCount: %w", err))
+	}
+	peerCount, err := rpc.HexToUint64(peerHex)
+	if err != nil {
+		return fail(fmt.Errorf("net_peerCount decode: %w", err))
+	}
+	st.Up = true
+	st.ChainID, st.ChainHex = chainID, chainHex
+	st.BlockNumber, st.BlockHex, st.BlockTime = uint64(head.Number), head.Number.String(), uint64(head.Timestamp)
+	st.PeerCount, st.PeerHex = peerCount, peerHex
+	st.HeadAgeSeconds = now.Sub(time.Unix(int64(head.Timestamp), 0)).Seconds()
+	return st
+}
+
+// Poll collects every node concurrently; clients[i] talks to nodes[i].
+func Poll(nodes []Node, clients []*rpc.Client) []NodeStatus {
+	out := make([]NodeStatus, len(nodes))
+	var wg sync.WaitGroup
+	for i := range nodes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			out[i] = Collect(clients[i], nodes[i])
+		}(i)
+	}
+	wg.Wait()
+	return out
+}
+
+// Policy sets the cluster health thresholds.
+type Policy struct {
+	Quorum        int    // nodes that must be up; 0 means a majority
+	MaxDivergence uint64 // max head spread between up nodes
+	StallCycles   int    // polls without head progress before a head is stalled; 0 disables
+	ChainID       uint64 // expected chain ID; 0 means the first one seen
+}
+
+// Cluster is the aggregated record of one poll cycle.
+type Cluster struct {
+	Timestamp      string       `json:"timestamp"`
+	Healthy        bool         `json:"healthy"`
+	Reasons        []string     `json:"reasons,omitempty"`
+	NodesTotal     int          `json:"nodesTotal"`
+	NodesUp        int          `json:"nodesUp"`
+	Quorum         int          `json:"quorum"`
+	ChainID        uint64       `json:"chainId,omitempty"`
+	MinHead        *uint64      `json:"minHead"`
+	MaxHead        *uint64      `json:"maxHead"`
+	HeadDivergence uint64       `json:"headDivergence"`
+	HeadStalled    bool         `json:"headStalled"`
+	StalledNodes   []string     `json:"stalledNodes,omitempty"`
+	Nodes          []NodeStatus `json:"nodes"`
+}
+
+type progress struct {
+	head  uint64
+	polls int // consecutive polls without progress
+	seen  bool
+}
+
+func (p *progress) observe(head uint64) int {
+	if p.seen && head <= p.head {
+		p.polls++
+	} else {
+		p.head, p.polls, p.seen = head, 0, true
+	}
+	return p.polls
+}
+
+// Tracker evaluates successive poll cycles against a Policy. It remembers
+// heads between cycles to detect stalls.
+type Tracker struct {
+	Policy  Policy
+	nodes   map[string]*progress
+	cluster progress
+}
+
+// Forget drops what t remembers about the node name, which is no longer
+// polled. A node added back later starts afresh.
+func (t *Tracker) Forget(name string) {
+	delete(t.nodes, name)
+}
+
+func (t *Tracker) Evaluate(now time.Time, statuses []NodeStatus) Cluster {
+	if t.nodes == nil {
+		t.nodes = map[string]*progress{}
+	}
+	c := Cluster{Timestamp: now.UTC().Format(time.RFC3339), NodesTotal: len(statuses), Quorum: t.Policy.Quorum, Nodes: statuses}
+	if c.Quorum <= 0 {
+		c.Quorum = len(statuses)/2 + 1
+	}
+	var reasons []string
+	for i := range statuses {
+		st := &statuses[i]
+		if !st.Up {
+			continue
+		}
+		c.NodesUp++
+		if t.Policy.ChainID == 0 {
+			t.Policy.ChainID = st.ChainID
+		}
+		if st.ChainID != t.Policy.ChainID {
+			st.ChainIDMismatch = true
+			reasons = append(reasons, fmt.Sprintf("node %s reports chain ID %d, expected %d", st.Name, st.ChainID, t.Policy.ChainID))
+		}
+		if c.MinHead == nil || st.BlockNumber < *c.MinHead {
+			v := st.BlockNumber
+			c.MinHead = &v
+		}
+		if c.MaxHead == nil || st.BlockNumber > *c.MaxHead {
+			v := st.BlockNumber
+			c.MaxHead = &v
+		}
+		p := t.nodes[st.Name]
+		if p == nil {
+			p = &progress{}
+			t.nodes[st.Name] = p
+		}
+		if n := p.observe(st.BlockNumber); t.Policy.StallCycles > 0 && n >= t.Policy.StallCycles {
+			st.Stalled = true
+			c.StalledNodes = append(c.StalledNodes, st.Name)
+		}
+	}
+	sort.Strings(c.StalledNodes)
+	c.ChainID = t.Policy.ChainID
+
+	if c.NodesUp < c.Quorum {
+		reasons = append(reasons, fmt.Sprintf("%d of %d nodes up, quorum is %d", c.NodesUp, c.NodesTotal, c.Quorum))
+	}
+	if c.MaxHead != nil {
+		c.HeadDivergence = *c.MaxHead - *c.MinHead
+		if c.NodesUp >= 2 && c.HeadDivergence > t.Policy.MaxDivergence {
+			reasons = append(reasons, fmt.Sprintf("head divergence %d > %d", c.HeadDivergence, t.Policy.MaxDivergence))
+		}
+		if n := t.cluster.observe(*c.MaxHead); t.Policy.StallCycles > 0 && n >= t.Policy.StallCycles {
+			c.HeadStalled = true
+			reasons = append(reasons, fmt.Sprintf("cluster head stalled at %d for %d polls", *c.MaxHead, n))
+		}
+	}
+	c.Reasons = reasons
+	c.Healthy = len(reasons) == 0
+	return c
+}
+
+// ReadyPolicy sets when a cluster counts as synced and producing blocks.
+type ReadyPolicy struct {
+	MaxHeadAge time.Duration // max age of the freshest head; 0 disables
+	MinPeers   uint64        // min peers of every up node
+}
+
+// NotReady returns why c is not ready under p, or nil when it is: quorum must
+// be up, the head must not be stalled or older than MaxHeadAge, and every up
+// node needs MinPeers peers.
+func (c Cluster) NotReady(p ReadyPolicy) []string {
+	var reasons []string
+	if c.NodesUp < c.Quorum {
+		reasons = append(reasons, fmt.Sprintf("%d of %d nodes up, quorum is %d", c.NodesUp, c.NodesTotal, c.Quorum))
+	}
+	if c.HeadStalled {
+		reasons = append(reasons, "cluster head stalled")
+	}
+	freshest := -1.0
+	for _, st := range c.Nodes {
+		if !st.Up {
+			continue
+		}
+		if freshest < 0 || st.HeadAgeSeconds < freshest {
+			freshest = st.HeadAgeSeconds
+		}
+		if st.PeerCount < p.MinPeers {
+			reasons = append(reasons, fmt.Sprintf("node %s has %d peers, need %d", st.Name, st.PeerCount, p.MinPeers))
+		}
+	}
+	if p.MaxHeadAge > 0 && freshest >= 0 && freshest > p.MaxHeadAge.Seconds() {
+		reasons = append(reasons, fmt.Sprintf("newest block is %.0fs old, max %s", freshest, p.MaxHeadAge))
+	}
+	return reasons
+}
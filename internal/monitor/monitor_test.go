@@ -0,0 +1,116 @@
+package monitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+)
+
+func TestParseNodes(t *testing.T) {
+	nodes, err := ParseNodes("node1=http://127.0.0.1:8545, http://10.0.0.2:8546 ,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 2 || nodes[0] != (Node{"node1", "http://127.0.0.1:8545"}) || nodes[1] != (Node{"10.0.0.2:8546", "http://10.0.0.2:8546"}) {
+		t.Fatalf("nodes %+v", nodes)
+	}
+	for _, bad := range []string{"", "node1=127.0.0.1:8545", "a=http://x:1,a=http://y:1"} {
+		if _, err := ParseNodes(bad); err == nil {
+			t.Errorf("ParseNodes(%q) succeeded", bad)
+		}
+	}
+	nodes, err = CheckNodes([]Node{{URL: "http://10.0.0.5:8545"}})
+	if err != nil || nodes[0].Name != "10.0.0.5:8545" {
+		t.Fatalf("CheckNodes: %+v %v", nodes, err)
+	}
+}
+
+func up(name string, head, chainID uint64) NodeStatus {
+	return NodeStatus{Name: name, Up: true, BlockNumber: head, ChainID: chainID}
+}
+
+func TestTracker(t *testing.T) {
+	tr := &Tracker{Policy: Policy{MaxDivergence: 3, StallCycles: 2}}
+	now := time.Unix(1700000000, 0)
+
+	c := tr.Evaluate(now, []NodeStatus{up("n1", 10, 100), up("n2", 9, 100), up("n3", 10, 100), {Name: "n4", Error: "timeout"}})
+	if !c.Healthy || c.NodesUp != 3 || c.Quorum != 3 || *c.MinHead != 9 || c.HeadDivergence != 1 || c.ChainID != 100 {
+		t.Fatalf("cycle 1: %+v", c)
+	}
+
+	c = tr.Evaluate(now, []NodeStatus{up("n1", 15, 100), {Name: "n2"}, {Name: "n3"}, up("n4", 10, 7)})
+	if c.Healthy || len(c.Reasons) != 3 || !c.Nodes[3].ChainIDMismatch {
+		t.Fatalf("cycle 2: %+v", c)
+	}
+	want := []string{"node n4 reports chain ID 7, expected 100", "2 of 4 nodes up, quorum is 3", "head divergence 5 > 3"}
+	if strings.Join(c.Reasons, "; ") != strings.Join(want, "; ") {
+		t.Fatalf("reasons %q", c.Reasons)
+	}
+
+	// n1 and the cluster head stay at 15 for two more polls.
+	for i := 0; i < 2; i++ {
+		c = tr.Evaluate(now, []NodeStatus{up("n1", 15, 100), up("n2", 15, 100), up("n3", 14, 100), up("n4", 14, 100)})
+	}
+	if c.Healthy || !c.HeadStalled || strings.Join(c.StalledNodes, ",") != "n1" || !c.Nodes[0].Stalled {
+		t.Fatalf("stalled: %+v", c)
+	}
+	c = tr.Evaluate(now, []NodeStatus{up("n1", 16, 100), up("n2", 16, 100), up("n3", 16, 100), up("n4", 15, 100)})
+	if !c.Healthy || c.HeadStalled || len(c.StalledNodes) != 0 {
+		t.Fatalf("recovered: %+v", c)
+	}
+
+	// n4 is removed and added back at its old head: its stall count restarts.
+	tr.Forget("n4")
+	for head := uint64(17); head < 19; head++ {
+		c = tr.Evaluate(now, []NodeStatus{up("n1", head, 100), up("n2", head, 100), up("n3", head, 100), up("n4", 15, 100)})
+	}
+	if len(c.StalledNodes) != 0 {
+		t.Fatalf("forgotten node: %+v", c)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		result := map[string]any{
+			"eth_chainId":          "0x64",
+			"net_peerCount":        "0x3",
+			"eth_getBlockByNumber": map[string]any{"number": "0x2a", "timestamp": "0x6553f100"},
+		}[req.Method]
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	defer srv.Close()
+
+	nodes := []Node{{"good", srv.URL}, {"down", "http://127.0.0.1:1"}}
+	clients := []*rpc.Client{rpc.NewClient(nodes[0].URL, time.Second), rpc.NewClient(nodes[1].URL, time.Second)}
+	got := Poll(nodes, clients)
+	if !got[0].Up || got[0].ChainID != 100 || got[0].BlockNumber != 42 || got[0].BlockTime != 1700000000 || got[0].PeerCount != 3 {
+		t.Fatalf("good: %+v", got[0])
+	}
+	if got[1].Up || !strings.HasPrefix(got[1].Error, "eth_chainId:") {
+		t.Fatalf("down: %+v", got[1])
+	}
+}
+
+func TestNotReady(t *testing.T) {
+	c := Cluster{NodesTotal: 2, NodesUp: 2, Quorum: 2, Nodes: []NodeStatus{
+		{Name: "n1", Up: true, PeerCount: 3, HeadAgeSeconds: 4},
+		{Name: "n2", Up: true, PeerCount: 0, HeadAgeSeconds: 90},
+	}}
+	if r := c.NotReady(ReadyPolicy{MaxHeadAge: 30 * time.Second}); r != nil {
+		t.Fatalf("ready cluster: %q", r)
+	}
+	c.Nodes[0].Up, c.NodesUp = false, 1
+	want := "1 of 2 nodes up, quorum is 2; node n2 has 0 peers, need 1; newest block is 90s old, max 30s"
+	if r := c.NotReady(ReadyPolicy{MaxHeadAge: 30 * time.Second, MinPeers: 1}); strings.Join(r, "; ") != want {
+		t.Fatalf("reasons %q", r)
+	}
+}
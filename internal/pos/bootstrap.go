@@ -0,0 +1,218 @@
+// Package pos holds the PoS operator workflows built on the staking contract:
+// contract deployment records, declarative validator bootstrap and its
+// journal of in-flight transactions, and validator set reconciliation.
+package pos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BootstrapConfig mirrors config/pos.bootstrap.json.
+type BootstrapConfig struct {
+	Deployer struct {
+		PrivateKeyEnv string `json:"privateKeyEnv"`
+		Address       string `json:"address"`
+	} `json:"deployer"`
+	Operators []struct {
+		Operator         string         `json:"operator"`
+		Payout           string         `json:"payout"`
+		ConsensusKeyFile string         `json:"consensusKeyFile"`
+		InitialStakeWei  string         `json:"initialStakeWei"`
+		PrivateKeyEnv    string         `json:"privateKeyEnv"`
+		Signer           OperatorSigner `json:"signer"`
+	} `json:"operators"`
+}
+
+// OperatorSigner is how one operator signs. Set fields override the signer
+// flags of pos bootstrap; the names follow those flags.
+type OperatorSigner struct {
+	Backend        string `json:"keyBackend"`
+	Keystore       string `json:"keystore"`
+	Account        string `json:"account"`
+	PassphraseFile string `json:"passphraseFile"`
+	RemoteURL      string `json:"remoteSigner"`
+	RemoteMethod   string `json:"remoteMethod"`
+	HSMModule      string `json:"hsmModule"`
+	HSMTokenLabel  string `json:"hsmTokenLabel"`
+	HSMKeyLabel    string `json:"hsmKeyLabel"`
+	HSMPinFile     string `json:"hsmPinFile"`
+}
+
+// Operator is the desired on-chain state of one operator.
+type Operator struct {
+	Index         int
+	Address       common.Address
+	Payout        common.Address
+	ConsensusKey  []byte
+	SelfStake     *big.Int // minimum stake of the operator on itself
+	PrivateKeyEnv string
+	Signer        OperatorSigner
+}
+
+// Bootstrap is a resolved bootstrap config.
+type Bootstrap struct {
+	DeployerKeyEnv string
+	Operators      []Operator
+}
+
+var placeholderRe = regexp.MustCompile(`^\{\{([A-Z0-9_]+)\}\}$`)
+
+// LoadBootstrap reads a bootstrap config, resolving {{NAME}} placeholders with
+// lookup and consensus key files relative to baseDir.
+func LoadBootstrap(path, baseDir string, lookup func(string) (string, bool)) (*Bootstrap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg BootstrapConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(cfg.Operators) == 0 {
+		return nil, fmt.Errorf("%s: no operators", path)
+	}
+
+	resolve := func(field, v string) (string, error) {
+		m := placeholderRe.FindStringSubmatch(v)
+		if m == nil {
+			return v, nil
+		}
+		if r, ok := lookup(m[1]); ok && r != "" {
+			return r, nil
+		}
+		return "", fmt.Errorf("%s: %s is not set", field, m[1])
+	}
+	address := func(field, v string) (common.Address, error) {
+		v, err := resolve(field, v)
+		if err != nil {
+			return common.Address{}, err
+		}
+		if !common.IsHexAddress(v) {
+			return common.Address{}, fmt.Errorf("%s: invalid address %q", field, v)
+		}
+		return common.HexToAddress(v), nil
+	}
+
+	b := &Bootstrap{DeployerKeyEnv: cfg.Deployer.PrivateKeyEnv}
+	seen := map[common.Address]bool{}
+	for i, raw := range cfg.Operators {
+		field := fmt.Sprintf("operators[%d]", i)
+		op := Operator{Index: i, PrivateKeyEnv: raw.PrivateKeyEnv, Signer: raw.Signer, SelfStake: new(big.Int)}
+		if op.Address, err = address(field+".operator", raw.Operator); err != nil {
+			return nil, err
+		}
+		if seen[op.Address] {
+			return nil, fmt.Errorf("%s: duplicate operator %s", field, op.Address.Hex())
+		}
+		seen[op.Address] = true
+		if op.Payout, err = address(field+".payout", raw.Payout); err != nil {
+			return nil, err
+		}
+		if op.Payout == (common.Address{}) {
+			return nil, fmt.Errorf("%s.payout: zero address", field)
+		}
+		keyFile := raw.ConsensusKeyFile
+		if !filepath.IsAbs(keyFile) {
+			keyFile = filepath.Join(baseDir, keyFile)
+		}
+		if op.ConsensusKey, err = LoadConsensusKey(keyFile); err != nil {
+			return nil, fmt.Errorf("%s.consensusKeyFile: %w", field, err)
+		}
+		if raw.InitialStakeWei != "" {
+			stake, ok := new(big.Int).SetString(raw.InitialStakeWei, 10)
+			if !ok || stake.Sign() < 0 {
+				return nil, fmt.Errorf("%s.initialStakeWei: invalid amount %q", field, raw.InitialStakeWei)
+			}
+			op.SelfStake = stake
+		}
+		b.Operators = append(b.Operators, op)
+	}
+	return b, nil
+}
+
+// LoadConsensusKey reads a hex consensus key, with or without 0x, as written
+// by qikchain validator init.
+func LoadConsensusKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	raw := strings.ToLower(strings.Join(strings.Fields(string(data)), ""))
+	if raw == "" || raw == "0x" {
+		return nil, fmt.Errorf("%s: empty consensus key", path)
+	}
+	if !strings.HasPrefix(raw, "0x") {
+		raw = "0x" + raw
+	}
+	key, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: consensus key is not hex: %w", path, err)
+	}
+	return key, nil
+}
+
+// Observed is the on-chain state of an operator.
+type Observed struct {
+	Registered   bool
+	ConsensusKey []byte
+	Payout       common.Address
+	SelfStake    *big.Int
+}
+
+const (
+	ActionRegister     = "register"
+	ActionUpdateKey    = "update-key"
+	ActionUpdatePayout = "update-payout"
+	ActionStake        = "stake"
+)
+
+// Step is one transaction of a bootstrap plan, sent by Operator.
+type Step struct {
+	Operator     common.Address `json:"operator"`
+	Action       string         `json:"action"`
+	ConsensusKey hexutil.Bytes  `json:"consensusKey,omitempty"`
+	Payout       string         `json:"payout,omitempty"`
+	AmountWei    string         `json:"amountWei,omitempty"`
+}
+
+// ID names the step in the journal.
+func (s Step) ID() string {
+	return s.Operator.Hex() + ":" + s.Action
+}
+
+// Plan lists the transactions that move observed towards desired, in order.
+// Stake is only ever topped up to the target; it is never reduced.
+func Plan(desired []Operator, observed map[common.Address]Observed) []Step {
+	var steps []Step
+	for _, op := range desired {
+		cur := observed[op.Address]
+		if !cur.Registered {
+			steps = append(steps, Step{Operator: op.Address, Action: ActionRegister, ConsensusKey: op.ConsensusKey, Payout: op.Payout.Hex()})
+		} else {
+			if !bytes.Equal(cur.ConsensusKey, op.ConsensusKey) {
+				steps = append(steps, Step{Operator: op.Address, Action: ActionUpdateKey, ConsensusKey: op.ConsensusKey})
+			}
+			if cur.Payout != op.Payout {
+				steps = append(steps, Step{Operator: op.Address, Action: ActionUpdatePayout, Payout: op.Payout.Hex()})
+			}
+		}
+		have := cur.SelfStake
+		if have == nil {
+			have = new(big.Int)
+		}
+		if have.Cmp(op.SelfStake) < 0 {
+			steps = append(steps, Step{Operator: op.Address, Action: ActionStake, AmountWei: new(big.Int).Sub(op.SelfStake, have).String()})
+		}
+	}
+	return steps
+}
@@ -0,0 +1,322 @@
+package pos
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Create2Factory is the deterministic deployment proxy
+// (github.com/Arachnid/deterministic-deployment-proxy). It lives at the same
+// address on most chains; a fresh chain needs it in its genesis alloc.
+var Create2Factory = common.HexToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C")
+
+// DeploySpec is one contract of config/pos.contracts.json.
+type DeploySpec struct {
+	Contract        string                     `json:"contract"`
+	Artifact        string                     `json:"artifact,omitempty"`
+	ConstructorArgs []json.RawMessage          `json:"constructorArgs"`
+	Salt            string                     `json:"salt,omitempty"`
+	Init            map[string]json.RawMessage `json:"init,omitempty"`
+}
+
+// Deployment is a DeploySpec under its name, the key it has in both the plan
+// and the deployment record.
+type Deployment struct {
+	Name string
+	DeploySpec
+}
+
+// InitParam is a value applied after deployment through the getter Name and
+// its setter, e.g. minStake and setMinStake.
+type InitParam struct {
+	Name  string
+	Value string
+}
+
+// Setter is the name of the method that sets p.
+func (p InitParam) Setter() string {
+	r := []rune(p.Name)
+	return "set" + string(unicode.ToUpper(r[0])) + string(r[1:])
+}
+
+// AddressPlaceholder is the placeholder that refers to the address of the
+// deployment name: staking is STAKING_ADDRESS, validatorSet is
+// VALIDATOR_SET_ADDRESS.
+func AddressPlaceholder(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String() + "_ADDRESS"
+}
+
+// LoadDeployPlan reads a contracts plan and orders it so that every deployment
+// comes after the deployments whose address it references.
+func LoadDeployPlan(path string) ([]Deployment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var specs map[string]DeploySpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("%s: no contracts", path)
+	}
+	names := make([]string, 0, len(specs))
+	for name, spec := range specs {
+		if spec.Contract == "" && spec.Artifact == "" {
+			return nil, fmt.Errorf("%s: %s: contract is required", path, name)
+		}
+		for key := range spec.Init {
+			if key == "" {
+				return nil, fmt.Errorf("%s: %s.init: empty parameter name", path, name)
+			}
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dependsOn := func(name, other string) bool {
+		ref := "{{" + AddressPlaceholder(other) + "}}"
+		for _, arg := range specs[name].ConstructorArgs {
+			if bytes.Contains(arg, []byte(ref)) {
+				return true
+			}
+		}
+		return false
+	}
+	var plan []Deployment
+	placed := map[string]bool{}
+	for len(plan) < len(names) {
+		progressed := false
+		for _, name := range names {
+			if placed[name] {
+				continue
+			}
+			ready := true
+			for _, other := range names {
+				if other != name && !placed[other] && dependsOn(name, other) {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				plan = append(plan, Deployment{Name: name, DeploySpec: specs[name]})
+				placed[name] = true
+				progressed = true
+			}
+		}
+		if !progressed {
+			return nil, fmt.Errorf("%s: constructor args reference each other in a cycle", path)
+		}
+	}
+	return plan, nil
+}
+
+// Args returns the constructor args in the string form abiutil.ParseArgs
+// takes, with {{NAME}} string args replaced by lookup(NAME).
+func (d Deployment) Args(lookup func(string) (string, bool)) ([]string, error) {
+	out := make([]string, len(d.ConstructorArgs))
+	for i, raw := range d.ConstructorArgs {
+		v, err := resolveValue(raw, lookup)
+		if err != nil {
+			return nil, fmt.Errorf("%s.constructorArgs[%d]: %w", d.Name, i, err)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// InitParams returns the init values of d, sorted by name.
+func (d Deployment) InitParams(lookup func(string) (string, bool)) ([]InitParam, error) {
+	params := make([]InitParam, 0, len(d.Init))
+	for name, raw := range d.Init {
+		v, err := resolveValue(raw, lookup)
+		if err != nil {
+			return nil, fmt.Errorf("%s.init.%s: %w", d.Name, name, err)
+		}
+		params = append(params, InitParam{Name: name, Value: v})
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	return params, nil
+}
+
+// Salt is the CREATE2 salt of d: its own salt, or base joined with its name.
+// A 32-byte hex salt is used as is; anything else is hashed.
+func (d Deployment) Salt(base string) common.Hash {
+	s := d.DeploySpec.Salt
+	if s == "" {
+		s = base + ":" + d.Name
+	}
+	if b, err := hexutil.Decode(s); err == nil && len(b) == common.HashLength {
+		return common.BytesToHash(b)
+	}
+	return crypto.Keccak256Hash([]byte(s))
+}
+
+// Create2Address is where factory deploys initCode with salt.
+func Create2Address(factory common.Address, salt common.Hash, initCode []byte) common.Address {
+	return crypto.CreateAddress2(factory, salt, crypto.Keccak256(initCode))
+}
+
+func resolveValue(raw json.RawMessage, lookup func(string) (string, bool)) (string, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || raw[0] != '"' {
+		return string(raw), nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", err
+	}
+	m := placeholderRe.FindStringSubmatch(s)
+	if m == nil {
+		return s, nil
+	}
+	if v, ok := lookup(m[1]); ok && v != "" {
+		return v, nil
+	}
+	return "", fmt.Errorf("%s is not set", m[1])
+}
+
+// ContractRecord is one contract in a deployment record. A record with a tx
+// but no block number was sent and not yet seen mined.
+type ContractRecord struct {
+	Contract        string          `json:"contract,omitempty"`
+	Address         common.Address  `json:"address"`
+	Tx              string          `json:"tx,omitempty"`
+	BlockNumber     uint64          `json:"blockNumber,omitempty"`
+	BytecodeHash    *common.Hash    `json:"bytecodeHash,omitempty"` // keccak256 of the creation bytecode, without args
+	CodeHash        *common.Hash    `json:"codeHash,omitempty"`     // keccak256 of the runtime code
+	ConstructorArgs []string        `json:"constructorArgs,omitempty"`
+	Create2Factory  *common.Address `json:"create2Factory,omitempty"`
+	Create2Salt     *common.Hash    `json:"create2Salt,omitempty"`
+}
+
+// Pending reports whether the deployment tx of r was sent but not confirmed.
+func (r ContractRecord) Pending() bool {
+	return r.Tx != "" && r.BlockNumber == 0
+}
+
+// SameSource reports whether r was deployed from the bytecode and args of
+// want. Records written before bytecode hashes were kept always match.
+func (r ContractRecord) SameSource(want ContractRecord) bool {
+	if r.BytecodeHash == nil || want.BytecodeHash == nil {
+		return true
+	}
+	return *r.BytecodeHash == *want.BytecodeHash && strings.Join(r.ConstructorArgs, "\x00") == strings.Join(want.ConstructorArgs, "\x00")
+}
+
+// Record is a PoS deployment record such as build/deployments/pos.local.json:
+// a few fields about the deployment and one object per contract, keyed by its
+// plan name. genesis.LoadPOSAddresses reads it.
+type Record struct {
+	Network   string
+	RPC       string
+	ChainID   uint64
+	Deployer  common.Address
+	Contracts map[string]ContractRecord
+
+	path string
+}
+
+// OpenRecord loads the record at path, or starts an empty one. It refuses a
+// record written for another chain.
+func OpenRecord(path string, chainID uint64) (*Record, error) {
+	stored, err := ReadRecord(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Record{ChainID: chainID, Contracts: map[string]ContractRecord{}, path: path}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stored.ChainID != 0 && stored.ChainID != chainID {
+		return nil, fmt.Errorf("deployment record %s belongs to chain %d; move it aside or pass another --deployments", path, stored.ChainID)
+	}
+	stored.ChainID, stored.path = chainID, path
+	return stored, nil
+}
+
+// ReadRecord parses the record at path. Every reader of deployment records
+// goes through it, so they all accept the same format.
+func ReadRecord(path string) (*Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parse deployment record %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// Save writes the record atomically.
+func (r *Record) Save() error {
+	return writeJSON(r.path, r)
+}
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	doc := make(map[string]any, len(r.Contracts)+4)
+	for name, c := range r.Contracts {
+		doc[name] = c
+	}
+	if r.Network != "" {
+		doc["network"] = r.Network
+	}
+	if r.RPC != "" {
+		doc["rpc"] = r.RPC
+	}
+	if r.ChainID != 0 {
+		doc["chainId"] = r.ChainID
+	}
+	if r.Deployer != (common.Address{}) {
+		doc["deployer"] = r.Deployer
+	}
+	return json.Marshal(doc)
+}
+
+func (r *Record) UnmarshalJSON(data []byte) error {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	r.Contracts = map[string]ContractRecord{}
+	for key, raw := range doc {
+		var err error
+		switch key {
+		case "network":
+			err = json.Unmarshal(raw, &r.Network)
+		case "rpc":
+			err = json.Unmarshal(raw, &r.RPC)
+		case "chainId":
+			// The shell deployer wrote a number; accept a quoted one too.
+			r.ChainID, err = strconv.ParseUint(strings.Trim(string(raw), `"`), 10, 64)
+		case "deployer":
+			err = json.Unmarshal(raw, &r.Deployer)
+		default:
+			var c ContractRecord
+			err = json.Unmarshal(raw, &c)
+			r.Contracts[key] = c
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
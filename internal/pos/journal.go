@@ -0,0 +1,164 @@
+package pos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Journal entry states.
+const (
+	TxSent      = "sent"
+	TxConfirmed = "confirmed"
+	TxFailed    = "failed"
+	TxDropped   = "dropped"
+)
+
+// JournalEntry records a transaction before it is broadcast, so a rerun can
+// tell whether it is still in flight instead of sending it again.
+type JournalEntry struct {
+	Step   string         `json:"step"`
+	From   common.Address `json:"from"`
+	Nonce  uint64         `json:"nonce"`
+	TxHash common.Hash    `json:"txHash"`
+	State  string         `json:"state"`
+	SentAt time.Time      `json:"sentAt"`
+}
+
+// Journal is the bootstrap journal for one staking contract on one chain.
+type Journal struct {
+	ChainID string         `json:"chainId"`
+	Staking common.Address `json:"staking"`
+	Entries []JournalEntry `json:"entries"`
+
+	path string
+}
+
+// OpenJournal loads the journal at path, or starts an empty one. It refuses a
+// journal written for another chain or staking contract.
+func OpenJournal(path string, chainID *big.Int, staking common.Address) (*Journal, error) {
+	j := &Journal{ChainID: chainID.String(), Staking: staking, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stored Journal
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("parse journal %s: %w", path, err)
+	}
+	if stored.ChainID != j.ChainID || stored.Staking != staking {
+		return nil, fmt.Errorf("journal %s belongs to chain %s staking %s; move it aside or pass another --journal", path, stored.ChainID, stored.Staking.Hex())
+	}
+	j.Entries = stored.Entries
+	return j, nil
+}
+
+// Record appends a sent entry and saves the journal.
+func (j *Journal) Record(step string, from common.Address, nonce uint64, hash common.Hash) error {
+	j.Entries = append(j.Entries, JournalEntry{Step: step, From: from, Nonce: nonce, TxHash: hash, State: TxSent, SentAt: time.Now().UTC()})
+	return j.Save()
+}
+
+// Mark sets the state of the entry for hash and saves the journal.
+func (j *Journal) Mark(hash common.Hash, state string) error {
+	for i := range j.Entries {
+		if j.Entries[i].TxHash == hash {
+			j.Entries[i].State = state
+		}
+	}
+	return j.Save()
+}
+
+// Resolve records the outcome of broadcasting the entry for hash. A nil
+// status, because the send or the wait for the receipt failed, leaves the
+// entry sent: the node may have accepted the transaction before the error,
+// and only Settle can tell.
+func (j *Journal) Resolve(hash common.Hash, status *uint64) error {
+	if status == nil {
+		return nil
+	}
+	state := TxConfirmed
+	if *status != types.ReceiptStatusSuccessful {
+		state = TxFailed
+	}
+	return j.Mark(hash, state)
+}
+
+// Save writes the journal atomically.
+func (j *Journal) Save() error {
+	return writeJSON(j.path, j)
+}
+
+func writeJSON(path string, v any) error {
+	body, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(body, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// TxSource is the subset of rpc.Client that Settle needs.
+type TxSource interface {
+	TransactionReceipt(hash common.Hash) (*rpc.Receipt, error)
+	TransactionByHash(hash common.Hash) (*rpc.Transaction, error)
+}
+
+// Settle resolves every sent entry against the chain: mined transactions
+// become confirmed or failed, transactions the node no longer knows become
+// dropped. It returns the entries that are still pending.
+func (j *Journal) Settle(src TxSource) ([]JournalEntry, error) {
+	var pending []JournalEntry
+	changed := false
+	for i := range j.Entries {
+		e := &j.Entries[i]
+		if e.State != TxSent {
+			continue
+		}
+		receipt, err := src.TransactionReceipt(e.TxHash)
+		if err == nil {
+			e.State = TxConfirmed
+			if receipt.Status != nil && uint64(*receipt.Status) != types.ReceiptStatusSuccessful {
+				e.State = TxFailed
+			}
+			changed = true
+			continue
+		}
+		if !errors.Is(err, rpc.ErrNotFound) {
+			return nil, err
+		}
+		if _, err := src.TransactionByHash(e.TxHash); err == nil {
+			pending = append(pending, *e)
+			continue
+		} else if !errors.Is(err, rpc.ErrNotFound) {
+			return nil, err
+		}
+		// Unknown to the node: never broadcast, evicted or replaced. A resend
+		// takes the pending nonce, which is this one unless it was used since.
+		e.State = TxDropped
+		changed = true
+	}
+	if changed {
+		if err := j.Save(); err != nil {
+			return nil, err
+		}
+	}
+	return pending, nil
+}
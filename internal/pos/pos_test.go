@@ -0,0 +1,351 @@
+package pos
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	op1    = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	op2    = common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	payout = common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906")
+)
+
+func TestLoadBootstrap(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "v1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "v1", "consensus.key"), []byte(" 0xABcd\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := filepath.Join(dir, "bootstrap.json")
+	body := `{"operators":[{"operator":"{{V1}}","payout":"` + payout.Hex() + `","consensusKeyFile":"v1/consensus.key","initialStakeWei":"5000","signer":{"keyBackend":"remote","remoteSigner":"http://127.0.0.1:8550"}}]}`
+	if err := os.WriteFile(cfg, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{"V1": op1.Hex()}
+	b, err := LoadBootstrap(cfg, dir, func(k string) (string, bool) { v, ok := env[k]; return v, ok })
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := b.Operators[0]
+	if got.Address != op1 || got.Payout != payout || hexutil.Encode(got.ConsensusKey) != "0xabcd" || got.SelfStake.String() != "5000" || got.Signer.Backend != "remote" || got.Signer.RemoteURL != "http://127.0.0.1:8550" {
+		t.Fatalf("operator = %+v", got)
+	}
+
+	delete(env, "V1")
+	if _, err := LoadBootstrap(cfg, dir, func(k string) (string, bool) { v, ok := env[k]; return v, ok }); err == nil || !strings.Contains(err.Error(), "operators[0].operator: V1 is not set") {
+		t.Fatalf("expected unresolved placeholder error, got %v", err)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	desired := []Operator{
+		{Address: op1, Payout: payout, ConsensusKey: []byte{1}, SelfStake: big.NewInt(100)},
+		{Address: op2, Payout: payout, ConsensusKey: []byte{2}, SelfStake: big.NewInt(100)},
+	}
+	cases := []struct {
+		name     string
+		observed map[common.Address]Observed
+		want     []string
+	}{
+		{"fresh", nil, []string{"register", "stake 100", "register", "stake 100"}},
+		{"registered, stake timed out", map[common.Address]Observed{
+			op1: {Registered: true, ConsensusKey: []byte{1}, Payout: payout, SelfStake: new(big.Int)},
+			op2: {Registered: true, ConsensusKey: []byte{2}, Payout: payout, SelfStake: big.NewInt(100)},
+		}, []string{"stake 100"}},
+		{"partial stake and drift", map[common.Address]Observed{
+			op1: {Registered: true, ConsensusKey: []byte{9}, Payout: op2, SelfStake: big.NewInt(40)},
+			op2: {Registered: true, ConsensusKey: []byte{2}, Payout: payout, SelfStake: big.NewInt(500)},
+		}, []string{"update-key", "update-payout", "stake 60"}},
+	}
+	for _, tc := range cases {
+		var got []string
+		for _, s := range Plan(desired, tc.observed) {
+			got = append(got, strings.TrimSpace(s.Action+" "+s.AmountWei))
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("%s: plan %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+type fakeTxSource struct {
+	receipts map[common.Hash]*rpc.Receipt
+	pool     map[common.Hash]bool
+}
+
+func (f fakeTxSource) TransactionReceipt(hash common.Hash) (*rpc.Receipt, error) {
+	if r, ok := f.receipts[hash]; ok {
+		return r, nil
+	}
+	return nil, fmt.Errorf("eth_getTransactionReceipt: %w", rpc.ErrNotFound)
+}
+
+func (f fakeTxSource) TransactionByHash(hash common.Hash) (*rpc.Transaction, error) {
+	if f.pool[hash] {
+		return &rpc.Transaction{Hash: hash}, nil
+	}
+	return nil, fmt.Errorf("eth_getTransactionByHash: %w", rpc.ErrNotFound)
+}
+
+func TestJournalSettle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	chainID, staking := big.NewInt(100), common.HexToAddress("0xaa")
+	j, err := OpenJournal(path, chainID, staking)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, reverted := hexutil.Uint64(1), hexutil.Uint64(0)
+	src := fakeTxSource{
+		receipts: map[common.Hash]*rpc.Receipt{{1}: {Status: &ok}, {2}: {Status: &reverted}},
+		pool:     map[common.Hash]bool{{3}: true},
+	}
+	for i := byte(1); i <= 4; i++ {
+		if err := j.Record(fmt.Sprintf("step%d", i), op1, uint64(i), common.Hash{i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reopened, err := OpenJournal(path, chainID, staking)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending, err := reopened.Settle(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Step != "step3" {
+		t.Fatalf("pending = %+v", pending)
+	}
+	var states []string
+	for _, e := range reopened.Entries {
+		states = append(states, e.State)
+	}
+	if got := strings.Join(states, ","); got != "confirmed,failed,sent,dropped" {
+		t.Fatalf("states = %s", got)
+	}
+
+	if _, err := OpenJournal(path, big.NewInt(101), staking); err == nil {
+		t.Fatal("expected chain mismatch error")
+	}
+}
+
+// A send that errors, e.g. on an RPC timeout, may still have reached the
+// node. The entry must stay sent until the chain says otherwise.
+func TestJournalSendErrorThenMined(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	j, err := OpenJournal(path, big.NewInt(100), common.HexToAddress("0xaa"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := common.Hash{7}
+	if err := j.Record("stake:"+op1.Hex(), op1, 3, hash); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Resolve(hash, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := j.Settle(fakeTxSource{pool: map[common.Hash]bool{hash: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || j.Entries[0].State != TxSent {
+		t.Fatalf("in the pool: pending %+v, state %s", pending, j.Entries[0].State)
+	}
+	ok := hexutil.Uint64(1)
+	pending, err = j.Settle(fakeTxSource{receipts: map[common.Hash]*rpc.Receipt{hash: {Status: &ok}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 || j.Entries[0].State != TxConfirmed {
+		t.Fatalf("mined: pending %+v, state %s", pending, j.Entries[0].State)
+	}
+
+	status := uint64(0)
+	if err := j.Record("register:"+op2.Hex(), op2, 4, common.Hash{8}); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Resolve(common.Hash{8}, &status); err != nil || j.Entries[1].State != TxFailed {
+		t.Fatalf("reverted: %v, state %s", err, j.Entries[1].State)
+	}
+}
+
+func TestLoadDeployPlan(t *testing.T) {
+	plan, err := LoadDeployPlan("../../config/pos.contracts.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan) != 2 || plan[0].Name != "staking" || plan[1].Name != "validatorSet" {
+		t.Fatalf("plan order = %+v", plan)
+	}
+
+	deployer := op1.Hex()
+	staking := common.HexToAddress("0xaa").Hex()
+	lookup := func(k string) (string, bool) {
+		v, ok := map[string]string{"POS_DEPLOYER_ADDRESS": deployer, "STAKING_ADDRESS": staking}[k]
+		return v, ok
+	}
+	args, err := plan[0].Args(lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(args, ",") != deployer+",1000000000000000000,50,86400" {
+		t.Fatalf("staking args = %v", args)
+	}
+	params, err := plan[0].InitParams(lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 3 || params[0].Name != "maxValidators" || params[0].Setter() != "setMaxValidators" || params[0].Value != "50" {
+		t.Fatalf("init params = %+v", params)
+	}
+	if args, err := plan[1].Args(lookup); err != nil || args[0] != staking {
+		t.Fatalf("validatorSet args = %v, %v", args, err)
+	}
+	if _, err := plan[1].Args(func(string) (string, bool) { return "", false }); err == nil || !strings.Contains(err.Error(), "validatorSet.constructorArgs[0]: STAKING_ADDRESS is not set") {
+		t.Fatalf("expected unresolved placeholder error, got %v", err)
+	}
+
+	if a, b := plan[0].Salt("qikchain"), plan[1].Salt("qikchain"); a == b {
+		t.Fatal("deployments share a salt")
+	}
+	explicit := Deployment{DeploySpec: DeploySpec{Salt: common.Hash{31: 7}.Hex()}}
+	if explicit.Salt("ignored") != (common.Hash{31: 7}) {
+		t.Fatal("32-byte salt was hashed")
+	}
+	// EIP-1014 example 1.
+	if got := Create2Address(common.Address{}, common.Hash{}, []byte{0}); got != common.HexToAddress("0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38") {
+		t.Fatalf("create2 address = %s", got.Hex())
+	}
+}
+
+func TestDeployPlanCycle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	body := `{"a":{"contract":"A","constructorArgs":["{{B_ADDRESS}}"]},"b":{"contract":"B","constructorArgs":["{{A_ADDRESS}}"]}}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDeployPlan(path); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pos.local.json")
+	legacy := `{"network":"local","rpc":"http://127.0.0.1:8545","chainId":100,"deployer":"` + op1.Hex() + `",` +
+		`"staking":{"address":"0x00000000000000000000000000000000000000aa","tx":"0x01"},` +
+		`"validatorSet":{"address":"0x00000000000000000000000000000000000000bb","tx":"0x02"}}`
+	if err := os.WriteFile(path, []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := OpenRecord(path, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	staking := r.Contracts["staking"]
+	if r.Network != "local" || r.Deployer != op1 || staking.Address != common.HexToAddress("0xaa") || !staking.Pending() {
+		t.Fatalf("record = %+v", r)
+	}
+	bytecode := common.Hash{1}
+	if !staking.SameSource(ContractRecord{BytecodeHash: &bytecode}) {
+		t.Fatal("legacy entry should match any source")
+	}
+
+	staking.BlockNumber, staking.BytecodeHash, staking.ConstructorArgs = 7, &bytecode, []string{"1"}
+	r.Contracts["staking"] = staking
+	if err := r.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := OpenRecord(path, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := reopened.Contracts["staking"]
+	if got.Pending() || got.BlockNumber != 7 || !got.SameSource(ContractRecord{BytecodeHash: &bytecode, ConstructorArgs: []string{"1"}}) {
+		t.Fatalf("staking = %+v", got)
+	}
+	if got.SameSource(ContractRecord{BytecodeHash: &bytecode, ConstructorArgs: []string{"2"}}) {
+		t.Fatal("changed constructor args should not match")
+	}
+	if _, err := OpenRecord(path, 101); err == nil {
+		t.Fatal("expected chain mismatch error")
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	k1, _ := crypto.GenerateKey()
+	k2, _ := crypto.GenerateKey()
+	v1, v2 := crypto.PubkeyToAddress(k1.PublicKey), crypto.PubkeyToAddress(k2.PublicKey)
+	active := []ActiveOperator{
+		{Operator: op1, ConsensusKey: crypto.CompressPubkey(&k1.PublicKey)},
+		{Operator: op2, ConsensusKey: crypto.FromECDSAPub(&k2.PublicKey)},
+	}
+	checks := func(ms []Mismatch) string {
+		var out []string
+		for _, m := range ms {
+			s := m.Check
+			if m.Operator != nil {
+				s += " op=" + m.Operator.Hex()
+			}
+			if m.Validator != nil {
+				s += " val=" + m.Validator.Hex()
+			}
+			out = append(out, s)
+		}
+		return strings.Join(out, "; ")
+	}
+
+	agree := ValidatorSets{Consensus: []common.Address{v2, v1}, Snapshot: []common.Address{v1, v2}, Active: active, ValidatorSet: []common.Address{op1, op2}, EpochSnapshot: []common.Address{op2, op1}}
+	if ms := Reconcile(agree); len(ms) != 0 {
+		t.Fatalf("agreeing views: %s", checks(ms))
+	}
+	if ms := Reconcile(ValidatorSets{Consensus: []common.Address{v1, v2}, Active: active, ValidatorSet: []common.Address{op1, op2}}); len(ms) != 0 {
+		t.Fatalf("unread snapshots: %s", checks(ms))
+	}
+
+	extra := common.HexToAddress("0x00000000000000000000000000000000000000e1")
+	drift := ValidatorSets{
+		Consensus:     []common.Address{v1, extra},
+		Snapshot:      []common.Address{v1, v2},
+		Active:        active,
+		ValidatorSet:  []common.Address{op1},
+		EpochSnapshot: []common.Address{op1, payout},
+	}
+	want := strings.Join([]string{
+		"consensus op=" + op2.Hex() + " val=" + v2.Hex(),
+		"consensus val=" + extra.Hex(),
+		"ibft-snapshot val=" + v2.Hex(),
+		"ibft-snapshot val=" + extra.Hex(),
+		"validator-set op=" + op2.Hex(),
+		"epoch-snapshot op=" + payout.Hex(),
+		"epoch-snapshot op=" + op2.Hex(),
+	}, "; ")
+	if got := checks(Reconcile(drift)); got != want {
+		t.Fatalf("mismatches\n got %s\nwant %s", got, want)
+	}
+
+	empty := agree
+	empty.EpochSnapshot = []common.Address{}
+	if got := checks(Reconcile(empty)); got != "epoch-snapshot" {
+		t.Fatalf("empty epoch snapshot: %s", got)
+	}
+
+	bls := []byte{0xb1, 0x5}
+	ms := Reconcile(ValidatorSets{Consensus: []common.Address{extra}, ConsensusBLS: [][]byte{bls}, Active: []ActiveOperator{{Operator: op1, ConsensusKey: bls}}, ValidatorSet: []common.Address{op1}})
+	if len(ms) != 0 {
+		t.Fatalf("bls key: %s", checks(ms))
+	}
+}
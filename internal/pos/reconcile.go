@@ -0,0 +1,198 @@
+package pos
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Reconcile checks.
+const (
+	CheckConsensus     = "consensus"      // IBFT extraData vs contract active set
+	CheckIBFTSnapshot  = "ibft-snapshot"  // ibft_getSnapshot vs IBFT extraData
+	CheckValidatorSet  = "validator-set"  // QikValidatorSet.getValidators vs staking
+	CheckEpochSnapshot = "epoch-snapshot" // EpochManager.getActiveSet vs staking
+)
+
+// ValidatorAddress derives the IBFT validator address from a consensus key.
+// It reports false when the key is not a secp256k1 public key, e.g. a BLS key.
+func ValidatorAddress(key []byte) (common.Address, bool) {
+	var pub *ecdsa.PublicKey
+	var err error
+	switch len(key) {
+	case 33:
+		pub, err = crypto.DecompressPubkey(key)
+	case 65:
+		pub, err = crypto.UnmarshalPubkey(key)
+	default:
+		return common.Address{}, false
+	}
+	if err != nil {
+		return common.Address{}, false
+	}
+	return crypto.PubkeyToAddress(*pub), true
+}
+
+// ActiveOperator is an operator of the staking active set with its
+// consensus key.
+type ActiveOperator struct {
+	Operator     common.Address `json:"operator"`
+	ConsensusKey hexutil.Bytes  `json:"consensusKey"`
+	Validator    string         `json:"validator,omitempty"` // sealing address for secp256k1 keys
+}
+
+// ValidatorSets are the views of the validator set at one block. Snapshot and
+// EpochSnapshot are nil when they were not read.
+type ValidatorSets struct {
+	Consensus     []common.Address // validators in the block's IBFT extraData
+	ConsensusBLS  [][]byte         // their BLS public keys, for BLS validator sets
+	Snapshot      []common.Address // ibft_getSnapshot
+	Active        []ActiveOperator // staking getActiveOperators and getActiveConsensusKeys
+	ValidatorSet  []common.Address // QikValidatorSet.getValidators
+	EpochSnapshot []common.Address // EpochManager.getActiveSet for the current epoch
+}
+
+// Mismatch is one disagreement between two views.
+type Mismatch struct {
+	Check     string          `json:"check"`
+	Operator  *common.Address `json:"operator,omitempty"`
+	Validator *common.Address `json:"validator,omitempty"`
+	Detail    string          `json:"detail"`
+}
+
+// Reconcile compares the views and lists every operator or validator that is
+// in one and not the other.
+func Reconcile(s ValidatorSets) []Mismatch {
+	var out []Mismatch
+	add := func(check string, op, val *common.Address, format string, args ...any) {
+		out = append(out, Mismatch{Check: check, Operator: op, Validator: val, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	sealing := addressSet(s.Consensus)
+	claimed := map[common.Address]bool{}
+	for _, a := range s.Active {
+		op := a.Operator
+		if addr, ok := ValidatorAddress(a.ConsensusKey); ok {
+			claimed[addr] = true
+			if !sealing[addr] {
+				add(CheckConsensus, &op, &addr, "operator %s is active in the contract but its validator %s is not in the consensus set", op.Hex(), addr.Hex())
+			}
+			continue
+		}
+		found := false
+		for i, key := range s.ConsensusBLS {
+			if bytes.Equal(key, a.ConsensusKey) && i < len(s.Consensus) {
+				claimed[s.Consensus[i]], found = true, true
+			}
+		}
+		if !found {
+			add(CheckConsensus, &op, nil, "operator %s is active in the contract but its consensus key %s is not in the consensus set", op.Hex(), a.ConsensusKey)
+		}
+	}
+	for _, v := range s.Consensus {
+		if !claimed[v] {
+			v := v
+			add(CheckConsensus, nil, &v, "validator %s is in the consensus set but no active operator holds its key", v.Hex())
+		}
+	}
+
+	if s.Snapshot != nil {
+		onlySnap, onlyExtra := diff(s.Snapshot, s.Consensus)
+		for _, v := range onlySnap {
+			v := v
+			add(CheckIBFTSnapshot, nil, &v, "validator %s is in ibft_getSnapshot but not in the block extraData", v.Hex())
+		}
+		for _, v := range onlyExtra {
+			v := v
+			add(CheckIBFTSnapshot, nil, &v, "validator %s is in the block extraData but not in ibft_getSnapshot", v.Hex())
+		}
+	}
+
+	active := make([]common.Address, len(s.Active))
+	for i, a := range s.Active {
+		active[i] = a.Operator
+	}
+	onlySet, onlyStaking := diff(s.ValidatorSet, active)
+	for _, op := range onlySet {
+		op := op
+		add(CheckValidatorSet, &op, nil, "operator %s is returned by getValidators but not active in staking", op.Hex())
+	}
+	for _, op := range onlyStaking {
+		op := op
+		add(CheckValidatorSet, &op, nil, "operator %s is active in staking but missing from getValidators", op.Hex())
+	}
+
+	if s.EpochSnapshot != nil {
+		if len(s.EpochSnapshot) == 0 {
+			add(CheckEpochSnapshot, nil, nil, "no active set snapshot for the current epoch")
+		} else {
+			onlyEpoch, onlyActive := diff(s.EpochSnapshot, active)
+			for _, op := range onlyEpoch {
+				op := op
+				add(CheckEpochSnapshot, &op, nil, "operator %s is in the epoch snapshot but no longer active", op.Hex())
+			}
+			for _, op := range onlyActive {
+				op := op
+				add(CheckEpochSnapshot, &op, nil, "operator %s is active but not in the epoch snapshot", op.Hex())
+			}
+		}
+	}
+	return out
+}
+
+// diff returns the addresses only in a and only in b, in their order.
+func diff(a, b []common.Address) (onlyA, onlyB []common.Address) {
+	inA, inB := addressSet(a), addressSet(b)
+	for _, x := range a {
+		if !inB[x] {
+			onlyA = append(onlyA, x)
+		}
+	}
+	for _, x := range b {
+		if !inA[x] {
+			onlyB = append(onlyB, x)
+		}
+	}
+	return onlyA, onlyB
+}
+
+func addressSet(addrs []common.Address) map[common.Address]bool {
+	set := make(map[common.Address]bool, len(addrs))
+	for _, a := range addrs {
+		set[a] = true
+	}
+	return set
+}
+
+// LoadEpochManager reads the epochManager address from the addresses file
+// written by script/pos/DeployPos.s.sol.
+func LoadEpochManager(path string) (common.Address, error) {
+	return loadAddress(path, "epochManager")
+}
+
+// LoadStakeManager reads the stakeManager address from the same file.
+func LoadStakeManager(path string) (common.Address, error) {
+	return loadAddress(path, "stakeManager")
+}
+
+func loadAddress(path, key string) (common.Address, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return common.Address{}, err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return common.Address{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	v, _ := doc[key].(string)
+	if !common.IsHexAddress(v) {
+		return common.Address{}, fmt.Errorf("%s has no %s address", path, key)
+	}
+	return common.HexToAddress(v), nil
+}
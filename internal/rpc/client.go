@@ -2,16 +2,26 @@ package rpc
 
 import (
 	"bytes"
+	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/BioMark3r/qikchain/internal/tracing"
 )
 
 type Client struct {
 	rpcURL  string
-	timeout time.Duration
-	http    *http.Client
+	http    *http.Client // shared by copies and goroutines; never modified
+	observe func(method string, took time.Duration, err error)
+	ctx     context.Context
+	server  []tracing.Attr
 }
 
 type request struct {
@@ -22,63 +32,246 @@ type request struct {
 }
 
 type response struct {
-	Result string    `json:"result"`
-	Error  *rpcError `json:"error,omitempty"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error object. Data carries revert data for failed
+// eth_call and eth_estimateGas requests on nodes that return it.
+type Error struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
 }
 
-type rpcError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+// RevertData returns the hex-encoded revert data attached to err, if any.
+func RevertData(err error) ([]byte, bool) {
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) || len(rpcErr.Data) == 0 {
+		return nil, false
+	}
+	var s string
+	if json.Unmarshal(rpcErr.Data, &s) != nil || !strings.HasPrefix(s, "0x") {
+		return nil, false
+	}
+	data, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, false
+	}
+	return data, true
 }
 
 func NewClient(rpcURL string, timeout time.Duration) *Client {
 	return &Client{
-		rpcURL:  rpcURL,
-		timeout: timeout,
-		http:    &http.Client{},
+		rpcURL: rpcURL,
+		http:   &http.Client{Timeout: timeout},
+		server: serverAttrs(rpcURL),
 	}
 }
 
+// serverAttrs names the node in spans by host and port only, since the
+// path or userinfo of a provider URL may carry an API key.
+func serverAttrs(rpcURL string) []tracing.Attr {
+	u, err := url.Parse(rpcURL)
+	if err != nil {
+		return nil
+	}
+	attrs := []tracing.Attr{tracing.String("server.address", u.Hostname())}
+	if port, err := strconv.Atoi(u.Port()); err == nil {
+		attrs = append(attrs, tracing.Int("server.port", port))
+	}
+	return attrs
+}
+
+// WithContext returns a copy of c whose requests are bound to ctx: they are
+// cancelled with it and their spans are children of the span it carries.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cp := *c
+	cp.ctx = ctx
+	return &cp
+}
+
+func (c *Client) startSpan(method string, attrs ...tracing.Attr) (context.Context, *tracing.Span) {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	attrs = append(append([]tracing.Attr{tracing.String("rpc.system", "jsonrpc"), tracing.String("rpc.method", method)}, c.server...), attrs...)
+	return tracing.StartClient(ctx, method, attrs...)
+}
+
+// endSpan ends span with err. A null result is an answer, not a failure.
+func endSpan(span *tracing.Span, err error) {
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		span.SetAttributes(tracing.Int("rpc.jsonrpc.error_code", rpcErr.Code))
+	}
+	if !errors.Is(err, ErrNotFound) {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+var ErrNotFound = errors.New("not found")
+
+// SetObserver has fn called after every call with its method, duration and
+// error. A null result counts as an ErrNotFound error.
+func (c *Client) SetObserver(fn func(method string, took time.Duration, err error)) {
+	c.observe = fn
+}
+
 func (c *Client) CallString(method string) (string, error) {
+	var out string
+	if err := c.Call(&out, method); err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// Call invokes method with params and decodes the result into result.
+// A null result yields ErrNotFound.
+func (c *Client) Call(result any, method string, params ...any) error {
+	ctx, span := c.startSpan(method)
+	start := time.Now()
+	err := c.call(ctx, span, result, method, params)
+	endSpan(span, err)
+	if c.observe != nil {
+		c.observe(method, time.Since(start), err)
+	}
+	return err
+}
+
+func (c *Client) call(ctx context.Context, span *tracing.Span, result any, method string, params []any) error {
+	if params == nil {
+		params = []any{}
+	}
 	payload := request{
 		JSONRPC: "2.0",
 		ID:      1,
 		Method:  method,
-		Params:  []any{},
+		Params:  params,
 	}
 
+	var out response
+	if err := c.post(ctx, span, payload, &out); err != nil {
+		return err
+	}
+	return decodeResult(out, method, result)
+}
+
+// BatchElem is one request of a BatchCall.
+type BatchElem struct {
+	Method string
+	Params []any
+	Result any
+	Error  error
+}
+
+// BatchCall sends elems as one JSON-RPC batch. The returned error is for
+// the batch as a whole; each element's own error, including ErrNotFound for
+// a null result, is set in its Error field.
+func (c *Client) BatchCall(elems []BatchElem) error {
+	if len(elems) == 0 {
+		return nil
+	}
+	ctx, span := c.startSpan("batch", tracing.Int("rpc.batch_size", len(elems)))
+	start := time.Now()
+	err := c.batchCall(ctx, span, elems)
+	endSpan(span, err)
+	if c.observe != nil {
+		c.observe("batch", time.Since(start), err)
+	}
+	return err
+}
+
+func (c *Client) batchCall(ctx context.Context, span *tracing.Span, elems []BatchElem) error {
+	payload := make([]request, len(elems))
+	for i, e := range elems {
+		params := e.Params
+		if params == nil {
+			params = []any{}
+		}
+		payload[i] = request{JSONRPC: "2.0", ID: i, Method: e.Method, Params: params}
+	}
+
+	var raw json.RawMessage
+	if err := c.post(ctx, span, payload, &raw); err != nil {
+		return err
+	}
+	// Nodes answer a batch they reject, e.g. one over their size limit,
+	// with a single error object.
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		var single response
+		if err := json.Unmarshal(trimmed, &single); err != nil {
+			return err
+		}
+		if single.Error != nil {
+			return fmt.Errorf("batch: %w", single.Error)
+		}
+		return errors.New("batch: node returned a single response")
+	}
+	var out []struct {
+		ID int `json:"id"`
+		response
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return err
+	}
+	seen := make([]bool, len(elems))
+	for _, r := range out {
+		if r.ID < 0 || r.ID >= len(elems) || seen[r.ID] {
+			return fmt.Errorf("batch: unexpected response id %d", r.ID)
+		}
+		seen[r.ID] = true
+		elems[r.ID].Error = decodeResult(r.response, elems[r.ID].Method, elems[r.ID].Result)
+	}
+	for i, ok := range seen {
+		if !ok {
+			return fmt.Errorf("batch: no response to request %d (%s)", i, elems[i].Method)
+		}
+	}
+	return nil
+}
+
+func (c *Client) post(ctx context.Context, span *tracing.Span, payload, out any) error {
 	body, err := json.Marshal(payload)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.rpcURL, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.rpcURL, bytes.NewReader(body))
 	if err != nil {
-		return "", err
+		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	tracing.Inject(ctx, req.Header)
 
-	client := c.http
-	client.Timeout = c.timeout
-
-	resp, err := client.Do(req)
+	resp, err := c.http.Do(req)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer resp.Body.Close()
+	span.SetAttributes(tracing.Int("http.response.status_code", resp.StatusCode))
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", fmt.Errorf("rpc http status: %s", resp.Status)
-	}
-
-	var out response
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		return "", err
+		return fmt.Errorf("rpc http status: %s", resp.Status)
 	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
 
+func decodeResult(out response, method string, result any) error {
 	if out.Error != nil {
-		return "", fmt.Errorf("rpc error %d: %s", out.Error.Code, out.Error.Message)
+		return out.Error
 	}
-
-	return out.Result, nil
+	if len(out.Result) == 0 || string(out.Result) == "null" {
+		return fmt.Errorf("%s: %w", method, ErrNotFound)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(out.Result, result)
 }
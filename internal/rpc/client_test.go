@@ -1,11 +1,19 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/BioMark3r/qikchain/internal/tracing"
 )
 
 func TestCallString(t *testing.T) {
@@ -46,3 +54,158 @@ func TestHexToUint64(t *testing.T) {
 		t.Fatalf("got %d", got)
 	}
 }
+
+func TestCallParamsAndNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		var req struct {
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if len(req.Params) != 2 || req.Params[0] != "0x1" || req.Params[1] != false {
+			t.Fatalf("unexpected params: %v", req.Params)
+		}
+		var result any
+		if req.Method == "eth_getBlockByNumber" {
+			result = map[string]any{"number": "0x1", "gasUsed": "0x5208", "extraData": "0x", "transactions": []string{}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, 2*time.Second)
+	block, err := client.BlockByNumber("0x1", false)
+	if err != nil {
+		t.Fatalf("BlockByNumber: %v", err)
+	}
+	if block.Number != 1 || block.GasUsed != 21000 {
+		t.Fatalf("unexpected block: %+v", block)
+	}
+	if err := client.Call(nil, "eth_getBlockByHash", "0x1", false); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRevertData(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"error":   map[string]any{"code": 3, "message": "execution reverted", "data": "0x08c379a0"},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, 2*time.Second)
+	_, err := client.CallContract(CallMsg{}, "latest")
+	if err == nil || err.Error() != "rpc error 3: execution reverted" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, ok := RevertData(fmt.Errorf("wrapped: %w", err))
+	if !ok || len(data) != 4 || data[0] != 0x08 {
+		t.Fatalf("RevertData = %x, %v", data, ok)
+	}
+	if _, ok := RevertData(errors.New("other")); ok {
+		t.Fatal("expected no revert data")
+	}
+}
+
+func TestBatchCall(t *testing.T) {
+	limit := 3
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("decode batch: %v", err)
+		}
+		if len(reqs) > limit {
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": nil, "error": map[string]any{"code": -32600, "message": "batch request length too long"}})
+			return
+		}
+		var out []map[string]any
+		for i := len(reqs) - 1; i >= 0; i-- {
+			var result any
+			if reqs[i].Params[0] != "0x63" {
+				result = map[string]any{"number": reqs[i].Params[0]}
+			}
+			out = append(out, map[string]any{"jsonrpc": "2.0", "id": reqs[i].ID, "result": result})
+		}
+		_ = json.NewEncoder(w).Encode(out)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, 2*time.Second)
+	blocks := make([]Block, 3)
+	elems := make([]BatchElem, 3)
+	for i, n := range []string{"0x1", "0x63", "0x2"} {
+		elems[i] = BatchElem{Method: "eth_getBlockByNumber", Params: []any{n, false}, Result: &blocks[i]}
+	}
+	if err := client.BatchCall(elems); err != nil {
+		t.Fatalf("BatchCall: %v", err)
+	}
+	if elems[0].Error != nil || blocks[0].Number != 1 || blocks[2].Number != 2 || !errors.Is(elems[1].Error, ErrNotFound) {
+		t.Fatalf("elems %+v blocks %+v", elems, blocks)
+	}
+
+	limit = 2
+	var rpcErr *Error
+	if err := client.BatchCall(elems); !errors.As(err, &rpcErr) || rpcErr.Code != -32600 {
+		t.Fatalf("oversized batch: %v", err)
+	}
+}
+
+func TestCallSpans(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`)
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	shutdown, err := tracing.Config{Exporter: "file", File: path}.Setup("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, root := tracing.Start(context.Background(), "cycle")
+	client := NewClient(srv.URL, 2*time.Second).WithContext(ctx)
+	if err := client.Call(nil, "eth_foo"); err == nil {
+		t.Fatal("want an rpc error")
+	}
+	root.End()
+	shutdown()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		`"name":"eth_foo"`,
+		`{"key":"rpc.method","value":{"stringValue":"eth_foo"}}`,
+		`{"key":"server.address","value":{"stringValue":"127.0.0.1"}}`,
+		`{"key":"http.response.status_code","value":{"intValue":"200"}}`,
+		`{"key":"rpc.jsonrpc.error_code","value":{"intValue":"-32601"}}`,
+		`"status":{"code":2,"message":"rpc error -32601: method not found"}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("spans lack %s: %s", want, got)
+		}
+	}
+	if !strings.HasPrefix(traceparent, "00-") || !strings.Contains(got, traceparent[3:35]) {
+		t.Errorf("traceparent %q not in trace", traceparent)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := NewClient(srv.URL, 2*time.Second).WithContext(cancelled).Call(nil, "eth_foo"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled call: %v", err)
+	}
+}
@@ -0,0 +1,279 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type Block struct {
+	Number       hexutil.Uint64    `json:"number"`
+	Hash         common.Hash       `json:"hash"`
+	ParentHash   common.Hash       `json:"parentHash"`
+	Miner        common.Address    `json:"miner"`
+	StateRoot    common.Hash       `json:"stateRoot"`
+	Timestamp    hexutil.Uint64    `json:"timestamp"`
+	GasLimit     hexutil.Uint64    `json:"gasLimit"`
+	GasUsed      hexutil.Uint64    `json:"gasUsed"`
+	BaseFee      *hexutil.Big      `json:"baseFeePerGas,omitempty"`
+	ExtraData    hexutil.Bytes     `json:"extraData"`
+	Transactions []json.RawMessage `json:"transactions"`
+}
+
+// TxHashes returns the transaction hashes whether the block was fetched with
+// full transactions or hashes only.
+func (b *Block) TxHashes() ([]common.Hash, error) {
+	out := make([]common.Hash, 0, len(b.Transactions))
+	for _, raw := range b.Transactions {
+		var h common.Hash
+		if err := json.Unmarshal(raw, &h); err == nil {
+			out = append(out, h)
+			continue
+		}
+		var tx Transaction
+		if err := json.Unmarshal(raw, &tx); err != nil {
+			return nil, err
+		}
+		out = append(out, tx.Hash)
+	}
+	return out, nil
+}
+
+func (b *Block) FullTransactions() ([]Transaction, error) {
+	out := make([]Transaction, 0, len(b.Transactions))
+	for _, raw := range b.Transactions {
+		var tx Transaction
+		if err := json.Unmarshal(raw, &tx); err != nil {
+			return nil, fmt.Errorf("block does not contain full transactions: %w", err)
+		}
+		out = append(out, tx)
+	}
+	return out, nil
+}
+
+type Transaction struct {
+	Hash                 common.Hash     `json:"hash"`
+	Type                 *hexutil.Uint64 `json:"type,omitempty"`
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Value                *hexutil.Big    `json:"value"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Input                hexutil.Bytes   `json:"input"`
+	BlockHash            *common.Hash    `json:"blockHash"`
+	BlockNumber          *hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex     *hexutil.Uint64 `json:"transactionIndex"`
+}
+
+type Receipt struct {
+	TransactionHash   common.Hash     `json:"transactionHash"`
+	TransactionIndex  hexutil.Uint64  `json:"transactionIndex"`
+	BlockHash         common.Hash     `json:"blockHash"`
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	From              common.Address  `json:"from"`
+	To                *common.Address `json:"to"`
+	ContractAddress   *common.Address `json:"contractAddress"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
+	EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice,omitempty"`
+	Status            *hexutil.Uint64 `json:"status,omitempty"`
+	Logs              []Log           `json:"logs"`
+}
+
+type Log struct {
+	Address         common.Address `json:"address"`
+	Topics          []common.Hash  `json:"topics"`
+	Data            hexutil.Bytes  `json:"data"`
+	LogIndex        hexutil.Uint64 `json:"logIndex"`
+	BlockNumber     hexutil.Uint64 `json:"blockNumber"`
+	BlockHash       common.Hash    `json:"blockHash"`
+	TransactionHash common.Hash    `json:"transactionHash"`
+	Removed         bool           `json:"removed,omitempty"`
+}
+
+// FilterQuery is the eth_getLogs filter. FromBlock and ToBlock take a block
+// tag or a hex quantity.
+type FilterQuery struct {
+	FromBlock string           `json:"fromBlock,omitempty"`
+	ToBlock   string           `json:"toBlock,omitempty"`
+	Address   []common.Address `json:"address,omitempty"`
+	Topics    [][]common.Hash  `json:"topics,omitempty"`
+}
+
+func (c *Client) Logs(q FilterQuery) ([]Log, error) {
+	var out []Log
+	if err := c.Call(&out, "eth_getLogs", q); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// BlockByNumber accepts a block tag ("latest", "earliest", "pending") or a
+// hex quantity.
+func (c *Client) BlockByNumber(tag string, fullTx bool) (*Block, error) {
+	var b Block
+	if err := c.Call(&b, "eth_getBlockByNumber", tag, fullTx); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Client) BlockByHash(hash common.Hash, fullTx bool) (*Block, error) {
+	var b Block
+	if err := c.Call(&b, "eth_getBlockByHash", hash, fullTx); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Client) TransactionByHash(hash common.Hash) (*Transaction, error) {
+	var tx Transaction
+	if err := c.Call(&tx, "eth_getTransactionByHash", hash); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+func (c *Client) TransactionReceipt(hash common.Hash) (*Receipt, error) {
+	var r Receipt
+	if err := c.Call(&r, "eth_getTransactionReceipt", hash); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// BlockHashParam is the EIP-1898 form for selecting state by block hash.
+func BlockHashParam(hash common.Hash) any {
+	return map[string]any{"blockHash": hash}
+}
+
+// BalanceAt, NonceAt, CodeAt and StorageAt take a block tag, a hex quantity
+// or a BlockHashParam.
+func (c *Client) BalanceAt(addr common.Address, block any) (*big.Int, error) {
+	var out hexutil.Big
+	if err := c.Call(&out, "eth_getBalance", addr, block); err != nil {
+		return nil, err
+	}
+	return out.ToInt(), nil
+}
+
+func (c *Client) NonceAt(addr common.Address, block any) (uint64, error) {
+	var out hexutil.Uint64
+	if err := c.Call(&out, "eth_getTransactionCount", addr, block); err != nil {
+		return 0, err
+	}
+	return uint64(out), nil
+}
+
+func (c *Client) CodeAt(addr common.Address, block any) ([]byte, error) {
+	var out hexutil.Bytes
+	if err := c.Call(&out, "eth_getCode", addr, block); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) StorageAt(addr common.Address, slot common.Hash, block any) (common.Hash, error) {
+	var out hexutil.Bytes
+	if err := c.Call(&out, "eth_getStorageAt", addr, slot, block); err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(out), nil
+}
+
+type CallMsg struct {
+	From                 common.Address    `json:"from"`
+	To                   *common.Address   `json:"to,omitempty"`
+	Gas                  *hexutil.Uint64   `json:"gas,omitempty"`
+	GasPrice             *hexutil.Big      `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big      `json:"value,omitempty"`
+	Data                 hexutil.Bytes     `json:"data,omitempty"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+}
+
+func (c *Client) ChainID() (*big.Int, error) {
+	var out hexutil.Big
+	if err := c.Call(&out, "eth_chainId"); err != nil {
+		return nil, err
+	}
+	return out.ToInt(), nil
+}
+
+func (c *Client) GasPrice() (*big.Int, error) {
+	var out hexutil.Big
+	if err := c.Call(&out, "eth_gasPrice"); err != nil {
+		return nil, err
+	}
+	return out.ToInt(), nil
+}
+
+func (c *Client) MaxPriorityFeePerGas() (*big.Int, error) {
+	var out hexutil.Big
+	if err := c.Call(&out, "eth_maxPriorityFeePerGas"); err != nil {
+		return nil, err
+	}
+	return out.ToInt(), nil
+}
+
+func (c *Client) EstimateGas(msg CallMsg) (uint64, error) {
+	var out hexutil.Uint64
+	if err := c.Call(&out, "eth_estimateGas", msg); err != nil {
+		return 0, err
+	}
+	return uint64(out), nil
+}
+
+// CallContract executes msg with eth_call at block (see NonceAt). Reverts
+// surface as *Error; use RevertData to get the revert payload.
+func (c *Client) CallContract(msg CallMsg, block any) ([]byte, error) {
+	var out hexutil.Bytes
+	if err := c.Call(&out, "eth_call", msg, block); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) SendRawTransaction(tx *types.Transaction) (common.Hash, error) {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var hash common.Hash
+	if err := c.Call(&hash, "eth_sendRawTransaction", hexutil.Bytes(raw)); err != nil {
+		return common.Hash{}, err
+	}
+	return hash, nil
+}
+
+// WaitForReceipt polls for the receipt of hash until it is mined or timeout
+// elapses.
+func (c *Client) WaitForReceipt(hash common.Hash, timeout, interval time.Duration) (*Receipt, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		receipt, err := c.TransactionReceipt(hash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("receipt for %s not found after %s", hash.Hex(), timeout)
+		}
+		time.Sleep(interval)
+	}
+}
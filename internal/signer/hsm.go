@@ -0,0 +1,97 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// HSM signs with a key held in a hardware or software security module. The
+// module only produces raw r||s ECDSA signatures; the recovery id Ethereum
+// needs is derived here from the public key.
+type HSM struct {
+	mu    sync.Mutex
+	sign  func(digest []byte) ([]byte, error)
+	close func() error
+	addr  common.Address
+}
+
+func newHSM(pub *ecdsa.PublicKey, sign func(digest []byte) ([]byte, error), close func() error) *HSM {
+	return &HSM{sign: sign, close: close, addr: crypto.PubkeyToAddress(*pub)}
+}
+
+func (h *HSM) Address() common.Address { return h.addr }
+
+func (h *HSM) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	s := types.LatestSignerForChainID(chainID)
+	hash := s.Hash(tx)
+
+	h.mu.Lock()
+	rs, err := h.sign(hash[:])
+	h.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("hsm sign: %w", err)
+	}
+	sig, err := recoverableSignature(hash[:], rs, h.addr)
+	if err != nil {
+		return nil, fmt.Errorf("hsm sign: %w", err)
+	}
+	return tx.WithSignature(s, sig)
+}
+
+func (h *HSM) Close() error {
+	if h.close == nil {
+		return nil
+	}
+	return h.close()
+}
+
+// recoverableSignature turns a raw r||s signature into the 65 byte [R || S || V]
+// form, normalising S to the lower half of the curve order as Ethereum requires.
+func recoverableSignature(digest, rs []byte, want common.Address) ([]byte, error) {
+	if len(rs) != 64 {
+		return nil, fmt.Errorf("signature has %d bytes, want 64", len(rs))
+	}
+	s := new(big.Int).SetBytes(rs[32:])
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s.Sub(secp256k1N, s)
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, rs[:32])
+	s.FillBytes(sig[32:64])
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		pub, err := crypto.SigToPub(digest, sig)
+		if err == nil && crypto.PubkeyToAddress(*pub) == want {
+			return sig, nil
+		}
+	}
+	return nil, errors.New("signature does not recover to the key's address")
+}
+
+// parseECPoint decodes CKA_EC_POINT, which modules return either as a DER
+// OCTET STRING or as the bare uncompressed point.
+func parseECPoint(v []byte) (*ecdsa.PublicKey, error) {
+	point := v
+	if !(len(v) == 65 && v[0] == 4) {
+		var inner []byte
+		if _, err := asn1.Unmarshal(v, &inner); err != nil {
+			return nil, fmt.Errorf("decode EC point: %w", err)
+		}
+		point = inner
+	}
+	return crypto.UnmarshalPubkey(point)
+}
@@ -0,0 +1,136 @@
+//go:build pkcs11
+
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/pkcs11"
+)
+
+func newHSMFromConfig(cfg Config) (Signer, error) {
+	if cfg.HSMModule == "" || cfg.HSMKeyLabel == "" {
+		return nil, errors.New("hsm signer: --hsm-module and --hsm-key-label are required")
+	}
+	if cfg.HSMPinFile == "" {
+		return nil, errors.New("hsm signer: --hsm-pin-file is required")
+	}
+	pin, err := readSecretFile(cfg.HSMPinFile)
+	if err != nil {
+		return nil, fmt.Errorf("hsm signer: read pin: %w", err)
+	}
+	return OpenHSM(cfg.HSMModule, cfg.HSMTokenLabel, cfg.HSMKeyLabel, pin)
+}
+
+// OpenHSM logs into the token labelled tokenLabel (the first token with a key
+// when empty) and selects the secp256k1 key pair labelled keyLabel.
+func OpenHSM(module, tokenLabel, keyLabel, pin string) (*HSM, error) {
+	p := pkcs11.New(module)
+	if p == nil {
+		return nil, fmt.Errorf("hsm signer: unable to load PKCS#11 module %s", module)
+	}
+	if err := p.Initialize(); err != nil {
+		p.Destroy()
+		return nil, fmt.Errorf("hsm signer: initialize: %w", err)
+	}
+	cleanup := func() {
+		p.Finalize()
+		p.Destroy()
+	}
+
+	slot, err := findSlot(p, tokenLabel)
+	if err != nil {
+		cleanup()
+		return nil, fmt.Errorf("hsm signer: %w", err)
+	}
+	session, err := p.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		cleanup()
+		return nil, fmt.Errorf("hsm signer: open session: %w", err)
+	}
+	closeAll := func() error {
+		_ = p.Logout(session)
+		_ = p.CloseSession(session)
+		cleanup()
+		return nil
+	}
+	if err := p.Login(session, pkcs11.CKU_USER, pin); err != nil {
+		closeAll()
+		return nil, fmt.Errorf("hsm signer: login: %w", err)
+	}
+
+	priv, err := findObject(p, session, pkcs11.CKO_PRIVATE_KEY, keyLabel)
+	if err != nil {
+		closeAll()
+		return nil, fmt.Errorf("hsm signer: %w", err)
+	}
+	pubObj, err := findObject(p, session, pkcs11.CKO_PUBLIC_KEY, keyLabel)
+	if err != nil {
+		closeAll()
+		return nil, fmt.Errorf("hsm signer: %w", err)
+	}
+	attrs, err := p.GetAttributeValue(session, pubObj, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+	if err != nil || len(attrs) == 0 {
+		closeAll()
+		return nil, fmt.Errorf("hsm signer: read public key: %v", err)
+	}
+	pub, err := parseECPoint(attrs[0].Value)
+	if err != nil {
+		closeAll()
+		return nil, fmt.Errorf("hsm signer: %w", err)
+	}
+
+	sign := func(digest []byte) ([]byte, error) {
+		if err := p.SignInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, priv); err != nil {
+			return nil, err
+		}
+		return p.Sign(session, digest)
+	}
+	return newHSM(pub, sign, closeAll), nil
+}
+
+func findSlot(p *pkcs11.Ctx, label string) (uint, error) {
+	slots, err := p.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("list slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := p.GetTokenInfo(slot)
+		if err != nil {
+			continue
+		}
+		if label == "" || strings.TrimSpace(info.Label) == label {
+			return slot, nil
+		}
+	}
+	if label == "" {
+		return 0, errors.New("no initialised token found")
+	}
+	return 0, fmt.Errorf("token %q not found", label)
+}
+
+func findObject(p *pkcs11.Ctx, session pkcs11.SessionHandle, class uint, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := p.FindObjectsInit(session, template); err != nil {
+		return 0, err
+	}
+	objs, _, err := p.FindObjects(session, 2)
+	_ = p.FindObjectsFinal(session)
+	if err != nil {
+		return 0, err
+	}
+	switch len(objs) {
+	case 0:
+		return 0, fmt.Errorf("no key labelled %q", label)
+	case 1:
+		return objs[0], nil
+	default:
+		return 0, fmt.Errorf("more than one key labelled %q", label)
+	}
+}
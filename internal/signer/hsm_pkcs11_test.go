@@ -0,0 +1,95 @@
+//go:build pkcs11
+
+package signer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/miekg/pkcs11"
+)
+
+// secp256k1 OID 1.3.132.0.10, DER encoded for CKA_EC_PARAMS.
+var secp256k1Params = []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}
+
+// TestHSMSoftHSM runs against a real PKCS#11 module, e.g. SoftHSM:
+//
+//	softhsm2-util --init-token --free --label qik --pin 1234 --so-pin 1234
+//	QIKCHAIN_TEST_PKCS11_MODULE=/usr/lib/softhsm/libsofthsm2.so \
+//	QIKCHAIN_TEST_PKCS11_TOKEN=qik QIKCHAIN_TEST_PKCS11_PIN=1234 \
+//	go test -tags pkcs11 ./internal/signer
+func TestHSMSoftHSM(t *testing.T) {
+	module := os.Getenv("QIKCHAIN_TEST_PKCS11_MODULE")
+	if module == "" {
+		t.Skip("QIKCHAIN_TEST_PKCS11_MODULE not set")
+	}
+	token := os.Getenv("QIKCHAIN_TEST_PKCS11_TOKEN")
+	pin := os.Getenv("QIKCHAIN_TEST_PKCS11_PIN")
+	label := fmt.Sprintf("qik-test-%d", time.Now().UnixNano())
+
+	generateKey(t, module, token, pin, label)
+
+	h, err := OpenHSM(module, token, label, pin)
+	if err != nil {
+		t.Fatalf("OpenHSM: %v", err)
+	}
+	defer h.Close()
+
+	for _, tx := range testTxs() {
+		signed, err := h.SignTx(context.Background(), tx, chainID)
+		if err != nil {
+			t.Fatalf("SignTx type %d: %v", tx.Type(), err)
+		}
+		assertSignedBy(t, signed, h.Address())
+	}
+}
+
+func generateKey(t *testing.T, module, token, pin, label string) {
+	t.Helper()
+	p := pkcs11.New(module)
+	if p == nil {
+		t.Fatalf("load module %s", module)
+	}
+	defer p.Destroy()
+	if err := p.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Finalize()
+
+	slot, err := findSlot(p, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := p.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.CloseSession(session)
+	if err := p.Login(session, pkcs11.CKU_USER, pin); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Logout(session)
+
+	pub := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, secp256k1Params),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	priv := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if _, _, err := p.GenerateKeyPair(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)}, pub, priv); err != nil {
+		t.Fatalf("generate key pair: %v", err)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !pkcs11
+
+package signer
+
+import "errors"
+
+func newHSMFromConfig(cfg Config) (Signer, error) {
+	return nil, errors.New("hsm key backend requires a build with -tags pkcs11")
+}
@@ -0,0 +1,26 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type Local struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+func NewLocal(key *ecdsa.PrivateKey) *Local {
+	return &Local{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func (l *Local) Address() common.Address { return l.addr }
+
+func (l *Local) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), l.key)
+}
@@ -0,0 +1,166 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	MethodClef    = "account_signTransaction"
+	MethodEthSign = "eth_signTransaction"
+)
+
+// Remote delegates signing to an external signer such as Clef, so the key
+// never lives on the machine that builds transactions.
+type Remote struct {
+	client *rpc.Client
+	method string
+	from   common.Address
+}
+
+type sendTxArgs struct {
+	From                 common.Address    `json:"from"`
+	To                   *common.Address   `json:"to,omitempty"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	GasPrice             *hexutil.Big      `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Value                hexutil.Big       `json:"value"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	Data                 *hexutil.Bytes    `json:"data,omitempty"`
+	Input                *hexutil.Bytes    `json:"input,omitempty"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+	ChainID              *hexutil.Big      `json:"chainId,omitempty"`
+}
+
+func NewRemote(client *rpc.Client, method string, from common.Address) *Remote {
+	if method == "" {
+		method = MethodClef
+	}
+	return &Remote{client: client, method: method, from: from}
+}
+
+func newRemoteFromConfig(ctx context.Context, cfg Config) (Signer, error) {
+	if cfg.RemoteURL == "" {
+		return nil, errors.New("remote signer: --remote-signer URL is required")
+	}
+	client := rpc.NewClient(cfg.RemoteURL, cfg.Timeout)
+	r := NewRemote(client, cfg.RemoteMethod, common.Address{})
+	if cfg.From != "" {
+		if !common.IsHexAddress(cfg.From) {
+			return nil, fmt.Errorf("remote signer: invalid --from %q", cfg.From)
+		}
+		r.from = common.HexToAddress(cfg.From)
+		return r, nil
+	}
+
+	listMethod := "eth_accounts"
+	if r.method == MethodClef {
+		listMethod = "account_list"
+	}
+	var accs []common.Address
+	if err := client.Call(&accs, listMethod); err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	if len(accs) != 1 {
+		return nil, fmt.Errorf("remote signer: %s returned %d accounts; select one with --from", listMethod, len(accs))
+	}
+	r.from = accs[0]
+	return r, nil
+}
+
+func (r *Remote) Address() common.Address { return r.from }
+
+func (r *Remote) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := sendTxArgs{
+		From:    r.from,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if r.method == MethodEthSign {
+		args.Input = &data
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.AccessListTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		al := tx.AccessList()
+		args.AccessList = &al
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		al := tx.AccessList()
+		if len(al) > 0 {
+			args.AccessList = &al
+		}
+	default:
+		return nil, fmt.Errorf("remote signer: unsupported tx type %d", tx.Type())
+	}
+
+	var result json.RawMessage
+	if err := r.client.Call(&result, r.method, args); err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	raw, err := decodeSignResult(result)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("remote signer: decode signed tx: %w", err)
+	}
+	if err := checkSigned(tx, signed, chainID, r.from); err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	return signed, nil
+}
+
+// decodeSignResult accepts the {raw, tx} object returned by Clef and geth or
+// the bare raw transaction returned by other signers.
+func decodeSignResult(result json.RawMessage) ([]byte, error) {
+	var bare hexutil.Bytes
+	if err := json.Unmarshal(result, &bare); err == nil {
+		return bare, nil
+	}
+	var obj struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &obj); err != nil {
+		return nil, fmt.Errorf("unexpected sign result: %w", err)
+	}
+	if len(obj.Raw) == 0 {
+		return nil, errors.New("sign result has no raw transaction")
+	}
+	return obj.Raw, nil
+}
+
+// checkSigned makes sure a signer external to this process signed exactly the
+// transaction it was given, with the expected account.
+func checkSigned(unsigned, signed *types.Transaction, chainID *big.Int, from common.Address) error {
+	s := types.LatestSignerForChainID(chainID)
+	if s.Hash(unsigned) != s.Hash(signed) {
+		return errors.New("signed transaction does not match the request")
+	}
+	sender, err := types.Sender(s, signed)
+	if err != nil {
+		return err
+	}
+	if sender != from {
+		return fmt.Errorf("transaction signed by %s, want %s", sender.Hex(), from.Hex())
+	}
+	return nil
+}
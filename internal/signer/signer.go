@@ -0,0 +1,153 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/flagutil"
+	"github.com/BioMark3r/qikchain/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	BackendLocal  = "local"
+	BackendRemote = "remote"
+	BackendHSM    = "hsm"
+)
+
+var Backends = []string{BackendLocal, BackendRemote, BackendHSM}
+
+// Signer signs transactions for a single account. Implementations must not
+// change anything but the signature of tx.
+type Signer interface {
+	Address() common.Address
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+type Config struct {
+	Backend string
+
+	// local: a keystore account, or a raw hex key from KeyEnv falling back to
+	// DefaultKey when neither Keystore nor Account is set.
+	Keystore       string
+	Account        string
+	PassphraseFile string
+	KeyEnv         string
+	DefaultKey     string
+
+	// remote: an external signer speaking account_signTransaction (Clef) or
+	// eth_signTransaction over HTTP.
+	RemoteURL    string
+	RemoteMethod string
+	From         string
+	Timeout      time.Duration
+
+	// hsm: a PKCS#11 module holding a secp256k1 key.
+	HSMModule     string
+	HSMTokenLabel string
+	HSMKeyLabel   string
+	HSMPinFile    string
+}
+
+func New(ctx context.Context, cfg Config) (Signer, error) {
+	switch cfg.backend() {
+	case "", BackendLocal:
+		return newLocalFromConfig(cfg)
+	case BackendRemote:
+		return newRemoteFromConfig(ctx, cfg)
+	case BackendHSM:
+		return newHSMFromConfig(cfg)
+	default:
+		return nil, fmt.Errorf("unknown key backend %q (want %s)", cfg.Backend, strings.Join(Backends, "|"))
+	}
+}
+
+func newLocalFromConfig(cfg Config) (Signer, error) {
+	if cfg.Keystore != "" || cfg.Account != "" {
+		dir := cfg.Keystore
+		if dir == "" {
+			dir = wallet.DefaultDir()
+		}
+		key, err := wallet.LoadKey(dir, cfg.Account, cfg.PassphraseFile)
+		if err != nil {
+			return nil, err
+		}
+		return NewLocal(key), nil
+	}
+
+	raw := ""
+	if cfg.KeyEnv != "" {
+		raw = strings.TrimSpace(os.Getenv(cfg.KeyEnv))
+	}
+	if raw == "" {
+		raw = cfg.DefaultKey
+	}
+	if raw == "" {
+		if cfg.KeyEnv != "" {
+			return nil, fmt.Errorf("local signer: set --keystore/--account or %s", cfg.KeyEnv)
+		}
+		return nil, fmt.Errorf("local signer: set --keystore/--account")
+	}
+	key, err := wallet.ParsePrivateKey(raw)
+	if err != nil {
+		if cfg.KeyEnv != "" {
+			return nil, fmt.Errorf("invalid %s: %w", cfg.KeyEnv, err)
+		}
+		return nil, err
+	}
+	return NewLocal(key), nil
+}
+
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	return strings.TrimRight(line, "\r"), nil
+}
+
+// BindFlags registers the --key-backend flag and the options of every backend.
+func (c *Config) BindFlags(fs flagutil.FlagSet) {
+	if c.Backend == "" {
+		c.Backend = BackendLocal
+	}
+	if c.RemoteMethod == "" {
+		c.RemoteMethod = MethodClef
+	}
+	fs.StringVar(&c.Backend, "key-backend", c.Backend, "signing backend: local|remote|hsm")
+	fs.StringVar(&c.Keystore, "keystore", c.Keystore, "local: keystore directory")
+	fs.StringVar(&c.Account, "account", c.Account, "local: keystore account address or index")
+	fs.StringVar(&c.PassphraseFile, "passphrase-file", c.PassphraseFile, "local: file holding the keystore passphrase (prompts when unset)")
+	fs.StringVar(&c.RemoteURL, "remote-signer", c.RemoteURL, "remote: external signer URL, e.g. Clef at http://127.0.0.1:8550")
+	fs.StringVar(&c.RemoteMethod, "remote-method", c.RemoteMethod, "remote: signing method, account_signTransaction or eth_signTransaction")
+	fs.StringVar(&c.From, "from", c.From, "remote: signing account (discovered when the signer holds one)")
+	fs.StringVar(&c.HSMModule, "hsm-module", c.HSMModule, "hsm: PKCS#11 module path")
+	fs.StringVar(&c.HSMTokenLabel, "hsm-token-label", c.HSMTokenLabel, "hsm: token label (first token when unset)")
+	fs.StringVar(&c.HSMKeyLabel, "hsm-key-label", c.HSMKeyLabel, "hsm: key pair label")
+	fs.StringVar(&c.HSMPinFile, "hsm-pin-file", c.HSMPinFile, "hsm: file holding the user PIN")
+}
+
+// backend is the normalised --key-backend, as New and UsesDefaultKey read it.
+func (c *Config) backend() string {
+	return strings.ToLower(strings.TrimSpace(c.Backend))
+}
+
+// UsesDefaultKey reports whether New would fall back to DefaultKey.
+func (c *Config) UsesDefaultKey() bool {
+	if c.DefaultKey == "" {
+		return false
+	}
+	if b := c.backend(); b != "" && b != BackendLocal {
+		return false
+	}
+	if c.Keystore != "" || c.Account != "" {
+		return false
+	}
+	return c.KeyEnv == "" || strings.TrimSpace(os.Getenv(c.KeyEnv)) == ""
+}
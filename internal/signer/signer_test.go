@@ -0,0 +1,214 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const hardhatKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+var chainID = big.NewInt(100)
+
+func testTxs() []*types.Transaction {
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	return []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1e9), Gas: 21000, To: &to, Value: big.NewInt(1)}),
+		types.NewTx(&types.AccessListTx{ChainID: chainID, Nonce: 2, GasPrice: big.NewInt(1e9), Gas: 30000, To: &to, Value: big.NewInt(1),
+			AccessList: types.AccessList{{Address: to, StorageKeys: []common.Hash{{1}}}}}),
+		types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2e9), Gas: 21000, To: &to, Value: big.NewInt(1), Data: []byte{1, 2}}),
+	}
+}
+
+func assertSignedBy(t *testing.T, tx *types.Transaction, want common.Address) {
+	t.Helper()
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		t.Fatalf("Sender: %v", err)
+	}
+	if from != want {
+		t.Fatalf("signed by %s, want %s", from.Hex(), want.Hex())
+	}
+}
+
+func TestLocalFromEnvAndDefault(t *testing.T) {
+	t.Setenv("TEST_SIGNER_KEY", "")
+	s, err := New(context.Background(), Config{KeyEnv: "TEST_SIGNER_KEY", DefaultKey: hardhatKey})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if s.Address().Hex() != "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266" {
+		t.Fatalf("unexpected address %s", s.Address().Hex())
+	}
+	for _, tx := range testTxs() {
+		signed, err := s.SignTx(context.Background(), tx, chainID)
+		if err != nil {
+			t.Fatalf("SignTx type %d: %v", tx.Type(), err)
+		}
+		assertSignedBy(t, signed, s.Address())
+	}
+
+	mixed := Config{Backend: " Local", KeyEnv: "TEST_SIGNER_KEY", DefaultKey: hardhatKey}
+	if !mixed.UsesDefaultKey() {
+		t.Fatal("UsesDefaultKey ignores a mixed-case local backend")
+	}
+	if _, err := New(context.Background(), mixed); err != nil {
+		t.Fatalf("New(%q): %v", mixed.Backend, err)
+	}
+
+	if _, err := New(context.Background(), Config{KeyEnv: "TEST_SIGNER_KEY"}); err == nil {
+		t.Fatal("expected error without a key")
+	}
+	if _, err := New(context.Background(), Config{Backend: "yubikey"}); err == nil {
+		t.Fatal("expected unknown backend error")
+	}
+}
+
+// fakeClef implements account_list and account_signTransaction with a local key.
+func fakeClef(t *testing.T, local *Local, tamper bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		var result any
+		switch req.Method {
+		case "account_list":
+			result = []common.Address{local.Address()}
+		case MethodClef:
+			var args sendTxArgs
+			if err := json.Unmarshal(req.Params[0], &args); err != nil {
+				t.Fatalf("decode args: %v", err)
+			}
+			value := (*big.Int)(&args.Value)
+			if tamper {
+				value = new(big.Int).Add(value, big.NewInt(1))
+			}
+			var tx *types.Transaction
+			switch {
+			case args.MaxFeePerGas != nil:
+				tx = types.NewTx(&types.DynamicFeeTx{ChainID: args.ChainID.ToInt(), Nonce: uint64(args.Nonce), GasTipCap: args.MaxPriorityFeePerGas.ToInt(), GasFeeCap: args.MaxFeePerGas.ToInt(), Gas: uint64(args.Gas), To: args.To, Value: value, Data: *args.Data})
+			case args.AccessList != nil:
+				tx = types.NewTx(&types.AccessListTx{ChainID: args.ChainID.ToInt(), Nonce: uint64(args.Nonce), GasPrice: args.GasPrice.ToInt(), Gas: uint64(args.Gas), To: args.To, Value: value, Data: *args.Data, AccessList: *args.AccessList})
+			default:
+				tx = types.NewTx(&types.LegacyTx{Nonce: uint64(args.Nonce), GasPrice: args.GasPrice.ToInt(), Gas: uint64(args.Gas), To: args.To, Value: value, Data: *args.Data})
+			}
+			signed, err := local.SignTx(context.Background(), tx, args.ChainID.ToInt())
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+			raw, _ := signed.MarshalBinary()
+			result = map[string]any{"raw": hexutil.Bytes(raw), "tx": signed}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+}
+
+func TestBindFlagsKeepsPresets(t *testing.T) {
+	cfg := Config{Backend: BackendRemote, RemoteMethod: MethodEthSign, From: "0x01"}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.BindFlags(fs)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Backend != BackendRemote || cfg.RemoteMethod != MethodEthSign || cfg.From != "0x01" {
+		t.Fatalf("presets overwritten: %+v", cfg)
+	}
+
+	var empty Config
+	empty.BindFlags(flag.NewFlagSet("test", flag.ContinueOnError))
+	if empty.Backend != BackendLocal || empty.RemoteMethod != MethodClef {
+		t.Fatalf("defaults %+v", empty)
+	}
+}
+
+func TestRemoteClef(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	local := NewLocal(key)
+	srv := fakeClef(t, local, false)
+	defer srv.Close()
+
+	s, err := New(context.Background(), Config{Backend: BackendRemote, RemoteURL: srv.URL, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if s.Address() != local.Address() {
+		t.Fatalf("address %s, want %s", s.Address().Hex(), local.Address().Hex())
+	}
+	for _, tx := range testTxs() {
+		signed, err := s.SignTx(context.Background(), tx, chainID)
+		if err != nil {
+			t.Fatalf("SignTx type %d: %v", tx.Type(), err)
+		}
+		assertSignedBy(t, signed, local.Address())
+	}
+}
+
+func TestRemoteRejectsTamperedTx(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	local := NewLocal(key)
+	srv := fakeClef(t, local, true)
+	defer srv.Close()
+
+	s := NewRemote(rpc.NewClient(srv.URL, 2*time.Second), MethodClef, local.Address())
+	if _, err := s.SignTx(context.Background(), testTxs()[2], chainID); err == nil {
+		t.Fatal("expected tampered transaction to be rejected")
+	}
+}
+
+func TestHSMRecoversAndNormalisesSignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	highS := false
+	sign := func(digest []byte) ([]byte, error) {
+		sig, err := crypto.Sign(digest, key)
+		if err != nil {
+			return nil, err
+		}
+		rs := sig[:64]
+		if highS {
+			s := new(big.Int).SetBytes(rs[32:])
+			new(big.Int).Sub(secp256k1N, s).FillBytes(rs[32:])
+		}
+		return rs, nil
+	}
+	h := newHSM(&key.PublicKey, sign, nil)
+	for _, high := range []bool{false, true} {
+		highS = high
+		for _, tx := range testTxs() {
+			signed, err := h.SignTx(context.Background(), tx, chainID)
+			if err != nil {
+				t.Fatalf("SignTx (highS=%t) type %d: %v", high, tx.Type(), err)
+			}
+			assertSignedBy(t, signed, h.Address())
+		}
+	}
+}
+
+func TestParseECPoint(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	point := crypto.FromECDSAPub(&key.PublicKey)
+	der := append([]byte{0x04, byte(len(point))}, point...)
+	for _, v := range [][]byte{point, der} {
+		pub, err := parseECPoint(v)
+		if err != nil {
+			t.Fatalf("parseECPoint: %v", err)
+		}
+		if crypto.PubkeyToAddress(*pub) != crypto.PubkeyToAddress(key.PublicKey) {
+			t.Fatal("public key mismatch")
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const scopeName = "github.com/BioMark3r/qikchain"
+
+// Attr is a span attribute.
+type Attr struct {
+	Key   string
+	Value any // string, int64, bool or float64
+}
+
+func String(key, v string) Attr          { return Attr{key, v} }
+func Int(key string, v int) Attr         { return Attr{key, int64(v)} }
+func Int64(key string, v int64) Attr     { return Attr{key, v} }
+func Bool(key string, v bool) Attr       { return Attr{key, v} }
+func Float64(key string, v float64) Attr { return Attr{key, v} }
+
+// MarshalJSON encodes a as an OTLP KeyValue; 64-bit integers are strings.
+func (a Attr) MarshalJSON() ([]byte, error) {
+	var v any
+	switch x := a.Value.(type) {
+	case string:
+		v = map[string]string{"stringValue": x}
+	case int64:
+		v = map[string]string{"intValue": strconv.FormatInt(x, 10)}
+	case bool:
+		v = map[string]bool{"boolValue": x}
+	case float64:
+		v = map[string]float64{"doubleValue": x}
+	default:
+		v = map[string]string{"stringValue": fmt.Sprint(x)}
+	}
+	return json.Marshal(struct {
+		Key   string `json:"key"`
+		Value any    `json:"value"`
+	}{a.Key, v})
+}
+
+type otlpSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId,omitempty"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+	Start        string `json:"startTimeUnixNano"`
+	End          string `json:"endTimeUnixNano"`
+	Attributes   []Attr `json:"attributes,omitempty"`
+	Status       struct {
+		Code    int    `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	} `json:"status"`
+}
+
+// encode renders spans as one OTLP ExportTraceServiceRequest.
+func encode(service string, spans []*Span) ([]byte, error) {
+	out := make([]otlpSpan, len(spans))
+	for i, s := range spans {
+		o := &out[i]
+		o.TraceID = hex.EncodeToString(s.traceID[:])
+		o.SpanID = hex.EncodeToString(s.spanID[:])
+		if s.parent != ([8]byte{}) {
+			o.ParentSpanID = hex.EncodeToString(s.parent[:])
+		}
+		o.Name, o.Kind, o.Attributes = s.name, s.kind, s.attrs
+		o.Start = strconv.FormatInt(s.start.UnixNano(), 10)
+		o.End = strconv.FormatInt(s.end.UnixNano(), 10)
+		if s.failed {
+			o.Status.Code, o.Status.Message = statusError, s.errMsg
+		}
+	}
+	type scopeSpans struct {
+		Scope struct {
+			Name string `json:"name"`
+		} `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	type resourceSpans struct {
+		Resource struct {
+			Attributes []Attr `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []scopeSpans `json:"scopeSpans"`
+	}
+	var rs resourceSpans
+	rs.Resource.Attributes = []Attr{String("service.name", service)}
+	ss := scopeSpans{Spans: out}
+	ss.Scope.Name = scopeName
+	rs.ScopeSpans = []scopeSpans{ss}
+	return json.Marshal(struct {
+		ResourceSpans []resourceSpans `json:"resourceSpans"`
+	}{[]resourceSpans{rs}})
+}
+
+// defaultEndpoint follows the OpenTelemetry SDK environment variables.
+func defaultEndpoint() string {
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); v != "" {
+		return v
+	}
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); v != "" {
+		return strings.TrimRight(v, "/") + "/v1/traces"
+	}
+	return "http://127.0.0.1:4318/v1/traces"
+}
+
+type httpExporter struct {
+	url     string
+	headers http.Header
+	client  *http.Client
+}
+
+// newHTTPExporter posts to endpoint with headers given as the
+// comma-separated key=value list of OTEL_EXPORTER_OTLP_HEADERS.
+func newHTTPExporter(endpoint, headers string) (*httpExporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%q is not an http(s) URL", endpoint)
+	}
+	e := &httpExporter{url: endpoint, headers: http.Header{}, client: &http.Client{}}
+	for _, kv := range strings.Split(headers, ",") {
+		if strings.TrimSpace(kv) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("OTEL_EXPORTER_OTLP_HEADERS: %q is not key=value", kv)
+		}
+		if uv, err := url.QueryUnescape(v); err == nil {
+			v = uv
+		}
+		e.headers.Set(strings.TrimSpace(k), strings.TrimSpace(v))
+	}
+	return e, nil
+}
+
+func (e *httpExporter) export(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range e.headers {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector status: %s", resp.Status)
+	}
+	return nil
+}
+
+func (e *httpExporter) close() error { return nil }
+
+// fileExporter appends one ExportTraceServiceRequest per line, the format
+// the collector's file exporter writes and its otlpjsonfile receiver reads.
+type fileExporter struct {
+	f *os.File
+}
+
+func newFileExporter(path string) (*fileExporter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileExporter{f: f}, nil
+}
+
+func (e *fileExporter) export(_ context.Context, body []byte) error {
+	_, err := e.f.Write(append(body, '\n'))
+	return err
+}
+
+func (e *fileExporter) close() error {
+	return e.f.Close()
+}
@@ -0,0 +1,274 @@
+// Package tracing records spans and exports them in the OTLP/JSON format,
+// either over OTLP/HTTP to a collector or as JSON lines to a file for
+// offline use. Until Setup installs an exporter, Start returns a nil span
+// and every Span method is a no-op, so instrumented code costs next to
+// nothing when tracing is off.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/flagutil"
+	"github.com/BioMark3r/qikchain/internal/logging"
+)
+
+const (
+	EnvExporter = "QIKCHAIN_TRACE_EXPORTER"
+	EnvEndpoint = "QIKCHAIN_TRACE_ENDPOINT"
+	EnvFile     = "QIKCHAIN_TRACE_FILE"
+)
+
+// Span kinds and status codes, as numbered by OTLP.
+const (
+	kindInternal = 1
+	kindClient   = 3
+
+	statusError = 2
+)
+
+const (
+	queueSize     = 4096
+	maxBatch      = 512
+	flushInterval = 5 * time.Second
+	exportTimeout = 10 * time.Second
+)
+
+var active atomic.Pointer[Provider]
+
+// Span is one timed operation. A nil *Span is valid and records nothing. A
+// span must not be used from more than one goroutine.
+type Span struct {
+	p       *Provider
+	traceID [16]byte
+	spanID  [8]byte
+	parent  [8]byte
+	name    string
+	kind    int
+	start   time.Time
+	end     time.Time
+	attrs   []Attr
+	errMsg  string
+	failed  bool
+	ended   bool
+}
+
+type spanKey struct{}
+
+// Start begins an internal span, a child of the span in ctx if there is
+// one, and returns a context carrying it.
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	return start(ctx, kindInternal, name, attrs)
+}
+
+// StartClient begins a span for an outgoing request.
+func StartClient(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	return start(ctx, kindClient, name, attrs)
+}
+
+func start(ctx context.Context, kind int, name string, attrs []Attr) (context.Context, *Span) {
+	p := active.Load()
+	if p == nil {
+		return ctx, nil
+	}
+	s := &Span{p: p, name: name, kind: kind, start: time.Now(), attrs: attrs}
+	if parent := FromContext(ctx); parent != nil {
+		s.traceID, s.parent = parent.traceID, parent.spanID
+	} else {
+		rand.Read(s.traceID[:])
+	}
+	rand.Read(s.spanID[:])
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// FromContext returns the span carried by ctx, or nil.
+func FromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// Inject sets the W3C traceparent header for the span in ctx, so that a
+// traced server can join the trace.
+func Inject(ctx context.Context, h http.Header) {
+	if s := FromContext(ctx); s != nil {
+		h.Set("traceparent", "00-"+hex.EncodeToString(s.traceID[:])+"-"+hex.EncodeToString(s.spanID[:])+"-01")
+	}
+}
+
+// SetAttributes adds attributes to s.
+func (s *Span) SetAttributes(attrs ...Attr) {
+	if s != nil {
+		s.attrs = append(s.attrs, attrs...)
+	}
+}
+
+// RecordError marks s as failed with err. A nil err is ignored.
+func (s *Span) RecordError(err error) {
+	if s != nil && err != nil {
+		s.failed, s.errMsg = true, err.Error()
+	}
+}
+
+// End finishes s and queues it for export. Later calls do nothing.
+func (s *Span) End() {
+	if s == nil || s.ended {
+		return
+	}
+	s.ended, s.end = true, time.Now()
+	s.p.enqueue(s)
+}
+
+// Provider batches ended spans and hands them to an exporter from a single
+// goroutine. Spans are dropped, not blocked on, when the queue is full.
+type Provider struct {
+	service string
+	exp     exporter
+	mu      sync.RWMutex
+	closed  bool
+	spans   chan *Span
+	done    chan struct{}
+	dropped atomic.Uint64
+}
+
+type exporter interface {
+	export(ctx context.Context, body []byte) error
+	close() error
+}
+
+func newProvider(service string, exp exporter) *Provider {
+	p := &Provider{service: service, exp: exp, spans: make(chan *Span, queueSize), done: make(chan struct{})}
+	go p.loop()
+	return p
+}
+
+func (p *Provider) enqueue(s *Span) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return
+	}
+	select {
+	case p.spans <- s:
+	default:
+		p.dropped.Add(1)
+	}
+}
+
+func (p *Provider) loop() {
+	defer close(p.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	var batch []*Span
+	for {
+		select {
+		case s, ok := <-p.spans:
+			if !ok {
+				p.flush(batch)
+				return
+			}
+			if batch = append(batch, s); len(batch) >= maxBatch {
+				p.flush(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			p.flush(batch)
+			batch = nil
+		}
+	}
+}
+
+func (p *Provider) flush(batch []*Span) {
+	if n := p.dropped.Swap(0); n > 0 {
+		slog.Warn("trace queue full, spans dropped", "spans", n)
+	}
+	if len(batch) == 0 {
+		return
+	}
+	body, err := encode(p.service, batch)
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		err = p.exp.export(ctx, body)
+		cancel()
+	}
+	if err != nil {
+		slog.Warn("trace export failed", "spans", len(batch), logging.Err(err))
+	}
+}
+
+// Shutdown exports the queued spans and closes the exporter. Spans ended
+// afterwards are discarded.
+func (p *Provider) Shutdown() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.spans)
+	p.mu.Unlock()
+	<-p.done
+	return p.exp.close()
+}
+
+// Config selects where spans go.
+type Config struct {
+	Exporter string // none, otlp or file
+	Endpoint string // OTLP/HTTP traces URL
+	File     string // JSON lines file for the file exporter
+}
+
+// BindFlags registers --trace-exporter, --trace-endpoint and --trace-file.
+// The endpoint defaults to the standard OTEL_EXPORTER_OTLP_* variables.
+func (c *Config) BindFlags(fs flagutil.FlagSet) {
+	fs.StringVar(&c.Exporter, "trace-exporter", flagutil.EnvOr(EnvExporter, "none"), "span exporter: none|otlp|file")
+	fs.StringVar(&c.Endpoint, "trace-endpoint", flagutil.EnvOr(EnvEndpoint, defaultEndpoint()), "OTLP/HTTP traces URL for --trace-exporter otlp")
+	fs.StringVar(&c.File, "trace-file", os.Getenv(EnvFile), "append spans as OTLP JSON lines to this file for --trace-exporter file")
+}
+
+// Setup starts exporting spans for service, which OTEL_SERVICE_NAME
+// overrides, and installs the provider used by Start. The returned function
+// flushes the remaining spans and must be called before exiting.
+func (c Config) Setup(service string) (func(), error) {
+	var exp exporter
+	switch strings.ToLower(strings.TrimSpace(c.Exporter)) {
+	case "", "none":
+		return func() {}, nil
+	case "otlp":
+		e, err := newHTTPExporter(c.Endpoint, os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"))
+		if err != nil {
+			return nil, fmt.Errorf("--trace-endpoint: %w", err)
+		}
+		exp = e
+	case "file":
+		if c.File == "" {
+			return nil, fmt.Errorf("--trace-exporter file needs --trace-file")
+		}
+		e, err := newFileExporter(c.File)
+		if err != nil {
+			return nil, fmt.Errorf("--trace-file: %w", err)
+		}
+		exp = e
+	default:
+		return nil, fmt.Errorf("--trace-exporter %q: want none, otlp or file", c.Exporter)
+	}
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		service = name
+	}
+	p := newProvider(service, exp)
+	active.Store(p)
+	return func() {
+		active.CompareAndSwap(p, nil)
+		if err := p.Shutdown(); err != nil {
+			slog.Warn("trace exporter close failed", logging.Err(err))
+		}
+	}, nil
+}
@@ -0,0 +1,151 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type exportRequest struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []json.RawMessage `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []struct {
+			Spans []struct {
+				TraceID      string `json:"traceId"`
+				SpanID       string `json:"spanId"`
+				ParentSpanID string `json:"parentSpanId"`
+				Name         string `json:"name"`
+				Kind         int    `json:"kind"`
+				Attributes   []struct {
+					Key   string         `json:"key"`
+					Value map[string]any `json:"value"`
+				} `json:"attributes"`
+				Status struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+func TestDisabled(t *testing.T) {
+	ctx, span := Start(context.Background(), "noop", String("k", "v"))
+	if span != nil || FromContext(ctx) != nil {
+		t.Fatal("span recorded without a provider")
+	}
+	span.SetAttributes(Int("n", 1))
+	span.RecordError(errors.New("boom"))
+	span.End()
+	shutdown, err := Config{Exporter: "none"}.Setup("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shutdown()
+	for _, bad := range []Config{{Exporter: "zipkin"}, {Exporter: "file"}, {Exporter: "otlp", Endpoint: "127.0.0.1:4318"}} {
+		if _, err := bad.Setup("test"); err == nil {
+			t.Errorf("%+v accepted", bad)
+		}
+	}
+}
+
+func TestOTLPExport(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies [][]byte
+		auth   string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		auth = r.Header.Get("Authorization")
+		mu.Unlock()
+	}))
+	defer srv.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer%20secret")
+	t.Setenv("OTEL_SERVICE_NAME", "")
+
+	shutdown, err := Config{Exporter: "otlp", Endpoint: srv.URL + "/v1/traces"}.Setup("qikchaind")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, root := Start(context.Background(), "cycle", Int("nodes", 2))
+	cctx, child := StartClient(ctx, "eth_blockNumber", String("rpc.system", "jsonrpc"))
+	h := http.Header{}
+	Inject(cctx, h)
+	child.RecordError(errors.New("timeout"))
+	child.End()
+	child.End()
+	root.SetAttributes(Bool("healthy", false))
+	root.End()
+	shutdown()
+	if _, span := Start(context.Background(), "late"); span != nil {
+		t.Fatal("span recorded after shutdown")
+	}
+
+	if len(bodies) != 1 || auth != "Bearer secret" {
+		t.Fatalf("got %d exports, auth %q", len(bodies), auth)
+	}
+	var req exportRequest
+	if err := json.Unmarshal(bodies[0], &req); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(req.ResourceSpans[0].Resource.Attributes[0]); got != `{"key":"service.name","value":{"stringValue":"qikchaind"}}` {
+		t.Fatalf("resource %s", got)
+	}
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans", len(spans))
+	}
+	c, r := spans[0], spans[1]
+	if c.Name != "eth_blockNumber" || c.Kind != kindClient || c.TraceID != r.TraceID || c.ParentSpanID != r.SpanID || r.ParentSpanID != "" {
+		t.Fatalf("spans %+v", spans)
+	}
+	if c.Status.Code != statusError || c.Status.Message != "timeout" || r.Status.Code != 0 {
+		t.Fatalf("status %+v %+v", c.Status, r.Status)
+	}
+	if want := "00-" + c.TraceID + "-" + c.SpanID + "-01"; h.Get("traceparent") != want {
+		t.Fatalf("traceparent %q, want %q", h.Get("traceparent"), want)
+	}
+	if a := r.Attributes; len(a) != 2 || a[0].Value["intValue"] != "2" || a[1].Value["boolValue"] != false {
+		t.Fatalf("attributes %+v", a)
+	}
+}
+
+func TestFileExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	for i := 0; i < 2; i++ {
+		shutdown, err := Config{Exporter: "file", File: path}.Setup("qikchain")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, span := Start(context.Background(), "genesis.build")
+		span.End()
+		shutdown()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got %q", data)
+	}
+	for _, line := range lines {
+		var req exportRequest
+		if err := json.Unmarshal([]byte(line), &req); err != nil || req.ResourceSpans[0].ScopeSpans[0].Spans[0].Name != "genesis.build" {
+			t.Fatalf("line %q: %v", line, err)
+		}
+	}
+}
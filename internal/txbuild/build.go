@@ -0,0 +1,83 @@
+package txbuild
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type Request struct {
+	ChainID    *big.Int
+	Type       uint8
+	Nonce      uint64
+	To         *common.Address
+	Value      *big.Int
+	Data       []byte
+	Gas        uint64
+	Fees       Fees
+	AccessList types.AccessList
+}
+
+func Build(r Request) (*types.Transaction, error) {
+	if r.To == nil && len(r.Data) == 0 {
+		return nil, errors.New("contract creation needs --data")
+	}
+	value := r.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	switch r.Type {
+	case types.LegacyTxType:
+		if len(r.AccessList) > 0 {
+			return nil, errors.New("legacy transactions cannot carry an access list")
+		}
+		return types.NewTx(&types.LegacyTx{Nonce: r.Nonce, GasPrice: r.Fees.GasPrice, Gas: r.Gas, To: r.To, Value: value, Data: r.Data}), nil
+	case types.AccessListTxType:
+		return types.NewTx(&types.AccessListTx{ChainID: r.ChainID, Nonce: r.Nonce, GasPrice: r.Fees.GasPrice, Gas: r.Gas, To: r.To, Value: value, Data: r.Data, AccessList: r.AccessList}), nil
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{ChainID: r.ChainID, Nonce: r.Nonce, GasTipCap: r.Fees.GasTipCap, GasFeeCap: r.Fees.GasFeeCap, Gas: r.Gas, To: r.To, Value: value, Data: r.Data, AccessList: r.AccessList}), nil
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", r.Type)
+	}
+}
+
+var units = map[string]int{
+	"wei":   0,
+	"gwei":  9,
+	"qik":   18,
+	"ether": 18,
+	"eth":   18,
+}
+
+// ParseAmount parses a wei integer or a decimal followed by a unit, e.g.
+// "1.5qik" or "20 gwei".
+func ParseAmount(s string) (*big.Int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return new(big.Int), nil
+	}
+	num := strings.TrimRight(s, "abcdefghijklmnopqrstuvwxyz")
+	unit := s[len(num):]
+	num = strings.TrimSpace(num)
+	decimals := 0
+	if unit != "" {
+		d, ok := units[unit]
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q: unknown unit %q", s, unit)
+		}
+		decimals = d
+	}
+	whole, frac, _ := strings.Cut(num, ".")
+	if len(frac) > decimals {
+		return nil, fmt.Errorf("invalid amount %q: too many decimals", s)
+	}
+	v, ok := new(big.Int).SetString(whole+frac+strings.Repeat("0", decimals-len(frac)), 10)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	return v, nil
+}
@@ -0,0 +1,240 @@
+package txbuild
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	StrategyFixed      = "fixed"
+	StrategyPercentile = "percentile"
+	StrategyMultiplier = "multiplier"
+)
+
+var Strategies = []string{StrategyMultiplier, StrategyPercentile, StrategyFixed}
+
+// FeeSource is the subset of the RPC client fee suggestion needs.
+type FeeSource interface {
+	GasPrice() (*big.Int, error)
+	MaxPriorityFeePerGas() (*big.Int, error)
+	BlockByNumber(tag string, fullTx bool) (*rpc.Block, error)
+}
+
+type FeeOptions struct {
+	Strategy string
+
+	// multiplier: scale the node's suggestion.
+	Multiplier float64
+
+	// percentile: sample effective prices (or tips) of recent transactions.
+	Percentile float64
+	Blocks     int
+
+	// fixed: explicit prices in wei.
+	GasPrice    *big.Int
+	MaxFee      *big.Int
+	PriorityFee *big.Int
+}
+
+type Fees struct {
+	GasPrice  *big.Int
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+}
+
+// SelectType resolves auto|legacy|2930|1559. Chains without a base fee only
+// accept legacy and access-list transactions.
+func SelectType(requested string, baseFee *big.Int) (uint8, error) {
+	switch strings.ToLower(requested) {
+	case "", "auto":
+		if baseFee == nil {
+			return types.LegacyTxType, nil
+		}
+		return types.DynamicFeeTxType, nil
+	case "legacy", "0":
+		return types.LegacyTxType, nil
+	case "2930", "1", "access-list":
+		return types.AccessListTxType, nil
+	case "1559", "2", "dynamic":
+		if baseFee == nil {
+			return 0, errors.New("EIP-1559 transactions need a chain with a base fee; use --type legacy or 2930")
+		}
+		return types.DynamicFeeTxType, nil
+	default:
+		return 0, fmt.Errorf("unknown transaction type %q (want auto|legacy|2930|1559)", requested)
+	}
+}
+
+// SuggestFees prices a transaction of txType. For EIP-1559 the fee cap always
+// leaves room for the base fee to double.
+func SuggestFees(src FeeSource, txType uint8, baseFee *big.Int, opts FeeOptions) (Fees, error) {
+	dynamic := txType == types.DynamicFeeTxType
+	if dynamic && baseFee == nil {
+		return Fees{}, errors.New("EIP-1559 fees need a base fee")
+	}
+
+	var price *big.Int
+	switch opts.Strategy {
+	case StrategyFixed:
+		if !dynamic {
+			if opts.GasPrice == nil {
+				return Fees{}, errors.New("fixed fees need --gas-price")
+			}
+			return Fees{GasPrice: opts.GasPrice}, nil
+		}
+		if opts.PriorityFee == nil {
+			return Fees{}, errors.New("fixed EIP-1559 fees need --priority-fee")
+		}
+		feeCap := opts.MaxFee
+		if feeCap == nil {
+			feeCap = headroomFeeCap(baseFee, opts.PriorityFee)
+		}
+		if feeCap.Cmp(opts.PriorityFee) < 0 {
+			return Fees{}, errors.New("--max-fee must not be below --priority-fee")
+		}
+		return Fees{GasTipCap: opts.PriorityFee, GasFeeCap: feeCap}, nil
+
+	case StrategyPercentile:
+		p, err := recentPercentile(src, dynamic, opts)
+		if err != nil {
+			return Fees{}, err
+		}
+		price = p
+
+	case "", StrategyMultiplier:
+		var err error
+		if dynamic {
+			price, err = src.MaxPriorityFeePerGas()
+		} else {
+			price, err = src.GasPrice()
+		}
+		if err != nil {
+			return Fees{}, fmt.Errorf("suggest fee: %w", err)
+		}
+		m := opts.Multiplier
+		if m == 0 {
+			m = 1
+		}
+		if m < 0 {
+			return Fees{}, errors.New("fee multiplier must be positive")
+		}
+		price = mulFloat(price, m)
+
+	default:
+		return Fees{}, fmt.Errorf("unknown fee strategy %q (want %s)", opts.Strategy, strings.Join(Strategies, "|"))
+	}
+
+	if !dynamic {
+		return Fees{GasPrice: price}, nil
+	}
+	return Fees{GasTipCap: price, GasFeeCap: headroomFeeCap(baseFee, price)}, nil
+}
+
+func headroomFeeCap(baseFee, tip *big.Int) *big.Int {
+	feeCap := new(big.Int).Mul(baseFee, big.NewInt(2))
+	return feeCap.Add(feeCap, tip)
+}
+
+func mulFloat(v *big.Int, m float64) *big.Int {
+	out, _ := new(big.Float).Mul(new(big.Float).SetInt(v), big.NewFloat(m)).Int(nil)
+	return out
+}
+
+// recentPercentile samples the last opts.Blocks blocks. Legacy prices use the
+// effective gas price of each transaction; EIP-1559 prices use the tip paid
+// above each block's base fee. Empty windows fall back to the node's
+// suggestion.
+func recentPercentile(src FeeSource, dynamic bool, opts FeeOptions) (*big.Int, error) {
+	if opts.Percentile < 0 || opts.Percentile > 100 {
+		return nil, fmt.Errorf("fee percentile %.2f outside 0..100", opts.Percentile)
+	}
+	blocks := opts.Blocks
+	if blocks <= 0 {
+		blocks = 20
+	}
+
+	head, err := src.BlockByNumber("latest", true)
+	if err != nil {
+		return nil, fmt.Errorf("fee history: %w", err)
+	}
+	var samples []*big.Int
+	block := head
+	for i := 0; i < blocks; i++ {
+		txs, err := block.FullTransactions()
+		if err != nil {
+			return nil, fmt.Errorf("fee history: %w", err)
+		}
+		for _, tx := range txs {
+			if v := effectivePrice(tx, block.BaseFee, dynamic); v != nil {
+				samples = append(samples, v)
+			}
+		}
+		if block.Number == 0 || i == blocks-1 {
+			break
+		}
+		if block, err = src.BlockByNumber(hexutil.EncodeUint64(uint64(block.Number)-1), true); err != nil {
+			return nil, fmt.Errorf("fee history: %w", err)
+		}
+	}
+
+	if len(samples) == 0 {
+		if dynamic {
+			return src.MaxPriorityFeePerGas()
+		}
+		return src.GasPrice()
+	}
+	return Percentile(samples, opts.Percentile), nil
+}
+
+func effectivePrice(tx rpc.Transaction, baseFee *hexutil.Big, dynamic bool) *big.Int {
+	var price *big.Int
+	switch {
+	case tx.MaxFeePerGas != nil && baseFee != nil:
+		tip := tx.MaxPriorityFeePerGas.ToInt()
+		price = new(big.Int).Add(baseFee.ToInt(), tip)
+		if feeCap := tx.MaxFeePerGas.ToInt(); price.Cmp(feeCap) > 0 {
+			price = new(big.Int).Set(feeCap)
+		}
+	case tx.GasPrice != nil:
+		price = tx.GasPrice.ToInt()
+	default:
+		return nil
+	}
+	if !dynamic {
+		return price
+	}
+	if baseFee == nil {
+		return price
+	}
+	tip := new(big.Int).Sub(price, baseFee.ToInt())
+	if tip.Sign() < 0 {
+		return nil
+	}
+	return tip
+}
+
+// Percentile returns the nearest-rank p-th percentile of values.
+func Percentile(values []*big.Int, p float64) *big.Int {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := make([]*big.Int, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+	idx := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return new(big.Int).Set(sorted[idx])
+}
@@ -0,0 +1,126 @@
+package txbuild
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type fakeSource struct {
+	gasPrice *big.Int
+	tip      *big.Int
+	blocks   map[string]*rpc.Block
+}
+
+func (f *fakeSource) GasPrice() (*big.Int, error)             { return f.gasPrice, nil }
+func (f *fakeSource) MaxPriorityFeePerGas() (*big.Int, error) { return f.tip, nil }
+func (f *fakeSource) BlockByNumber(tag string, full bool) (*rpc.Block, error) {
+	return f.blocks[tag], nil
+}
+
+func hb(v int64) *hexutil.Big { return (*hexutil.Big)(big.NewInt(v)) }
+
+func TestSelectType(t *testing.T) {
+	if typ, _ := SelectType("auto", nil); typ != types.LegacyTxType {
+		t.Fatalf("auto without base fee = %d", typ)
+	}
+	if typ, _ := SelectType("auto", big.NewInt(1)); typ != types.DynamicFeeTxType {
+		t.Fatalf("auto with base fee = %d", typ)
+	}
+	if _, err := SelectType("1559", nil); err == nil {
+		t.Fatal("expected 1559 without base fee to fail")
+	}
+	if typ, _ := SelectType("2930", nil); typ != types.AccessListTxType {
+		t.Fatalf("2930 = %d", typ)
+	}
+}
+
+func TestSuggestFeesMultiplierAndFixed(t *testing.T) {
+	src := &fakeSource{gasPrice: big.NewInt(1000), tip: big.NewInt(10)}
+
+	fees, err := SuggestFees(src, types.LegacyTxType, nil, FeeOptions{Strategy: StrategyMultiplier, Multiplier: 1.5})
+	if err != nil || fees.GasPrice.Int64() != 1500 {
+		t.Fatalf("legacy multiplier: %v %v", fees.GasPrice, err)
+	}
+	fees, err = SuggestFees(src, types.DynamicFeeTxType, big.NewInt(100), FeeOptions{Strategy: StrategyMultiplier, Multiplier: 2})
+	if err != nil || fees.GasTipCap.Int64() != 20 || fees.GasFeeCap.Int64() != 220 {
+		t.Fatalf("1559 multiplier: %+v %v", fees, err)
+	}
+	if _, err := SuggestFees(src, types.LegacyTxType, nil, FeeOptions{Strategy: StrategyFixed}); err == nil {
+		t.Fatal("expected fixed without --gas-price to fail")
+	}
+	fees, err = SuggestFees(src, types.DynamicFeeTxType, big.NewInt(100), FeeOptions{Strategy: StrategyFixed, PriorityFee: big.NewInt(5), MaxFee: big.NewInt(500)})
+	if err != nil || fees.GasFeeCap.Int64() != 500 || fees.GasTipCap.Int64() != 5 {
+		t.Fatalf("1559 fixed: %+v %v", fees, err)
+	}
+}
+
+func TestSuggestFeesPercentile(t *testing.T) {
+	tx := func(price int64) []byte {
+		return []byte(`{"hash":"` + common.Hash{1}.Hex() + `","gasPrice":"` + hexutil.EncodeBig(big.NewInt(price)) + `"}`)
+	}
+	block := func(n uint64, prices ...int64) *rpc.Block {
+		b := &rpc.Block{Number: hexutil.Uint64(n)}
+		for _, p := range prices {
+			b.Transactions = append(b.Transactions, tx(p))
+		}
+		return b
+	}
+	src := &fakeSource{gasPrice: big.NewInt(7), blocks: map[string]*rpc.Block{
+		"latest": block(2, 10, 40),
+		"0x1":    block(1, 20, 30),
+		"0x0":    block(0, 1000),
+	}}
+	fees, err := SuggestFees(src, types.LegacyTxType, nil, FeeOptions{Strategy: StrategyPercentile, Percentile: 50, Blocks: 2})
+	if err != nil || fees.GasPrice.Int64() != 20 {
+		t.Fatalf("percentile: %v %v", fees.GasPrice, err)
+	}
+
+	empty := &fakeSource{gasPrice: big.NewInt(7), blocks: map[string]*rpc.Block{"latest": block(0)}}
+	fees, err = SuggestFees(empty, types.LegacyTxType, nil, FeeOptions{Strategy: StrategyPercentile, Percentile: 50})
+	if err != nil || fees.GasPrice.Int64() != 7 {
+		t.Fatalf("empty window fallback: %v %v", fees.GasPrice, err)
+	}
+}
+
+func TestEffectivePriceTip(t *testing.T) {
+	tx := rpc.Transaction{MaxFeePerGas: hb(150), MaxPriorityFeePerGas: hb(80)}
+	if got := effectivePrice(tx, hb(100), true); got.Int64() != 50 {
+		t.Fatalf("tip = %v, want 50 (capped by max fee)", got)
+	}
+}
+
+func TestBuildAndParseAmount(t *testing.T) {
+	to := common.HexToAddress("0xdead")
+	tx, err := Build(Request{ChainID: big.NewInt(1), Type: types.LegacyTxType, To: &to, Gas: 21000, Fees: Fees{GasPrice: big.NewInt(1)}})
+	if err != nil || tx.Type() != types.LegacyTxType || tx.Value().Sign() != 0 {
+		t.Fatalf("Build legacy: %v %v", tx, err)
+	}
+	if _, err := Build(Request{Type: types.LegacyTxType}); err == nil {
+		t.Fatal("expected contract creation without data to fail")
+	}
+
+	cases := map[string]string{
+		"1":        "1",
+		"1.5qik":   "1500000000000000000",
+		"20 gwei":  "20000000000",
+		"0.000001": "",
+		"2eth":     "2000000000000000000",
+	}
+	for in, want := range cases {
+		got, err := ParseAmount(in)
+		if want == "" {
+			if err == nil {
+				t.Fatalf("ParseAmount(%q) should fail", in)
+			}
+			continue
+		}
+		if err != nil || got.String() != want {
+			t.Fatalf("ParseAmount(%q) = %v, %v", in, got, err)
+		}
+	}
+}
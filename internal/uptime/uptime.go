@@ -0,0 +1,195 @@
+// Package uptime tracks validator participation from IBFT block headers:
+// who proposed each block and whose committed seals it carries.
+package uptime
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/BioMark3r/qikchain/internal/ibft"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Record is the participation in one block.
+type Record struct {
+	Number     uint64
+	Proposer   common.Address
+	Validators []common.Address
+	Signers    []common.Address
+}
+
+// FromBlock decodes the IBFT extra of b. Committed seals sign the block
+// hash, so b must come from the node as-is.
+func FromBlock(b *rpc.Block) (Record, error) {
+	extra, err := ibft.DecodeExtra(b.ExtraData)
+	if err != nil {
+		return Record{}, fmt.Errorf("block %d: %w", uint64(b.Number), err)
+	}
+	r := Record{Number: uint64(b.Number), Validators: extra.Validators, Proposer: b.Miner}
+	if proposer, err := extra.Proposer(b.Hash); err == nil {
+		r.Proposer = proposer
+	}
+	if r.Signers, err = extra.CommittedSigners(b.Hash); err != nil {
+		return Record{}, fmt.Errorf("block %d: %w", r.Number, err)
+	}
+	return r, nil
+}
+
+// Fetch reads blocks from..to inclusive with up to workers concurrent
+// requests and returns their records in block order. Block 0 carries no
+// seals and is skipped.
+func Fetch(c *rpc.Client, from, to uint64, workers int) ([]Record, error) {
+	if from == 0 {
+		from = 1
+	}
+	if to < from {
+		return nil, nil
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	out := make([]Record, to-from+1)
+	errs := make([]error, len(out))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				b, err := c.BlockByNumber(hexutil.EncodeUint64(from+uint64(i)), false)
+				if err == nil {
+					out[i], err = FromBlock(b)
+				}
+				errs[i] = err
+			}
+		}()
+	}
+	for i := range out {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", from+uint64(i), err)
+		}
+	}
+	return out, nil
+}
+
+// Stats is a validator's participation over the blocks of a window in which
+// it was in the validator set.
+type Stats struct {
+	Validator       string  `json:"validator"`
+	Blocks          int     `json:"blocks"`
+	Signed          int     `json:"signed"`
+	Missed          int     `json:"missed"`
+	Proposed        int     `json:"proposed"`
+	Ratio           float64 `json:"ratio"`
+	LastSignedBlock uint64  `json:"lastSignedBlock,omitempty"`
+	LastMissedBlock uint64  `json:"lastMissedBlock,omitempty"`
+}
+
+// Window keeps the records of the last Size blocks.
+type Window struct {
+	size    int
+	records []Record
+}
+
+func NewWindow(size int) *Window {
+	return &Window{size: size}
+}
+
+// Add appends r. A record at or below the newest one replaces it and
+// everything after it, as after a reorg.
+func (w *Window) Add(r Record) {
+	n := len(w.records)
+	for n > 0 && w.records[n-1].Number >= r.Number {
+		n--
+	}
+	w.records = append(w.records[:n], r)
+	if len(w.records) > w.size {
+		w.records = append(w.records[:0], w.records[len(w.records)-w.size:]...)
+	}
+}
+
+// Resize changes the window to size blocks, dropping the oldest records when
+// it shrinks.
+func (w *Window) Resize(size int) {
+	w.size = size
+	if len(w.records) > size {
+		w.records = append(w.records[:0], w.records[len(w.records)-size:]...)
+	}
+}
+
+// Head is the newest block in the window, or 0 when it is empty.
+func (w *Window) Head() uint64 {
+	if len(w.records) == 0 {
+		return 0
+	}
+	return w.records[len(w.records)-1].Number
+}
+
+// Len is the number of blocks in the window.
+func (w *Window) Len() int {
+	return len(w.records)
+}
+
+// Stats returns the participation of every validator seen in the window,
+// ordered by address.
+func (w *Window) Stats() []Stats {
+	byAddr := map[common.Address]*Stats{}
+	get := func(a common.Address) *Stats {
+		s := byAddr[a]
+		if s == nil {
+			s = &Stats{Validator: a.Hex()}
+			byAddr[a] = s
+		}
+		return s
+	}
+	for _, r := range w.records {
+		signed := make(map[common.Address]bool, len(r.Signers))
+		for _, a := range r.Signers {
+			signed[a] = true
+		}
+		for _, v := range r.Validators {
+			s := get(v)
+			s.Blocks++
+			if signed[v] {
+				s.Signed++
+				s.LastSignedBlock = r.Number
+			} else {
+				s.Missed++
+				s.LastMissedBlock = r.Number
+			}
+		}
+		if r.Proposer != (common.Address{}) {
+			get(r.Proposer).Proposed++
+		}
+	}
+	out := make([]Stats, 0, len(byAddr))
+	for _, s := range byAddr {
+		if s.Blocks > 0 {
+			s.Ratio = float64(s.Signed) / float64(s.Blocks)
+		}
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i].Validator) < strings.ToLower(out[j].Validator) })
+	return out
+}
+
+// StatsFor returns the participation of one validator; Blocks is 0 when it
+// was not in the validator set of any block in the window.
+func (w *Window) StatsFor(a common.Address) Stats {
+	for _, s := range w.Stats() {
+		if s.Validator == a.Hex() {
+			return s
+		}
+	}
+	return Stats{Validator: a.Hex()}
+}
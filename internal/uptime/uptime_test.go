@@ -0,0 +1,136 @@
+package uptime
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BioMark3r/qikchain/internal/ibft"
+	"github.com/BioMark3r/qikchain/internal/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestFromBlock(t *testing.T) {
+	var keys []*ecdsa.PrivateKey
+	var addrs []common.Address
+	for i := 0; i < 4; i++ {
+		k, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, k)
+		addrs = append(addrs, crypto.PubkeyToAddress(k.PublicKey))
+	}
+	hash := common.HexToHash("0xabcd")
+	sign := func(digest []byte, k *ecdsa.PrivateKey) []byte {
+		sig, err := crypto.Sign(digest, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	commit := crypto.Keccak256(crypto.Keccak256(hash.Bytes(), []byte{2}))
+	seals := [][]byte{sign(commit, keys[0]), sign(commit, keys[2]), sign(commit, keys[3])}
+	body, err := rlp.EncodeToBytes([]any{addrs, sign(crypto.Keccak256(hash.Bytes()), keys[2]), seals})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &rpc.Block{Number: 7, Hash: hash, ExtraData: append(bytes.Repeat([]byte{0}, ibft.ExtraVanity), body...)}
+
+	r, err := FromBlock(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Number != 7 || r.Proposer != addrs[2] || len(r.Validators) != 4 || len(r.Signers) != 3 {
+		t.Fatalf("record %+v", r)
+	}
+	w := NewWindow(10)
+	w.Add(r)
+	if s := w.StatsFor(addrs[1]); s.Blocks != 1 || s.Missed != 1 || s.LastMissedBlock != 7 || s.Ratio != 0 {
+		t.Fatalf("validator 1: %+v", s)
+	}
+	if s := w.StatsFor(addrs[2]); s.Signed != 1 || s.Proposed != 1 || s.Ratio != 1 {
+		t.Fatalf("validator 2: %+v", s)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	a, b, c := common.HexToAddress("0xa"), common.HexToAddress("0xb"), common.HexToAddress("0xc")
+	w := NewWindow(4)
+	for n := uint64(1); n <= 6; n++ {
+		signers := []common.Address{a, b}
+		if n%2 == 0 {
+			signers = []common.Address{a}
+		}
+		w.Add(Record{Number: n, Proposer: a, Validators: []common.Address{a, b}, Signers: signers})
+	}
+	if w.Len() != 4 || w.Head() != 6 {
+		t.Fatalf("len %d head %d", w.Len(), w.Head())
+	}
+	if s := w.StatsFor(b); s.Blocks != 4 || s.Signed != 2 || s.Missed != 2 || s.Ratio != 0.5 || s.LastSignedBlock != 5 || s.LastMissedBlock != 6 {
+		t.Fatalf("b: %+v", s)
+	}
+
+	// A reorg at 5 replaces blocks 5 and 6; c joins the set.
+	w.Add(Record{Number: 5, Proposer: c, Validators: []common.Address{a, b, c}, Signers: []common.Address{a, b, c}})
+	stats := w.Stats()
+	if w.Head() != 5 || len(stats) != 3 || stats[2].Validator != c.Hex() || stats[2].Proposed != 1 || stats[0].Proposed != 2 {
+		t.Fatalf("after reorg: %+v", stats)
+	}
+	if s := w.StatsFor(common.HexToAddress("0xd")); s.Blocks != 0 {
+		t.Fatalf("unknown: %+v", s)
+	}
+
+	w.Resize(2)
+	if w.Len() != 2 || w.Head() != 5 || w.StatsFor(c).Blocks != 1 {
+		t.Fatalf("resized: len %d head %d", w.Len(), w.Head())
+	}
+}
+
+// TestFetch runs the workers on one shared client, so go test -race checks
+// that calls do not write to it.
+func TestFetch(t *testing.T) {
+	validators := []common.Address{common.HexToAddress("0xa"), common.HexToAddress("0xb")}
+	body, err := rlp.EncodeToBytes([]any{validators, []byte{}, [][]byte{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	extra := append(bytes.Repeat([]byte{0}, ibft.ExtraVanity), body...)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int               `json:"id"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		var tag string
+		json.Unmarshal(req.Params[0], &tag)
+		n, _ := hexutil.DecodeUint64(tag)
+		block := rpc.Block{Number: hexutil.Uint64(n), Hash: common.BigToHash(new(big.Int).SetUint64(n)), Miner: validators[n%2], ExtraData: extra}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": block})
+	}))
+	defer srv.Close()
+
+	records, err := Fetch(rpc.NewClient(srv.URL, 2*time.Second), 0, 40, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 40 {
+		t.Fatalf("got %d records", len(records))
+	}
+	for i, r := range records {
+		if r.Number != uint64(i+1) || r.Proposer != validators[(i+1)%2] || len(r.Validators) != 2 {
+			t.Fatalf("record %d: %+v", i, r)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ReadPassphrase returns the first line of file when it is set and otherwise
+// prompts on the terminal. confirm asks a second time, for new keys.
+func ReadPassphrase(file, prompt string, confirm bool) (string, error) {
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("read passphrase file: %w", err)
+		}
+		line, _, _ := strings.Cut(string(data), "\n")
+		return strings.TrimRight(line, "\r"), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New("no passphrase file given and stdin is not a terminal")
+	}
+	pass, err := promptOnce(fd, prompt)
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		again, err := promptOnce(fd, "Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != pass {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+	return pass, nil
+}
+
+func promptOnce(fd int, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	b, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("read passphrase: %w", err)
+	}
+	return string(b), nil
+}
+
+// LoadKey opens the keystore in dir, selects account and decrypts it with a
+// passphrase read from passphraseFile or the terminal.
+func LoadKey(dir, account, passphraseFile string) (*ecdsa.PrivateKey, error) {
+	store := Open(dir, false)
+	acc, err := store.Find(account)
+	if err != nil {
+		return nil, fmt.Errorf("keystore %s: %w", dir, err)
+	}
+	pass, err := ReadPassphrase(passphraseFile, fmt.Sprintf("Passphrase for %s: ", acc.Address.Hex()), false)
+	if err != nil {
+		return nil, err
+	}
+	return store.Decrypt(acc, pass)
+}
@@ -0,0 +1,117 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const keystoreEnv = "QIKCHAIN_KEYSTORE"
+
+var ErrNoAccount = errors.New("no matching account in keystore")
+
+// DefaultDir returns $QIKCHAIN_KEYSTORE or ~/.qikchain/keystore.
+func DefaultDir() string {
+	if dir := os.Getenv(keystoreEnv); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".qikchain", "keystore")
+	}
+	return filepath.Join(home, ".qikchain", "keystore")
+}
+
+type Store struct {
+	ks *keystore.KeyStore
+}
+
+// Open returns the Web3 Secret Storage keystore in dir. lightKDF trades
+// scrypt strength for speed and is meant for devnet keys and tests.
+func Open(dir string, lightKDF bool) *Store {
+	n, p := keystore.StandardScryptN, keystore.StandardScryptP
+	if lightKDF {
+		n, p = keystore.LightScryptN, keystore.LightScryptP
+	}
+	return &Store{ks: keystore.NewKeyStore(dir, n, p)}
+}
+
+func (s *Store) Accounts() []accounts.Account {
+	return s.ks.Accounts()
+}
+
+func (s *Store) New(passphrase string) (accounts.Account, error) {
+	return s.ks.NewAccount(passphrase)
+}
+
+func (s *Store) Import(key *ecdsa.PrivateKey, passphrase string) (accounts.Account, error) {
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	if s.ks.HasAddress(addr) {
+		return accounts.Account{}, fmt.Errorf("account %s already exists", addr.Hex())
+	}
+	return s.ks.ImportECDSA(key, passphrase)
+}
+
+// Find resolves spec, an address or a zero-based index into Accounts. An
+// empty spec selects the only account when there is exactly one.
+func (s *Store) Find(spec string) (accounts.Account, error) {
+	accs := s.ks.Accounts()
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		switch len(accs) {
+		case 0:
+			return accounts.Account{}, ErrNoAccount
+		case 1:
+			return accs[0], nil
+		default:
+			return accounts.Account{}, fmt.Errorf("keystore holds %d accounts; select one by address or index", len(accs))
+		}
+	}
+	if common.IsHexAddress(spec) {
+		addr := common.HexToAddress(spec)
+		for _, a := range accs {
+			if a.Address == addr {
+				return a, nil
+			}
+		}
+		return accounts.Account{}, fmt.Errorf("%s: %w", addr.Hex(), ErrNoAccount)
+	}
+	if i, err := strconv.Atoi(spec); err == nil {
+		if i < 0 || i >= len(accs) {
+			return accounts.Account{}, fmt.Errorf("account index %d: %w", i, ErrNoAccount)
+		}
+		return accs[i], nil
+	}
+	return accounts.Account{}, fmt.Errorf("invalid account %q: want an address or index", spec)
+}
+
+// Decrypt loads and decrypts the key of account a.
+func (s *Store) Decrypt(a accounts.Account, passphrase string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(a.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w", a.Address.Hex(), err)
+	}
+	return key.PrivateKey, nil
+}
+
+// ParsePrivateKey parses a 32-byte hex key with or without 0x prefix.
+func ParsePrivateKey(raw string) (*ecdsa.PrivateKey, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(raw), "0x")
+	if len(trimmed) != 64 {
+		return nil, fmt.Errorf("expected 32-byte hex key, got %d hex chars", len(trimmed))
+	}
+	return crypto.HexToECDSA(trimmed)
+}
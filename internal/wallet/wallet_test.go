@@ -0,0 +1,75 @@
+package wallet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestImportFindDecrypt(t *testing.T) {
+	dir := t.TempDir()
+	store := Open(dir, true)
+
+	key, err := ParsePrivateKey("0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("ParsePrivateKey: %v", err)
+	}
+	acc, err := store.Import(key, "secret")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if acc.Address.Hex() != "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266" {
+		t.Fatalf("unexpected address %s", acc.Address.Hex())
+	}
+	if _, err := store.Import(key, "secret"); err == nil {
+		t.Fatal("expected duplicate import to fail")
+	}
+	if _, err := store.New("other"); err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if _, err := store.Find(""); err == nil {
+		t.Fatal("expected ambiguous selection error with two accounts")
+	}
+	found, err := store.Find(acc.Address.Hex())
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if _, err := store.Find("7"); !errors.Is(err, ErrNoAccount) {
+		t.Fatalf("expected ErrNoAccount, got %v", err)
+	}
+
+	if _, err := store.Decrypt(found, "wrong"); err == nil {
+		t.Fatal("expected wrong passphrase to fail")
+	}
+	got, err := store.Decrypt(found, "secret")
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if crypto.PubkeyToAddress(got.PublicKey) != acc.Address {
+		t.Fatal("decrypted key does not match account")
+	}
+}
+
+func TestLoadKeyWithPassphraseFile(t *testing.T) {
+	dir := t.TempDir()
+	acc, err := Open(dir, true).New("hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	passFile := filepath.Join(t.TempDir(), "pass")
+	if err := os.WriteFile(passFile, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := LoadKey(dir, "", passFile)
+	if err != nil {
+		t.Fatalf("LoadKey: %v", err)
+	}
+	if crypto.PubkeyToAddress(key.PublicKey) != acc.Address {
+		t.Fatal("loaded key does not match account")
+	}
+}
@@ -4,7 +4,7 @@ set -euo pipefail
 docker run --rm \
   -v "$PWD:/src" \
   -w /src \
-  golang:1.20.14-bookworm \
+  golang:1.21.13-bookworm \
   bash -lc '
     set -euo pipefail
     export PATH="/usr/local/go/bin:$PATH"
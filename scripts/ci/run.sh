@@ -35,6 +35,7 @@ CI_LINT_STRICT="${CI_LINT_STRICT:-1}" make lint
 
 echo "==> Running unit tests"
 go test ./... -count=1
+(cd internal/cobra && go test ./... -count=1)
 
 echo "==> Building"
 make build
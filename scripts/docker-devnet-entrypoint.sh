@@ -102,7 +102,7 @@ build_genesis_if_node1() {
     --chain-id "$CHAIN_ID"
     --block-gas-limit "$BLOCK_GAS_LIMIT"
     --min-gas-price "$MIN_GAS_PRICE"
-    --base-fee-enabled "$BASE_FEE_ENABLED"
+    --base-fee-enabled="$BASE_FEE_ENABLED"
     --allocations "$ALLOCATIONS_FILE"
     --token "$TOKEN_FILE"
     --out-combined "$COMBINED_OUT"
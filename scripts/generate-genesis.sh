@@ -34,7 +34,7 @@ cmd=("$QIKCHAIN_BIN" genesis build
   --allocations "$ALLOCATIONS_FILE"
   --block-gas-limit "$BLOCK_GAS_LIMIT"
   --min-gas-price "$MIN_GAS_PRICE"
-  --base-fee-enabled "$BASE_FEE_ENABLED"
+  --base-fee-enabled="$BASE_FEE_ENABLED"
   --pos-deployments "$POS_DEPLOYMENTS_FILE"
   --out-chain "$CHAIN_OUT_FILE"
   --out-genesis "$GENESIS_OUT_FILE"
@@ -1,9 +1,48 @@
 #!/usr/bin/env bash
 set -euo pipefail
 
+# EVM_TOOL selects the client: cast (Foundry), qikchain, or auto (cast when
+# installed, otherwise the qikchain binary). Contract deployment and raw
+# transaction publishing still require cast.
+EVM_QIKCHAIN_BIN="${QIKCHAIN_BIN:-$(cd "$(dirname "${BASH_SOURCE[0]}")/../.." && pwd)/bin/qikchain}"
+
+evm_tool() {
+  case "${EVM_TOOL:-auto}" in
+    cast|qikchain) echo "$EVM_TOOL" ;;
+    auto)
+      if command -v cast >/dev/null 2>&1; then
+        echo cast
+      elif [[ -x "$EVM_QIKCHAIN_BIN" ]]; then
+        echo qikchain
+      else
+        echo none
+      fi
+      ;;
+    *) echo "[evm] ERROR: unknown EVM_TOOL=$EVM_TOOL (want auto|cast|qikchain)" >&2; return 1 ;;
+  esac
+}
+
 require_tool() {
+  case "$(evm_tool)" in
+    cast)
+      command -v cast >/dev/null 2>&1 && return 0
+      echo "[evm] ERROR: EVM_TOOL=cast but Foundry 'cast' is not installed." >&2
+      ;;
+    qikchain)
+      [[ -x "$EVM_QIKCHAIN_BIN" ]] && return 0
+      echo "[evm] ERROR: qikchain binary not found at $EVM_QIKCHAIN_BIN (run 'make build' or set QIKCHAIN_BIN)." >&2
+      ;;
+    *)
+      echo "[evm] ERROR: PoS scripts need Foundry 'cast' or the qikchain binary ($EVM_QIKCHAIN_BIN)." >&2
+      echo "[evm] Run 'make build', or install Foundry from https://book.getfoundry.sh/getting-started/installation" >&2
+      ;;
+  esac
+  return 1
+}
+
+require_cast() {
   if ! command -v cast >/dev/null 2>&1; then
-    echo "[evm] ERROR: Foundry 'cast' is required for PoS deployment/bootstrap scripts." >&2
+    echo "[evm] ERROR: Foundry 'cast' is required for $1." >&2
     echo "[evm] Install Foundry from https://book.getfoundry.sh/getting-started/installation" >&2
     return 1
   fi
@@ -13,14 +52,52 @@ evm_rpc_url() {
   echo "${EVM_RPC_URL:-${RPC_URL:-http://127.0.0.1:8545}}"
 }
 
+evm_qikchain() {
+  "$EVM_QIKCHAIN_BIN" --rpc "$(evm_rpc_url)" "$@"
+}
+
 evm_chain_id() {
   require_tool >/dev/null
+  if [[ "$(evm_tool)" == qikchain ]]; then
+    evm_qikchain --json status | jq -r '.chainId'
+    return
+  fi
   cast chain-id --rpc-url "$(evm_rpc_url)"
 }
 
+evm_block_number() {
+  require_tool >/dev/null
+  if [[ "$(evm_tool)" == qikchain ]]; then
+    evm_qikchain --json status | jq -r '.blockNumber'
+    return
+  fi
+  cast block-number --rpc-url "$(evm_rpc_url)"
+}
+
+evm_peer_count() {
+  require_tool >/dev/null
+  if [[ "$(evm_tool)" == qikchain ]]; then
+    evm_qikchain --json status | jq -r '.peerCount'
+    return
+  fi
+  local peer_hex
+  peer_hex="$(cast rpc --rpc-url "$(evm_rpc_url)" net_peerCount | tr -d '"')"
+  echo "$((peer_hex))"
+}
+
+evm_address_from_pk() {
+  local pk="$1"
+  require_tool >/dev/null
+  if [[ "$(evm_tool)" == qikchain ]]; then
+    EVM_PK="$pk" "$EVM_QIKCHAIN_BIN" wallet export-address --from-env EVM_PK
+    return
+  fi
+  cast wallet address --private-key "$pk"
+}
+
 evm_send_raw_tx() {
   local signed_tx="$1"
-  require_tool >/dev/null
+  require_cast "publishing raw transactions"
   cast publish --rpc-url "$(evm_rpc_url)" "$signed_tx"
 }
 
@@ -28,7 +105,7 @@ evm_deploy_contract() {
   local from_pk="$1"
   local bytecode="$2"
   shift 2
-  require_tool >/dev/null
+  require_cast "contract deployment"
   cast send --json --private-key "$from_pk" --create "$bytecode" "$@" --rpc-url "$(evm_rpc_url)"
 }
 
@@ -37,6 +114,10 @@ evm_call() {
   local sig="$2"
   shift 2
   require_tool >/dev/null
+  if [[ "$(evm_tool)" == qikchain ]]; then
+    evm_qikchain contract call --sig "$sig" --address "$to" "$@"
+    return
+  fi
   cast call "$to" "$sig" "$@" --rpc-url "$(evm_rpc_url)"
 }
 
@@ -46,12 +127,20 @@ evm_send() {
   local sig="$3"
   shift 3
   require_tool >/dev/null
+  if [[ "$(evm_tool)" == qikchain ]]; then
+    QIKCHAIN_PRIVATE_KEY="$from_pk" evm_qikchain --json contract send --sig "$sig" --address "$to" "$@"
+    return
+  fi
   cast send --json --private-key "$from_pk" "$to" "$sig" "$@" --rpc-url "$(evm_rpc_url)"
 }
 
 evm_get_code() {
   local address="$1"
   require_tool >/dev/null
+  if [[ "$(evm_tool)" == qikchain ]]; then
+    evm_qikchain account code "$address"
+    return
+  fi
   cast code "$address" --rpc-url "$(evm_rpc_url)"
 }
 
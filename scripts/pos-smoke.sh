@@ -30,9 +30,9 @@ fi
 
 echo "active operators count >= 1 ($count)"
 
-start_block="$(cast block-number --rpc-url "$RPC_URL")"
+start_block="$(evm_block_number)"
 sleep 10
-end_block="$(cast block-number --rpc-url "$RPC_URL")"
+end_block="$(evm_block_number)"
 if (( end_block <= start_block )); then
   echo "block height did not increase ($start_block -> $end_block)" >&2
   exit 1
@@ -40,8 +40,7 @@ fi
 
 echo "blocks increasing: $start_block -> $end_block"
 
-peer_hex="$(cast rpc --rpc-url "$RPC_URL" net_peerCount)"
-peer_count="$((peer_hex))"
+peer_count="$(evm_peer_count)"
 expected="${EXPECTED_MIN_PEERS:-1}"
 if (( peer_count < expected )); then
   echo "peer count too low: $peer_count < $expected" >&2